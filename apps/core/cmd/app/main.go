@@ -3,13 +3,18 @@ package main
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	contentservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/service"
+	notificationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/notification/service"
 	userservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/user/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/router"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/email"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
 	"github.com/gin-gonic/gin"
 
@@ -76,6 +81,9 @@ func main() {
 	}
 
 	startAccountDeletionExecutor(ctx, userservice.NewUserService(database.DB))
+	startInteractionReconciler(ctx, contentservice.NewInteractionService(database.DB))
+	notificationservice.NewProducer(database.DB).Register(eventbus.Default)
+	startNotificationDispatcher(ctx, newNotificationDispatcher(ctx, cfg))
 
 	// Initialize Gin router with JSON logging
 	gin.SetMode(gin.ReleaseMode)
@@ -113,7 +121,7 @@ func jsonLoggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
+		query := redactQuery(c.Request.URL.RawQuery)
 
 		c.Next()
 
@@ -132,6 +140,32 @@ func jsonLoggerMiddleware() gin.HandlerFunc {
 	}
 }
 
+// credentialQueryParams are query parameters whose values must never reach the logs.
+var credentialQueryParams = []string{"access_token", "refresh_token", "token", "ticket", "code", "password"}
+
+// redactQuery masks credential values in a raw query string. Unparseable queries are dropped
+// entirely rather than risk logging a secret.
+func redactQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return "[unparseable]"
+	}
+	redacted := false
+	for _, param := range credentialQueryParams {
+		if _, ok := values[param]; ok {
+			values.Set(param, "[REDACTED]")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return raw
+	}
+	return values.Encode()
+}
+
 func startAccountDeletionExecutor(ctx context.Context, svc *userservice.UserService) {
 	runOnce := func() {
 		processed, err := svc.ExecuteDueAccountDeletions(ctx, time.Now().UTC(), 0)
@@ -154,3 +188,85 @@ func startAccountDeletionExecutor(ctx context.Context, svc *userservice.UserServ
 		}
 	}()
 }
+
+// startInteractionReconciler periodically repairs like counters that drifted from the likes table.
+// ReconcileCounters takes an advisory lock, so only one instance reconciles at a time.
+func startInteractionReconciler(ctx context.Context, svc *contentservice.InteractionService) {
+	runOnce := func() {
+		repaired, err := svc.ReconcileCounters(ctx)
+		if err != nil {
+			logger.Error(ctx, "Failed to reconcile interaction counters", "error", err.Error())
+			return
+		}
+		if repaired > 0 {
+			logger.Info(ctx, "Reconciled interaction counters", "repaired", repaired)
+		}
+	}
+
+	go func() {
+		runOnce()
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			runOnce()
+		}
+	}()
+}
+
+// newNotificationDispatcher sends email through SMTP when it is configured. No push provider
+// is wired up yet, so pushes stay queued until one is.
+func newNotificationDispatcher(ctx context.Context, cfg *config.Config) *notificationservice.Dispatcher {
+	var emailSender notificationservice.EmailSender
+	if cfg.SMTP.Host != "" && cfg.SMTP.Port != 0 {
+		emailSender = email.NewSMTPClient(cfg.SMTP)
+	} else {
+		logger.Warn(ctx, "SMTP not configured; notification emails will stay queued")
+	}
+	logger.Warn(ctx, "Push provider not configured; push notifications will stay queued")
+	return notificationservice.NewDispatcher(database.DB, emailSender, nil, cfg.FrontendURL)
+}
+
+// startNotificationDispatcher sends due push and email deliveries every 30 seconds and queues
+// email digests hourly.
+func startNotificationDispatcher(ctx context.Context, dispatcher *notificationservice.Dispatcher) {
+	deliver := func() {
+		sent, err := dispatcher.ProcessDue(ctx, time.Now().UTC())
+		if err != nil {
+			logger.Error(ctx, "Failed to send notification deliveries", "error", err.Error())
+			return
+		}
+		if sent > 0 {
+			logger.Info(ctx, "Sent notification deliveries", "sent", sent)
+		}
+	}
+	queueDigests := func() {
+		if !dispatcher.HasEmail() {
+			return
+		}
+		queued, err := dispatcher.QueueDigests(ctx, time.Now().UTC())
+		if err != nil {
+			logger.Error(ctx, "Failed to queue notification digests", "error", err.Error())
+			return
+		}
+		if queued > 0 {
+			logger.Info(ctx, "Queued notification digests", "queued", queued)
+		}
+	}
+
+	go func() {
+		queueDigests()
+		deliver()
+		deliveries := time.NewTicker(30 * time.Second)
+		digests := time.NewTicker(time.Hour)
+		defer deliveries.Stop()
+		defer digests.Stop()
+		for {
+			select {
+			case <-deliveries.C:
+				deliver()
+			case <-digests.C:
+				queueDigests()
+			}
+		}
+	}()
+}
@@ -16,6 +16,10 @@ func migrationModels() []interface{} {
 		// Social
 		&model.UserFollow{},
 		&model.MerchantFollow{},
+		&model.FollowRequest{},
+		&model.UserBlock{},
+		&model.UserMute{},
+		&model.StoreFollow{},
 		&model.Like{},
 		&model.Favorite{},
 		// User settings
@@ -26,15 +30,19 @@ func migrationModels() []interface{} {
 		// Merchant & Store
 		&model.Merchant{},
 		&model.Category{},
+		&model.CategoryTranslation{},
 		&model.StoreCategory{},
 		&model.Store{},
 		&model.StoreHour{},
+		&model.StoreSpecialHour{},
 		// Tags
 		&model.Tag{},
 		// Content
 		&model.Review{},
 		&model.ReviewMedia{},
 		&model.ReviewComment{},
+		&model.ReviewEdit{},
+		&model.RatingAggregate{},
 		&model.Post{},
 		&model.PostComment{},
 		// Commerce
@@ -48,7 +56,10 @@ func migrationModels() []interface{} {
 		// Messaging
 		&model.Conversation{},
 		&model.ConversationParticipant{},
+		&model.SocketTicket{},
+		&model.FeedSnapshot{},
 		&model.Message{},
+		&model.MessageDeletion{},
 		// Merchant verification
 		&model.MerchantVerification{},
 		// Marketing & Analytics
@@ -56,11 +67,14 @@ func migrationModels() []interface{} {
 		&model.MerchantAnalytics{},
 		// Notifications
 		&model.Notification{},
+		&model.DeviceToken{},
+		&model.NotificationDelivery{},
 		// Reports & Admin
 		&model.Report{},
 		&model.AdminAuditLog{},
 		// Browsing History
 		&model.BrowsingHistory{},
+		&model.RecentSearch{},
 	}
 }
 
@@ -13,3 +13,18 @@ func TestBuildRouter(t *testing.T) {
 		t.Fatal("expected router")
 	}
 }
+
+func TestRedactQueryMasksCredentials(t *testing.T) {
+	cases := map[string]string{
+		"":                             "",
+		"page=2&sort=new":              "page=2&sort=new",
+		"ticket=abc&conversation_id=3": "conversation_id=3&ticket=%5BREDACTED%5D",
+		"access_token=eyJhbGc":         "access_token=%5BREDACTED%5D",
+		"code=x;y":                     "[unparseable]",
+	}
+	for raw, want := range cases {
+		if got := redactQuery(raw); got != want {
+			t.Errorf("redactQuery(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
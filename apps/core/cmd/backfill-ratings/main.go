@@ -0,0 +1,45 @@
+// Command backfill-ratings rebuilds the rating aggregates of every merchant and store from
+// their visible reviews. Run it once after migration 00012 and periodically (e.g. daily)
+// to refresh the recency-weighted scores.
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	reviewservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
+)
+
+func main() {
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error(ctx, "Failed to load configuration", "error", err.Error())
+		os.Exit(1)
+	}
+
+	logger.Init(logger.Config{
+		Service: "revieu-backfill-ratings",
+		Version: "1.0.0",
+		Level:   cfg.Logger.Level,
+	})
+
+	if err := database.Connect(cfg.Database); err != nil {
+		logger.Error(ctx, "Failed to connect to database", "error", err.Error())
+		os.Exit(1)
+	}
+
+	start := time.Now()
+	svc := reviewservice.NewReviewService(database.DB, nil, cfg.Reviews)
+	rebuilt, err := svc.RebuildRatingAggregates(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to rebuild rating aggregates", "rebuilt", rebuilt, "error", err.Error())
+		os.Exit(1)
+	}
+	logger.Info(ctx, "Rebuilt rating aggregates", "rebuilt", rebuilt, "duration_ms", time.Since(start).Milliseconds())
+}
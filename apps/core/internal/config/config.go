@@ -10,15 +10,20 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server      ServerConfig   `yaml:"server"`
-	Database    DatabaseConfig `yaml:"database"`
-	Logger      LoggerConfig   `yaml:"logger"`
-	JWT         JWTConfig      `yaml:"jwt"`
-	OAuth       OAuthConfig    `yaml:"oauth"`
-	SMTP        SMTPConfig     `yaml:"smtp"`
-	R2          R2Config       `yaml:"r2"`
-	Gemini      GeminiConfig   `yaml:"gemini"`
-	FrontendURL string         `yaml:"frontend_url"`
+	Server      ServerConfig     `yaml:"server"`
+	Database    DatabaseConfig   `yaml:"database"`
+	Logger      LoggerConfig     `yaml:"logger"`
+	JWT         JWTConfig        `yaml:"jwt"`
+	OAuth       OAuthConfig      `yaml:"oauth"`
+	SMTP        SMTPConfig       `yaml:"smtp"`
+	R2          R2Config         `yaml:"r2"`
+	Gemini      GeminiConfig     `yaml:"gemini"`
+	Moderation  ModerationConfig `yaml:"moderation"`
+	Media       MediaConfig      `yaml:"media"`
+	Reviews     ReviewConfig     `yaml:"reviews"`
+	Feed        FeedConfig       `yaml:"feed"`
+	Search      SearchConfig     `yaml:"search"`
+	FrontendURL string           `yaml:"frontend_url"`
 }
 
 // GeminiConfig holds Google Gemini API configuration for AI-powered review polishing.
@@ -32,6 +37,71 @@ type GeminiConfig struct {
 	TimeoutSeconds int    `yaml:"timeout_seconds"`
 }
 
+// ModerationConfig controls the text moderation pipeline that screens reviews, comments
+// and messages. Mode is "sync" (default: screen before persisting), "async" (persist as
+// visible and screen in the background) or "off". BlockedTerms is keyed by locale; the
+// "default" list applies to every locale. Zero-valued limits fall back to built-in defaults.
+type ModerationConfig struct {
+	Mode                string              `yaml:"mode"`
+	BlockedTerms        map[string][]string `yaml:"blocked_terms"`
+	MaxLinks            int                 `yaml:"max_links"`
+	MaxPhoneNumbers     int                 `yaml:"max_phone_numbers"`
+	RepeatWindowMinutes int                 `yaml:"repeat_window_minutes"`
+	RepeatThreshold     int                 `yaml:"repeat_threshold"`
+	UseLLM              bool                `yaml:"use_llm"`
+	AutoReport          bool                `yaml:"auto_report"`
+}
+
+// MediaConfig holds limits for the upload analysis pipeline behind POST /media/:id/analysis.
+// SafetyClassifier is "gemini" (vision model through the ai service) or "local" (approve
+// everything, for development); when gemini is configured but cannot be built, analysis
+// fails and uploads stay pending. KnownBadHashes are 16-char hex perceptual hashes; uploads
+// within HashDistance bits of one are rejected, and the same distance marks duplicates of
+// earlier uploads. Zero values fall back to built-in defaults.
+type MediaConfig struct {
+	MaxFileBytes     int64    `yaml:"max_file_bytes"`
+	MinDimension     int      `yaml:"min_dimension"`
+	MaxDimension     int      `yaml:"max_dimension"`
+	SafetyClassifier string   `yaml:"safety_classifier"`
+	KnownBadHashes   []string `yaml:"known_bad_hashes"`
+	HashDistance     int      `yaml:"hash_distance"`
+}
+
+// ReviewConfig holds the review authenticity rules. StoreWindowDays limits a user to one
+// review per store (or per merchant when no store is given) within that many days.
+// Accounts younger than NewAccountDays may post at most NewAccountDailyLimit reviews per
+// 24 hours. A zero value disables the corresponding rule.
+//
+// The remaining fields tune the rating scores kept on merchants and stores: the Bayesian
+// score blends each average with PriorWeight reviews at PriorMean, and the recent score
+// halves a review's weight every RecencyHalfLifeDays. Zero values fall back to defaults.
+type ReviewConfig struct {
+	StoreWindowDays      int     `yaml:"store_window_days"`
+	NewAccountDays       int     `yaml:"new_account_days"`
+	NewAccountDailyLimit int     `yaml:"new_account_daily_limit"`
+	PriorMean            float64 `yaml:"prior_mean"`
+	PriorWeight          float64 `yaml:"prior_weight"`
+	RecencyHalfLifeDays  int     `yaml:"recency_half_life_days"`
+}
+
+// FeedConfig tunes the home feed. Ranker is "weighted" (default: engagement and source
+// weighted, decayed by age) or "chronological". Candidates are drawn from the last
+// LookbackDays; trending stores are limited to RadiusKM around the caller when a location
+// is given. Zero values fall back to built-in defaults.
+type FeedConfig struct {
+	Ranker       string  `yaml:"ranker"`
+	LookbackDays int     `yaml:"lookback_days"`
+	RadiusKM     float64 `yaml:"radius_km"`
+}
+
+// SearchConfig tunes search autocomplete. Suggestions are served from an in-process cache
+// rebuilt every SuggestRefreshSeconds; RecentLimit caps the searches kept per user. Zero
+// values fall back to built-in defaults.
+type SearchConfig struct {
+	SuggestRefreshSeconds int `yaml:"suggest_refresh_seconds"`
+	RecentLimit           int `yaml:"recent_limit"`
+}
+
 // SMTPConfig holds SMTP email configuration
 type SMTPConfig struct {
 	Host     string `yaml:"host"`
@@ -0,0 +1,16 @@
+package dto
+
+// Safety labels returned by the moderation classifier. Anything the model cannot place
+// confidently is reported as LabelQuestionable so callers can route it to manual review.
+const (
+	LabelSafe         = "safe"
+	LabelQuestionable = "questionable"
+	LabelUnsafe       = "unsafe"
+)
+
+// Classification is the model's safety assessment of a piece of user content.
+type Classification struct {
+	Label      string   `json:"label"`
+	Confidence float64  `json:"confidence"`
+	Categories []string `json:"categories"`
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/dto"
+	"google.golang.org/genai"
+)
+
+// classifierInstruction anchors the model as a content-safety rater. Temperature is pinned
+// to zero for classification so identical inputs produce identical labels.
+const classifierInstruction = "You are a content-safety rater for a consumer review platform. " +
+	"Classify the user content as \"safe\", \"questionable\" or \"unsafe\". Unsafe covers hate, " +
+	"harassment, sexual content, violence, self-harm, illegal goods, personal data and spam. " +
+	"Questionable covers borderline cases a human moderator should look at. " +
+	"Return JSON that conforms to the response schema."
+
+// TextClassifier is the narrow surface the moderation pipeline depends on. Like GeminiClient
+// it is an interface so callers can be unit-tested with a fake.
+type TextClassifier interface {
+	ClassifyText(ctx context.Context, text string) (dto.Classification, error)
+}
+
+// ImageClassifier rates a single image; the media analysis pipeline depends on it.
+type ImageClassifier interface {
+	ClassifyImage(ctx context.Context, mimeType string, data []byte) (dto.Classification, error)
+}
+
+// Classifier is implemented by the Gemini client for both text and images.
+type Classifier interface {
+	TextClassifier
+	ImageClassifier
+}
+
+// NewGeminiClassifier constructs a Gemini-backed Classifier from the same configuration
+// used for review polishing.
+func NewGeminiClassifier(ctx context.Context, cfg config.GeminiConfig) (Classifier, error) {
+	return newGeminiClient(ctx, cfg)
+}
+
+func (c *geminiClient) ClassifyText(ctx context.Context, text string) (dto.Classification, error) {
+	prompt := "--- CONTENT ---\n" + text + "\n--- END CONTENT ---"
+	return c.classify(ctx, []*genai.Part{genai.NewPartFromText(prompt)})
+}
+
+func (c *geminiClient) ClassifyImage(ctx context.Context, mimeType string, data []byte) (dto.Classification, error) {
+	return c.classify(ctx, []*genai.Part{
+		genai.NewPartFromText("Classify the attached user-uploaded image."),
+		genai.NewPartFromBytes(data, mimeType),
+	})
+}
+
+// classify sends the parts to the model and decodes the label. A prompt or response
+// blocked by Gemini's own safety filter is itself a strong unsafe signal, so it is
+// reported as an unsafe classification rather than an error.
+func (c *geminiClient) classify(ctx context.Context, parts []*genai.Part) (dto.Classification, error) {
+	var temperature float32
+	resp, err := c.sdk.Models.GenerateContent(
+		ctx,
+		c.model,
+		[]*genai.Content{{Parts: parts, Role: genai.RoleUser}},
+		&genai.GenerateContentConfig{
+			SystemInstruction: &genai.Content{
+				Parts: []*genai.Part{genai.NewPartFromText(classifierInstruction)},
+			},
+			Temperature:      &temperature,
+			ResponseMIMEType: "application/json",
+			ResponseSchema:   classificationSchema(),
+		},
+	)
+	if err != nil {
+		return dto.Classification{}, classifyUpstreamError(err)
+	}
+
+	if fb := resp.PromptFeedback; fb != nil && fb.BlockReason != "" {
+		return blockedClassification(string(fb.BlockReason)), nil
+	}
+	if len(resp.Candidates) > 0 {
+		switch resp.Candidates[0].FinishReason {
+		case genai.FinishReasonSafety,
+			genai.FinishReasonBlocklist,
+			genai.FinishReasonProhibitedContent,
+			genai.FinishReasonSPII,
+			genai.FinishReasonImageSafety,
+			genai.FinishReasonImageProhibitedContent:
+			return blockedClassification(string(resp.Candidates[0].FinishReason)), nil
+		}
+	}
+
+	return parseClassification(resp.Text())
+}
+
+func blockedClassification(reason string) dto.Classification {
+	return dto.Classification{
+		Label:      dto.LabelUnsafe,
+		Confidence: 1,
+		Categories: []string{strings.ToLower(reason)},
+	}
+}
+
+// classificationSchema constrains the model output to
+// {"label":"safe|questionable|unsafe","confidence":0..1,"categories":[...]}.
+func classificationSchema() *genai.Schema {
+	minConfidence := 0.0
+	maxConfidence := 1.0
+	return &genai.Schema{
+		Type:     genai.TypeObject,
+		Required: []string{"label", "confidence"},
+		Properties: map[string]*genai.Schema{
+			"label": {
+				Type: genai.TypeString,
+				Enum: []string{dto.LabelSafe, dto.LabelQuestionable, dto.LabelUnsafe},
+			},
+			"confidence": {
+				Type:    genai.TypeNumber,
+				Minimum: &minConfidence,
+				Maximum: &maxConfidence,
+			},
+			"categories": {
+				Type:        genai.TypeArray,
+				Description: "Short snake_case policy categories that apply, empty when safe.",
+				Items:       &genai.Schema{Type: genai.TypeString},
+			},
+		},
+	}
+}
+
+// parseClassification decodes the model's JSON output. Unknown labels are rejected so a
+// schema drift never silently approves content.
+func parseClassification(raw string) (dto.Classification, error) {
+	trimmed := stripJSONFence(strings.TrimSpace(raw))
+	if trimmed == "" {
+		return dto.Classification{}, fmt.Errorf("%w: empty response", ErrGeminiInvalidResponse)
+	}
+	var out dto.Classification
+	if err := json.Unmarshal([]byte(trimmed), &out); err != nil {
+		return dto.Classification{}, fmt.Errorf("%w: %v", ErrGeminiInvalidResponse, err)
+	}
+	out.Label = strings.ToLower(strings.TrimSpace(out.Label))
+	switch out.Label {
+	case dto.LabelSafe, dto.LabelQuestionable, dto.LabelUnsafe:
+	default:
+		return dto.Classification{}, fmt.Errorf("%w: unknown label %q", ErrGeminiInvalidResponse, out.Label)
+	}
+	if out.Categories == nil {
+		out.Categories = []string{}
+	}
+	return out, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/dto"
+)
+
+func TestParseClassification_HappyPath(t *testing.T) {
+	got, err := parseClassification(`{"label":"Unsafe","confidence":0.92,"categories":["spam"]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Label != dto.LabelUnsafe || got.Confidence != 0.92 || len(got.Categories) != 1 {
+		t.Fatalf("unexpected classification: %+v", got)
+	}
+}
+
+func TestParseClassification_FencedOutputWithoutCategories(t *testing.T) {
+	got, err := parseClassification("```json\n{\"label\":\"safe\",\"confidence\":0.99}\n```")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Label != dto.LabelSafe {
+		t.Fatalf("expected safe label, got %q", got.Label)
+	}
+	if got.Categories == nil {
+		t.Fatal("expected categories to default to an empty slice")
+	}
+}
+
+func TestParseClassification_RejectsUnknownLabel(t *testing.T) {
+	_, err := parseClassification(`{"label":"probably fine","confidence":0.5}`)
+	if !errors.Is(err, ErrGeminiInvalidResponse) {
+		t.Fatalf("expected ErrGeminiInvalidResponse, got %v", err)
+	}
+}
+
+func TestParseClassification_Empty(t *testing.T) {
+	if _, err := parseClassification("  "); !errors.Is(err, ErrGeminiInvalidResponse) {
+		t.Fatalf("expected ErrGeminiInvalidResponse, got %v", err)
+	}
+}
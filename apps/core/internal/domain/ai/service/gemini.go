@@ -56,6 +56,12 @@ type geminiClient struct {
 // when the API key or model are missing — callers (e.g., routes wiring) decide whether to
 // hard-fail boot or skip the route.
 func NewGeminiClient(ctx context.Context, cfg config.GeminiConfig) (GeminiClient, error) {
+	return newGeminiClient(ctx, cfg)
+}
+
+// newGeminiClient validates the configuration and builds the SDK-backed client shared by
+// every Gemini-facing interface in this package.
+func newGeminiClient(ctx context.Context, cfg config.GeminiConfig) (*geminiClient, error) {
 	if strings.TrimSpace(cfg.APIKey) == "" {
 		return nil, errors.New("ai: gemini api_key is empty")
 	}
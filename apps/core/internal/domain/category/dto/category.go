@@ -0,0 +1,45 @@
+package dto
+
+// CategoryNode is a category in the public tree, named in the requested locale. StoreCount
+// is the number of published stores in the category or any of its descendants.
+type CategoryNode struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	ParentID   *int64         `json:"parent_id"`
+	IconURL    string         `json:"icon_url"`
+	StoreCount int64          `json:"store_count"`
+	Children   []CategoryNode `json:"children"`
+}
+
+// AdminCategory is a category with all of its translations, keyed by locale.
+type AdminCategory struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	ParentID *int64            `json:"parent_id"`
+	IconURL  string            `json:"icon_url"`
+	Names    map[string]string `json:"names"`
+}
+
+// CreateCategoryRequest is the request payload for creating a category. IconMediaID is the
+// UUID of an approved media upload; Names holds translations keyed by locale ("es", "zh-TW").
+type CreateCategoryRequest struct {
+	Name        string            `json:"name" binding:"required,max=50"`
+	ParentID    *int64            `json:"parent_id"`
+	IconMediaID string            `json:"icon_media_id" binding:"max=36"`
+	Names       map[string]string `json:"names"`
+}
+
+// UpdateCategoryRequest is the request payload for partially updating a category. A
+// parent_id of 0 moves the category to the top level, an empty icon_media_id removes the
+// icon, and names replaces every translation.
+type UpdateCategoryRequest struct {
+	Name        *string            `json:"name" binding:"omitempty,max=50"`
+	ParentID    *int64             `json:"parent_id"`
+	IconMediaID *string            `json:"icon_media_id" binding:"omitempty,max=36"`
+	Names       *map[string]string `json:"names"`
+}
+
+// MergeCategoryRequest names the category that absorbs the merged one.
+type MergeCategoryRequest struct {
+	TargetID int64 `json:"target_id" binding:"required"`
+}
@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/category/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/category/service"
+	storedto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/dto"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,11 +26,316 @@ func NewCategoryHandler(svc *service.CategoryService) *CategoryHandler {
 
 // ListCategories godoc
 // @Summary List categories
-// @Description Returns a list of all categories
+// @Description Returns all categories as a tree, each with the number of published stores in it or its subcategories. Names are translated for the locale query parameter, or else the Accept-Language header, when a translation exists.
 // @Tags category
 // @Produce json
+// @Param locale query string false "Locale for category names, e.g. es or zh-TW"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /categories [get]
 func (h *CategoryHandler) List(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "not implemented"})
+	locale, err := requestLocale(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tree, err := h.svc.Tree(c.Request.Context(), locale)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list categories"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tree})
+}
+
+// CategoryStores godoc
+// @Summary List stores in a category
+// @Description Returns published stores in a category or any of its subcategories
+// @Tags category
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param lat query number false "Latitude"
+// @Param lng query number false "Longitude"
+// @Param radius_km query number false "Search radius in KM (default 20)"
+// @Param sort query string false "newest (default), distance (needs lat and lng), rating or popularity"
+// @Param open_now query bool false "Only stores open right now in their own timezone"
+// @Param cursor query string false "Cursor returned with the previous page"
+// @Param limit query int false "Page size (max 100)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categories/{id}/stores [get]
+func (h *CategoryHandler) Stores(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+	query, err := parseStoreListQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stores, cursor, err := h.svc.ListStores(c.Request.Context(), id, query)
+	if err != nil {
+		if errors.Is(err, service.ErrCategoryNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		if errors.Is(err, storeservice.ErrInvalidSort) ||
+			errors.Is(err, storeservice.ErrLocationRequired) ||
+			errors.Is(err, storeservice.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list stores"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": stores, "cursor": cursor})
+}
+
+// AdminGetCategory godoc
+// @Summary Get a category (admin)
+// @Description Returns a category with all of its translated names
+// @Tags admin
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/categories/{id} [get]
+func (h *CategoryHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+	category, err := h.svc.Get(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err, "failed to load category")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": category})
+}
+
+// AdminCreateCategory godoc
+// @Summary Create a category (admin)
+// @Description Creates a category, optionally under a parent. icon_media_id is the UUID of an approved image upload from /media; names holds translations keyed by locale.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateCategoryRequest true "Create category request"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/categories [post]
+func (h *CategoryHandler) Create(c *gin.Context) {
+	var req dto.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	category, err := h.svc.Create(c.Request.Context(), c.GetInt64("user_id"), req)
+	if err != nil {
+		writeError(c, err, "failed to create category")
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": category})
+}
+
+// AdminUpdateCategory godoc
+// @Summary Update a category (admin)
+// @Description Renames, reparents, re-icons or re-translates a category. parent_id 0 moves it to the top level; moving it under itself or a descendant is rejected. An empty icon_media_id removes the icon and names replaces every translation.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param request body dto.UpdateCategoryRequest true "Update category request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/categories/{id} [patch]
+func (h *CategoryHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+	var req dto.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	category, err := h.svc.Update(c.Request.Context(), c.GetInt64("user_id"), id, req)
+	if err != nil {
+		writeError(c, err, "failed to update category")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": category})
+}
+
+// AdminDeleteCategory godoc
+// @Summary Delete a category (admin)
+// @Description Deletes a category. Its subcategories move up to its parent and its stores lose the category.
+// @Tags admin
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/categories/{id} [delete]
+func (h *CategoryHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
+		writeError(c, err, "failed to delete category")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// AdminMergeCategory godoc
+// @Summary Merge categories (admin)
+// @Description Merges a category into target_id: its stores and subcategories move to the target and the category is deleted
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID to merge away"
+// @Param request body dto.MergeCategoryRequest true "Merge target"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/categories/{id}/merge [post]
+func (h *CategoryHandler) Merge(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+	var req dto.MergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	category, err := h.svc.Merge(c.Request.Context(), id, req.TargetID)
+	if err != nil {
+		writeError(c, err, "failed to merge categories")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": category})
+}
+
+func writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, service.ErrParentNotFound),
+		errors.Is(err, service.ErrCategoryCycle),
+		errors.Is(err, service.ErrMergeIntoSelf),
+		errors.Is(err, service.ErrIconNotApproved),
+		errors.Is(err, service.ErrInvalidLocale),
+		errors.Is(err, service.ErrInvalidCategoryName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// requestLocale reads the locale query parameter, falling back to the first language in
+// Accept-Language. Only an explicit but malformed locale is an error.
+func requestLocale(c *gin.Context) (string, error) {
+	if raw := c.Query("locale"); raw != "" {
+		return service.NormalizeLocale(raw)
+	}
+	header := c.GetHeader("Accept-Language")
+	first, _, _ := strings.Cut(header, ",")
+	tag, _, _ := strings.Cut(first, ";")
+	locale, err := service.NormalizeLocale(tag)
+	if err != nil {
+		return "", nil
+	}
+	return locale, nil
+}
+
+func parseStoreListQuery(c *gin.Context) (storedto.StoreListQuery, error) {
+	var q storedto.StoreListQuery
+
+	lat, hasLat, err := parseFloat64Query(c, "lat")
+	if err != nil {
+		return q, err
+	}
+	lng, hasLng, err := parseFloat64Query(c, "lng")
+	if err != nil {
+		return q, err
+	}
+	if hasLat != hasLng {
+		return q, errors.New("lat and lng must be provided together")
+	}
+	if hasLat {
+		q.Lat = &lat
+		q.Lng = &lng
+	}
+
+	radiusKM, hasRadiusKM, err := parseFloat64Query(c, "radius_km")
+	if err != nil {
+		return q, err
+	}
+	if hasRadiusKM {
+		if radiusKM <= 0 {
+			return q, errors.New("radius_km must be greater than 0")
+		}
+		q.RadiusKM = &radiusKM
+	}
+
+	q.Sort = c.Query("sort")
+
+	if raw := c.Query("open_now"); raw != "" {
+		openNow, err := strconv.ParseBool(raw)
+		if err != nil {
+			return q, errors.New("invalid open_now")
+		}
+		q.OpenNow = openNow
+	}
+
+	q.Cursor = c.Query("cursor")
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return q, errors.New("invalid limit")
+		}
+		q.Limit = &limit
+	}
+
+	return q, nil
+}
+
+func parseFloat64Query(c *gin.Context, key string) (float64, bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return 0, false, nil
+	}
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false, errors.New("invalid " + key)
+	}
+	return parsed, true, nil
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/category/handler"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/category/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,5 +16,15 @@ func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
 	categories := r.Group("/categories")
 	{
 		categories.GET("", h.List)
+		categories.GET("/:id/stores", h.Stores)
+	}
+
+	adminCategories := r.Group("/admin/categories", middleware.JWTAuth(cfg.JWT), middleware.RequireRole("admin"))
+	{
+		adminCategories.POST("", h.Create)
+		adminCategories.GET("/:id", h.Get)
+		adminCategories.PATCH("/:id", h.Update)
+		adminCategories.DELETE("/:id", h.Delete)
+		adminCategories.POST("/:id/merge", h.Merge)
 	}
 }
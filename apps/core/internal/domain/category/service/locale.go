@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxCategoryNameLength = 50
+
+var ErrInvalidLocale = errors.New("invalid locale")
+var ErrInvalidCategoryName = errors.New("category name must be 1 to 50 characters")
+
+// localePattern accepts a language with an optional region or script ("es", "pt-br",
+// "zh-hant").
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// NormalizeLocale lowercases a locale tag and turns "zh_TW" into "zh-tw". It returns
+// ErrInvalidLocale for anything that is not a language with an optional subtag.
+func NormalizeLocale(raw string) (string, error) {
+	locale := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(raw)), "_", "-")
+	if !localePattern.MatchString(locale) {
+		return "", ErrInvalidLocale
+	}
+	return locale, nil
+}
+
+// localeCandidates lists the translations to try for a locale, most specific first:
+// "zh-tw" falls back to "zh". An empty or invalid locale has none.
+func localeCandidates(raw string) []string {
+	locale, err := NormalizeLocale(raw)
+	if err != nil {
+		return nil
+	}
+	candidates := []string{locale}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	return candidates
+}
+
+func normalizeCategoryName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCategoryNameLength {
+		return "", ErrInvalidCategoryName
+	}
+	return name, nil
+}
+
+// normalizeNames validates translations and keys them by normalized locale.
+func normalizeNames(raw map[string]string) (map[string]string, error) {
+	names := make(map[string]string, len(raw))
+	for rawLocale, rawName := range raw {
+		locale, err := NormalizeLocale(rawLocale)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLocale, rawLocale)
+		}
+		name, err := normalizeCategoryName(rawName)
+		if err != nil {
+			return nil, fmt.Errorf("%w (%s)", ErrInvalidCategoryName, locale)
+		}
+		names[locale] = name
+	}
+	return names, nil
+}
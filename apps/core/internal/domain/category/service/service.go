@@ -1,17 +1,469 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/category/dto"
+	storedto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/dto"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var ErrCategoryNotFound = errors.New("category not found")
+var ErrParentNotFound = errors.New("parent category not found")
+var ErrCategoryCycle = errors.New("a category cannot be moved inside itself")
+var ErrMergeIntoSelf = errors.New("a category cannot be merged into itself")
+var ErrIconNotApproved = errors.New("icon must be an approved media upload")
+
 type CategoryService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	stores *storeservice.StoreService
 }
 
 func NewCategoryService(db *gorm.DB) *CategoryService {
 	if db == nil {
 		db = database.DB
 	}
-	return &CategoryService{db: db}
+	return &CategoryService{db: db, stores: storeservice.NewStoreService(db)}
+}
+
+// Tree returns every category nested under its parent, named in locale when a translation
+// exists, with siblings sorted by name.
+func (s *CategoryService) Tree(ctx context.Context, locale string) ([]dto.CategoryNode, error) {
+	db := s.db.WithContext(ctx)
+
+	var categories []model.Category
+	if err := db.Order("id ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	names, err := s.localizedNames(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []model.StoreCategory
+	if err := db.Table("store_categories AS sc").
+		Select("sc.store_id, sc.category_id").
+		Joins("JOIN stores s ON s.id = sc.store_id AND s.status = ? AND s.deleted_at IS NULL", storeservice.StoreStatusPublished).
+		Scan(&links).Error; err != nil {
+		return nil, err
+	}
+	storesByCategory := make(map[int64][]int64)
+	for _, link := range links {
+		storesByCategory[link.CategoryID] = append(storesByCategory[link.CategoryID], link.StoreID)
+	}
+
+	exists := make(map[int64]bool, len(categories))
+	for _, category := range categories {
+		exists[category.ID] = true
+	}
+	children := make(map[int64][]model.Category)
+	var roots []model.Category
+	for _, category := range categories {
+		if category.ParentID != nil && exists[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	// build returns the node with its subtree and the distinct stores in it, so a store
+	// listed under both a parent and a child counts once.
+	var build func(category model.Category) (dto.CategoryNode, map[int64]struct{})
+	build = func(category model.Category) (dto.CategoryNode, map[int64]struct{}) {
+		node := dto.CategoryNode{
+			ID:       category.ID,
+			Name:     category.Name,
+			ParentID: category.ParentID,
+			IconURL:  category.IconURL,
+			Children: []dto.CategoryNode{},
+		}
+		if name, ok := names[category.ID]; ok {
+			node.Name = name
+		}
+		stores := make(map[int64]struct{})
+		for _, storeID := range storesByCategory[category.ID] {
+			stores[storeID] = struct{}{}
+		}
+		for _, child := range children[category.ID] {
+			childNode, childStores := build(child)
+			node.Children = append(node.Children, childNode)
+			for storeID := range childStores {
+				stores[storeID] = struct{}{}
+			}
+		}
+		sortNodes(node.Children)
+		node.StoreCount = int64(len(stores))
+		return node, stores
+	}
+
+	tree := make([]dto.CategoryNode, 0, len(roots))
+	for _, root := range roots {
+		node, _ := build(root)
+		tree = append(tree, node)
+	}
+	sortNodes(tree)
+	return tree, nil
+}
+
+func sortNodes(nodes []dto.CategoryNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := strings.ToLower(nodes[i].Name), strings.ToLower(nodes[j].Name)
+		if a != b {
+			return a < b
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+}
+
+// localizedNames maps category ids to their best translation for locale.
+func (s *CategoryService) localizedNames(ctx context.Context, locale string) (map[int64]string, error) {
+	candidates := localeCandidates(locale)
+	names := make(map[int64]string)
+	if len(candidates) == 0 {
+		return names, nil
+	}
+	var translations []model.CategoryTranslation
+	if err := s.db.WithContext(ctx).Where("locale IN ?", candidates).Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	rank := make(map[int64]int)
+	for _, translation := range translations {
+		for i, candidate := range candidates {
+			if translation.Locale != candidate {
+				continue
+			}
+			if best, ok := rank[translation.CategoryID]; !ok || i < best {
+				rank[translation.CategoryID] = i
+				names[translation.CategoryID] = translation.Name
+			}
+		}
+	}
+	return names, nil
+}
+
+// ListStores lists published stores in the category or any of its descendants, with the
+// same sorting, filters and cursor as the public store list.
+func (s *CategoryService) ListStores(ctx context.Context, id int64, query storedto.StoreListQuery) ([]model.Store, *string, error) {
+	parents, err := loadParents(s.db.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := parents[id]; !ok {
+		return nil, nil, ErrCategoryNotFound
+	}
+	query.Category = nil
+	query.CategoryIDs = subtreeIDs(parents, id)
+	return s.stores.ListPublishedFiltered(ctx, query)
+}
+
+// Get returns a category with its translations.
+func (s *CategoryService) Get(ctx context.Context, id int64) (*dto.AdminCategory, error) {
+	return adminCategory(s.db.WithContext(ctx), id)
+}
+
+// Create adds a category, optionally under a parent, with its icon and translations.
+func (s *CategoryService) Create(ctx context.Context, adminID int64, req dto.CreateCategoryRequest) (*dto.AdminCategory, error) {
+	name, err := normalizeCategoryName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	names, err := normalizeNames(req.Names)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *dto.AdminCategory
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		category := model.Category{Name: name}
+		if req.ParentID != nil && *req.ParentID != 0 {
+			if err := tx.Select("id").First(&model.Category{}, *req.ParentID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrParentNotFound
+				}
+				return err
+			}
+			category.ParentID = req.ParentID
+		}
+		if req.IconMediaID != "" {
+			iconURL, err := resolveIcon(tx, adminID, req.IconMediaID)
+			if err != nil {
+				return err
+			}
+			category.IconURL = iconURL
+		}
+		if err := tx.Create(&category).Error; err != nil {
+			return err
+		}
+		if err := createTranslations(tx, category.ID, names); err != nil {
+			return err
+		}
+		created, err = adminCategory(tx, category.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Update renames, reparents, re-icons or re-translates a category. Moving a category under
+// itself or one of its descendants returns ErrCategoryCycle.
+func (s *CategoryService) Update(ctx context.Context, adminID, id int64, req dto.UpdateCategoryRequest) (*dto.AdminCategory, error) {
+	var updated *dto.AdminCategory
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category model.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+
+		updates := make(map[string]interface{})
+		if req.Name != nil {
+			name, err := normalizeCategoryName(*req.Name)
+			if err != nil {
+				return err
+			}
+			updates["name"] = name
+		}
+		if req.ParentID != nil {
+			if *req.ParentID == 0 {
+				updates["parent_id"] = nil
+			} else {
+				parents, err := loadParents(tx)
+				if err != nil {
+					return err
+				}
+				if _, ok := parents[*req.ParentID]; !ok {
+					return ErrParentNotFound
+				}
+				if isWithin(parents, *req.ParentID, id) {
+					return ErrCategoryCycle
+				}
+				updates["parent_id"] = *req.ParentID
+			}
+		}
+		if req.IconMediaID != nil {
+			iconURL := ""
+			if *req.IconMediaID != "" {
+				var err error
+				if iconURL, err = resolveIcon(tx, adminID, *req.IconMediaID); err != nil {
+					return err
+				}
+			}
+			updates["icon_url"] = iconURL
+		}
+		if req.Names != nil {
+			names, err := normalizeNames(*req.Names)
+			if err != nil {
+				return err
+			}
+			if err := tx.Where("category_id = ?", id).Delete(&model.CategoryTranslation{}).Error; err != nil {
+				return err
+			}
+			if err := createTranslations(tx, id, names); err != nil {
+				return err
+			}
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&model.Category{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		var err error
+		updated, err = adminCategory(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete removes a category. Its children move up to its parent and its stores simply lose
+// the category.
+func (s *CategoryService) Delete(ctx context.Context, id int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category model.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+		if err := tx.Model(&model.Category{}).Where("parent_id = ?", id).
+			Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+		return deleteCategory(tx, id)
+	})
+}
+
+// Merge folds source into target: source's stores and children move to target and source
+// is deleted. Merging a category into one of its own descendants returns ErrCategoryCycle.
+func (s *CategoryService) Merge(ctx context.Context, sourceID, targetID int64) (*dto.AdminCategory, error) {
+	if sourceID == targetID {
+		return nil, ErrMergeIntoSelf
+	}
+	var merged *dto.AdminCategory
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		parents, err := loadParents(tx)
+		if err != nil {
+			return err
+		}
+		if _, ok := parents[sourceID]; !ok {
+			return ErrCategoryNotFound
+		}
+		if _, ok := parents[targetID]; !ok {
+			return ErrCategoryNotFound
+		}
+		if isWithin(parents, targetID, sourceID) {
+			return ErrCategoryCycle
+		}
+
+		var storeIDs []int64
+		if err := tx.Model(&model.StoreCategory{}).Where("category_id = ?", sourceID).
+			Pluck("store_id", &storeIDs).Error; err != nil {
+			return err
+		}
+		if len(storeIDs) > 0 {
+			links := make([]model.StoreCategory, 0, len(storeIDs))
+			for _, storeID := range storeIDs {
+				links = append(links, model.StoreCategory{StoreID: storeID, CategoryID: targetID})
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&model.Category{}).Where("parent_id = ?", sourceID).
+			Update("parent_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := deleteCategory(tx, sourceID); err != nil {
+			return err
+		}
+		merged, err = adminCategory(tx, targetID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func deleteCategory(tx *gorm.DB, id int64) error {
+	if err := tx.Where("category_id = ?", id).Delete(&model.StoreCategory{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("category_id = ?", id).Delete(&model.CategoryTranslation{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&model.Category{}, id).Error
+}
+
+func createTranslations(tx *gorm.DB, categoryID int64, names map[string]string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	translations := make([]model.CategoryTranslation, 0, len(names))
+	for locale, name := range names {
+		translations = append(translations, model.CategoryTranslation{CategoryID: categoryID, Locale: locale, Name: name})
+	}
+	return tx.Create(&translations).Error
+}
+
+func adminCategory(db *gorm.DB, id int64) (*dto.AdminCategory, error) {
+	var category model.Category
+	if err := db.First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	var translations []model.CategoryTranslation
+	if err := db.Where("category_id = ?", id).Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	out := &dto.AdminCategory{
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+		IconURL:  category.IconURL,
+		Names:    make(map[string]string, len(translations)),
+	}
+	for _, translation := range translations {
+		out.Names[translation.Locale] = translation.Name
+	}
+	return out, nil
+}
+
+// resolveIcon returns the URL of an approved media upload owned by the admin.
+func resolveIcon(tx *gorm.DB, adminID int64, uuid string) (string, error) {
+	var upload model.MediaUpload
+	if err := tx.Where("uuid = ? AND user_id = ? AND status = ?", uuid, adminID, model.MediaStatusApproved).
+		First(&upload).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrIconNotApproved
+		}
+		return "", err
+	}
+	if !strings.HasPrefix(upload.MimeType, "image/") {
+		return "", ErrIconNotApproved
+	}
+	return upload.FileURL, nil
+}
+
+// loadParents maps every category id to its parent id (nil for top-level categories).
+func loadParents(db *gorm.DB) (map[int64]*int64, error) {
+	var categories []model.Category
+	if err := db.Select("id", "parent_id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	parents := make(map[int64]*int64, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+	return parents, nil
+}
+
+// isWithin reports whether id is ancestorID or one of its descendants, guarding against
+// cycles already in the data.
+func isWithin(parents map[int64]*int64, id, ancestorID int64) bool {
+	seen := make(map[int64]bool)
+	for current := &id; current != nil && !seen[*current]; current = parents[*current] {
+		if *current == ancestorID {
+			return true
+		}
+		seen[*current] = true
+	}
+	return false
+}
+
+// subtreeIDs returns id and the ids of all its descendants.
+func subtreeIDs(parents map[int64]*int64, id int64) []int64 {
+	children := make(map[int64][]int64)
+	for child, parent := range parents {
+		if parent != nil {
+			children[*parent] = append(children[*parent], child)
+		}
+	}
+	ids := []int64{id}
+	seen := map[int64]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
 }
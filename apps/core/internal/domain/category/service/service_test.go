@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/category/dto"
+	storedto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/dto"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type categoryFixture struct {
+	food, pizza, sushi, shops int64
+	stores                    []model.Store
+}
+
+// seedCategories builds Food > {Pizza, Sushi} and Shops, with three published stores and
+// one draft.
+func seedCategories(t *testing.T, db *gorm.DB) categoryFixture {
+	t.Helper()
+	food := model.Category{Name: "Food"}
+	shops := model.Category{Name: "Shops"}
+	for _, category := range []*model.Category{&food, &shops} {
+		if err := db.Create(category).Error; err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+	}
+	pizza := model.Category{Name: "Pizza", ParentID: &food.ID}
+	sushi := model.Category{Name: "Sushi", ParentID: &food.ID}
+	for _, category := range []*model.Category{&pizza, &sushi} {
+		if err := db.Create(category).Error; err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+	}
+
+	merchant := model.Merchant{Name: "Owner"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	stores := []model.Store{
+		{MerchantID: merchant.ID, Name: "Slice", Status: storeservice.StoreStatusPublished},
+		{MerchantID: merchant.ID, Name: "Fish", Status: storeservice.StoreStatusPublished},
+		{MerchantID: merchant.ID, Name: "Fusion", Status: storeservice.StoreStatusPublished},
+		{MerchantID: merchant.ID, Name: "Draft"},
+	}
+	if err := db.Create(&stores).Error; err != nil {
+		t.Fatalf("failed to create stores: %v", err)
+	}
+	links := []model.StoreCategory{
+		{StoreID: stores[0].ID, CategoryID: pizza.ID},
+		{StoreID: stores[1].ID, CategoryID: sushi.ID},
+		{StoreID: stores[2].ID, CategoryID: pizza.ID},
+		{StoreID: stores[2].ID, CategoryID: sushi.ID},
+		{StoreID: stores[2].ID, CategoryID: food.ID},
+		{StoreID: stores[3].ID, CategoryID: sushi.ID},
+	}
+	if err := db.Create(&links).Error; err != nil {
+		t.Fatalf("failed to link categories: %v", err)
+	}
+	return categoryFixture{food: food.ID, pizza: pizza.ID, sushi: sushi.ID, shops: shops.ID, stores: stores}
+}
+
+func storeIDsIn(t *testing.T, db *gorm.DB, categoryID int64) map[int64]bool {
+	t.Helper()
+	var ids []int64
+	if err := db.Model(&model.StoreCategory{}).Where("category_id = ?", categoryID).Pluck("store_id", &ids).Error; err != nil {
+		t.Fatalf("failed to load links: %v", err)
+	}
+	out := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		out[id] = true
+	}
+	return out
+}
+
+func TestTreeNestsCategoriesWithDistinctPublishedStoreCounts(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	f := seedCategories(t, db)
+	svc := NewCategoryService(db)
+
+	tree, err := svc.Tree(context.Background(), "")
+	if err != nil {
+		t.Fatalf("tree failed: %v", err)
+	}
+	if len(tree) != 2 || tree[0].ID != f.food || tree[1].ID != f.shops {
+		t.Fatalf("expected roots Food, Shops; got %+v", tree)
+	}
+	food := tree[0]
+	if food.StoreCount != 3 {
+		t.Fatalf("expected Food to count each published store once, got %d", food.StoreCount)
+	}
+	if len(food.Children) != 2 || food.Children[0].Name != "Pizza" || food.Children[1].Name != "Sushi" {
+		t.Fatalf("expected children Pizza, Sushi; got %+v", food.Children)
+	}
+	if food.Children[0].StoreCount != 2 || food.Children[1].StoreCount != 2 {
+		t.Fatalf("expected 2 published stores in each child, got %d and %d",
+			food.Children[0].StoreCount, food.Children[1].StoreCount)
+	}
+	if tree[1].StoreCount != 0 || tree[1].Children == nil {
+		t.Fatalf("expected an empty Shops node, got %+v", tree[1])
+	}
+}
+
+func TestTreeTranslatesNamesWithLanguageFallback(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	f := seedCategories(t, db)
+	translations := []model.CategoryTranslation{
+		{CategoryID: f.food, Locale: "zh", Name: "美食"},
+		{CategoryID: f.food, Locale: "zh-tw", Name: "美食 (TW)"},
+		{CategoryID: f.shops, Locale: "zh", Name: "商店"},
+	}
+	if err := db.Create(&translations).Error; err != nil {
+		t.Fatalf("failed to create translations: %v", err)
+	}
+	svc := NewCategoryService(db)
+
+	tree, err := svc.Tree(context.Background(), "zh_TW")
+	if err != nil {
+		t.Fatalf("tree failed: %v", err)
+	}
+	names := map[int64]string{}
+	for _, node := range tree {
+		names[node.ID] = node.Name
+		for _, child := range node.Children {
+			names[child.ID] = child.Name
+		}
+	}
+	if names[f.food] != "美食 (TW)" || names[f.shops] != "商店" || names[f.pizza] != "Pizza" {
+		t.Fatalf("unexpected localized names: %v", names)
+	}
+}
+
+func TestListStoresIncludesSubcategories(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	f := seedCategories(t, db)
+	svc := NewCategoryService(db)
+
+	stores, _, err := svc.ListStores(context.Background(), f.food, storedto.StoreListQuery{})
+	if err != nil {
+		t.Fatalf("list stores failed: %v", err)
+	}
+	if len(stores) != 3 {
+		t.Fatalf("expected the 3 published stores under Food, got %d", len(stores))
+	}
+	stores, _, err = svc.ListStores(context.Background(), f.pizza, storedto.StoreListQuery{})
+	if err != nil {
+		t.Fatalf("list stores failed: %v", err)
+	}
+	if len(stores) != 2 {
+		t.Fatalf("expected 2 stores under Pizza, got %d", len(stores))
+	}
+	if _, _, err := svc.ListStores(context.Background(), 999, storedto.StoreListQuery{}); !errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestCreateResolvesIconAndTranslations(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	f := seedCategories(t, db)
+	uploads := []model.MediaUpload{
+		{UUID: "icon-approved", UserID: 1, ObjectKey: "a", FileURL: "https://cdn/icon.png", MimeType: "image/png", Status: model.MediaStatusApproved},
+		{UUID: "icon-pending", UserID: 1, ObjectKey: "b", FileURL: "https://cdn/pending.png", MimeType: "image/png", Status: model.MediaStatusPending},
+	}
+	if err := db.Create(&uploads).Error; err != nil {
+		t.Fatalf("failed to create uploads: %v", err)
+	}
+	svc := NewCategoryService(db)
+
+	created, err := svc.Create(context.Background(), 1, dto.CreateCategoryRequest{
+		Name:        " Ramen ",
+		ParentID:    &f.food,
+		IconMediaID: "icon-approved",
+		Names:       map[string]string{"ES": "Ramen", "ja_JP": "ラーメン"},
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if created.Name != "Ramen" || created.IconURL != "https://cdn/icon.png" || created.ParentID == nil || *created.ParentID != f.food {
+		t.Fatalf("unexpected category: %+v", created)
+	}
+	if created.Names["es"] != "Ramen" || created.Names["ja-jp"] != "ラーメン" {
+		t.Fatalf("unexpected translations: %v", created.Names)
+	}
+
+	if _, err := svc.Create(context.Background(), 1, dto.CreateCategoryRequest{Name: "Udon", IconMediaID: "icon-pending"}); !errors.Is(err, ErrIconNotApproved) {
+		t.Fatalf("expected ErrIconNotApproved for a pending upload, got %v", err)
+	}
+	if _, err := svc.Create(context.Background(), 2, dto.CreateCategoryRequest{Name: "Udon", IconMediaID: "icon-approved"}); !errors.Is(err, ErrIconNotApproved) {
+		t.Fatalf("expected ErrIconNotApproved for another user's upload, got %v", err)
+	}
+	if _, err := svc.Create(context.Background(), 1, dto.CreateCategoryRequest{Name: "Udon", Names: map[string]string{"not a locale": "x"}}); !errors.Is(err, ErrInvalidLocale) {
+		t.Fatalf("expected ErrInvalidLocale, got %v", err)
+	}
+	missing := int64(999)
+	if _, err := svc.Create(context.Background(), 1, dto.CreateCategoryRequest{Name: "Udon", ParentID: &missing}); !errors.Is(err, ErrParentNotFound) {
+		t.Fatalf("expected ErrParentNotFound, got %v", err)
+	}
+}
+
+func TestUpdateReparentsAndRejectsCycles(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	f := seedCategories(t, db)
+	svc := NewCategoryService(db)
+	ctx := context.Background()
+
+	if _, err := svc.Update(ctx, 1, f.food, dto.UpdateCategoryRequest{ParentID: &f.pizza}); !errors.Is(err, ErrCategoryCycle) {
+		t.Fatalf("expected ErrCategoryCycle moving Food under its child, got %v", err)
+	}
+	if _, err := svc.Update(ctx, 1, f.food, dto.UpdateCategoryRequest{ParentID: &f.food}); !errors.Is(err, ErrCategoryCycle) {
+		t.Fatalf("expected ErrCategoryCycle moving Food under itself, got %v", err)
+	}
+
+	updated, err := svc.Update(ctx, 1, f.food, dto.UpdateCategoryRequest{ParentID: &f.shops})
+	if err != nil {
+		t.Fatalf("reparent failed: %v", err)
+	}
+	if updated.ParentID == nil || *updated.ParentID != f.shops {
+		t.Fatalf("expected Food under Shops, got %+v", updated)
+	}
+	if _, err := svc.Update(ctx, 1, f.shops, dto.UpdateCategoryRequest{ParentID: &f.sushi}); !errors.Is(err, ErrCategoryCycle) {
+		t.Fatalf("expected ErrCategoryCycle moving Shops under its grandchild, got %v", err)
+	}
+
+	root := int64(0)
+	name := "Eat"
+	names := map[string]string{"fr": "Manger"}
+	updated, err = svc.Update(ctx, 1, f.food, dto.UpdateCategoryRequest{ParentID: &root, Name: &name, Names: &names})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if updated.ParentID != nil || updated.Name != "Eat" || len(updated.Names) != 1 || updated.Names["fr"] != "Manger" {
+		t.Fatalf("unexpected category after update: %+v", updated)
+	}
+}
+
+func TestDeleteMovesChildrenUp(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	f := seedCategories(t, db)
+	svc := NewCategoryService(db)
+
+	if err := svc.Delete(context.Background(), f.food); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	var pizza model.Category
+	if err := db.First(&pizza, f.pizza).Error; err != nil {
+		t.Fatalf("failed to load child: %v", err)
+	}
+	if pizza.ParentID != nil {
+		t.Fatalf("expected Pizza at the top level, got parent %d", *pizza.ParentID)
+	}
+	if len(storeIDsIn(t, db, f.food)) != 0 {
+		t.Fatal("expected store links of the deleted category to be removed")
+	}
+	if err := svc.Delete(context.Background(), f.food); !errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestMergeReassignsStoresAndChildren(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	f := seedCategories(t, db)
+	svc := NewCategoryService(db)
+	ctx := context.Background()
+
+	if _, err := svc.Merge(ctx, f.food, f.food); !errors.Is(err, ErrMergeIntoSelf) {
+		t.Fatalf("expected ErrMergeIntoSelf, got %v", err)
+	}
+	if _, err := svc.Merge(ctx, f.food, f.pizza); !errors.Is(err, ErrCategoryCycle) {
+		t.Fatalf("expected ErrCategoryCycle merging into a descendant, got %v", err)
+	}
+
+	merged, err := svc.Merge(ctx, f.sushi, f.pizza)
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	if merged.ID != f.pizza {
+		t.Fatalf("expected the target back, got %+v", merged)
+	}
+	pizzaStores := storeIDsIn(t, db, f.pizza)
+	for _, store := range f.stores {
+		if !pizzaStores[store.ID] {
+			t.Fatalf("expected store %d to be reassigned to Pizza, got %v", store.ID, pizzaStores)
+		}
+	}
+	if len(pizzaStores) != len(f.stores) {
+		t.Fatalf("expected no duplicate links, got %v", pizzaStores)
+	}
+	var count int64
+	db.Model(&model.Category{}).Where("id = ?", f.sushi).Count(&count)
+	if count != 0 {
+		t.Fatal("expected the merged category to be deleted")
+	}
+
+	if _, err := svc.Merge(ctx, f.food, f.shops); err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	var pizza model.Category
+	if err := db.First(&pizza, f.pizza).Error; err != nil {
+		t.Fatalf("failed to load child: %v", err)
+	}
+	if pizza.ParentID == nil || *pizza.ParentID != f.shops {
+		t.Fatalf("expected Pizza to move under Shops, got %+v", pizza.ParentID)
+	}
+}
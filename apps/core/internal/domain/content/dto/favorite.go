@@ -12,6 +12,11 @@ type FavoriteItem struct {
 	CreatedAt  time.Time      `json:"created_at"`
 }
 
+type FavoriteRequest struct {
+	TargetType string `json:"target_type" binding:"required,oneof=post review merchant"`
+	TargetID   int64  `json:"target_id" binding:"required,min=1"`
+}
+
 type FavoriteListResponse struct {
 	Items  []FavoriteItem `json:"items"`
 	Total  int            `json:"total"`
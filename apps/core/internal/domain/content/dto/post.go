@@ -3,16 +3,21 @@ package dto
 import "time"
 
 type PostItem struct {
-	ID        int64          `json:"id"`
-	Title     string         `json:"title"`
-	Content   string         `json:"content"`
-	Images    []string       `json:"images"`
-	LikeCount int            `json:"like_count"`
-	ViewCount int            `json:"view_count"`
-	IsLiked   bool           `json:"is_liked"`
-	Merchant  *MerchantBrief `json:"merchant,omitempty"`
-	Tags      []string       `json:"tags"`
-	CreatedAt time.Time      `json:"created_at"`
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	Title        string         `json:"title"`
+	Content      string         `json:"content"`
+	Images       []string       `json:"images"`
+	LikeCount    int            `json:"like_count"`
+	CommentCount int            `json:"comment_count"`
+	ViewCount    int            `json:"view_count"`
+	IsLiked      bool           `json:"is_liked"`
+	IsPinned     bool           `json:"is_pinned"`
+	Status       int16          `json:"status"`
+	ReviewID     *int64         `json:"review_id,omitempty"`
+	Merchant     *MerchantBrief `json:"merchant,omitempty"`
+	Tags         []string       `json:"tags"`
+	CreatedAt    time.Time      `json:"created_at"`
 }
 
 type PostListResponse struct {
@@ -20,3 +25,57 @@ type PostListResponse struct {
 	Total  int        `json:"total"`
 	Cursor *int64     `json:"cursor,omitempty"`
 }
+
+// CreatePostRequest is the request payload for publishing a post. MediaIDs are UUIDs of the
+// author's approved media uploads; ReviewID alone also links the review's merchant.
+type CreatePostRequest struct {
+	Title      string   `json:"title" binding:"max=100"`
+	Content    string   `json:"content" binding:"required"`
+	MediaIDs   []string `json:"media_ids"`
+	Tags       []string `json:"tags"`
+	MerchantID *int64   `json:"merchant_id"`
+	ReviewID   *int64   `json:"review_id"`
+}
+
+// UpdatePostRequest is the request payload for editing a post. Omitted fields are kept;
+// media_ids and tags replace the current ones, and a merchant_id or review_id of 0 unlinks.
+type UpdatePostRequest struct {
+	Title      *string   `json:"title" binding:"omitempty,max=100"`
+	Content    *string   `json:"content"`
+	MediaIDs   *[]string `json:"media_ids"`
+	Tags       *[]string `json:"tags"`
+	MerchantID *int64    `json:"merchant_id"`
+	ReviewID   *int64    `json:"review_id"`
+}
+
+// PostCommentRequest is the request payload for commenting on a post. ParentCommentID
+// makes it a reply.
+type PostCommentRequest struct {
+	Content         string `json:"content" binding:"required"`
+	ParentCommentID *int64 `json:"parent_comment_id"`
+}
+
+// UpdatePostCommentRequest is the request payload for editing a post comment.
+type UpdatePostCommentRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// PostCommentItem is a post comment with its nested replies.
+type PostCommentItem struct {
+	ID              int64             `json:"id"`
+	PostID          int64             `json:"post_id"`
+	ParentCommentID *int64            `json:"parent_comment_id,omitempty"`
+	Content         string            `json:"content"`
+	LikeCount       int               `json:"like_count"`
+	IsLiked         bool              `json:"is_liked"`
+	Status          int16             `json:"status"`
+	Author          *UserBrief        `json:"author,omitempty"`
+	Replies         []PostCommentItem `json:"replies"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// PostCommentListResponse is a page of top-level comments; Cursor is nil on the last page.
+type PostCommentListResponse struct {
+	Data   []PostCommentItem `json:"data"`
+	Cursor *int64            `json:"cursor"`
+}
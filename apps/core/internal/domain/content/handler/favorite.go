@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
@@ -10,15 +11,81 @@ import (
 )
 
 type FavoriteHandler struct {
-	svc    *service.ContentService
-	helper helper
+	svc          *service.ContentService
+	interactions *service.InteractionService
+	helper       helper
 }
 
-func NewFavoriteHandler(svc *service.ContentService) *FavoriteHandler {
+func NewFavoriteHandler(svc *service.ContentService, interactions *service.InteractionService) *FavoriteHandler {
 	if svc == nil {
 		svc = service.NewContentService(nil)
 	}
-	return &FavoriteHandler{svc: svc, helper: newHelper()}
+	if interactions == nil {
+		interactions = service.NewInteractionService(nil)
+	}
+	return &FavoriteHandler{svc: svc, interactions: interactions, helper: newHelper()}
+}
+
+// Favorite godoc
+// @Summary Add a favorite
+// @Description Saves a post, review or merchant to the authenticated user's favorites; repeating it is a no-op
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param request body dto.FavoriteRequest true "Favorite target"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /user/favorites [post]
+func (h *FavoriteHandler) Favorite(c *gin.Context) {
+	var req dto.FavoriteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.interactions.Favorite(c.Request.Context(), c.GetInt64("user_id"), req.TargetType, req.TargetID); err != nil {
+		respondInteractionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Unfavorite godoc
+// @Summary Remove a favorite
+// @Description Removes a target from the authenticated user's favorites; removing a missing favorite is a no-op
+// @Tags content
+// @Produce json
+// @Param type path string true "Target type (post|review|merchant)"
+// @Param id path int true "Target ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /user/favorites/{type}/{id} [delete]
+func (h *FavoriteHandler) Unfavorite(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid target id"})
+		return
+	}
+	if err := h.interactions.Unfavorite(c.Request.Context(), c.GetInt64("user_id"), c.Param("type"), id); err != nil {
+		respondInteractionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func respondInteractionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInteractionTargetNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, service.ErrUnsupportedInteraction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
 
 // ListMyFavorites godoc
@@ -53,7 +120,7 @@ func (h *FavoriteHandler) ListMyFavorites(c *gin.Context) {
 			CreatedAt:  item.CreatedAt,
 		}
 		switch item.TargetType {
-		case "post":
+		case service.TargetPost:
 			var post model.Post
 			if err := h.helper.db.WithContext(c.Request.Context()).First(&post, item.TargetID).Error; err == nil {
 				fav.Post = &dto.PostItem{
@@ -69,7 +136,7 @@ func (h *FavoriteHandler) ListMyFavorites(c *gin.Context) {
 					CreatedAt: post.CreatedAt,
 				}
 			}
-		case "review":
+		case service.TargetReview:
 			var review model.Review
 			if err := h.helper.db.WithContext(c.Request.Context()).First(&review, item.TargetID).Error; err == nil {
 				fav.Review = &dto.ReviewItem{
@@ -88,7 +155,7 @@ func (h *FavoriteHandler) ListMyFavorites(c *gin.Context) {
 					CreatedAt:     review.CreatedAt,
 				}
 			}
-		case "merchant":
+		case service.TargetMerchant:
 			if merchant := h.helper.loadMerchantBrief(item.TargetID); merchant != nil {
 				fav.Merchant = merchant
 			}
@@ -2,9 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"net/http"
 	"strconv"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"github.com/gin-gonic/gin"
@@ -12,11 +14,27 @@ import (
 )
 
 type helper struct {
-	db *gorm.DB
+	db      *gorm.DB
+	follows *followservice.FollowService
 }
 
 func newHelper() helper {
-	return helper{db: database.DB}
+	return helper{db: database.DB, follows: followservice.NewFollowService(database.DB)}
+}
+
+// allowProfile reports whether the caller may see targetID's content, answering the request
+// itself when they may not.
+func (h helper) allowProfile(c *gin.Context, targetID int64) bool {
+	allowed, err := h.follows.CanView(c.Request.Context(), c.GetInt64("user_id"), targetID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": followservice.ErrProfilePrivate.Error()})
+		return false
+	}
+	return true
 }
 
 func (h helper) getLikedIDs(c *gin.Context, targetType string) map[int64]bool {
@@ -1,28 +1,34 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/gin-gonic/gin"
 )
 
 type PostHandler struct {
 	svc    *service.ContentService
+	posts  *service.PostService
 	helper helper
 }
 
-func NewPostHandler(svc *service.ContentService) *PostHandler {
+func NewPostHandler(svc *service.ContentService, posts *service.PostService) *PostHandler {
 	if svc == nil {
 		svc = service.NewContentService(nil)
 	}
-	return &PostHandler{svc: svc, helper: newHelper()}
+	if posts == nil {
+		posts = service.NewPostService(nil, nil)
+	}
+	return &PostHandler{svc: svc, posts: posts, helper: newHelper()}
 }
 
 // ListUserPosts godoc
 // @Summary List user's posts
-// @Description Returns a user's posts
+// @Description Returns a user's posts; private profiles only show them to the owner and approved followers
 // @Tags content
 // @Produce json
 // @Param id path int true "User ID"
@@ -30,6 +36,7 @@ func NewPostHandler(svc *service.ContentService) *PostHandler {
 // @Param limit query int false "Limit"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /users/{id}/posts [get]
 func (h *PostHandler) ListUserPosts(c *gin.Context) {
@@ -38,8 +45,11 @@ func (h *PostHandler) ListUserPosts(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
 		return
 	}
+	if !h.helper.allowProfile(c, targetID) {
+		return
+	}
 	cursor, limit := parseCursorLimit(c)
-	posts, total, err := h.svc.ListUserPosts(c.Request.Context(), targetID, cursor, limit)
+	posts, total, err := h.svc.ListUserPosts(c.Request.Context(), c.GetInt64("user_id"), targetID, cursor, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -47,22 +57,7 @@ func (h *PostHandler) ListUserPosts(c *gin.Context) {
 	liked := h.helper.getLikedIDs(c, "post")
 	items := make([]dto.PostItem, 0, len(posts))
 	for _, post := range posts {
-		var merchant *dto.MerchantBrief
-		if post.MerchantID != nil {
-			merchant = h.helper.loadMerchantBrief(*post.MerchantID)
-		}
-		items = append(items, dto.PostItem{
-			ID:        post.ID,
-			Title:     post.Title,
-			Content:   post.Content,
-			Images:    parseJSONStrings(post.Images),
-			LikeCount: post.LikeCount,
-			ViewCount: post.ViewCount,
-			IsLiked:   liked[post.ID],
-			Merchant:  merchant,
-			Tags:      []string{},
-			CreatedAt: post.CreatedAt,
-		})
+		items = append(items, h.postItem(post, liked[post.ID]))
 	}
 	c.JSON(http.StatusOK, dto.PostListResponse{Posts: items, Total: int(total), Cursor: nextCursor(posts)})
 }
@@ -81,7 +76,7 @@ func (h *PostHandler) ListUserPosts(c *gin.Context) {
 func (h *PostHandler) ListMyPosts(c *gin.Context) {
 	userID := c.GetInt64("user_id")
 	cursor, limit := parseCursorLimit(c)
-	posts, total, err := h.svc.ListUserPosts(c.Request.Context(), userID, cursor, limit)
+	posts, total, err := h.svc.ListUserPosts(c.Request.Context(), userID, userID, cursor, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -89,22 +84,279 @@ func (h *PostHandler) ListMyPosts(c *gin.Context) {
 	liked := h.helper.getLikedIDs(c, "post")
 	items := make([]dto.PostItem, 0, len(posts))
 	for _, post := range posts {
-		var merchant *dto.MerchantBrief
-		if post.MerchantID != nil {
-			merchant = h.helper.loadMerchantBrief(*post.MerchantID)
-		}
-		items = append(items, dto.PostItem{
-			ID:        post.ID,
-			Title:     post.Title,
-			Content:   post.Content,
-			Images:    parseJSONStrings(post.Images),
-			LikeCount: post.LikeCount,
-			ViewCount: post.ViewCount,
-			IsLiked:   liked[post.ID],
-			Merchant:  merchant,
-			Tags:      []string{},
-			CreatedAt: post.CreatedAt,
-		})
+		items = append(items, h.postItem(post, liked[post.ID]))
 	}
 	c.JSON(http.StatusOK, dto.PostListResponse{Posts: items, Total: int(total), Cursor: nextCursor(posts)})
 }
+
+// GetPost godoc
+// @Summary Get a post
+// @Description Returns a post with its tags. Hidden posts are only visible to their author.
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} dto.PostItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /posts/{id} [get]
+func (h *PostHandler) Detail(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	post, err := h.posts.Detail(c.Request.Context(), c.GetInt64("user_id"), id)
+	if err != nil {
+		respondPostError(c, err)
+		return
+	}
+	liked := h.helper.getLikedIDs(c, service.TargetPost)
+	c.JSON(http.StatusOK, h.postItem(*post, liked[post.ID]))
+}
+
+// CreatePost godoc
+// @Summary Create a post
+// @Description Publishes a post. media_ids are UUIDs of approved media uploads; a review_id alone also links that review's merchant.
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param request body dto.CreatePostRequest true "Create post request"
+// @Success 201 {object} dto.PostItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts [post]
+func (h *PostHandler) Create(c *gin.Context) {
+	var req dto.CreatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	post, err := h.posts.Create(c.Request.Context(), c.GetInt64("user_id"), req)
+	if err != nil {
+		respondPostError(c, err)
+		return
+	}
+	h.respondPost(c, http.StatusCreated, post.ID)
+}
+
+// UpdatePost godoc
+// @Summary Update a post
+// @Description Edits a post owned by the authenticated user. media_ids and tags replace the current ones; a merchant_id or review_id of 0 removes the link.
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body dto.UpdatePostRequest true "Update post request"
+// @Success 200 {object} dto.PostItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id} [patch]
+func (h *PostHandler) Update(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	var req dto.UpdatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	post, err := h.posts.Update(c.Request.Context(), c.GetInt64("user_id"), id, req)
+	if err != nil {
+		respondPostError(c, err)
+		return
+	}
+	h.respondPost(c, http.StatusOK, post.ID)
+}
+
+// DeletePost godoc
+// @Summary Delete a post
+// @Description Deletes a post owned by the authenticated user together with its comments
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id} [delete]
+func (h *PostHandler) Delete(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	if err := h.posts.Delete(c.Request.Context(), c.GetInt64("user_id"), id); err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// PinPost godoc
+// @Summary Pin a post
+// @Description Pins a post to the top of the author's profile; at most 3 posts can be pinned
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/pin [post]
+func (h *PostHandler) Pin(c *gin.Context) {
+	h.setPinned(c, true)
+}
+
+// UnpinPost godoc
+// @Summary Unpin a post
+// @Description Removes a post from the top of the author's profile
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/pin [delete]
+func (h *PostHandler) Unpin(c *gin.Context) {
+	h.setPinned(c, false)
+}
+
+func (h *PostHandler) setPinned(c *gin.Context, pinned bool) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	if err := h.posts.SetPinned(c.Request.Context(), c.GetInt64("user_id"), id, pinned); err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// LikePost godoc
+// @Summary Like a post
+// @Description Likes a post for the authenticated user
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/like [post]
+func (h *PostHandler) Like(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	if err := h.posts.Like(c.Request.Context(), c.GetInt64("user_id"), id); err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// UnlikePost godoc
+// @Summary Unlike a post
+// @Description Removes the authenticated user's like from a post
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/like [delete]
+func (h *PostHandler) Unlike(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	if err := h.posts.Unlike(c.Request.Context(), c.GetInt64("user_id"), id); err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// respondPost reloads a post the caller just wrote so the response carries its tags.
+func (h *PostHandler) respondPost(c *gin.Context, status int, id int64) {
+	post, err := h.posts.Detail(c.Request.Context(), c.GetInt64("user_id"), id)
+	if err != nil {
+		respondPostError(c, err)
+		return
+	}
+	liked := h.helper.getLikedIDs(c, service.TargetPost)
+	c.JSON(status, h.postItem(*post, liked[post.ID]))
+}
+
+func (h *PostHandler) postItem(post model.Post, liked bool) dto.PostItem {
+	var merchant *dto.MerchantBrief
+	if post.MerchantID != nil {
+		merchant = h.helper.loadMerchantBrief(*post.MerchantID)
+	}
+	tags := make([]string, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tags = append(tags, tag.Name)
+	}
+	return dto.PostItem{
+		ID:           post.ID,
+		UserID:       post.UserID,
+		Title:        post.Title,
+		Content:      post.Content,
+		Images:       parseJSONStrings(post.Images),
+		LikeCount:    post.LikeCount,
+		CommentCount: post.CommentCount,
+		ViewCount:    post.ViewCount,
+		IsLiked:      liked,
+		IsPinned:     post.IsPinned,
+		Status:       post.Status,
+		ReviewID:     post.ReviewID,
+		Merchant:     merchant,
+		Tags:         tags,
+		CreatedAt:    post.CreatedAt,
+	}
+}
+
+func respondPostError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPostNotFound),
+		errors.Is(err, service.ErrPostCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, service.ErrPostForbidden),
+		errors.Is(err, service.ErrPostCommentForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrEmptyPost),
+		errors.Is(err, service.ErrPostTooLong),
+		errors.Is(err, service.ErrTooManyPostImages),
+		errors.Is(err, service.ErrPostMediaNotApproved),
+		errors.Is(err, service.ErrInvalidPostTags),
+		errors.Is(err, service.ErrMerchantNotFound),
+		errors.Is(err, service.ErrReviewNotFound),
+		errors.Is(err, service.ErrReviewMerchantMismatch),
+		errors.Is(err, service.ErrTooManyPinnedPosts),
+		errors.Is(err, service.ErrInvalidPostComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// ListPostComments godoc
+// @Summary List post comments
+// @Description Returns top-level comments on a post, newest first, with replies nested up to three levels
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param cursor query int false "Cursor (comment id)"
+// @Param limit query int false "Page size (max 100)"
+// @Success 200 {object} dto.PostCommentListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /posts/{id}/comments [get]
+func (h *PostHandler) Comments(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	cursor, limit := parseCursorLimit(c)
+	comments, next, err := h.posts.ListComments(c.Request.Context(), c.GetInt64("user_id"), id, cursor, &limit)
+	if err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.PostCommentListResponse{Data: comments, Cursor: next})
+}
+
+// CommentPost godoc
+// @Summary Comment on a post
+// @Description Adds a comment to a post, or a reply to another comment when parent_comment_id is set
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param request body dto.PostCommentRequest true "Comment request"
+// @Success 201 {object} dto.PostCommentItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/comments [post]
+func (h *PostHandler) Comment(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+	var req dto.PostCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	comment, err := h.posts.Comment(c.Request.Context(), c.GetInt64("user_id"), id, req.ParentCommentID, req.Content)
+	if err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// UpdatePostComment godoc
+// @Summary Update a post comment
+// @Description Edits a comment owned by the authenticated user
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param commentId path int true "Comment ID"
+// @Param request body dto.UpdatePostCommentRequest true "Update comment request"
+// @Success 200 {object} dto.PostCommentItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/comments/{commentId} [patch]
+func (h *PostHandler) UpdateComment(c *gin.Context) {
+	id, commentID, ok := parsePostCommentPath(c)
+	if !ok {
+		return
+	}
+	var req dto.UpdatePostCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	comment, err := h.posts.UpdateComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID, req.Content)
+	if err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeletePostComment godoc
+// @Summary Delete a post comment
+// @Description Deletes a comment owned by the authenticated user together with its replies
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/comments/{commentId} [delete]
+func (h *PostHandler) DeleteComment(c *gin.Context) {
+	id, commentID, ok := parsePostCommentPath(c)
+	if !ok {
+		return
+	}
+	if err := h.posts.DeleteComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID); err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// LikePostComment godoc
+// @Summary Like a post comment
+// @Description Likes a post comment for the authenticated user
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/comments/{commentId}/like [post]
+func (h *PostHandler) LikeComment(c *gin.Context) {
+	id, commentID, ok := parsePostCommentPath(c)
+	if !ok {
+		return
+	}
+	if err := h.posts.LikeComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID); err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// UnlikePostComment godoc
+// @Summary Unlike a post comment
+// @Description Removes the authenticated user's like from a post comment
+// @Tags content
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /posts/{id}/comments/{commentId}/like [delete]
+func (h *PostHandler) UnlikeComment(c *gin.Context) {
+	id, commentID, ok := parsePostCommentPath(c)
+	if !ok {
+		return
+	}
+	if err := h.posts.UnlikeComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID); err != nil {
+		respondPostError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func parsePostCommentPath(c *gin.Context) (int64, int64, bool) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return 0, 0, false
+	}
+	commentID, err := parseIDParam(c, "commentId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return 0, 0, false
+	}
+	return id, commentID, true
+}
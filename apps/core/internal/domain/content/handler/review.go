@@ -22,7 +22,7 @@ func NewReviewHandler(svc *service.ContentService) *ReviewHandler {
 
 // ListUserReviews godoc
 // @Summary List user's reviews
-// @Description Returns a user's reviews
+// @Description Returns a user's reviews; private profiles only show them to the owner and approved followers
 // @Tags content
 // @Produce json
 // @Param id path int true "User ID"
@@ -30,6 +30,7 @@ func NewReviewHandler(svc *service.ContentService) *ReviewHandler {
 // @Param limit query int false "Limit"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /users/{id}/reviews [get]
 func (h *ReviewHandler) ListUserReviews(c *gin.Context) {
@@ -38,8 +39,11 @@ func (h *ReviewHandler) ListUserReviews(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
 		return
 	}
+	if !h.helper.allowProfile(c, targetID) {
+		return
+	}
 	cursor, limit := parseCursorLimit(c)
-	reviews, total, nextCursor, err := h.svc.ListUserReviews(c.Request.Context(), targetID, cursor, limit)
+	reviews, total, nextCursor, err := h.svc.ListUserReviews(c.Request.Context(), c.GetInt64("user_id"), targetID, cursor, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -86,7 +90,7 @@ func (h *ReviewHandler) ListUserReviews(c *gin.Context) {
 func (h *ReviewHandler) ListMyReviews(c *gin.Context) {
 	userID := c.GetInt64("user_id")
 	cursor, limit := parseCursorLimit(c)
-	reviews, total, nextCursor, err := h.svc.ListUserReviews(c.Request.Context(), userID, cursor, limit)
+	reviews, total, nextCursor, err := h.svc.ListUserReviews(c.Request.Context(), userID, userID, cursor, limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
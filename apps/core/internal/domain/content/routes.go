@@ -4,20 +4,41 @@ import (
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/handler"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes registers content routes.
-func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
+// RegisterRoutes registers content routes. moderation is shared with the other domains that
+// screen user content.
+func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config, moderation *moderationservice.ModerationService) {
 	contentSvc := service.NewContentService(nil)
+	postSvc := service.NewPostService(nil, moderation)
 
-	postHandler := handler.NewPostHandler(contentSvc)
+	postHandler := handler.NewPostHandler(contentSvc, postSvc)
 	reviewHandler := handler.NewReviewHandler(contentSvc)
-	favHandler := handler.NewFavoriteHandler(contentSvc)
+	favHandler := handler.NewFavoriteHandler(contentSvc, service.NewInteractionService(nil))
 	likeHandler := handler.NewLikeHandler(contentSvc)
 
-	users := r.Group("/users")
+	posts := r.Group("/posts")
+	{
+		posts.POST("", middleware.JWTAuth(cfg.JWT), postHandler.Create)
+		posts.GET("/:id", middleware.OptionalJWTAuth(cfg.JWT), postHandler.Detail)
+		posts.PATCH("/:id", middleware.JWTAuth(cfg.JWT), postHandler.Update)
+		posts.DELETE("/:id", middleware.JWTAuth(cfg.JWT), postHandler.Delete)
+		posts.POST("/:id/pin", middleware.JWTAuth(cfg.JWT), postHandler.Pin)
+		posts.DELETE("/:id/pin", middleware.JWTAuth(cfg.JWT), postHandler.Unpin)
+		posts.POST("/:id/like", middleware.JWTAuth(cfg.JWT), postHandler.Like)
+		posts.DELETE("/:id/like", middleware.JWTAuth(cfg.JWT), postHandler.Unlike)
+		posts.GET("/:id/comments", middleware.OptionalJWTAuth(cfg.JWT), postHandler.Comments)
+		posts.POST("/:id/comments", middleware.JWTAuth(cfg.JWT), postHandler.Comment)
+		posts.PATCH("/:id/comments/:commentId", middleware.JWTAuth(cfg.JWT), postHandler.UpdateComment)
+		posts.DELETE("/:id/comments/:commentId", middleware.JWTAuth(cfg.JWT), postHandler.DeleteComment)
+		posts.POST("/:id/comments/:commentId/like", middleware.JWTAuth(cfg.JWT), postHandler.LikeComment)
+		posts.DELETE("/:id/comments/:commentId/like", middleware.JWTAuth(cfg.JWT), postHandler.UnlikeComment)
+	}
+
+	users := r.Group("/users", middleware.OptionalJWTAuth(cfg.JWT))
 	{
 		users.GET("/:id/posts", postHandler.ListUserPosts)
 		users.GET("/:id/reviews", reviewHandler.ListUserReviews)
@@ -28,6 +49,8 @@ func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
 		user.GET("/posts", postHandler.ListMyPosts)
 		user.GET("/reviews", reviewHandler.ListMyReviews)
 		user.GET("/favorites", favHandler.ListMyFavorites)
+		user.POST("/favorites", favHandler.Favorite)
+		user.DELETE("/favorites/:type/:id", favHandler.Unfavorite)
 		user.GET("/likes", likeHandler.ListMyLikes)
 	}
 }
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// MaxCommentDepth bounds reply nesting; a top-level comment has depth 1.
+	MaxCommentDepth        = 3
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+)
+
+// CommentNode is the part of a comment a CommentThread needs to walk its thread.
+type CommentNode struct {
+	ID       int64
+	ParentID *int64
+	UserID   int64
+}
+
+// CommentThread holds the threading rules shared by post and review comments: top-level
+// comments are paged newest first, replies hang off parent_comment_id up to MaxCommentDepth,
+// only the author may change a comment, and the owner's comment_count counts its visible
+// comments. T is the comment model.
+type CommentThread[T any] struct {
+	// Table is the comment table and Owner its column holding the post or review id, which
+	// is a row of OwnerTable.
+	Table      string
+	Owner      string
+	OwnerTable string
+	// Node reads the threading fields of a comment.
+	Node func(*T) CommentNode
+	// ErrNotFound is returned for a comment missing from the owner, ErrForbidden when
+	// someone other than its author tries to change it.
+	ErrNotFound  error
+	ErrForbidden error
+}
+
+// Page returns a page of the owner's top-level comments, newest first, and their replies
+// keyed by parent id, oldest first so threads read top-down. Hidden comments are only
+// shown to their author, and comments by users on either side of a block with the viewer
+// are left out. The returned cursor is nil on the last page.
+func (t CommentThread[T]) Page(db *gorm.DB, viewerID, ownerID int64, cursor *int64, limit *int) ([]T, map[int64][]T, *int64, error) {
+	pageSize := defaultCommentPageSize
+	if limit != nil && *limit > 0 {
+		pageSize = min(*limit, maxCommentPageSize)
+	}
+	q := t.visible(db, viewerID).Where(t.Owner+" = ? AND parent_comment_id IS NULL", ownerID)
+	if cursor != nil {
+		q = q.Where("id < ?", *cursor)
+	}
+	var roots []T
+	if err := q.Order("id desc").Limit(pageSize + 1).Find(&roots).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	var next *int64
+	if len(roots) > pageSize {
+		roots = roots[:pageSize]
+		lastID := t.Node(&roots[len(roots)-1]).ID
+		next = &lastID
+	}
+
+	// Load each level of replies in one query.
+	level := make([]int64, 0, len(roots))
+	for i := range roots {
+		level = append(level, t.Node(&roots[i]).ID)
+	}
+	children := make(map[int64][]T)
+	for depth := 2; depth <= MaxCommentDepth && len(level) > 0; depth++ {
+		var replies []T
+		if err := t.visible(db, viewerID).
+			Where("parent_comment_id IN ?", level).
+			Order("id asc").
+			Find(&replies).Error; err != nil {
+			return nil, nil, nil, err
+		}
+		level = level[:0]
+		for i := range replies {
+			node := t.Node(&replies[i])
+			children[*node.ParentID] = append(children[*node.ParentID], replies[i])
+			level = append(level, node.ID)
+		}
+	}
+	return roots, children, next, nil
+}
+
+// ReplyParent resolves the comment a new reply to parentID should hang off, clamping the
+// thread depth, and returns it with the author of parentID.
+func (t CommentThread[T]) ReplyParent(tx *gorm.DB, ownerID, parentID int64) (int64, int64, error) {
+	var parent T
+	if err := t.Get(tx.Select("id", "parent_comment_id", "user_id"), ownerID, parentID, &parent); err != nil {
+		return 0, 0, err
+	}
+	node := t.Node(&parent)
+
+	ancestors := []int64{node.ID}
+	for current := node; current.ParentID != nil && len(ancestors) < MaxCommentDepth; {
+		var next T
+		if err := tx.Unscoped().Select("id", "parent_comment_id").First(&next, *current.ParentID).Error; err != nil {
+			return 0, 0, err
+		}
+		current = t.Node(&next)
+		ancestors = append(ancestors, current.ID)
+	}
+	// ancestors runs from the parent up to the root; a parent already at the maximum depth
+	// hands the reply to its own parent so the new comment stays within the limit.
+	if len(ancestors) >= MaxCommentDepth {
+		return ancestors[1], node.UserID, nil
+	}
+	return node.ID, node.UserID, nil
+}
+
+// Get loads a comment of the owner into comment.
+func (t CommentThread[T]) Get(db *gorm.DB, ownerID, commentID int64, comment *T) error {
+	if err := db.Where(t.Owner+" = ?", ownerID).First(comment, commentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return t.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Lock loads a comment of the owner into comment and locks it for the transaction.
+func (t CommentThread[T]) Lock(tx *gorm.DB, ownerID, commentID int64, comment *T) error {
+	return t.Get(tx.Clauses(clause.Locking{Strength: "UPDATE"}), ownerID, commentID, comment)
+}
+
+// LockOwned is Lock for a comment the user must have written.
+func (t CommentThread[T]) LockOwned(tx *gorm.DB, userID, ownerID, commentID int64, comment *T) error {
+	if err := t.Lock(tx, ownerID, commentID, comment); err != nil {
+		return err
+	}
+	if t.Node(comment).UserID != userID {
+		return t.ErrForbidden
+	}
+	return nil
+}
+
+// Subtree returns the ids of a comment and all its replies, and the distinct authors of
+// those comments.
+func (t CommentThread[T]) Subtree(tx *gorm.DB, commentID int64) ([]int64, []int64, error) {
+	ids := []int64{commentID}
+	for level := []int64{commentID}; len(level) > 0; {
+		var replyIDs []int64
+		if err := tx.Model(new(T)).Where("parent_comment_id IN ?", level).Pluck("id", &replyIDs).Error; err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, replyIDs...)
+		level = replyIDs
+	}
+
+	var authorIDs []int64
+	if err := tx.Model(new(T)).Where("id IN ?", ids).Distinct().Pluck("user_id", &authorIDs).Error; err != nil {
+		return nil, nil, err
+	}
+	return ids, authorIDs, nil
+}
+
+// SyncCount recomputes the owner's comment_count from its visible comments. Callers hold the
+// owner's row lock, so changes to the thread cannot interleave with the recount.
+func (t CommentThread[T]) SyncCount(tx *gorm.DB, ownerID int64) error {
+	var count int64
+	if err := tx.Model(new(T)).
+		Where(t.Owner+" = ? AND status = ?", ownerID, model.ContentStatusVisible).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	return tx.Table(t.OwnerTable).Where("id = ?", ownerID).UpdateColumn("comment_count", count).Error
+}
+
+// ApplyStatus is the moderation StatusApplier for the thread's comments: it moves a comment
+// that async moderation flagged to status and takes it out of its owner's comment_count.
+// Comments deleted in the meantime are left alone.
+func (t CommentThread[T]) ApplyStatus(_ context.Context, tx *gorm.DB, commentID int64, status int16) error {
+	var ownerIDs []int64
+	if err := tx.Model(new(T)).Where("id = ?", commentID).Pluck(t.Owner, &ownerIDs).Error; err != nil {
+		return err
+	}
+	if len(ownerIDs) == 0 {
+		return nil
+	}
+	// Lock the owner first, as every other change to the thread does.
+	var locked []int64
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Table(t.OwnerTable).
+		Where("id = ?", ownerIDs[0]).Pluck("id", &locked).Error; err != nil {
+		return err
+	}
+	result := tx.Model(new(T)).Where("id = ? AND status <> ?", commentID, status).Update("status", status)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+	return t.SyncCount(tx, ownerIDs[0])
+}
+
+func (t CommentThread[T]) visible(db *gorm.DB, viewerID int64) *gorm.DB {
+	return db.Model(new(T)).
+		Where("(status = ? OR user_id = ?)", model.ContentStatusVisible, viewerID).
+		Scopes(followservice.ExcludeBlocked(viewerID, t.Table+".user_id"))
+}
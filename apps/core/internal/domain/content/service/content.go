@@ -19,24 +19,43 @@ func NewContentService(db *gorm.DB) *ContentService {
 	return &ContentService{db: db}
 }
 
-func (s *ContentService) ListUserPosts(ctx context.Context, userID int64, cursor *int64, limit int) ([]model.Post, int64, error) {
-	q := s.db.WithContext(ctx).Model(&model.Post{}).Where("user_id = ?", userID).Order("id desc")
-	if cursor != nil {
-		q = q.Where("id < ?", *cursor)
+// ListUserPosts returns a user's posts with their tags, newest first. The first page (no
+// cursor) starts with the user's pinned posts, which later pages leave out. Hidden and pending
+// posts are only listed for their author.
+func (s *ContentService) ListUserPosts(ctx context.Context, viewerID, userID int64, cursor *int64, limit int) ([]model.Post, int64, error) {
+	base := s.db.WithContext(ctx).Model(&model.Post{}).Where("user_id = ?", userID)
+	if viewerID != userID {
+		base = base.Where("status = ?", model.ContentStatusVisible)
 	}
+	base = base.Session(&gorm.Session{})
 	var total int64
-	if err := q.Count(&total).Error; err != nil {
+	if err := base.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 	var posts []model.Post
-	if err := q.Limit(limit).Find(&posts).Error; err != nil {
+	if cursor == nil {
+		if err := base.Preload("Tags").Where("is_pinned = ?", true).Order("id desc").Find(&posts).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+	q := base.Preload("Tags").Where("is_pinned = ?", false).Order("id desc")
+	if cursor != nil {
+		q = q.Where("id < ?", *cursor)
+	}
+	var rest []model.Post
+	if err := q.Limit(limit).Find(&rest).Error; err != nil {
 		return nil, 0, err
 	}
-	return posts, total, nil
+	return append(posts, rest...), total, nil
 }
 
-func (s *ContentService) ListUserReviews(ctx context.Context, userID int64, cursor *int64, limit int) ([]model.Review, int64, *int64, error) {
+// ListUserReviews returns a user's reviews, newest first. Hidden and pending reviews are only
+// listed for their author.
+func (s *ContentService) ListUserReviews(ctx context.Context, viewerID, userID int64, cursor *int64, limit int) ([]model.Review, int64, *int64, error) {
 	baseQuery := s.db.WithContext(ctx).Model(&model.Review{}).Where("user_id = ?", userID)
+	if viewerID != userID {
+		baseQuery = baseQuery.Where("status = ?", model.ContentStatusVisible)
+	}
 	var total int64
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, nil, err
@@ -14,8 +14,45 @@ func TestContentServiceListPosts(t *testing.T) {
 	user := model.User{Role: "user", Status: 0}
 	db.Create(&user)
 	db.Create(&model.Post{UserID: user.ID, Content: "a"})
-	posts, total, err := svc.ListUserPosts(context.Background(), user.ID, nil, 10)
+	posts, total, err := svc.ListUserPosts(context.Background(), user.ID, user.ID, nil, 10)
 	if err != nil || total != 1 || len(posts) != 1 {
 		t.Fatalf("list posts failed: %v", err)
 	}
 }
+
+func TestContentServiceListUserPostsHidesModeratedPostsFromOthers(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewContentService(db)
+	user := model.User{Role: "user", Status: 0}
+	db.Create(&user)
+	db.Create(&model.Post{UserID: user.ID, Content: "ok"})
+	db.Create(&model.Post{UserID: user.ID, Content: "held", Status: model.ContentStatusPending})
+	db.Create(&model.Post{UserID: user.ID, Content: "hidden", Status: model.ContentStatusHidden, IsPinned: true})
+
+	posts, total, err := svc.ListUserPosts(context.Background(), user.ID+1, user.ID, nil, 10)
+	if err != nil || total != 1 || len(posts) != 1 || posts[0].Content != "ok" {
+		t.Fatalf("expected only the visible post for other viewers, got %d/%d: %v", len(posts), total, err)
+	}
+	posts, total, err = svc.ListUserPosts(context.Background(), user.ID, user.ID, nil, 10)
+	if err != nil || total != 3 || len(posts) != 3 {
+		t.Fatalf("expected the author to see every post, got %d/%d: %v", len(posts), total, err)
+	}
+}
+
+func TestContentServiceListUserReviewsHidesModeratedReviewsFromOthers(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewContentService(db)
+	user := model.User{Role: "user", Status: 0}
+	db.Create(&user)
+	db.Create(&model.Review{UserID: user.ID, MerchantID: 1, VenueID: 1, Rating: 4, Content: "ok"})
+	db.Create(&model.Review{UserID: user.ID, MerchantID: 1, VenueID: 1, Rating: 1, Content: "held", Status: model.ContentStatusPending})
+
+	reviews, total, _, err := svc.ListUserReviews(context.Background(), user.ID+1, user.ID, nil, 10)
+	if err != nil || total != 1 || len(reviews) != 1 || reviews[0].Content != "ok" {
+		t.Fatalf("expected only the visible review for other viewers, got %d/%d: %v", len(reviews), total, err)
+	}
+	reviews, total, _, err = svc.ListUserReviews(context.Background(), user.ID, user.ID, nil, 10)
+	if err != nil || total != 2 || len(reviews) != 2 {
+		t.Fatalf("expected the author to see both reviews, got %d/%d: %v", len(reviews), total, err)
+	}
+}
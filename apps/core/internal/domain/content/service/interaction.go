@@ -2,12 +2,74 @@ package service
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Target types stored in likes.target_type and favorites.target_type.
+const (
+	TargetPost          = "post"
+	TargetPostComment   = "post_comment"
+	TargetReview        = "review"
+	TargetReviewComment = "review_comment"
+	TargetMerchant      = "merchant"
+)
+
+var ErrUnsupportedInteraction = errors.New("unsupported interaction target")
+var ErrInteractionTargetNotFound = errors.New("interaction target not found")
+
+// interactionTarget describes a table that can be liked or favorited.
+type interactionTarget struct {
+	table string
+	// ownerColumn is credited in user_profiles.like_count; empty when likes have no author.
+	ownerColumn string
+	// likeColumn is the denormalized like counter; empty when the target cannot be liked.
+	likeColumn  string
+	favoritable bool
+	softDelete  bool
+	// visible narrows a query to rows the viewer may interact with; nil means every live row.
+	visible func(db *gorm.DB, viewerID int64) *gorm.DB
+}
+
+var interactionTargets = map[string]interactionTarget{
+	TargetPost: {
+		table: "posts", ownerColumn: "user_id", likeColumn: "like_count", favoritable: true,
+		visible: visibleOrOwned("posts"),
+	},
+	TargetPostComment: {
+		table: "post_comments", ownerColumn: "user_id", likeColumn: "like_count",
+		visible: visibleOrOwned("post_comments"),
+	},
+	TargetReview: {
+		table: "reviews", ownerColumn: "user_id", likeColumn: "like_count", favoritable: true, softDelete: true,
+		visible: visibleOrOwned("reviews"),
+	},
+	TargetReviewComment: {
+		table: "review_comments", ownerColumn: "user_id", likeColumn: "like_count", softDelete: true,
+		visible: visibleOrOwned("review_comments"),
+	},
+	TargetMerchant: {
+		table: "merchants", favoritable: true, softDelete: true,
+	},
+}
+
+// visibleOrOwned admits visible rows plus hidden or pending rows authored by the viewer,
+// leaving out rows by users on either side of a block with the viewer.
+func visibleOrOwned(table string) func(db *gorm.DB, viewerID int64) *gorm.DB {
+	return func(db *gorm.DB, viewerID int64) *gorm.DB {
+		return db.Where("("+table+".status = ? OR "+table+".user_id = ?)", model.ContentStatusVisible, viewerID).
+			Scopes(followservice.ExcludeBlocked(viewerID, table+".user_id"))
+	}
+}
+
 type InteractionService struct {
 	db *gorm.DB
 }
@@ -19,20 +81,243 @@ func NewInteractionService(db *gorm.DB) *InteractionService {
 	return &InteractionService{db: db}
 }
 
+// Like records the user's like on a target they can see and bumps the target's like counter
+// and its author's received likes. Liking twice is a no-op; a new like publishes
+// eventbus.ContentLiked.
 func (s *InteractionService) Like(ctx context.Context, userID int64, targetType string, targetID int64) error {
-	like := model.Like{UserID: userID, TargetType: targetType, TargetID: targetID}
-	return s.db.WithContext(ctx).FirstOrCreate(&like, like).Error
+	target, ok := interactionTargets[targetType]
+	if !ok || target.likeColumn == "" {
+		return ErrUnsupportedInteraction
+	}
+	var liked *eventbus.ContentLiked
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ownerID, err := target.lock(tx, userID, targetID, true)
+		if err != nil {
+			return err
+		}
+		like := model.Like{UserID: userID, TargetType: targetType, TargetID: targetID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&like)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		liked = &eventbus.ContentLiked{UserID: userID, OwnerID: ownerID, TargetType: targetType, TargetID: targetID}
+		return target.adjustLikes(tx, targetID, ownerID, 1)
+	}); err != nil {
+		return err
+	}
+	if liked != nil {
+		eventbus.Publish(ctx, *liked)
+	}
+	return nil
 }
 
+// Unlike removes the user's like and rolls the counters back. Unliking a target that was
+// never liked is a no-op; the target only has to exist, not be visible.
 func (s *InteractionService) Unlike(ctx context.Context, userID int64, targetType string, targetID int64) error {
-	return s.db.WithContext(ctx).Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).Delete(&model.Like{}).Error
+	target, ok := interactionTargets[targetType]
+	if !ok || target.likeColumn == "" {
+		return ErrUnsupportedInteraction
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ownerID, err := target.lock(tx, userID, targetID, false)
+		if err != nil {
+			return err
+		}
+		result := tx.Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+			Delete(&model.Like{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return target.adjustLikes(tx, targetID, ownerID, -1)
+	})
 }
 
+// Favorite saves a target the user can see to their favorites. Favoriting twice is a no-op.
 func (s *InteractionService) Favorite(ctx context.Context, userID int64, targetType string, targetID int64) error {
-	fav := model.Favorite{UserID: userID, TargetType: targetType, TargetID: targetID}
-	return s.db.WithContext(ctx).FirstOrCreate(&fav, fav).Error
+	target, ok := interactionTargets[targetType]
+	if !ok || !target.favoritable {
+		return ErrUnsupportedInteraction
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := target.lock(tx, userID, targetID, true); err != nil {
+			return err
+		}
+		fav := model.Favorite{UserID: userID, TargetType: targetType, TargetID: targetID}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&fav).Error
+	})
 }
 
+// Unfavorite removes a favorite without requiring the target to still exist, so users can
+// clear favorites whose target has since been deleted.
 func (s *InteractionService) Unfavorite(ctx context.Context, userID int64, targetType string, targetID int64) error {
+	if target, ok := interactionTargets[targetType]; !ok || !target.favoritable {
+		return ErrUnsupportedInteraction
+	}
 	return s.db.WithContext(ctx).Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).Delete(&model.Favorite{}).Error
 }
+
+// RecountReceivedLikes recomputes user_profiles.like_count for the given users from likes on
+// their live content. Callers use it after deleting content together with its likes.
+func (s *InteractionService) RecountReceivedLikes(ctx context.Context, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	expr, args := receivedLikesExpr()
+	return s.db.WithContext(ctx).Model(&model.UserProfile{}).Where("user_id IN ?", userIDs).
+		UpdateColumn("like_count", gorm.Expr(expr, args...)).Error
+}
+
+const (
+	// reconcileBatchSize is the id range ReconcileCounters repairs per statement, so each
+	// statement commits quickly and only locks the rows in its range.
+	reconcileBatchSize = 1000
+	// reconcileLockKey is the Postgres advisory lock held while reconciling, so only one
+	// instance runs ReconcileCounters at a time.
+	reconcileLockKey = 410041
+)
+
+// ReconcileCounters repairs counter drift: every like counter and every author's received
+// likes are recomputed from the likes table. Tables are walked in id ranges of
+// reconcileBatchSize, each repaired in its own short statement. On Postgres the run is
+// skipped while another instance holds the advisory lock. It returns the number of rows it
+// corrected.
+func (s *InteractionService) ReconcileCounters(ctx context.Context) (int64, error) {
+	var repaired int64
+	err := s.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		// The advisory lock belongs to the session, so every statement runs on this connection.
+		conn = conn.Session(&gorm.Session{})
+		if conn.Dialector.Name() == "postgres" {
+			var locked bool
+			if err := conn.Raw("SELECT pg_try_advisory_lock(?)", reconcileLockKey).Scan(&locked).Error; err != nil {
+				return err
+			}
+			if !locked {
+				return nil
+			}
+			// Unlock even when ctx is done; the connection goes back to the pool afterwards.
+			defer conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", reconcileLockKey)
+		}
+
+		for _, targetType := range sortedTargetTypes() {
+			target := interactionTargets[targetType]
+			if target.likeColumn == "" {
+				continue
+			}
+			count := "(SELECT COUNT(*) FROM likes WHERE likes.target_type = ? AND likes.target_id = " + target.table + ".id)"
+			n, err := reconcileInBatches(ctx, conn, target.table, "id", func(batch *gorm.DB) *gorm.DB {
+				return batch.Where(target.likeColumn+" <> "+count, targetType).
+					UpdateColumn(target.likeColumn, gorm.Expr(count, targetType))
+			})
+			repaired += n
+			if err != nil {
+				return err
+			}
+		}
+
+		expr, args := receivedLikesExpr()
+		n, err := reconcileInBatches(ctx, conn, "user_profiles", "user_id", func(batch *gorm.DB) *gorm.DB {
+			return batch.Where("like_count <> "+expr, args...).UpdateColumn("like_count", gorm.Expr(expr, args...))
+		})
+		repaired += n
+		return err
+	})
+	return repaired, err
+}
+
+// reconcileInBatches runs update over table one id range at a time and returns the rows it
+// changed. It stops early when ctx is done.
+func reconcileInBatches(ctx context.Context, db *gorm.DB, table, idColumn string, update func(batch *gorm.DB) *gorm.DB) (int64, error) {
+	var maxID int64
+	if err := db.Table(table).Select("COALESCE(MAX(" + idColumn + "), 0)").Scan(&maxID).Error; err != nil {
+		return 0, err
+	}
+	var repaired int64
+	for low := int64(0); low < maxID; low += reconcileBatchSize {
+		if err := ctx.Err(); err != nil {
+			return repaired, err
+		}
+		result := update(db.Table(table).Where(table+"."+idColumn+" > ? AND "+table+"."+idColumn+" <= ?", low, low+reconcileBatchSize))
+		if result.Error != nil {
+			return repaired, result.Error
+		}
+		repaired += result.RowsAffected
+	}
+	return repaired, nil
+}
+
+// lock locks the live target row and returns its author. With visibleOnly set the row must
+// also pass the target's visibility check for viewerID.
+func (t interactionTarget) lock(tx *gorm.DB, viewerID, targetID int64, visibleOnly bool) (int64, error) {
+	query := tx.Table(t.table).Clauses(clause.Locking{Strength: "UPDATE"}).Where(t.table+".id = ?", targetID)
+	if t.softDelete {
+		query = query.Where(t.table + ".deleted_at IS NULL")
+	}
+	if visibleOnly && t.visible != nil {
+		query = t.visible(query, viewerID)
+	}
+	columns := t.table + ".id"
+	if t.ownerColumn != "" {
+		columns += ", " + t.table + "." + t.ownerColumn + " AS owner_id"
+	}
+
+	var row struct {
+		ID      int64
+		OwnerID int64
+	}
+	if err := query.Select(columns).Take(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrInteractionTargetNotFound
+		}
+		return 0, err
+	}
+	return row.OwnerID, nil
+}
+
+func (t interactionTarget) adjustLikes(tx *gorm.DB, targetID, ownerID int64, delta int) error {
+	if err := tx.Table(t.table).Where("id = ?", targetID).
+		UpdateColumn(t.likeColumn, counterExpr(t.likeColumn, delta)).Error; err != nil {
+		return err
+	}
+	if t.ownerColumn == "" {
+		return nil
+	}
+	return tx.Model(&model.UserProfile{}).Where("user_id = ?", ownerID).
+		UpdateColumn("like_count", counterExpr("like_count", delta)).Error
+}
+
+func counterExpr(column string, delta int) clause.Expr {
+	if delta >= 0 {
+		return gorm.Expr(column+" + ?", delta)
+	}
+	return gorm.Expr("CASE WHEN "+column+" > ? THEN "+column+" - ? ELSE 0 END", -delta, -delta)
+}
+
+// receivedLikesExpr builds the SQL for a user's received likes, correlated on
+// user_profiles.user_id, summed over every target type that credits an author.
+func receivedLikesExpr() (string, []interface{}) {
+	var parts []string
+	var args []interface{}
+	for _, targetType := range sortedTargetTypes() {
+		target := interactionTargets[targetType]
+		if target.likeColumn == "" || target.ownerColumn == "" {
+			continue
+		}
+		part := "(SELECT COUNT(*) FROM likes JOIN " + target.table + " ON " + target.table + ".id = likes.target_id" +
+			" WHERE likes.target_type = ? AND " + target.table + "." + target.ownerColumn + " = user_profiles.user_id"
+		if target.softDelete {
+			part += " AND " + target.table + ".deleted_at IS NULL"
+		}
+		parts = append(parts, part+")")
+		args = append(args, targetType)
+	}
+	return "(" + strings.Join(parts, " + ") + ")", args
+}
+
+func sortedTargetTypes() []string {
+	types := make([]string, 0, len(interactionTargets))
+	for targetType := range interactionTargets {
+		types = append(types, targetType)
+	}
+	sort.Strings(types)
+	return types
+}
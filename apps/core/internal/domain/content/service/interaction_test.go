@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
 )
 
 func TestInteractionServiceLike(t *testing.T) {
@@ -13,10 +15,188 @@ func TestInteractionServiceLike(t *testing.T) {
 	svc := NewInteractionService(db)
 	u := model.User{Role: "user", Status: 0}
 	db.Create(&u)
-	if err := svc.Like(context.Background(), u.ID, "post", 123); err != nil {
+	post := model.Post{UserID: u.ID, Content: "hello"}
+	db.Create(&post)
+	if err := svc.Like(context.Background(), u.ID, "post", post.ID); err != nil {
 		t.Fatal(err)
 	}
-	if err := svc.Unlike(context.Background(), u.ID, "post", 123); err != nil {
+	if err := svc.Unlike(context.Background(), u.ID, "post", post.ID); err != nil {
 		t.Fatal(err)
 	}
+	if err := svc.Like(context.Background(), u.ID, "post", 123); !errors.Is(err, ErrInteractionTargetNotFound) {
+		t.Fatalf("expected ErrInteractionTargetNotFound, got %v", err)
+	}
+}
+
+func receivedLikes(t *testing.T, db *gorm.DB, userID int64) int {
+	t.Helper()
+	var profile model.UserProfile
+	if err := db.First(&profile, "user_id = ?", userID).Error; err != nil {
+		t.Fatalf("failed to load profile: %v", err)
+	}
+	return profile.LikeCount
+}
+
+func TestInteractionLikesMaintainTargetAndAuthorCounters(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	fan := createPostAuthor(t, db, "fan")
+	svc := NewInteractionService(db)
+	ctx := context.Background()
+
+	post := model.Post{UserID: author.ID, Content: "hello"}
+	db.Create(&post)
+	review := model.Review{UserID: author.ID, MerchantID: 1, VenueID: 1, Rating: 4, Content: "ok"}
+	db.Create(&review)
+	comment := model.ReviewComment{ReviewID: review.ID, UserID: author.ID, Content: "thanks"}
+	db.Create(&comment)
+
+	for i := 0; i < 2; i++ {
+		for _, like := range []struct {
+			targetType string
+			id         int64
+		}{{TargetPost, post.ID}, {TargetReview, review.ID}, {TargetReviewComment, comment.ID}} {
+			if err := svc.Like(ctx, fan.ID, like.targetType, like.id); err != nil {
+				t.Fatalf("like %s failed: %v", like.targetType, err)
+			}
+		}
+	}
+	var reloadedPost model.Post
+	db.First(&reloadedPost, post.ID)
+	var reloadedReview model.Review
+	db.First(&reloadedReview, review.ID)
+	var reloadedComment model.ReviewComment
+	db.First(&reloadedComment, comment.ID)
+	if reloadedPost.LikeCount != 1 || reloadedReview.LikeCount != 1 || reloadedComment.LikeCount != 1 {
+		t.Fatalf("expected each like_count at 1, got %d, %d, %d",
+			reloadedPost.LikeCount, reloadedReview.LikeCount, reloadedComment.LikeCount)
+	}
+	if got := receivedLikes(t, db, author.ID); got != 3 {
+		t.Fatalf("expected the author to have received 3 likes, got %d", got)
+	}
+
+	if err := svc.Unlike(ctx, fan.ID, TargetReview, review.ID); err != nil {
+		t.Fatalf("unlike failed: %v", err)
+	}
+	if err := svc.Unlike(ctx, fan.ID, TargetReview, review.ID); err != nil {
+		t.Fatalf("repeated unlike failed: %v", err)
+	}
+	db.First(&reloadedReview, review.ID)
+	if reloadedReview.LikeCount != 0 || receivedLikes(t, db, author.ID) != 2 {
+		t.Fatalf("expected counters to drop after unlike, got %d and %d", reloadedReview.LikeCount, receivedLikes(t, db, author.ID))
+	}
+
+	if err := svc.Like(ctx, fan.ID, TargetMerchant, 1); !errors.Is(err, ErrUnsupportedInteraction) {
+		t.Fatalf("expected merchants to be unlikeable, got %v", err)
+	}
+	if err := svc.Like(ctx, fan.ID, "store", 1); !errors.Is(err, ErrUnsupportedInteraction) {
+		t.Fatalf("expected unknown types to be rejected, got %v", err)
+	}
+}
+
+func TestInteractionRespectsVisibility(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	fan := createPostAuthor(t, db, "fan")
+	svc := NewInteractionService(db)
+	ctx := context.Background()
+
+	hidden := model.Post{UserID: author.ID, Content: "draft", Status: model.ContentStatusHidden}
+	db.Create(&hidden)
+	if err := svc.Like(ctx, fan.ID, TargetPost, hidden.ID); !errors.Is(err, ErrInteractionTargetNotFound) {
+		t.Fatalf("expected hidden posts to be out of reach, got %v", err)
+	}
+	if err := svc.Favorite(ctx, fan.ID, TargetPost, hidden.ID); !errors.Is(err, ErrInteractionTargetNotFound) {
+		t.Fatalf("expected hidden posts to be unfavoritable, got %v", err)
+	}
+	if err := svc.Like(ctx, author.ID, TargetPost, hidden.ID); err != nil {
+		t.Fatalf("expected the author to reach their own post, got %v", err)
+	}
+
+	review := model.Review{UserID: author.ID, MerchantID: 1, VenueID: 1, Rating: 4, Content: "ok"}
+	db.Create(&review)
+	db.Delete(&review)
+	if err := svc.Like(ctx, fan.ID, TargetReview, review.ID); !errors.Is(err, ErrInteractionTargetNotFound) {
+		t.Fatalf("expected deleted reviews to be out of reach, got %v", err)
+	}
+}
+
+func TestInteractionFavorites(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	fan := createPostAuthor(t, db, "fan")
+	svc := NewInteractionService(db)
+	ctx := context.Background()
+
+	merchant := model.Merchant{Name: "Cafe"}
+	db.Create(&merchant)
+	for i := 0; i < 2; i++ {
+		if err := svc.Favorite(ctx, fan.ID, TargetMerchant, merchant.ID); err != nil {
+			t.Fatalf("favorite failed: %v", err)
+		}
+	}
+	var count int64
+	db.Model(&model.Favorite{}).Where("user_id = ?", fan.ID).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected one favorite row, got %d", count)
+	}
+	if err := svc.Favorite(ctx, fan.ID, TargetPostComment, 1); !errors.Is(err, ErrUnsupportedInteraction) {
+		t.Fatalf("expected comments to be unfavoritable, got %v", err)
+	}
+
+	db.Delete(&merchant)
+	if err := svc.Unfavorite(ctx, fan.ID, TargetMerchant, merchant.ID); err != nil {
+		t.Fatalf("unfavorite of a deleted target failed: %v", err)
+	}
+	db.Model(&model.Favorite{}).Where("user_id = ?", fan.ID).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected the favorite to be removed, got %d", count)
+	}
+}
+
+func TestReconcileCountersRepairsDrift(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	fan := createPostAuthor(t, db, "fan")
+	svc := NewInteractionService(db)
+	ctx := context.Background()
+
+	post := model.Post{UserID: author.ID, Content: "hello"}
+	db.Create(&post)
+	if err := svc.Like(ctx, fan.ID, TargetPost, post.ID); err != nil {
+		t.Fatalf("like failed: %v", err)
+	}
+	db.Model(&model.Post{}).Where("id = ?", post.ID).UpdateColumn("like_count", 7)
+	db.Model(&model.UserProfile{}).Where("user_id = ?", author.ID).UpdateColumn("like_count", 0)
+	db.Model(&model.UserProfile{}).Where("user_id = ?", fan.ID).UpdateColumn("like_count", 4)
+
+	repaired, err := svc.ReconcileCounters(ctx)
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if repaired != 3 {
+		t.Fatalf("expected 3 repaired rows, got %d", repaired)
+	}
+	var reloaded model.Post
+	db.First(&reloaded, post.ID)
+	if reloaded.LikeCount != 1 || receivedLikes(t, db, author.ID) != 1 || receivedLikes(t, db, fan.ID) != 0 {
+		t.Fatalf("expected counters recomputed from likes, got %d, %d, %d",
+			reloaded.LikeCount, receivedLikes(t, db, author.ID), receivedLikes(t, db, fan.ID))
+	}
+
+	if repaired, err := svc.ReconcileCounters(ctx); err != nil || repaired != 0 {
+		t.Fatalf("expected a second pass to be a no-op, got %d (%v)", repaired, err)
+	}
+
+	// A row past the first id range is repaired by a later batch.
+	far := model.Post{ID: 2*reconcileBatchSize + 5, UserID: author.ID, Content: "far", LikeCount: 3}
+	if err := db.Create(&far).Error; err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if repaired, err := svc.ReconcileCounters(ctx); err != nil || repaired != 1 {
+		t.Fatalf("expected the far post to be repaired, got %d (%v)", repaired, err)
+	}
+	db.First(&far, far.ID)
+	if far.LikeCount != 0 {
+		t.Fatalf("expected the far post's counter reset, got %d", far.LikeCount)
+	}
 }
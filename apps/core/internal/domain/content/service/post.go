@@ -0,0 +1,558 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxPostImages     = 9
+	maxPostTags       = 10
+	maxPostTagLength  = 50
+	maxPinnedPosts    = 3
+	maxPostTextLength = 10000
+)
+
+var ErrPostNotFound = errors.New("post not found")
+var ErrPostForbidden = errors.New("only the author can modify this post")
+var ErrEmptyPost = errors.New("post content is required")
+var ErrPostTooLong = errors.New("post content must be at most 10000 characters")
+var ErrTooManyPostImages = errors.New("at most 9 images can be attached to a post")
+var ErrPostMediaNotApproved = errors.New("post images must be approved uploads owned by the author")
+var ErrInvalidPostTags = errors.New("at most 10 tags of up to 50 characters are allowed")
+var ErrMerchantNotFound = errors.New("merchant not found")
+var ErrReviewNotFound = errors.New("review not found")
+var ErrReviewMerchantMismatch = errors.New("review does not belong to merchant")
+var ErrTooManyPinnedPosts = errors.New("at most 3 posts can be pinned")
+
+// PostService manages the post lifecycle: writing, editing, pinning, comments and likes.
+type PostService struct {
+	db         *gorm.DB
+	moderation *moderationservice.ModerationService
+}
+
+// NewPostService wires the service. A nil moderation pipeline disables text screening.
+func NewPostService(db *gorm.DB, moderation *moderationservice.ModerationService) *PostService {
+	if db == nil {
+		db = database.DB
+	}
+	if moderation == nil {
+		moderation = moderationservice.NewModerationService(db, moderationservice.ModeOff, false)
+	}
+	svc := &PostService{db: db, moderation: moderation}
+	moderation.RegisterApplier(moderationservice.TargetPost, svc.applyModerationStatus)
+	moderation.RegisterApplier(moderationservice.TargetPostComment, postCommentThread.ApplyStatus)
+	return svc
+}
+
+// applyModerationStatus hides or holds a published post once async moderation flags it and
+// takes it out of its author's and tags' post counts.
+func (s *PostService) applyModerationStatus(_ context.Context, tx *gorm.DB, postID int64, status int16) error {
+	var post model.Post
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", postID).Limit(1).Find(&post)
+	if result.Error != nil || result.RowsAffected == 0 || post.Status == status {
+		return result.Error
+	}
+	if err := tx.Model(&model.Post{}).Where("id = ?", post.ID).Update("status", status).Error; err != nil {
+		return err
+	}
+	if err := syncUserPostCount(tx, post.UserID); err != nil {
+		return err
+	}
+	var tagIDs []int64
+	if err := tx.Table("post_tags").Where("post_id = ?", post.ID).Pluck("tag_id", &tagIDs).Error; err != nil {
+		return err
+	}
+	return syncTagPostCounts(tx, tagIDs)
+}
+
+// Detail returns a post with its tags. Hidden posts are only shown to their author, posts by
+// a user on either side of a block with the viewer to nobody; other viewers bump view_count.
+func (s *PostService) Detail(ctx context.Context, viewerID, postID int64) (*model.Post, error) {
+	db := s.db.WithContext(ctx)
+	var post model.Post
+	if err := db.Preload("Tags").Scopes(followservice.ExcludeBlocked(viewerID, "posts.user_id")).
+		First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.Status != model.ContentStatusVisible && post.UserID != viewerID {
+		return nil, ErrPostNotFound
+	}
+	if post.UserID != viewerID {
+		if err := db.Model(&post).UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error; err != nil {
+			return nil, err
+		}
+		post.ViewCount++
+	}
+	return &post, nil
+}
+
+// Create publishes a post. Images come from the author's approved media uploads; a linked
+// review sets the merchant when none is given and must belong to it otherwise.
+func (s *PostService) Create(ctx context.Context, userID int64, req dto.CreatePostRequest) (model.Post, error) {
+	text, err := normalizePostText(req.Content)
+	if err != nil {
+		return model.Post{}, err
+	}
+	tagNames, err := normalizePostTags(req.Tags)
+	if err != nil {
+		return model.Post{}, err
+	}
+	if len(req.MediaIDs) > maxPostImages {
+		return model.Post{}, ErrTooManyPostImages
+	}
+
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetPost,
+		UserID:     userID,
+		Text:       joinPostText(req.Title, text),
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	post := model.Post{
+		UserID:  userID,
+		Title:   strings.TrimSpace(req.Title),
+		Content: text,
+		Status:  verdict.Status(),
+	}
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		merchantID, reviewID, err := resolvePostLinks(tx, req.MerchantID, req.ReviewID)
+		if err != nil {
+			return err
+		}
+		post.MerchantID, post.ReviewID = merchantID, reviewID
+
+		images, err := resolvePostImages(tx, userID, req.MediaIDs)
+		if err != nil {
+			return err
+		}
+		post.Images = images
+
+		if err := tx.Create(&post).Error; err != nil {
+			return err
+		}
+		tags, err := upsertPostTags(tx, tagNames)
+		if err != nil {
+			return err
+		}
+		if len(tags) > 0 {
+			if err := tx.Model(&post).Association("Tags").Append(&tags); err != nil {
+				return err
+			}
+		}
+		if err := syncTagPostCounts(tx, tagIDs(tags)); err != nil {
+			return err
+		}
+		return syncUserPostCount(tx, userID)
+	}); err != nil {
+		return model.Post{}, err
+	}
+
+	s.moderation.Finalize(ctx, screening, post.ID, verdict)
+	return post, nil
+}
+
+// Update applies the author's edits. Changed text is screened again, which may hold the post
+// but never lifts a status it already had; tags and images are replaced when given, and a
+// link id of 0 removes the link.
+func (s *PostService) Update(ctx context.Context, userID, postID int64, req dto.UpdatePostRequest) (model.Post, error) {
+	var text string
+	if req.Content != nil {
+		var err error
+		if text, err = normalizePostText(*req.Content); err != nil {
+			return model.Post{}, err
+		}
+	}
+	var tagNames []string
+	if req.Tags != nil {
+		var err error
+		if tagNames, err = normalizePostTags(*req.Tags); err != nil {
+			return model.Post{}, err
+		}
+	}
+	if req.MediaIDs != nil && len(*req.MediaIDs) > maxPostImages {
+		return model.Post{}, ErrTooManyPostImages
+	}
+
+	var screening moderationservice.Input
+	var verdict moderationservice.Verdict
+	rescreen := req.Content != nil || req.Title != nil
+	var post model.Post
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := loadOwnedPost(tx, userID, postID, &post); err != nil {
+			return err
+		}
+		if req.Title != nil {
+			post.Title = strings.TrimSpace(*req.Title)
+		}
+		if req.Content != nil {
+			post.Content = text
+		}
+		if rescreen {
+			// Screen only once the post is known to be the caller's, so nobody can run text
+			// through moderation against someone else's post.
+			screening = moderationservice.Input{
+				TargetType: moderationservice.TargetPost,
+				UserID:     userID,
+				Text:       joinPostText(post.Title, post.Content),
+			}
+			verdict = s.moderation.Screen(ctx, screening)
+			post.Status = verdict.EditStatus(post.Status)
+		}
+
+		if req.MerchantID != nil || req.ReviewID != nil {
+			merchantID, reviewID := post.MerchantID, post.ReviewID
+			if req.MerchantID != nil {
+				merchantID = nonZero(*req.MerchantID)
+			}
+			if req.ReviewID != nil {
+				reviewID = nonZero(*req.ReviewID)
+			}
+			var err error
+			if post.MerchantID, post.ReviewID, err = resolvePostLinks(tx, merchantID, reviewID); err != nil {
+				return err
+			}
+		}
+		if req.MediaIDs != nil {
+			images, err := resolvePostImages(tx, userID, *req.MediaIDs)
+			if err != nil {
+				return err
+			}
+			post.Images = images
+		}
+
+		if err := tx.Model(&post).
+			Select("title", "content", "status", "merchant_id", "review_id", "images").
+			Updates(&post).Error; err != nil {
+			return err
+		}
+
+		var affected []int64
+		if err := tx.Table("post_tags").Where("post_id = ?", post.ID).Pluck("tag_id", &affected).Error; err != nil {
+			return err
+		}
+		if req.Tags != nil {
+			tags, err := upsertPostTags(tx, tagNames)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&post).Association("Tags").Replace(&tags); err != nil {
+				return err
+			}
+			affected = append(affected, tagIDs(tags)...)
+		}
+		if err := syncTagPostCounts(tx, affected); err != nil {
+			return err
+		}
+		return syncUserPostCount(tx, userID)
+	}); err != nil {
+		return model.Post{}, err
+	}
+
+	if rescreen {
+		s.moderation.Finalize(ctx, screening, post.ID, verdict)
+	}
+	return post, nil
+}
+
+// Delete removes the author's post with its comments, tags and likes, and refreshes the
+// counters that included it.
+func (s *PostService) Delete(ctx context.Context, userID, postID int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post model.Post
+		if err := loadOwnedPost(tx, userID, postID, &post); err != nil {
+			return err
+		}
+		var tags []int64
+		if err := tx.Table("post_tags").Where("post_id = ?", post.ID).Pluck("tag_id", &tags).Error; err != nil {
+			return err
+		}
+		var commentIDs, commenterIDs []int64
+		if err := tx.Model(&model.PostComment{}).Where("post_id = ?", post.ID).Pluck("id", &commentIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.PostComment{}).Where("post_id = ?", post.ID).Distinct().
+			Pluck("user_id", &commenterIDs).Error; err != nil {
+			return err
+		}
+
+		if len(commentIDs) > 0 {
+			if err := tx.Where("target_type = ? AND target_id IN ?", TargetPostComment, commentIDs).
+				Delete(&model.Like{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("target_type = ? AND target_id = ?", TargetPost, post.ID).Delete(&model.Like{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("post_id = ?", post.ID).Delete(&model.PostComment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&post).Association("Tags").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Delete(&post).Error; err != nil {
+			return err
+		}
+		if err := syncTagPostCounts(tx, tags); err != nil {
+			return err
+		}
+		if err := NewInteractionService(tx).RecountReceivedLikes(ctx, append(commenterIDs, userID)...); err != nil {
+			return err
+		}
+		return syncUserPostCount(tx, userID)
+	})
+}
+
+// SetPinned pins or unpins the author's post on their profile; at most maxPinnedPosts can
+// be pinned at once.
+func (s *PostService) SetPinned(ctx context.Context, userID, postID int64, pinned bool) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post model.Post
+		if err := loadOwnedPost(tx, userID, postID, &post); err != nil {
+			return err
+		}
+		if post.IsPinned == pinned {
+			return nil
+		}
+		if pinned {
+			var count int64
+			if err := tx.Model(&model.Post{}).Where("user_id = ? AND is_pinned = ?", userID, true).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count >= maxPinnedPosts {
+				return ErrTooManyPinnedPosts
+			}
+		}
+		return tx.Model(&post).UpdateColumn("is_pinned", pinned).Error
+	})
+}
+
+// Like records the user's like on a visible post; liking twice is a no-op.
+func (s *PostService) Like(ctx context.Context, userID, postID int64) error {
+	return postInteractionError(NewInteractionService(s.db).Like(ctx, userID, TargetPost, postID))
+}
+
+// Unlike removes the user's like from a post. Unliking a post that was never liked is a no-op.
+func (s *PostService) Unlike(ctx context.Context, userID, postID int64) error {
+	return postInteractionError(NewInteractionService(s.db).Unlike(ctx, userID, TargetPost, postID))
+}
+
+func postInteractionError(err error) error {
+	if errors.Is(err, ErrInteractionTargetNotFound) {
+		return ErrPostNotFound
+	}
+	return err
+}
+
+func lockPost(tx *gorm.DB, postID int64, post *model.Post) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// lockVisiblePost locks a post the viewer may interact with: any visible post, or their own.
+func lockVisiblePost(tx *gorm.DB, viewerID, postID int64, post *model.Post) error {
+	if err := lockPost(tx, postID, post); err != nil {
+		return err
+	}
+	if post.Status != model.ContentStatusVisible && post.UserID != viewerID {
+		return ErrPostNotFound
+	}
+	return nil
+}
+
+func loadOwnedPost(tx *gorm.DB, userID, postID int64, post *model.Post) error {
+	if err := lockPost(tx, postID, post); err != nil {
+		return err
+	}
+	if post.UserID != userID {
+		return ErrPostForbidden
+	}
+	return nil
+}
+
+func normalizePostText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrEmptyPost
+	}
+	if utf8.RuneCountInString(text) > maxPostTextLength {
+		return "", ErrPostTooLong
+	}
+	return text, nil
+}
+
+func joinPostText(title, content string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return content
+	}
+	return title + "\n" + content
+}
+
+func nonZero(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// resolvePostLinks checks the linked merchant and review. A review alone links its merchant.
+func resolvePostLinks(tx *gorm.DB, merchantID, reviewID *int64) (*int64, *int64, error) {
+	if merchantID != nil {
+		if err := tx.Select("id").First(&model.Merchant{}, *merchantID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, ErrMerchantNotFound
+			}
+			return nil, nil, err
+		}
+	}
+	if reviewID != nil {
+		var review model.Review
+		if err := tx.Select("id", "merchant_id", "status").First(&review, *reviewID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, ErrReviewNotFound
+			}
+			return nil, nil, err
+		}
+		if review.Status != model.ContentStatusVisible {
+			return nil, nil, ErrReviewNotFound
+		}
+		if merchantID == nil {
+			merchantID = &review.MerchantID
+		} else if *merchantID != review.MerchantID {
+			return nil, nil, ErrReviewMerchantMismatch
+		}
+	}
+	return merchantID, reviewID, nil
+}
+
+// resolvePostImages turns media upload UUIDs into the JSON list of image URLs, in request
+// order. Each upload must be owned by the author and approved by analysis.
+func resolvePostImages(tx *gorm.DB, userID int64, uuids []string) (string, error) {
+	ordered := make([]string, 0, len(uuids))
+	seen := make(map[string]struct{}, len(uuids))
+	for _, id := range uuids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ordered = append(ordered, id)
+	}
+
+	urls := make([]string, 0, len(ordered))
+	if len(ordered) > 0 {
+		var uploads []model.MediaUpload
+		if err := tx.Where("uuid IN ? AND user_id = ? AND status = ?", ordered, userID, model.MediaStatusApproved).
+			Find(&uploads).Error; err != nil {
+			return "", err
+		}
+		if len(uploads) != len(ordered) {
+			return "", ErrPostMediaNotApproved
+		}
+		byUUID := make(map[string]string, len(uploads))
+		for _, upload := range uploads {
+			byUUID[upload.UUID] = upload.FileURL
+		}
+		for _, id := range ordered {
+			urls = append(urls, byUUID[id])
+		}
+	}
+	images, err := json.Marshal(urls)
+	if err != nil {
+		return "", err
+	}
+	return string(images), nil
+}
+
+// normalizePostTags trims, lowercases and de-duplicates tag names, dropping a leading '#'.
+func normalizePostTags(raw []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(raw))
+	names := make([]string, 0, len(raw))
+	for _, name := range raw {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "#")))
+		if name == "" {
+			continue
+		}
+		if utf8.RuneCountInString(name) > maxPostTagLength {
+			return nil, ErrInvalidPostTags
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	if len(names) > maxPostTags {
+		return nil, ErrInvalidPostTags
+	}
+	return names, nil
+}
+
+func upsertPostTags(tx *gorm.DB, names []string) ([]model.Tag, error) {
+	if len(names) == 0 {
+		return []model.Tag{}, nil
+	}
+	tags := make([]model.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, model.Tag{Name: name})
+	}
+	if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&tags).Error; err != nil {
+		return nil, err
+	}
+	tags = tags[:0]
+	if err := tx.Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func tagIDs(tags []model.Tag) []int64 {
+	ids := make([]int64, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
+func syncUserPostCount(tx *gorm.DB, userID int64) error {
+	var count int64
+	if err := tx.Model(&model.Post{}).
+		Where("user_id = ? AND status = ?", userID, model.ContentStatusVisible).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	return tx.Model(&model.UserProfile{}).Where("user_id = ?", userID).Update("post_count", int(count)).Error
+}
+
+func syncTagPostCounts(tx *gorm.DB, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	return tx.Model(&model.Tag{}).Where("id IN ?", tagIDs).Update("post_count", gorm.Expr(
+		"(SELECT COUNT(*) FROM post_tags JOIN posts ON posts.id = post_tags.post_id "+
+			"WHERE post_tags.tag_id = tags.id AND posts.status = ?)",
+		model.ContentStatusVisible,
+	)).Error
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+const maxPostCommentLength = 2000
+
+var ErrPostCommentNotFound = errors.New("comment not found")
+var ErrPostCommentForbidden = errors.New("only the author can modify this comment")
+var ErrInvalidPostComment = errors.New("comment must be 1 to 2000 characters")
+
+var postCommentThread = CommentThread[model.PostComment]{
+	Table:      "post_comments",
+	Owner:      "post_id",
+	OwnerTable: "posts",
+	Node: func(comment *model.PostComment) CommentNode {
+		return CommentNode{ID: comment.ID, ParentID: comment.ParentCommentID, UserID: comment.UserID}
+	},
+	ErrNotFound:  ErrPostCommentNotFound,
+	ErrForbidden: ErrPostCommentForbidden,
+}
+
+// ListComments returns a page of top-level comments on a post, newest first, each with its
+// replies nested up to MaxCommentDepth. Hidden comments are only shown to their author,
+// and comments by users on either side of a block with the viewer are left out.
+func (s *PostService) ListComments(ctx context.Context, viewerID, postID int64, cursor *int64, limit *int) ([]dto.PostCommentItem, *int64, error) {
+	db := s.db.WithContext(ctx)
+	var post model.Post
+	if err := db.Select("id", "user_id", "status").Scopes(followservice.ExcludeBlocked(viewerID, "posts.user_id")).
+		First(&post, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrPostNotFound
+		}
+		return nil, nil, err
+	}
+	if post.Status != model.ContentStatusVisible && post.UserID != viewerID {
+		return nil, nil, ErrPostNotFound
+	}
+
+	roots, children, next, err := postCommentThread.Page(db, viewerID, postID, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	comments := append([]model.PostComment{}, roots...)
+	for _, replies := range children {
+		comments = append(comments, replies...)
+	}
+
+	authors, err := loadUserBriefs(db, comments)
+	if err != nil {
+		return nil, nil, err
+	}
+	liked, err := likedCommentIDs(db, viewerID, comments)
+	if err != nil {
+		return nil, nil, err
+	}
+	var build func(comment model.PostComment) dto.PostCommentItem
+	build = func(comment model.PostComment) dto.PostCommentItem {
+		item := postCommentItem(comment, authors[comment.UserID], liked[comment.ID])
+		for _, reply := range children[comment.ID] {
+			item.Replies = append(item.Replies, build(reply))
+		}
+		return item
+	}
+	items := make([]dto.PostCommentItem, 0, len(roots))
+	for _, root := range roots {
+		items = append(items, build(root))
+	}
+	return items, next, nil
+}
+
+// Comment adds a comment to a post, or a reply when parentID is set. Replies nested deeper
+// than MaxCommentDepth are attached to the deepest allowed ancestor instead.
+func (s *PostService) Comment(ctx context.Context, userID, postID int64, parentID *int64, text string) (dto.PostCommentItem, error) {
+	text, err := normalizePostComment(text)
+	if err != nil {
+		return dto.PostCommentItem{}, err
+	}
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetPostComment,
+		UserID:     userID,
+		Text:       text,
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	comment := model.PostComment{PostID: postID, UserID: userID, Content: text, Status: verdict.Status()}
+	created := eventbus.CommentCreated{UserID: userID, TargetType: TargetPost, TargetID: postID, Content: text}
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post model.Post
+		if err := lockVisiblePost(tx, userID, postID, &post); err != nil {
+			return err
+		}
+		created.OwnerID = post.UserID
+		if parentID != nil {
+			parent, parentAuthorID, err := postCommentThread.ReplyParent(tx, postID, *parentID)
+			if err != nil {
+				return err
+			}
+			comment.ParentCommentID = &parent
+			created.ParentAuthorID = parentAuthorID
+		}
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+		return postCommentThread.SyncCount(tx, post.ID)
+	}); err != nil {
+		return dto.PostCommentItem{}, err
+	}
+
+	s.moderation.Finalize(ctx, screening, comment.ID, verdict)
+	if comment.Status == model.ContentStatusVisible {
+		created.CommentID = comment.ID
+		eventbus.Publish(ctx, created)
+	}
+	return s.commentItem(ctx, comment)
+}
+
+// UpdateComment replaces the text of the author's comment and screens it again. Screening
+// may hold the comment but never lifts a status it already had.
+func (s *PostService) UpdateComment(ctx context.Context, userID, postID, commentID int64, text string) (dto.PostCommentItem, error) {
+	text, err := normalizePostComment(text)
+	if err != nil {
+		return dto.PostCommentItem{}, err
+	}
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetPostComment,
+		UserID:     userID,
+		Text:       text,
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	var comment model.PostComment
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post model.Post
+		if err := lockPost(tx, postID, &post); err != nil {
+			return err
+		}
+		if err := postCommentThread.LockOwned(tx, userID, postID, commentID, &comment); err != nil {
+			return err
+		}
+		comment.Content = text
+		comment.Status = verdict.EditStatus(comment.Status)
+		if err := tx.Model(&comment).Select("content", "status").Updates(&comment).Error; err != nil {
+			return err
+		}
+		return postCommentThread.SyncCount(tx, post.ID)
+	}); err != nil {
+		return dto.PostCommentItem{}, err
+	}
+
+	s.moderation.Finalize(ctx, screening, comment.ID, verdict)
+	return s.commentItem(ctx, comment)
+}
+
+// DeleteComment removes the author's comment together with its replies and their likes, and
+// recounts the post's comments.
+func (s *PostService) DeleteComment(ctx context.Context, userID, postID, commentID int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post model.Post
+		if err := lockPost(tx, postID, &post); err != nil {
+			return err
+		}
+		var comment model.PostComment
+		if err := postCommentThread.LockOwned(tx, userID, postID, commentID, &comment); err != nil {
+			return err
+		}
+		ids, authorIDs, err := postCommentThread.Subtree(tx, comment.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("target_type = ? AND target_id IN ?", TargetPostComment, ids).Delete(&model.Like{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&model.PostComment{}).Error; err != nil {
+			return err
+		}
+		if err := NewInteractionService(tx).RecountReceivedLikes(ctx, authorIDs...); err != nil {
+			return err
+		}
+		return postCommentThread.SyncCount(tx, post.ID)
+	})
+}
+
+// LikeComment records the user's like on a visible comment of the post. The like runs in its
+// own transaction, which locks the comment, so its event is only published once committed.
+func (s *PostService) LikeComment(ctx context.Context, userID, postID, commentID int64) error {
+	var comment model.PostComment
+	if err := postCommentThread.Get(s.db.WithContext(ctx).Select("id"), postID, commentID, &comment); err != nil {
+		return err
+	}
+	return postCommentInteractionError(NewInteractionService(s.db).Like(ctx, userID, TargetPostComment, comment.ID))
+}
+
+// UnlikeComment removes the user's like from a comment; it is a no-op when there was none.
+func (s *PostService) UnlikeComment(ctx context.Context, userID, postID, commentID int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var comment model.PostComment
+		if err := postCommentThread.Lock(tx, postID, commentID, &comment); err != nil {
+			return err
+		}
+		return postCommentInteractionError(NewInteractionService(tx).Unlike(ctx, userID, TargetPostComment, comment.ID))
+	})
+}
+
+func postCommentInteractionError(err error) error {
+	if errors.Is(err, ErrInteractionTargetNotFound) {
+		return ErrPostCommentNotFound
+	}
+	return err
+}
+
+func (s *PostService) commentItem(ctx context.Context, comment model.PostComment) (dto.PostCommentItem, error) {
+	db := s.db.WithContext(ctx)
+	authors, err := loadUserBriefs(db, []model.PostComment{comment})
+	if err != nil {
+		return dto.PostCommentItem{}, err
+	}
+	liked, err := likedCommentIDs(db, comment.UserID, []model.PostComment{comment})
+	if err != nil {
+		return dto.PostCommentItem{}, err
+	}
+	return postCommentItem(comment, authors[comment.UserID], liked[comment.ID]), nil
+}
+
+func postCommentItem(comment model.PostComment, author *dto.UserBrief, liked bool) dto.PostCommentItem {
+	return dto.PostCommentItem{
+		ID:              comment.ID,
+		PostID:          comment.PostID,
+		ParentCommentID: comment.ParentCommentID,
+		Content:         comment.Content,
+		LikeCount:       comment.LikeCount,
+		IsLiked:         liked,
+		Status:          comment.Status,
+		Author:          author,
+		Replies:         []dto.PostCommentItem{},
+		CreatedAt:       comment.CreatedAt,
+	}
+}
+
+func normalizePostComment(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxPostCommentLength {
+		return "", ErrInvalidPostComment
+	}
+	return text, nil
+}
+
+func loadUserBriefs(db *gorm.DB, comments []model.PostComment) (map[int64]*dto.UserBrief, error) {
+	briefs := make(map[int64]*dto.UserBrief)
+	if len(comments) == 0 {
+		return briefs, nil
+	}
+	userIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		userIDs = append(userIDs, comment.UserID)
+	}
+	var profiles []model.UserProfile
+	if err := db.Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		briefs[profile.UserID] = &dto.UserBrief{
+			UserID:    profile.UserID,
+			Nickname:  profile.Nickname,
+			AvatarURL: profile.AvatarURL,
+			Intro:     profile.Intro,
+		}
+	}
+	return briefs, nil
+}
+
+func likedCommentIDs(db *gorm.DB, viewerID int64, comments []model.PostComment) (map[int64]bool, error) {
+	liked := make(map[int64]bool)
+	if viewerID == 0 || len(comments) == 0 {
+		return liked, nil
+	}
+	ids := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	var likedIDs []int64
+	if err := db.Model(&model.Like{}).
+		Where("user_id = ? AND target_type = ? AND target_id IN ?", viewerID, TargetPostComment, ids).
+		Pluck("target_id", &likedIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range likedIDs {
+		liked[id] = true
+	}
+	return liked, nil
+}
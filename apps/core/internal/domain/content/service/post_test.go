@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func createPostAuthor(t *testing.T, db *gorm.DB, nickname string) model.User {
+	t.Helper()
+	user := model.User{Role: "user"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := db.Create(&model.UserProfile{UserID: user.ID, Nickname: nickname}).Error; err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	return user
+}
+
+func postCounters(t *testing.T, db *gorm.DB, userID int64, tag string) (int, int) {
+	t.Helper()
+	var profile model.UserProfile
+	if err := db.First(&profile, "user_id = ?", userID).Error; err != nil {
+		t.Fatalf("failed to load profile: %v", err)
+	}
+	var row model.Tag
+	if err := db.Where("name = ?", tag).First(&row).Error; err != nil {
+		t.Fatalf("failed to load tag: %v", err)
+	}
+	return profile.PostCount, row.PostCount
+}
+
+func TestPostLifecycleKeepsCountersConsistent(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	review := model.Review{UserID: author.ID, MerchantID: merchant.ID, VenueID: merchant.ID, Rating: 5, Content: "great"}
+	if err := db.Create(&review).Error; err != nil {
+		t.Fatalf("failed to create review: %v", err)
+	}
+	uploads := []model.MediaUpload{
+		{UUID: "img-1", UserID: author.ID, ObjectKey: "k1", FileURL: "https://cdn/1.jpg", Status: model.MediaStatusApproved},
+		{UUID: "img-2", UserID: author.ID, ObjectKey: "k2", FileURL: "https://cdn/2.jpg", Status: model.MediaStatusApproved},
+		{UUID: "img-pending", UserID: author.ID, ObjectKey: "k3", FileURL: "https://cdn/3.jpg", Status: model.MediaStatusPending},
+	}
+	if err := db.Create(&uploads).Error; err != nil {
+		t.Fatalf("failed to create uploads: %v", err)
+	}
+	svc := NewPostService(db, nil)
+	ctx := context.Background()
+
+	post, err := svc.Create(ctx, author.ID, dto.CreatePostRequest{
+		Title:    "Brunch",
+		Content:  " Loved it ",
+		MediaIDs: []string{"img-2", "img-1"},
+		Tags:     []string{"#Brunch", "coffee", "brunch"},
+		ReviewID: &review.ID,
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if post.Content != "Loved it" || post.Images != `["https://cdn/2.jpg","https://cdn/1.jpg"]` {
+		t.Fatalf("unexpected post: %+v", post)
+	}
+	if post.MerchantID == nil || *post.MerchantID != merchant.ID {
+		t.Fatalf("expected the review's merchant to be linked, got %v", post.MerchantID)
+	}
+	if posts, tags := postCounters(t, db, author.ID, "brunch"); posts != 1 || tags != 1 {
+		t.Fatalf("expected post_count 1 and tag post_count 1, got %d and %d", posts, tags)
+	}
+
+	if _, err := svc.Create(ctx, author.ID, dto.CreatePostRequest{Content: "x", MediaIDs: []string{"img-pending"}}); !errors.Is(err, ErrPostMediaNotApproved) {
+		t.Fatalf("expected ErrPostMediaNotApproved, got %v", err)
+	}
+	other := model.Merchant{Name: "Other"}
+	db.Create(&other)
+	if _, err := svc.Create(ctx, author.ID, dto.CreatePostRequest{Content: "x", MerchantID: &other.ID, ReviewID: &review.ID}); !errors.Is(err, ErrReviewMerchantMismatch) {
+		t.Fatalf("expected ErrReviewMerchantMismatch, got %v", err)
+	}
+
+	stranger := createPostAuthor(t, db, "stranger")
+	text := "edited"
+	if _, err := svc.Update(ctx, stranger.ID, post.ID, dto.UpdatePostRequest{Content: &text}); !errors.Is(err, ErrPostForbidden) {
+		t.Fatalf("expected ErrPostForbidden, got %v", err)
+	}
+	tags := []string{"coffee"}
+	unlink := int64(0)
+	updated, err := svc.Update(ctx, author.ID, post.ID, dto.UpdatePostRequest{Content: &text, Tags: &tags, ReviewID: &unlink, MerchantID: &unlink})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if updated.Content != "edited" || updated.ReviewID != nil || updated.MerchantID != nil {
+		t.Fatalf("unexpected post after update: %+v", updated)
+	}
+	if _, brunch := postCounters(t, db, author.ID, "brunch"); brunch != 0 {
+		t.Fatalf("expected the dropped tag's post_count to fall to 0, got %d", brunch)
+	}
+	if _, coffee := postCounters(t, db, author.ID, "coffee"); coffee != 1 {
+		t.Fatalf("expected coffee post_count 1, got %d", coffee)
+	}
+
+	if err := svc.Delete(ctx, author.ID, post.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if posts, coffee := postCounters(t, db, author.ID, "coffee"); posts != 0 || coffee != 0 {
+		t.Fatalf("expected counters back at 0, got %d and %d", posts, coffee)
+	}
+}
+
+func TestPinningIsLimitedAndListedFirst(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	svc := NewPostService(db, nil)
+	ctx := context.Background()
+
+	var ids []int64
+	for i := 0; i < maxPinnedPosts+2; i++ {
+		post, err := svc.Create(ctx, author.ID, dto.CreatePostRequest{Content: "post"})
+		if err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		ids = append(ids, post.ID)
+	}
+	for i := 0; i < maxPinnedPosts; i++ {
+		if err := svc.SetPinned(ctx, author.ID, ids[i], true); err != nil {
+			t.Fatalf("pin failed: %v", err)
+		}
+	}
+	if err := svc.SetPinned(ctx, author.ID, ids[maxPinnedPosts], true); !errors.Is(err, ErrTooManyPinnedPosts) {
+		t.Fatalf("expected ErrTooManyPinnedPosts, got %v", err)
+	}
+	if err := svc.SetPinned(ctx, author.ID, ids[0], false); err != nil {
+		t.Fatalf("unpin failed: %v", err)
+	}
+
+	posts, total, err := NewContentService(db).ListUserPosts(ctx, author.ID, author.ID, nil, 10)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if total != int64(len(ids)) || len(posts) != len(ids) {
+		t.Fatalf("expected %d posts, got %d (total %d)", len(ids), len(posts), total)
+	}
+	if !posts[0].IsPinned || !posts[1].IsPinned || posts[2].IsPinned {
+		t.Fatalf("expected pinned posts first, got %+v", posts)
+	}
+	if posts[0].ID != ids[2] || posts[1].ID != ids[1] {
+		t.Fatalf("expected pinned posts newest first, got %d, %d", posts[0].ID, posts[1].ID)
+	}
+}
+
+func TestPostLikesUseInteractionsAndStayIdempotent(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	fan := createPostAuthor(t, db, "fan")
+	svc := NewPostService(db, nil)
+	ctx := context.Background()
+
+	post, err := svc.Create(ctx, author.ID, dto.CreatePostRequest{Content: "hello"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := svc.Like(ctx, fan.ID, post.ID); err != nil {
+			t.Fatalf("like failed: %v", err)
+		}
+	}
+	var reloaded model.Post
+	db.First(&reloaded, post.ID)
+	if reloaded.LikeCount != 1 {
+		t.Fatalf("expected like_count 1, got %d", reloaded.LikeCount)
+	}
+	for i := 0; i < 2; i++ {
+		if err := svc.Unlike(ctx, fan.ID, post.ID); err != nil {
+			t.Fatalf("unlike failed: %v", err)
+		}
+	}
+	db.First(&reloaded, post.ID)
+	if reloaded.LikeCount != 0 {
+		t.Fatalf("expected like_count 0, got %d", reloaded.LikeCount)
+	}
+	if err := svc.Like(ctx, fan.ID, 999); !errors.Is(err, ErrPostNotFound) {
+		t.Fatalf("expected ErrPostNotFound, got %v", err)
+	}
+}
+
+func TestPostCommentsThreadAndCount(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	fan := createPostAuthor(t, db, "fan")
+	svc := NewPostService(db, nil)
+	ctx := context.Background()
+
+	post, err := svc.Create(ctx, author.ID, dto.CreatePostRequest{Content: "hello"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	root, err := svc.Comment(ctx, fan.ID, post.ID, nil, "first")
+	if err != nil {
+		t.Fatalf("comment failed: %v", err)
+	}
+	if root.Author == nil || root.Author.Nickname != "fan" {
+		t.Fatalf("expected the author brief, got %+v", root.Author)
+	}
+	parent := root.ID
+	for depth := 2; depth <= MaxCommentDepth+1; depth++ {
+		reply, err := svc.Comment(ctx, author.ID, post.ID, &parent, "reply")
+		if err != nil {
+			t.Fatalf("reply failed: %v", err)
+		}
+		parent = reply.ID
+	}
+	if err := svc.LikeComment(ctx, author.ID, post.ID, root.ID); err != nil {
+		t.Fatalf("like comment failed: %v", err)
+	}
+
+	comments, next, err := svc.ListComments(ctx, author.ID, post.ID, nil, nil)
+	if err != nil {
+		t.Fatalf("list comments failed: %v", err)
+	}
+	if next != nil || len(comments) != 1 {
+		t.Fatalf("expected one thread, got %d (next %v)", len(comments), next)
+	}
+	thread := comments[0]
+	if !thread.IsLiked || thread.LikeCount != 1 {
+		t.Fatalf("expected the viewer's like on the root, got %+v", thread)
+	}
+	if len(thread.Replies) != 1 || len(thread.Replies[0].Replies) != 2 || len(thread.Replies[0].Replies[0].Replies) != 0 {
+		t.Fatalf("expected replies clamped to depth %d, got %+v", MaxCommentDepth, thread)
+	}
+
+	var reloaded model.Post
+	db.First(&reloaded, post.ID)
+	if reloaded.CommentCount != 4 {
+		t.Fatalf("expected comment_count 4, got %d", reloaded.CommentCount)
+	}
+	if err := svc.DeleteComment(ctx, author.ID, post.ID, root.ID); !errors.Is(err, ErrPostCommentForbidden) {
+		t.Fatalf("expected ErrPostCommentForbidden, got %v", err)
+	}
+	if err := svc.DeleteComment(ctx, fan.ID, post.ID, root.ID); err != nil {
+		t.Fatalf("delete comment failed: %v", err)
+	}
+	db.First(&reloaded, post.ID)
+	if reloaded.CommentCount != 0 {
+		t.Fatalf("expected comment_count 0 after deleting the thread, got %d", reloaded.CommentCount)
+	}
+	var likes int64
+	db.Model(&model.Like{}).Where("target_type = ?", TargetPostComment).Count(&likes)
+	if likes != 0 {
+		t.Fatalf("expected comment likes to be removed, got %d", likes)
+	}
+}
+
+func TestPostEditsNeverLiftModeration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	stranger := createPostAuthor(t, db, "stranger")
+	svc := NewPostService(db, nil)
+	ctx := context.Background()
+
+	post := model.Post{UserID: author.ID, Content: "reported", Status: model.ContentStatusHidden}
+	if err := db.Create(&post).Error; err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	comment := model.PostComment{PostID: post.ID, UserID: author.ID, Content: "reported", Status: model.ContentStatusHidden}
+	if err := db.Create(&comment).Error; err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	polite := "perfectly polite text"
+	if _, err := svc.Update(ctx, stranger.ID, post.ID, dto.UpdatePostRequest{Content: &polite}); !errors.Is(err, ErrPostForbidden) {
+		t.Fatalf("expected a forbidden edit, got %v", err)
+	}
+	updated, err := svc.Update(ctx, author.ID, post.ID, dto.UpdatePostRequest{Content: &polite})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := svc.UpdateComment(ctx, author.ID, post.ID, comment.ID, polite); err != nil {
+		t.Fatalf("update comment failed: %v", err)
+	}
+
+	var reloadedPost model.Post
+	var reloadedComment model.PostComment
+	if err := db.First(&reloadedPost, post.ID).Error; err != nil {
+		t.Fatalf("failed to reload post: %v", err)
+	}
+	if err := db.First(&reloadedComment, comment.ID).Error; err != nil {
+		t.Fatalf("failed to reload comment: %v", err)
+	}
+	if updated.Status != model.ContentStatusHidden || reloadedPost.Status != model.ContentStatusHidden {
+		t.Fatalf("expected the edited post to stay hidden, got %d/%d", updated.Status, reloadedPost.Status)
+	}
+	if reloadedComment.Status != model.ContentStatusHidden || reloadedComment.Content != polite {
+		t.Fatalf("expected the edited comment to stay hidden, got %+v", reloadedComment)
+	}
+}
+
+func TestModeratedPostCommentsLeaveTheCommentCount(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	author := createPostAuthor(t, db, "author")
+	svc := NewPostService(db, nil)
+	ctx := context.Background()
+
+	post, err := svc.Create(ctx, author.ID, dto.CreatePostRequest{Content: "hello"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	kept, err := svc.Comment(ctx, author.ID, post.ID, nil, "kept")
+	if err != nil {
+		t.Fatalf("comment failed: %v", err)
+	}
+	flagged, err := svc.Comment(ctx, author.ID, post.ID, nil, "flagged")
+	if err != nil {
+		t.Fatalf("comment failed: %v", err)
+	}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return postCommentThread.ApplyStatus(ctx, tx, flagged.ID, model.ContentStatusPending)
+	}); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	var reloaded model.Post
+	if err := db.First(&reloaded, post.ID).Error; err != nil {
+		t.Fatalf("failed to reload post: %v", err)
+	}
+	if reloaded.CommentCount != 1 {
+		t.Fatalf("expected only the visible comment counted, got %d", reloaded.CommentCount)
+	}
+	if err := svc.DeleteComment(ctx, author.ID, post.ID, kept.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := db.First(&reloaded, post.ID).Error; err != nil || reloaded.CommentCount != 0 {
+		t.Fatalf("expected comment_count 0 after deleting the visible comment, got %d (%v)", reloaded.CommentCount, err)
+	}
+}
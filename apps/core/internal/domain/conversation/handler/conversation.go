@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -15,7 +16,7 @@ type ConversationHandler struct {
 
 func NewConversationHandler(svc *service.ConversationService) *ConversationHandler {
 	if svc == nil {
-		svc = service.NewConversationService(nil)
+		svc = service.NewConversationService(nil, nil, nil)
 	}
 	return &ConversationHandler{svc: svc}
 }
@@ -71,6 +72,14 @@ func (h *ConversationHandler) Create(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation input"})
 			return
 		}
+		if errors.Is(err, service.ErrConversationBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "blocked"})
+			return
+		}
+		if errors.Is(err, service.ErrConversationFull) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create conversation"})
 		return
 	}
@@ -79,12 +88,17 @@ func (h *ConversationHandler) Create(c *gin.Context) {
 
 // ConversationMessages godoc
 // @Summary Get conversation messages
-// @Description Returns messages for a conversation
+// @Description Returns a page of messages, oldest first, without marking them read; use POST /conversations/{id}/read for that. Without cursors the latest page is returned; has_more reports whether more messages lie beyond the page in the requested direction
 // @Tags conversation
 // @Produce json
 // @Param id path int true "Conversation ID"
+// @Param before query int false "Only messages with a smaller ID"
+// @Param after query int false "Only messages with a larger ID"
+// @Param limit query int false "Page size (default 50, max 100)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /conversations/{id}/messages [get]
 func (h *ConversationHandler) Messages(c *gin.Context) {
 	userID := c.GetInt64("user_id")
@@ -99,7 +113,13 @@ func (h *ConversationHandler) Messages(c *gin.Context) {
 		return
 	}
 
-	messages, err := h.svc.Messages(c.Request.Context(), userID, id)
+	query, err := parseMessageListQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	messages, hasMore, err := h.svc.Messages(c.Request.Context(), userID, id, query)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrConversationForbidden):
@@ -110,18 +130,21 @@ func (h *ConversationHandler) Messages(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": messages})
+	c.JSON(http.StatusOK, gin.H{"data": messages, "has_more": hasMore})
 }
 
 // SendMessage godoc
 // @Summary Send message
-// @Description Sends a message in a conversation
+// @Description Sends a message in a conversation. message_type is one of text, image, voucher_share or store_card; attachment_ids are approved media upload UUIDs owned by the sender
 // @Tags conversation
 // @Accept json
 // @Produce json
 // @Param id path int true "Conversation ID"
+// @Param request body service.SendMessageInput true "Message"
 // @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /conversations/{id}/messages [post]
 func (h *ConversationHandler) SendMessage(c *gin.Context) {
 	userID := c.GetInt64("user_id")
@@ -147,8 +170,12 @@ func (h *ConversationHandler) SendMessage(c *gin.Context) {
 		switch {
 		case errors.Is(err, service.ErrConversationForbidden):
 			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		case errors.Is(err, service.ErrConversationBlocked):
+			c.JSON(http.StatusForbidden, gin.H{"error": "blocked"})
 		case errors.Is(err, service.ErrConversationInvalidInput):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message"})
+		case errors.Is(err, service.ErrMessageAttachmentInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send message"})
 		}
@@ -157,6 +184,182 @@ func (h *ConversationHandler) SendMessage(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"data": message})
 }
 
+// EditMessage godoc
+// @Summary Edit message
+// @Description Replaces the content of the caller's own message within 15 minutes of sending it and marks it edited
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param messageId path int true "Message ID"
+// @Param request body service.EditMessageInput true "New content"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /conversations/{id}/messages/{messageId} [patch]
+func (h *ConversationHandler) EditMessage(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, messageID, ok := parseMessagePath(c)
+	if !ok {
+		return
+	}
+
+	var req service.EditMessageInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message, err := h.svc.EditMessage(c.Request.Context(), userID, id, messageID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrConversationForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		case errors.Is(err, service.ErrMessageEditWindowExpired):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrMessageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "message not found"})
+		case errors.Is(err, service.ErrConversationInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to edit message"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": message})
+}
+
+// DeleteMessage godoc
+// @Summary Delete message
+// @Description Deletes a message for the caller only (scope=me, the default) or, for its sender, for every participant (scope=everyone)
+// @Tags conversation
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param messageId path int true "Message ID"
+// @Param scope query string false "me or everyone"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /conversations/{id}/messages/{messageId} [delete]
+func (h *ConversationHandler) DeleteMessage(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, messageID, ok := parseMessagePath(c)
+	if !ok {
+		return
+	}
+
+	var forEveryone bool
+	switch c.DefaultQuery("scope", "me") {
+	case "me":
+	case "everyone":
+		forEveryone = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope must be me or everyone"})
+		return
+	}
+
+	if err := h.svc.DeleteMessage(c.Request.Context(), userID, id, messageID, forEveryone); err != nil {
+		switch {
+		case errors.Is(err, service.ErrConversationForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		case errors.Is(err, service.ErrMessageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "message not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete message"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// MarkConversationRead godoc
+// @Summary Mark conversation read
+// @Description Records that the authenticated user has read the conversation up to now and sends a read receipt to the other participants
+// @Tags conversation
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Success 200 {object} service.ReadReceipt
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /conversations/{id}/read [post]
+func (h *ConversationHandler) MarkRead(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	receipt, err := h.svc.MarkRead(c.Request.Context(), userID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrConversationForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark conversation read"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, receipt)
+}
+
+// ConversationReadReceipts godoc
+// @Summary Get read receipts
+// @Description Returns how far each participant has read the conversation
+// @Tags conversation
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /conversations/{id}/read-receipts [get]
+func (h *ConversationHandler) ReadReceipts(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	receipts, err := h.svc.ReadReceipts(c.Request.Context(), userID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrConversationForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load read receipts"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": receipts})
+}
+
 // UpdateConversationSettings godoc
 // @Summary Update conversation settings
 // @Description Updates settings for a conversation (e.g. mute)
@@ -198,3 +401,38 @@ func (h *ConversationHandler) UpdateSettings(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"data": conversation})
 }
+
+func parseMessageListQuery(c *gin.Context) (service.MessageListQuery, error) {
+	var query service.MessageListQuery
+	for key, target := range map[string]**int64{"before": &query.Before, "after": &query.After} {
+		if v := c.Query(key); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s", key)
+			}
+			*target = &parsed
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			return query, errors.New("invalid limit")
+		}
+		query.Limit = parsed
+	}
+	return query, nil
+}
+
+func parseMessagePath(c *gin.Context) (int64, int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, 0, false
+	}
+	messageID, err := strconv.ParseInt(c.Param("messageId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message id"})
+		return 0, 0, false
+	}
+	return id, messageID, true
+}
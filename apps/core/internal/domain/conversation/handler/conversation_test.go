@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -28,7 +29,11 @@ func setupConversationTestDB(t *testing.T) *gorm.DB {
 		&model.UserProfile{},
 		&model.Conversation{},
 		&model.ConversationParticipant{},
+		&model.SocketTicket{},
 		&model.Message{},
+		&model.MessageDeletion{},
+		&model.MediaUpload{},
+		&model.UserBlock{},
 	); err != nil {
 		t.Fatalf("failed to migrate test db: %v", err)
 	}
@@ -99,7 +104,7 @@ func TestConversationHandlerListReturnsConversationSummaries(t *testing.T) {
 	c.Request = httptest.NewRequest(http.MethodGet, "/conversations", nil)
 	c.Set("user_id", int64(501))
 
-	h := NewConversationHandler(service.NewConversationService(db))
+	h := NewConversationHandler(service.NewConversationService(db, nil, nil))
 	h.List(c)
 
 	if recorder.Code != http.StatusOK {
@@ -148,7 +153,7 @@ func TestConversationHandlerSendMessagePersistsMessage(t *testing.T) {
 	c.Request.Header.Set("Content-Type", "application/json")
 	c.Set("user_id", int64(501))
 
-	h := NewConversationHandler(service.NewConversationService(db))
+	h := NewConversationHandler(service.NewConversationService(db, nil, nil))
 	h.SendMessage(c)
 
 	if recorder.Code != http.StatusCreated {
@@ -166,3 +171,213 @@ func TestConversationHandlerSendMessagePersistsMessage(t *testing.T) {
 		t.Fatalf("expected 2 messages after send, got %d", count)
 	}
 }
+
+func TestConversationHandlerRejectsBlockedPair(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := setupConversationTestDB(t)
+	seedConversationFixture(t, db)
+	if err := db.Create(&model.UserBlock{BlockerID: 502, BlockedID: 501}).Error; err != nil {
+		t.Fatalf("failed to create block: %v", err)
+	}
+	h := NewConversationHandler(service.NewConversationService(db, nil, nil))
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Params = gin.Params{{Key: "id", Value: "9001"}}
+	c.Request = httptest.NewRequest(http.MethodPost, "/conversations/9001/messages", bytes.NewReader([]byte(`{"content":"Hello?"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user_id", int64(501))
+	h.SendMessage(c)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a blocked sender, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodPost, "/conversations", bytes.NewReader([]byte(`{"title":"Again","participant_ids":[502]}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user_id", int64(501))
+	h.Create(c)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 when creating a conversation with a blocker, got %d", recorder.Code)
+	}
+}
+
+func serveConversation(h gin.HandlerFunc, method, target string, params gin.Params, userID int64, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Params = params
+	c.Request = httptest.NewRequest(method, target, bytes.NewReader([]byte(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user_id", userID)
+	h(c)
+	return recorder
+}
+
+type messagePage struct {
+	Data []struct {
+		ID          int64  `json:"id"`
+		Content     string `json:"content"`
+		MessageType string `json:"message_type"`
+		IsEdited    bool   `json:"is_edited"`
+		IsDeleted   bool   `json:"is_deleted"`
+		Attachments []struct {
+			MediaID string `json:"media_id"`
+			URL     string `json:"url"`
+		} `json:"attachments"`
+	} `json:"data"`
+	HasMore bool `json:"has_more"`
+}
+
+func listMessages(t *testing.T, h *ConversationHandler, userID int64, query string) messagePage {
+	t.Helper()
+	recorder := serveConversation(h.Messages, http.MethodGet, "/conversations/9001/messages?"+query,
+		gin.Params{{Key: "id", Value: "9001"}}, userID, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 listing messages, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var page messagePage
+	if err := json.Unmarshal(recorder.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return page
+}
+
+func pageIDs(page messagePage) []int64 {
+	ids := make([]int64, 0, len(page.Data))
+	for _, message := range page.Data {
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+func TestConversationHandlerPaginatesMessages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := setupConversationTestDB(t)
+	seedConversationFixture(t, db)
+	for id := int64(7002); id <= 7005; id++ {
+		message := model.Message{ID: id, ConversationID: 9001, SenderID: 501, Content: "reply", CreatedAt: time.Now()}
+		if err := db.Create(&message).Error; err != nil {
+			t.Fatalf("failed to create message: %v", err)
+		}
+	}
+	h := NewConversationHandler(service.NewConversationService(db, nil, nil))
+
+	tests := []struct {
+		query   string
+		want    []int64
+		hasMore bool
+	}{
+		{"limit=2", []int64{7004, 7005}, true},
+		{"limit=2&before=7004", []int64{7002, 7003}, true},
+		{"limit=2&before=7002", []int64{7001}, false},
+		{"after=7003", []int64{7004, 7005}, false},
+		{"limit=1&after=7001", []int64{7002}, true},
+	}
+	for _, tc := range tests {
+		page := listMessages(t, h, 502, tc.query)
+		if fmt.Sprint(pageIDs(page)) != fmt.Sprint(tc.want) || page.HasMore != tc.hasMore {
+			t.Fatalf("%s: expected %v has_more=%v, got %v has_more=%v", tc.query, tc.want, tc.hasMore, pageIDs(page), page.HasMore)
+		}
+	}
+
+	recorder := serveConversation(h.Messages, http.MethodGet, "/conversations/9001/messages?before=abc",
+		gin.Params{{Key: "id", Value: "9001"}}, 502, "")
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a malformed cursor, got %d", recorder.Code)
+	}
+}
+
+func TestConversationHandlerEditsAndDeletesMessages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := setupConversationTestDB(t)
+	seedConversationFixture(t, db)
+	h := NewConversationHandler(service.NewConversationService(db, nil, nil))
+	params := gin.Params{{Key: "id", Value: "9001"}, {Key: "messageId", Value: "7001"}}
+
+	recorder := serveConversation(h.EditMessage, http.MethodPatch, "/conversations/9001/messages/7001", params, 501, `{"content":"hijacked"}`)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 editing someone else's message, got %d", recorder.Code)
+	}
+	recorder = serveConversation(h.EditMessage, http.MethodPatch, "/conversations/9001/messages/7001", params, 502, `{"content":"Is the cake gluten free?"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 editing own message, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if page := listMessages(t, h, 501, ""); len(page.Data) != 1 || !page.Data[0].IsEdited || page.Data[0].Content != "Is the cake gluten free?" {
+		t.Fatalf("expected the edited message to be marked, got %+v", page.Data)
+	}
+
+	db.Model(&model.Message{}).Where("id = ?", 7001).Update("created_at", time.Now().Add(-time.Hour))
+	recorder = serveConversation(h.EditMessage, http.MethodPatch, "/conversations/9001/messages/7001", params, 502, `{"content":"too late"}`)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 outside the edit window, got %d", recorder.Code)
+	}
+
+	recorder = serveConversation(h.DeleteMessage, http.MethodDelete, "/conversations/9001/messages/7001?scope=me", params, 501, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 deleting for me, got %d", recorder.Code)
+	}
+	if page := listMessages(t, h, 501, ""); len(page.Data) != 0 {
+		t.Fatalf("expected the message to be hidden from the merchant, got %+v", page.Data)
+	}
+	if page := listMessages(t, h, 502, ""); len(page.Data) != 1 || page.Data[0].IsDeleted {
+		t.Fatalf("expected the customer to still see the message, got %+v", page.Data)
+	}
+
+	recorder = serveConversation(h.DeleteMessage, http.MethodDelete, "/conversations/9001/messages/7001?scope=everyone", params, 502, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 deleting for everyone, got %d", recorder.Code)
+	}
+	if page := listMessages(t, h, 502, ""); len(page.Data) != 1 || !page.Data[0].IsDeleted || page.Data[0].Content != "" {
+		t.Fatalf("expected a cleared placeholder, got %+v", page.Data)
+	}
+	recorder = serveConversation(h.DeleteMessage, http.MethodDelete, "/conversations/9001/messages/7001?scope=everyone", params, 501, "")
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a message the merchant deleted for themselves, got %d", recorder.Code)
+	}
+}
+
+func TestConversationHandlerValidatesTypesAndAttachments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := setupConversationTestDB(t)
+	seedConversationFixture(t, db)
+	uploads := []model.MediaUpload{
+		{UUID: "own-approved", UserID: 501, ObjectKey: "a", FileURL: "https://cdn.example.com/a.png", Status: model.MediaStatusApproved},
+		{UUID: "other-approved", UserID: 502, ObjectKey: "b", FileURL: "https://cdn.example.com/b.png", Status: model.MediaStatusApproved},
+		{UUID: "own-pending", UserID: 501, ObjectKey: "c", FileURL: "https://cdn.example.com/c.png", Status: model.MediaStatusPending},
+	}
+	if err := db.Create(&uploads).Error; err != nil {
+		t.Fatalf("failed to create uploads: %v", err)
+	}
+	h := NewConversationHandler(service.NewConversationService(db, nil, nil))
+	params := gin.Params{{Key: "id", Value: "9001"}}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"unknown type", `{"content":"hi","message_type":"sticker"}`, http.StatusBadRequest},
+		{"image without attachments", `{"message_type":"image"}`, http.StatusBadRequest},
+		{"someone else's upload", `{"message_type":"image","attachment_ids":["other-approved"]}`, http.StatusBadRequest},
+		{"pending upload", `{"message_type":"image","attachment_ids":["own-pending"]}`, http.StatusBadRequest},
+		{"store card", `{"content":"42","message_type":"store_card"}`, http.StatusCreated},
+		{"image", `{"message_type":"image","attachment_ids":["own-approved"]}`, http.StatusCreated},
+	}
+	for _, tc := range tests {
+		recorder := serveConversation(h.SendMessage, http.MethodPost, "/conversations/9001/messages", params, 501, tc.body)
+		if recorder.Code != tc.want {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.name, tc.want, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	page := listMessages(t, h, 502, "")
+	last := page.Data[len(page.Data)-1]
+	if last.MessageType != model.MessageTypeImage || len(last.Attachments) != 1 || last.Attachments[0].URL != "https://cdn.example.com/a.png" {
+		t.Fatalf("expected the image message to carry its attachment, got %+v", last)
+	}
+}
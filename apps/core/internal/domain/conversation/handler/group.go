@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/service"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateGroup godoc
+// @Summary Update group conversation
+// @Description Renames a group conversation or changes its avatar. Owner or admin only
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param request body service.UpdateGroupInput true "Title and avatar"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /conversations/{id} [patch]
+func (h *ConversationHandler) UpdateGroup(c *gin.Context) {
+	var req service.UpdateGroupInput
+	userID, id, ok := bindGroupRequest(c, &req)
+	if !ok {
+		return
+	}
+	conversation, err := h.svc.UpdateGroup(c.Request.Context(), userID, id, req)
+	respondGroup(c, conversation, err, "failed to update conversation")
+}
+
+// AddMembers godoc
+// @Summary Add group members
+// @Description Adds users to a group conversation. Owner or admin only; groups hold at most 100 members
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param request body service.AddMembersInput true "Users to add"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /conversations/{id}/members [post]
+func (h *ConversationHandler) AddMembers(c *gin.Context) {
+	var req service.AddMembersInput
+	userID, id, ok := bindGroupRequest(c, &req)
+	if !ok {
+		return
+	}
+	conversation, err := h.svc.AddMembers(c.Request.Context(), userID, id, req)
+	respondGroup(c, conversation, err, "failed to add members")
+}
+
+// RemoveMember godoc
+// @Summary Remove group member
+// @Description Removes another participant from a group conversation. The owner may remove anyone, admins only members
+// @Tags conversation
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /conversations/{id}/members/{userId} [delete]
+func (h *ConversationHandler) RemoveMember(c *gin.Context) {
+	userID, id, ok := bindGroupRequest(c, nil)
+	if !ok {
+		return
+	}
+	memberID, ok := parseMemberParam(c)
+	if !ok {
+		return
+	}
+	conversation, err := h.svc.RemoveMember(c.Request.Context(), userID, id, memberID)
+	respondGroup(c, conversation, err, "failed to remove member")
+}
+
+// UpdateMemberRole godoc
+// @Summary Change group member role
+// @Description Promotes a member to admin or demotes an admin to member. Owner only
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param userId path int true "User ID"
+// @Param request body service.UpdateMemberRoleInput true "admin or member"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /conversations/{id}/members/{userId}/role [put]
+func (h *ConversationHandler) UpdateMemberRole(c *gin.Context) {
+	var req service.UpdateMemberRoleInput
+	userID, id, ok := bindGroupRequest(c, &req)
+	if !ok {
+		return
+	}
+	memberID, ok := parseMemberParam(c)
+	if !ok {
+		return
+	}
+	conversation, err := h.svc.UpdateMemberRole(c.Request.Context(), userID, id, memberID, req)
+	respondGroup(c, conversation, err, "failed to update member role")
+}
+
+// TransferOwnership godoc
+// @Summary Transfer group ownership
+// @Description Makes another participant the owner; the caller stays on as admin. Owner only
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param request body service.TransferOwnershipInput true "New owner"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /conversations/{id}/transfer [post]
+func (h *ConversationHandler) TransferOwnership(c *gin.Context) {
+	var req service.TransferOwnershipInput
+	userID, id, ok := bindGroupRequest(c, &req)
+	if !ok {
+		return
+	}
+	conversation, err := h.svc.TransferOwnership(c.Request.Context(), userID, id, req)
+	respondGroup(c, conversation, err, "failed to transfer ownership")
+}
+
+// LeaveConversation godoc
+// @Summary Leave group conversation
+// @Description Removes the caller from a group conversation. An owner who leaves hands ownership to the longest serving admin, or member
+// @Tags conversation
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /conversations/{id}/leave [post]
+func (h *ConversationHandler) Leave(c *gin.Context) {
+	userID, id, ok := bindGroupRequest(c, nil)
+	if !ok {
+		return
+	}
+	if err := h.svc.Leave(c.Request.Context(), userID, id); err != nil {
+		respondGroup(c, nil, err, "failed to leave conversation")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// bindGroupRequest reads the caller, the conversation id and, when req is not nil, the JSON
+// body. It writes the error response itself and reports whether to continue.
+func bindGroupRequest(c *gin.Context, req any) (int64, int64, bool) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, 0, false
+	}
+
+	if req != nil {
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return 0, 0, false
+		}
+	}
+	return userID, id, true
+}
+
+func parseMemberParam(c *gin.Context) (int64, bool) {
+	memberID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return 0, false
+	}
+	return memberID, true
+}
+
+func respondGroup(c *gin.Context, conversation *service.ConversationSummary, err error, failure string) {
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrConversationForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		case errors.Is(err, service.ErrConversationBlocked):
+			c.JSON(http.StatusForbidden, gin.H{"error": "blocked"})
+		case errors.Is(err, service.ErrParticipantNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrConversationFull):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrNotGroupConversation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrConversationInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation input"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": conversation})
+}
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/service"
+	"github.com/gin-gonic/gin"
+)
+
+// StartMerchantConversation godoc
+// @Summary Message a merchant
+// @Description Opens a support conversation with a merchant, or with the merchant behind store_id, and returns the caller's unresolved one if it exists. order_id and voucher_id link a purchase from that merchant for context
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param request body service.StartMerchantConversationInput true "Merchant or store"
+// @Success 200 {object} map[string]interface{}
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /conversations/merchant [post]
+func (h *ConversationHandler) StartMerchantConversation(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req service.StartMerchantConversationInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conversation, created, err := h.svc.StartMerchantConversation(c.Request.Context(), userID, req)
+	if err != nil {
+		respondSupportError(c, err, "failed to start conversation")
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, gin.H{"data": conversation})
+}
+
+// MerchantInbox godoc
+// @Summary List merchant conversations
+// @Description Returns the support conversations of the caller's merchant, newest first
+// @Tags conversation
+// @Produce json
+// @Param store_id query int false "Store ID"
+// @Param status query string false "open, assigned or resolved"
+// @Param cursor query int false "Cursor"
+// @Param limit query int false "Limit"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /merchant/conversations [get]
+func (h *ConversationHandler) MerchantInbox(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	query := service.MerchantInboxQuery{Status: c.Query("status")}
+	var ok bool
+	if query.StoreID, ok = optionalInt64Query(c, "store_id"); !ok {
+		return
+	}
+	if query.Cursor, ok = optionalInt64Query(c, "cursor"); !ok {
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			query.Limit = parsed
+		}
+	}
+
+	conversations, next, err := h.svc.MerchantInbox(c.Request.Context(), userID, query)
+	if err != nil {
+		respondSupportError(c, err, "failed to list conversations")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": conversations, "cursor": next})
+}
+
+// SupportMetrics godoc
+// @Summary Get support metrics
+// @Description Returns the response rate, average first response time and open, assigned and resolved counts for the caller's merchant
+// @Tags conversation
+// @Produce json
+// @Param store_id query int false "Store ID"
+// @Param days query int false "Period in days (default 30, max 365)"
+// @Success 200 {object} service.SupportMetrics
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /merchant/conversations/metrics [get]
+func (h *ConversationHandler) SupportMetrics(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	storeID, ok := optionalInt64Query(c, "store_id")
+	if !ok {
+		return
+	}
+	days, _ := strconv.Atoi(c.Query("days"))
+
+	metrics, err := h.svc.SupportMetrics(c.Request.Context(), userID, storeID, days)
+	if err != nil {
+		respondSupportError(c, err, "failed to load support metrics")
+		return
+	}
+	c.JSON(http.StatusOK, metrics)
+}
+
+// AssignConversation godoc
+// @Summary Assign merchant conversation
+// @Description Assigns a support conversation to one of the merchant's staff, the caller by default
+// @Tags conversation
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param request body service.AssignConversationInput false "Assignee"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /merchant/conversations/{id}/assign [post]
+func (h *ConversationHandler) AssignConversation(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req service.AssignConversationInput
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	conversation, err := h.svc.AssignConversation(c.Request.Context(), userID, id, req)
+	if err != nil {
+		respondSupportError(c, err, "failed to assign conversation")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": conversation})
+}
+
+// ResolveConversation godoc
+// @Summary Resolve merchant conversation
+// @Description Marks a support conversation resolved; a new customer message reopens it
+// @Tags conversation
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /merchant/conversations/{id}/resolve [post]
+func (h *ConversationHandler) ResolveConversation(c *gin.Context) {
+	h.updateSupport(c, h.svc.ResolveConversation, "failed to resolve conversation")
+}
+
+// ReopenConversation godoc
+// @Summary Reopen merchant conversation
+// @Description Puts a resolved support conversation back into the inbox
+// @Tags conversation
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /merchant/conversations/{id}/reopen [post]
+func (h *ConversationHandler) ReopenConversation(c *gin.Context) {
+	h.updateSupport(c, h.svc.ReopenConversation, "failed to reopen conversation")
+}
+
+func (h *ConversationHandler) updateSupport(
+	c *gin.Context,
+	update func(ctx context.Context, userID, conversationID int64) (*service.ConversationSummary, error),
+	failure string,
+) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	conversation, err := update(c.Request.Context(), userID, id)
+	if err != nil {
+		respondSupportError(c, err, failure)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": conversation})
+}
+
+func respondSupportError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, service.ErrNotMerchantStaff):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrConversationBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "blocked"})
+	case errors.Is(err, service.ErrMerchantNotFound),
+		errors.Is(err, service.ErrStoreNotFound),
+		errors.Is(err, service.ErrConversationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrMerchantUnstaffed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSupportContextInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrConversationInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conversation input"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+	}
+}
+
+func optionalInt64Query(c *gin.Context, key string) (*int64, bool) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, true
+	}
+	parsed, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + key})
+		return nil, false
+	}
+	return &parsed, true
+}
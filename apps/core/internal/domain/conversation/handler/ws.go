@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Frame types clients send over the socket.
+const (
+	frameTyping = "typing"
+	frameRead   = "read"
+)
+
+// socketTicketParam carries a ticket from IssueTicket on the WebSocket URL.
+const socketTicketParam = "ticket"
+
+type WebSocketHandler struct {
+	svc      *service.ConversationService
+	hub      *realtime.Hub
+	upgrader websocket.Upgrader
+}
+
+// NewWebSocketHandler accepts upgrades from the API's own origin, from clients that send no
+// Origin (native apps) and from allowedOrigins.
+func NewWebSocketHandler(svc *service.ConversationService, hub *realtime.Hub, allowedOrigins ...string) *WebSocketHandler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin != "" {
+			allowed[origin] = true
+		}
+	}
+	return &WebSocketHandler{
+		svc: svc,
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" || allowed[origin] {
+					return true
+				}
+				u, err := url.Parse(origin)
+				return err == nil && u.Host == r.Host
+			},
+		},
+	}
+}
+
+// IssueTicket godoc
+// @Summary Issue WebSocket ticket
+// @Description Returns a single-use ticket that opens the WebSocket within 30 seconds. Browsers cannot send an Authorization header on a WebSocket handshake, so they pass the ticket as the ticket query parameter instead of their JWT.
+// @Tags conversation
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Router /ws/ticket [post]
+func (h *WebSocketHandler) IssueTicket(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	ticket, expiresAt, err := h.svc.IssueSocketTicket(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue ticket"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"ticket": ticket, "expires_at": expiresAt}})
+}
+
+// Serve godoc
+// @Summary Real-time conversation events
+// @Description Upgrades to a WebSocket that pushes message.created, read_receipt, typing and conversation.updated events for the authenticated user's conversations. Clients may send {"type":"typing"|"read","conversation_id":N}. Clients authenticate with an Authorization header or, in browsers, with a ticket from POST /ws/ticket. Clients that fall behind are disconnected with close code 1013 and should reconnect and resync over REST.
+// @Tags conversation
+// @Param ticket query string false "Single-use ticket, when no Authorization header can be sent"
+// @Success 101 {string} string "Switching Protocols"
+// @Failure 401 {object} map[string]string
+// @Router /ws [get]
+func (h *WebSocketHandler) Serve(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		redeemed, err := h.svc.RedeemSocketTicket(c.Request.Context(), c.Query(socketTicketParam))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		userID = redeemed
+	}
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written the HTTP error.
+		return
+	}
+	h.hub.Serve(c.Request.Context(), conn, userID, h.handleFrame)
+}
+
+// handleFrame applies a client frame. Frames for conversations the user is not in are
+// ignored, as are unknown frame types.
+func (h *WebSocketHandler) handleFrame(ctx context.Context, userID int64, frame realtime.Frame) {
+	switch frame.Type {
+	case frameTyping:
+		_ = h.svc.Typing(ctx, userID, frame.ConversationID)
+	case frameRead:
+		_, _ = h.svc.MarkRead(ctx, userID, frame.ConversationID)
+	}
+}
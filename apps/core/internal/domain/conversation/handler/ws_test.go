@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+func dialConversationSocket(t *testing.T, server *httptest.Server, hub *realtime.Hub, userID int64) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?user=" + strconv.FormatInt(userID, 10)
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.Connected(userID) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("socket was never registered with the hub")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return conn
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var event map[string]interface{}
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatalf("failed to read event: %v", err)
+	}
+	return event
+}
+
+func TestWebSocketPushesMessagesTypingAndReadReceipts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupConversationTestDB(t)
+	seedConversationFixture(t, db)
+
+	hub := realtime.NewHub(realtime.NewMemoryBroker())
+	defer hub.Close()
+	svc := service.NewConversationService(db, nil, hub)
+	r := gin.New()
+	r.GET("/ws", func(c *gin.Context) {
+		userID, _ := strconv.ParseInt(c.Query("user"), 10, 64)
+		c.Set("user_id", userID)
+	}, NewWebSocketHandler(svc, hub).Serve)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	merchant := dialConversationSocket(t, server, hub, 501)
+	customer := dialConversationSocket(t, server, hub, 502)
+
+	if _, err := svc.SendMessage(t.Context(), 501, 9001, service.SendMessageInput{Content: "Ready at 5"}); err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	for _, conn := range []*websocket.Conn{merchant, customer} {
+		event := readEvent(t, conn)
+		data, _ := event["data"].(map[string]interface{})
+		if event["type"] != realtime.EventMessageCreated || data["content"] != "Ready at 5" {
+			t.Fatalf("expected the new message, got %+v", event)
+		}
+	}
+
+	if err := customer.WriteJSON(realtime.Frame{Type: "typing", ConversationID: 9001}); err != nil {
+		t.Fatalf("failed to send typing frame: %v", err)
+	}
+	if event := readEvent(t, merchant); event["type"] != realtime.EventTyping {
+		t.Fatalf("expected a typing event, got %+v", event)
+	}
+
+	if err := customer.WriteJSON(realtime.Frame{Type: "read", ConversationID: 9001}); err != nil {
+		t.Fatalf("failed to send read frame: %v", err)
+	}
+	event := readEvent(t, merchant)
+	data, _ := event["data"].(map[string]interface{})
+	if event["type"] != realtime.EventReadReceipt || data["user_id"] != float64(502) {
+		t.Fatalf("expected the customer's read receipt, got %+v", event)
+	}
+	receipts, err := svc.ReadReceipts(t.Context(), 501, 9001)
+	if err != nil {
+		t.Fatalf("read receipts failed: %v", err)
+	}
+	for _, receipt := range receipts {
+		if receipt.UserID == 502 && receipt.LastReadAt.IsZero() {
+			t.Fatal("expected the customer's last_read_at to be stored")
+		}
+	}
+}
+
+func TestWebSocketTicketOpensOneSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupConversationTestDB(t)
+	seedConversationFixture(t, db)
+
+	hub := realtime.NewHub(realtime.NewMemoryBroker())
+	defer hub.Close()
+	svc := service.NewConversationService(db, nil, hub)
+	r := gin.New()
+	r.GET("/ws", NewWebSocketHandler(svc, hub).Serve)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	ticket, _, err := svc.IssueSocketTicket(t.Context(), 501)
+	if err != nil {
+		t.Fatalf("issue failed: %v", err)
+	}
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?ticket=" + ticket
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial with a fresh ticket failed: %v", err)
+	}
+	conn.Close()
+
+	if _, resp, err := websocket.DefaultDialer.Dial(url, nil); err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected a reused ticket to be rejected, got %v", err)
+	}
+	if _, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil); err == nil ||
+		resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected a missing ticket to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// Event types pushed to WebSocket clients.
+const (
+	EventMessageCreated      = "message.created"
+	EventReadReceipt         = "read_receipt"
+	EventTyping              = "typing"
+	EventConversationUpdated = "conversation.updated"
+	EventMessageUpdated      = "message.updated"
+	EventMessageDeleted      = "message.deleted"
+)
+
+// Event is one frame pushed to clients. Data must survive a JSON round trip so brokers that
+// cross process boundaries can carry it.
+type Event struct {
+	Type           string `json:"type"`
+	ConversationID int64  `json:"conversation_id,omitempty"`
+	Data           any    `json:"data,omitempty"`
+}
+
+// Envelope addresses an event to users; every instance delivers it to the recipients
+// connected to it.
+type Envelope struct {
+	Recipients []int64 `json:"recipients"`
+	Event      Event   `json:"event"`
+}
+
+// Broker fans envelopes out to every subscribed hub. The in-memory broker serves a single
+// instance; a pub/sub backed broker lets several instances share events.
+type Broker interface {
+	Publish(ctx context.Context, envelope Envelope) error
+	// Subscribe registers a handler for every published envelope and returns a function
+	// that removes it.
+	Subscribe(handler func(Envelope)) (unsubscribe func())
+}
+
+// MemoryBroker delivers envelopes synchronously to handlers in the same process.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[int]func(Envelope)
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{handlers: make(map[int]func(Envelope))}
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, envelope Envelope) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(envelope)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(handler func(Envelope)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
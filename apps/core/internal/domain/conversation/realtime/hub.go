@@ -0,0 +1,195 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// sendBuffer is how many frames may queue for a client before it counts as slow.
+	sendBuffer      = 64
+	writeWait       = 10 * time.Second
+	pongWait        = 60 * time.Second
+	pingPeriod      = pongWait * 9 / 10
+	maxFrameBytes   = 4096
+	closeSlowReason = "client too slow"
+)
+
+// Publisher sends an event to a set of users wherever they are connected.
+type Publisher interface {
+	Publish(ctx context.Context, recipients []int64, event Event) error
+}
+
+// Frame is a message a client sends over its socket.
+type Frame struct {
+	Type           string `json:"type"`
+	ConversationID int64  `json:"conversation_id"`
+}
+
+// FrameHandler handles a frame from userID's connection.
+type FrameHandler func(ctx context.Context, userID int64, frame Frame)
+
+// Hub tracks the WebSocket clients connected to this instance and delivers the envelopes
+// its broker receives to them.
+//
+// Backpressure: every client has a bounded send queue. When it is full, typing events are
+// dropped, since the next one supersedes them; any other event disconnects the client with
+// "try again later" so it reconnects and resyncs over REST instead of silently missing
+// messages.
+type Hub struct {
+	broker      Broker
+	unsubscribe func()
+
+	mu      sync.RWMutex
+	clients map[int64]map[*client]struct{}
+}
+
+type client struct {
+	conn   *websocket.Conn
+	userID int64
+	send   chan []byte
+	// quit is closed, with closeCode and closeReason set, to make the writer close the socket.
+	quit        chan struct{}
+	quitOnce    sync.Once
+	closeCode   int
+	closeReason string
+}
+
+func NewHub(broker Broker) *Hub {
+	if broker == nil {
+		broker = NewMemoryBroker()
+	}
+	h := &Hub{broker: broker, clients: make(map[int64]map[*client]struct{})}
+	h.unsubscribe = broker.Subscribe(h.deliver)
+	return h
+}
+
+// Publish hands an event to the broker for delivery to recipients.
+func (h *Hub) Publish(ctx context.Context, recipients []int64, event Event) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	return h.broker.Publish(ctx, Envelope{Recipients: recipients, Event: event})
+}
+
+// Close detaches the hub from its broker.
+func (h *Hub) Close() {
+	h.unsubscribe()
+}
+
+// Connected reports how many connections userID has on this instance.
+func (h *Hub) Connected(userID int64) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[userID])
+}
+
+// Serve runs conn for userID until it closes, passing every frame it reads to onFrame.
+func (h *Hub) Serve(ctx context.Context, conn *websocket.Conn, userID int64, onFrame FrameHandler) {
+	c := &client{conn: conn, userID: userID, send: make(chan []byte, sendBuffer), quit: make(chan struct{})}
+	h.register(c)
+	defer h.unregister(c)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.writePump()
+	}()
+	c.readPump(ctx, onFrame)
+	// Stop the writer once the reader is done, whichever side ended the connection.
+	c.stop(websocket.CloseNormalClosure, "")
+	<-done
+	conn.Close()
+}
+
+func (h *Hub) register(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[c.userID] == nil {
+		h.clients[c.userID] = make(map[*client]struct{})
+	}
+	h.clients[c.userID][c] = struct{}{}
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients[c.userID], c)
+	if len(h.clients[c.userID]) == 0 {
+		delete(h.clients, c.userID)
+	}
+}
+
+func (h *Hub) deliver(envelope Envelope) {
+	payload, err := json.Marshal(envelope.Event)
+	if err != nil {
+		return
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, userID := range envelope.Recipients {
+		for c := range h.clients[userID] {
+			select {
+			case c.send <- payload:
+			default:
+				if envelope.Event.Type != EventTyping {
+					c.stop(websocket.CloseTryAgainLater, closeSlowReason)
+				}
+			}
+		}
+	}
+}
+
+func (c *client) stop(code int, reason string) {
+	c.quitOnce.Do(func() {
+		c.closeCode, c.closeReason = code, reason
+		close(c.quit)
+	})
+}
+
+func (c *client) readPump(ctx context.Context, onFrame FrameHandler) {
+	c.conn.SetReadLimit(maxFrameBytes)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		var frame Frame
+		if err := c.conn.ReadJSON(&frame); err != nil {
+			return
+		}
+		if onFrame != nil {
+			onFrame(ctx, c.userID, frame)
+		}
+	}
+}
+
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case payload := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				c.conn.Close()
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.conn.Close()
+				return
+			}
+		case <-c.quit:
+			_ = c.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(c.closeCode, c.closeReason), time.Now().Add(writeWait))
+			c.conn.Close()
+			return
+		}
+	}
+}
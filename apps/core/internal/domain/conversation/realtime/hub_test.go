@@ -0,0 +1,68 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+)
+
+func testClient(userID int64, buffer int) *client {
+	return &client{userID: userID, send: make(chan []byte, buffer), quit: make(chan struct{})}
+}
+
+func TestHubsSharingABrokerFanOutToRecipients(t *testing.T) {
+	broker := NewMemoryBroker()
+	first, second := NewHub(broker), NewHub(broker)
+	defer first.Close()
+	defer second.Close()
+
+	alice, bob, carol := testClient(1, 4), testClient(2, 4), testClient(3, 4)
+	first.register(alice)
+	second.register(bob)
+	second.register(carol)
+
+	if err := first.Publish(context.Background(), []int64{1, 2}, Event{Type: EventMessageCreated, ConversationID: 9}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if len(alice.send) != 1 || len(bob.send) != 1 || len(carol.send) != 0 {
+		t.Fatalf("expected delivery to recipients on both hubs only, got %d, %d, %d", len(alice.send), len(bob.send), len(carol.send))
+	}
+	if got := string(<-bob.send); got != `{"type":"message.created","conversation_id":9}` {
+		t.Fatalf("unexpected payload %s", got)
+	}
+
+	second.unregister(bob)
+	if second.Connected(2) != 0 {
+		t.Fatal("expected bob to be unregistered")
+	}
+}
+
+func TestHubDropsTypingButDisconnectsSlowClients(t *testing.T) {
+	hub := NewHub(nil)
+	defer hub.Close()
+	slow := testClient(1, 1)
+	hub.register(slow)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := hub.Publish(ctx, []int64{1}, Event{Type: EventTyping}); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+	}
+	select {
+	case <-slow.quit:
+		t.Fatal("expected overflowing typing events to be dropped, not to disconnect")
+	default:
+	}
+
+	if err := hub.Publish(ctx, []int64{1}, Event{Type: EventMessageCreated}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	select {
+	case <-slow.quit:
+	default:
+		t.Fatal("expected a full queue to disconnect the client on a message event")
+	}
+	if slow.closeReason != closeSlowReason {
+		t.Fatalf("expected the slow-client close reason, got %q", slow.closeReason)
+	}
+}
@@ -3,22 +3,52 @@ package conversation
 import (
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/handler"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes registers conversation routes.
-func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
-	svc := service.NewConversationService(nil)
+// RegisterRoutes registers conversation routes. moderation is shared with the other domains that
+// screen user content.
+func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config, moderation *moderationservice.ModerationService) {
+	// Single-instance deployments use the in-memory broker; a shared pub/sub broker lets
+	// several instances fan events out to each other's clients.
+	hub := realtime.NewHub(realtime.NewMemoryBroker())
+	svc := service.NewConversationService(nil, moderation, hub)
 	h := handler.NewConversationHandler(svc)
+	ws := handler.NewWebSocketHandler(svc, hub, cfg.FrontendURL)
+
+	r.GET("/ws", middleware.OptionalJWTAuth(cfg.JWT), ws.Serve)
+	r.POST("/ws/ticket", middleware.JWTAuth(cfg.JWT), ws.IssueTicket)
 
 	convos := r.Group("/conversations", middleware.JWTAuth(cfg.JWT))
 	{
 		convos.GET("", h.List)
 		convos.POST("", h.Create)
+		convos.POST("/merchant", h.StartMerchantConversation)
 		convos.GET("/:id/messages", h.Messages)
 		convos.POST("/:id/messages", h.SendMessage)
+		convos.PATCH("/:id/messages/:messageId", h.EditMessage)
+		convos.DELETE("/:id/messages/:messageId", h.DeleteMessage)
+		convos.POST("/:id/read", h.MarkRead)
+		convos.GET("/:id/read-receipts", h.ReadReceipts)
 		convos.PATCH("/:id/settings", h.UpdateSettings)
+		convos.PATCH("/:id", h.UpdateGroup)
+		convos.POST("/:id/members", h.AddMembers)
+		convos.DELETE("/:id/members/:userId", h.RemoveMember)
+		convos.PUT("/:id/members/:userId/role", h.UpdateMemberRole)
+		convos.POST("/:id/transfer", h.TransferOwnership)
+		convos.POST("/:id/leave", h.Leave)
+	}
+
+	support := r.Group("/merchant/conversations", middleware.JWTAuth(cfg.JWT))
+	{
+		support.GET("", h.MerchantInbox)
+		support.GET("/metrics", h.SupportMetrics)
+		support.POST("/:id/assign", h.AssignConversation)
+		support.POST("/:id/resolve", h.ResolveConversation)
+		support.POST("/:id/reopen", h.ReopenConversation)
 	}
 }
@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Participant roles in a group conversation. The owner manages admins; admins manage members.
+const (
+	roleOwner  = "owner"
+	roleAdmin  = "admin"
+	roleMember = "member"
+)
+
+const (
+	maxGroupMembers     = 100
+	maxGroupTitleLength = 100
+	maxAvatarURLLength  = 255
+)
+
+var ErrNotGroupConversation = errors.New("not a group conversation")
+var ErrConversationFull = errors.New("group conversation is full")
+var ErrParticipantNotFound = errors.New("participant not found")
+
+type AddMembersInput struct {
+	UserIDs []int64 `json:"user_ids"`
+}
+
+// UpdateMemberRoleInput sets a member's role to admin or member.
+type UpdateMemberRoleInput struct {
+	Role string `json:"role"`
+}
+
+type TransferOwnershipInput struct {
+	UserID int64 `json:"user_id"`
+}
+
+// UpdateGroupInput renames a group or changes its avatar; nil fields are left alone.
+type UpdateGroupInput struct {
+	Title     *string `json:"title"`
+	AvatarURL *string `json:"avatar_url"`
+}
+
+// groupChange is what a group action did: the system messages to add to the timeline and the
+// users who are no longer participants but still need to hear about it.
+type groupChange struct {
+	notes   []string
+	removed []int64
+}
+
+// AddMembers adds users to a group. Only the owner and admins may add members; users who are
+// already in the group are skipped.
+func (s *ConversationService) AddMembers(ctx context.Context, userID, conversationID int64, input AddMembersInput) (*ConversationSummary, error) {
+	return s.manageGroup(ctx, userID, conversationID, func(tx *gorm.DB, actor model.ConversationParticipant) (groupChange, error) {
+		if !hasRole(actor, roleOwner, roleAdmin) {
+			return groupChange{}, ErrConversationForbidden
+		}
+		members, err := groupMemberIDs(tx, conversationID)
+		if err != nil {
+			return groupChange{}, err
+		}
+		newIDs := make([]int64, 0, len(input.UserIDs))
+		for _, id := range uniqueParticipantIDs(input.UserIDs) {
+			if !slices.Contains(members, id) {
+				newIDs = append(newIDs, id)
+			}
+		}
+		if len(newIDs) == 0 {
+			return groupChange{}, nil
+		}
+		if len(members)+len(newIDs) > maxGroupMembers {
+			return groupChange{}, ErrConversationFull
+		}
+		var found int64
+		if err := tx.Model(&model.User{}).Where("id IN ?", newIDs).Count(&found).Error; err != nil {
+			return groupChange{}, err
+		}
+		if int(found) != len(newIDs) {
+			return groupChange{}, ErrParticipantNotFound
+		}
+		blocked, err := blockedWithAny(tx, userID, newIDs)
+		if err != nil {
+			return groupChange{}, err
+		}
+		if blocked {
+			return groupChange{}, ErrConversationBlocked
+		}
+
+		now := time.Now().UTC()
+		participants := make([]model.ConversationParticipant, 0, len(newIDs))
+		for _, id := range newIDs {
+			participants = append(participants, model.ConversationParticipant{
+				ConversationID: conversationID,
+				UserID:         id,
+				Role:           roleMember,
+				JoinedAt:       now,
+			})
+		}
+		if err := tx.Create(&participants).Error; err != nil {
+			return groupChange{}, err
+		}
+
+		names, err := displayNames(tx, append([]int64{userID}, newIDs...))
+		if err != nil {
+			return groupChange{}, err
+		}
+		change := groupChange{}
+		for _, id := range newIDs {
+			change.notes = append(change.notes, fmt.Sprintf("%s added %s", names[userID], names[id]))
+		}
+		return change, nil
+	})
+}
+
+// RemoveMember removes someone else from a group. The owner may remove anyone; admins may only
+// remove members. Users leave on their own with Leave.
+func (s *ConversationService) RemoveMember(ctx context.Context, userID, conversationID, memberID int64) (*ConversationSummary, error) {
+	if memberID == userID {
+		return nil, ErrConversationInvalidInput
+	}
+	return s.manageGroup(ctx, userID, conversationID, func(tx *gorm.DB, actor model.ConversationParticipant) (groupChange, error) {
+		target, err := groupParticipant(tx, conversationID, memberID)
+		if err != nil {
+			return groupChange{}, err
+		}
+		allowed := actor.Role == roleOwner || (actor.Role == roleAdmin && target.Role == roleMember)
+		if !allowed {
+			return groupChange{}, ErrConversationForbidden
+		}
+		if err := tx.Delete(&target).Error; err != nil {
+			return groupChange{}, err
+		}
+		names, err := displayNames(tx, []int64{userID, memberID})
+		if err != nil {
+			return groupChange{}, err
+		}
+		return groupChange{
+			notes:   []string{fmt.Sprintf("%s removed %s", names[userID], names[memberID])},
+			removed: []int64{memberID},
+		}, nil
+	})
+}
+
+// Leave takes the caller out of a group. An owner who leaves hands ownership to the longest
+// serving admin, or failing that the longest serving member.
+func (s *ConversationService) Leave(ctx context.Context, userID, conversationID int64) error {
+	_, err := s.manageGroup(ctx, userID, conversationID, func(tx *gorm.DB, actor model.ConversationParticipant) (groupChange, error) {
+		if err := tx.Delete(&actor).Error; err != nil {
+			return groupChange{}, err
+		}
+		names, err := displayNames(tx, []int64{userID})
+		if err != nil {
+			return groupChange{}, err
+		}
+		change := groupChange{
+			notes:   []string{fmt.Sprintf("%s left", names[userID])},
+			removed: []int64{userID},
+		}
+		if actor.Role != roleOwner {
+			return change, nil
+		}
+
+		var successor model.ConversationParticipant
+		err = tx.Where("conversation_id = ?", conversationID).
+			Order("CASE WHEN role = '" + roleAdmin + "' THEN 0 ELSE 1 END, joined_at asc, id asc").
+			First(&successor).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return change, nil
+		}
+		if err != nil {
+			return groupChange{}, err
+		}
+		if err := tx.Model(&successor).Update("role", roleOwner).Error; err != nil {
+			return groupChange{}, err
+		}
+		successorNames, err := displayNames(tx, []int64{successor.UserID})
+		if err != nil {
+			return groupChange{}, err
+		}
+		change.notes = append(change.notes, fmt.Sprintf("%s is now the owner", successorNames[successor.UserID]))
+		return change, nil
+	})
+	return err
+}
+
+// UpdateMemberRole promotes a member to admin or demotes an admin. Only the owner may do so.
+func (s *ConversationService) UpdateMemberRole(ctx context.Context, userID, conversationID, memberID int64, input UpdateMemberRoleInput) (*ConversationSummary, error) {
+	role := strings.TrimSpace(input.Role)
+	if role != roleAdmin && role != roleMember {
+		return nil, ErrConversationInvalidInput
+	}
+	return s.manageGroup(ctx, userID, conversationID, func(tx *gorm.DB, actor model.ConversationParticipant) (groupChange, error) {
+		if actor.Role != roleOwner {
+			return groupChange{}, ErrConversationForbidden
+		}
+		target, err := groupParticipant(tx, conversationID, memberID)
+		if err != nil {
+			return groupChange{}, err
+		}
+		if target.Role == roleOwner {
+			return groupChange{}, ErrConversationInvalidInput
+		}
+		if target.Role == role {
+			return groupChange{}, nil
+		}
+		if err := tx.Model(&target).Update("role", role).Error; err != nil {
+			return groupChange{}, err
+		}
+		names, err := displayNames(tx, []int64{userID, memberID})
+		if err != nil {
+			return groupChange{}, err
+		}
+		note := fmt.Sprintf("%s made %s an admin", names[userID], names[memberID])
+		if role == roleMember {
+			note = fmt.Sprintf("%s removed %s as admin", names[userID], names[memberID])
+		}
+		return groupChange{notes: []string{note}}, nil
+	})
+}
+
+// TransferOwnership makes another participant the owner; the previous owner stays on as admin.
+func (s *ConversationService) TransferOwnership(ctx context.Context, userID, conversationID int64, input TransferOwnershipInput) (*ConversationSummary, error) {
+	if input.UserID == userID {
+		return nil, ErrConversationInvalidInput
+	}
+	return s.manageGroup(ctx, userID, conversationID, func(tx *gorm.DB, actor model.ConversationParticipant) (groupChange, error) {
+		if actor.Role != roleOwner {
+			return groupChange{}, ErrConversationForbidden
+		}
+		target, err := groupParticipant(tx, conversationID, input.UserID)
+		if err != nil {
+			return groupChange{}, err
+		}
+		if err := tx.Model(&target).Update("role", roleOwner).Error; err != nil {
+			return groupChange{}, err
+		}
+		if err := tx.Model(&actor).Update("role", roleAdmin).Error; err != nil {
+			return groupChange{}, err
+		}
+		names, err := displayNames(tx, []int64{userID, input.UserID})
+		if err != nil {
+			return groupChange{}, err
+		}
+		return groupChange{notes: []string{fmt.Sprintf("%s made %s the owner", names[userID], names[input.UserID])}}, nil
+	})
+}
+
+// UpdateGroup renames a group or changes its avatar. Only the owner and admins may do so.
+func (s *ConversationService) UpdateGroup(ctx context.Context, userID, conversationID int64, input UpdateGroupInput) (*ConversationSummary, error) {
+	updates := map[string]interface{}{}
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		if title == "" || utf8.RuneCountInString(title) > maxGroupTitleLength {
+			return nil, ErrConversationInvalidInput
+		}
+		updates["title"] = title
+	}
+	if input.AvatarURL != nil {
+		avatar := strings.TrimSpace(*input.AvatarURL)
+		if len(avatar) > maxAvatarURLLength {
+			return nil, ErrConversationInvalidInput
+		}
+		updates["avatar_url"] = avatar
+	}
+	return s.manageGroup(ctx, userID, conversationID, func(tx *gorm.DB, actor model.ConversationParticipant) (groupChange, error) {
+		if !hasRole(actor, roleOwner, roleAdmin) {
+			return groupChange{}, ErrConversationForbidden
+		}
+		if len(updates) == 0 {
+			return groupChange{}, nil
+		}
+		if err := tx.Model(&model.Conversation{}).Where("id = ?", conversationID).Updates(updates).Error; err != nil {
+			return groupChange{}, err
+		}
+		names, err := displayNames(tx, []int64{userID})
+		if err != nil {
+			return groupChange{}, err
+		}
+		change := groupChange{}
+		if title, ok := updates["title"]; ok {
+			change.notes = append(change.notes, fmt.Sprintf("%s renamed the group to %q", names[userID], title))
+		}
+		if _, ok := updates["avatar_url"]; ok {
+			change.notes = append(change.notes, fmt.Sprintf("%s changed the group photo", names[userID]))
+		}
+		return change, nil
+	})
+}
+
+// manageGroup runs a group action for a participant of a group conversation, writes its system
+// messages to the timeline and pushes the result to everyone affected. The returned summary is
+// nil when the caller is no longer a participant.
+func (s *ConversationService) manageGroup(
+	ctx context.Context,
+	userID, conversationID int64,
+	action func(tx *gorm.DB, actor model.ConversationParticipant) (groupChange, error),
+) (*ConversationSummary, error) {
+	var change groupChange
+	var notes []model.Message
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var conversation model.Conversation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&conversation, conversationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrConversationForbidden
+			}
+			return err
+		}
+		actor, err := groupParticipant(tx, conversationID, userID)
+		if err != nil {
+			if errors.Is(err, ErrParticipantNotFound) {
+				return ErrConversationForbidden
+			}
+			return err
+		}
+		if conversation.Type != model.ConversationTypeGroup {
+			return ErrNotGroupConversation
+		}
+
+		if change, err = action(tx, actor); err != nil {
+			return err
+		}
+		if len(change.notes) == 0 {
+			return nil
+		}
+		now := time.Now().UTC()
+		for _, note := range change.notes {
+			notes = append(notes, model.Message{
+				ConversationID: conversationID,
+				SenderID:       userID,
+				Content:        note,
+				MessageType:    model.MessageTypeSystem,
+				Status:         model.ContentStatusVisible,
+				CreatedAt:      now,
+			})
+		}
+		if err := tx.Create(&notes).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Conversation{}).Where("id = ?", conversationID).Update("updated_at", now).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	var summary *ConversationSummary
+	if !slices.Contains(change.removed, userID) {
+		var err error
+		if summary, err = s.summaryFor(ctx, userID, conversationID); err != nil {
+			return nil, err
+		}
+	}
+	if len(change.notes) == 0 {
+		return summary, nil
+	}
+
+	participants, err := s.participantIDs(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		if err := s.db.WithContext(ctx).Preload("Sender.Profile").First(&note, note.ID).Error; err != nil {
+			return nil, err
+		}
+		s.publish(ctx, participants, realtime.Event{
+			Type:           realtime.EventMessageCreated,
+			ConversationID: conversationID,
+			Data:           mapConversationMessage(note),
+		})
+	}
+	// Each participant's summary differs, so the event only tells clients what to refetch.
+	s.publish(ctx, append(participants, change.removed...), realtime.Event{
+		Type:           realtime.EventConversationUpdated,
+		ConversationID: conversationID,
+	})
+	return summary, nil
+}
+
+func groupParticipant(tx *gorm.DB, conversationID, userID int64) (model.ConversationParticipant, error) {
+	var participant model.ConversationParticipant
+	if err := tx.Where("conversation_id = ? AND user_id = ?", conversationID, userID).First(&participant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return participant, ErrParticipantNotFound
+		}
+		return participant, err
+	}
+	return participant, nil
+}
+
+func groupMemberIDs(tx *gorm.DB, conversationID int64) ([]int64, error) {
+	var ids []int64
+	if err := tx.Model(&model.ConversationParticipant{}).
+		Where("conversation_id = ?", conversationID).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func hasRole(participant model.ConversationParticipant, roles ...string) bool {
+	return slices.Contains(roles, participant.Role)
+}
+
+// displayNames resolves the names used in system messages.
+func displayNames(tx *gorm.DB, userIDs []int64) (map[int64]string, error) {
+	var users []model.User
+	if err := tx.Preload("Profile").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(userIDs))
+	for _, id := range userIDs {
+		names[id] = userDisplayName(nil)
+	}
+	for i := range users {
+		names[users[i].ID] = userDisplayName(&users[i])
+	}
+	return names, nil
+}
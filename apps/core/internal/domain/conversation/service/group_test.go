@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func createGroupUsers(t *testing.T, db *gorm.DB, nicknames ...string) []model.User {
+	t.Helper()
+	users := make([]model.User, 0, len(nicknames))
+	for _, nickname := range nicknames {
+		user := model.User{Role: "user"}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		if err := db.Create(&model.UserProfile{UserID: user.ID, Nickname: nickname}).Error; err != nil {
+			t.Fatalf("failed to create profile: %v", err)
+		}
+		users = append(users, user)
+	}
+	return users
+}
+
+func roleOf(t *testing.T, db *gorm.DB, conversationID, userID int64) string {
+	t.Helper()
+	var participant model.ConversationParticipant
+	if err := db.Where("conversation_id = ? AND user_id = ?", conversationID, userID).First(&participant).Error; err != nil {
+		return ""
+	}
+	return participant.Role
+}
+
+func systemNotes(t *testing.T, db *gorm.DB, conversationID int64) []string {
+	t.Helper()
+	var notes []string
+	db.Model(&model.Message{}).
+		Where("conversation_id = ? AND message_type = ?", conversationID, model.MessageTypeSystem).
+		Order("id asc").
+		Pluck("content", &notes)
+	return notes
+}
+
+func TestGroupMembershipAndRoles(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewConversationService(db, nil, nil)
+	ctx := context.Background()
+	users := createGroupUsers(t, db, "Ann", "Ben", "Cat", "Dan")
+	ann, ben, cat, dan := users[0], users[1], users[2], users[3]
+
+	group, err := svc.Create(ctx, ann.ID, CreateConversationInput{Title: "Brunch", ParticipantIDs: []int64{ben.ID}})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := svc.AddMembers(ctx, ben.ID, group.ID, AddMembersInput{UserIDs: []int64{cat.ID}}); !errors.Is(err, ErrConversationForbidden) {
+		t.Fatalf("expected a member to be kept from adding people, got %v", err)
+	}
+	if _, err := svc.AddMembers(ctx, ann.ID, group.ID, AddMembersInput{UserIDs: []int64{cat.ID, ben.ID, dan.ID}}); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if _, err := svc.UpdateMemberRole(ctx, ann.ID, group.ID, ben.ID, UpdateMemberRoleInput{Role: "admin"}); err != nil {
+		t.Fatalf("promote failed: %v", err)
+	}
+	if _, err := svc.RemoveMember(ctx, ben.ID, group.ID, ann.ID); !errors.Is(err, ErrConversationForbidden) {
+		t.Fatalf("expected an admin to be kept from removing the owner, got %v", err)
+	}
+	if _, err := svc.RemoveMember(ctx, ben.ID, group.ID, dan.ID); err != nil {
+		t.Fatalf("admin remove failed: %v", err)
+	}
+	if _, _, err := svc.Messages(ctx, dan.ID, group.ID, MessageListQuery{}); !errors.Is(err, ErrConversationForbidden) {
+		t.Fatalf("expected a removed member to lose access, got %v", err)
+	}
+	title := "Sunday brunch"
+	renamed, err := svc.UpdateGroup(ctx, ben.ID, group.ID, UpdateGroupInput{Title: &title})
+	if err != nil || renamed.Title != title || renamed.Role != roleAdmin {
+		t.Fatalf("expected an admin to rename the group, got %+v (%v)", renamed, err)
+	}
+
+	if err := svc.Leave(ctx, ann.ID, group.ID); err != nil {
+		t.Fatalf("leave failed: %v", err)
+	}
+	if roleOf(t, db, group.ID, ben.ID) != roleOwner {
+		t.Fatal("expected the admin to inherit ownership when the owner leaves")
+	}
+	if _, err := svc.TransferOwnership(ctx, ben.ID, group.ID, TransferOwnershipInput{UserID: cat.ID}); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if roleOf(t, db, group.ID, cat.ID) != roleOwner || roleOf(t, db, group.ID, ben.ID) != roleAdmin {
+		t.Fatal("expected ownership to move to Cat with Ben kept as admin")
+	}
+
+	want := []string{
+		"Ann added Cat",
+		"Ann added Dan",
+		"Ann made Ben an admin",
+		"Ben removed Dan",
+		`Ben renamed the group to "Sunday brunch"`,
+		"Ann left",
+		"Ben is now the owner",
+		"Ben made Cat the owner",
+	}
+	notes := systemNotes(t, db, group.ID)
+	if len(notes) != len(want) {
+		t.Fatalf("expected system messages %q, got %q", want, notes)
+	}
+	for i := range want {
+		if notes[i] != want[i] {
+			t.Fatalf("expected system message %q at %d, got %q", want[i], i, notes[i])
+		}
+	}
+}
+
+func TestGroupLimitsAndTypes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewConversationService(db, nil, nil)
+	ctx := context.Background()
+	users := createGroupUsers(t, db, "Ann", "Ben")
+
+	direct, err := svc.Create(ctx, users[0].ID, CreateConversationInput{Title: "Chat", Type: model.ConversationTypeDirect, ParticipantIDs: []int64{users[1].ID}})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := svc.AddMembers(ctx, users[0].ID, direct.ID, AddMembersInput{UserIDs: []int64{users[1].ID}}); !errors.Is(err, ErrNotGroupConversation) {
+		t.Fatalf("expected direct conversations to reject membership changes, got %v", err)
+	}
+
+	group, err := svc.Create(ctx, users[0].ID, CreateConversationInput{Title: "Big"})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	ids := make([]int64, 0, maxGroupMembers)
+	for i := 0; i < maxGroupMembers; i++ {
+		user := model.User{Role: "user"}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		ids = append(ids, user.ID)
+	}
+	if _, err := svc.AddMembers(ctx, users[0].ID, group.ID, AddMembersInput{UserIDs: ids}); !errors.Is(err, ErrConversationFull) {
+		t.Fatalf("expected ErrConversationFull, got %v", err)
+	}
+	if _, err := svc.Create(ctx, users[0].ID, CreateConversationInput{Title: "Too big", ParticipantIDs: ids}); !errors.Is(err, ErrConversationFull) {
+		t.Fatalf("expected ErrConversationFull on create, got %v", err)
+	}
+	if _, err := svc.SendMessage(ctx, users[0].ID, group.ID, SendMessageInput{Content: "x", MessageType: model.MessageTypeSystem}); !errors.Is(err, ErrConversationInvalidInput) {
+		t.Fatalf("expected clients to be unable to send system messages, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultMessageLimit   = 50
+	maxMessageLimit       = 100
+	maxMessageAttachments = 9
+	// messageEditWindow is how long after sending a message its sender may still edit it.
+	messageEditWindow = 15 * time.Minute
+)
+
+var messageTypes = map[string]struct{}{
+	model.MessageTypeText:         {},
+	model.MessageTypeImage:        {},
+	model.MessageTypeVoucherShare: {},
+	model.MessageTypeStoreCard:    {},
+}
+
+var ErrMessageNotFound = errors.New("message not found")
+var ErrMessageEditWindowExpired = errors.New("message can no longer be edited")
+var ErrMessageAttachmentInvalid = errors.New("message attachments must be approved uploads owned by the sender")
+
+// MessageListQuery pages through a conversation by message ID. Both cursors are exclusive.
+type MessageListQuery struct {
+	Before *int64
+	After  *int64
+	Limit  int
+}
+
+// MessageAttachment is a media upload attached to a message.
+type MessageAttachment struct {
+	MediaID  string `json:"media_id"`
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type,omitempty"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+}
+
+type EditMessageInput struct {
+	Content string `json:"content"`
+}
+
+// DeletedMessage is the payload of a message.deleted event.
+type DeletedMessage struct {
+	MessageID   int64 `json:"message_id"`
+	ForEveryone bool  `json:"for_everyone"`
+}
+
+// EditMessage replaces the content of the caller's own message while it is still inside the
+// edit window. The new text is screened again and the message is marked edited; screening
+// may hold the message but never lifts a status it already had. When an edit holds a
+// message the other participants could see, they are told to drop it.
+func (s *ConversationService) EditMessage(ctx context.Context, userID, conversationID, messageID int64, input EditMessageInput) (*ConversationMessage, error) {
+	content := strings.TrimSpace(input.Content)
+	if _, err := s.membershipForUser(ctx, userID, conversationID); err != nil {
+		return nil, err
+	}
+	message, err := s.visibleMessage(ctx, userID, conversationID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.SenderID != userID || message.MessageType == model.MessageTypeSystem {
+		return nil, ErrConversationForbidden
+	}
+	if message.DeletedForEveryoneAt != nil {
+		return nil, ErrMessageNotFound
+	}
+	if time.Since(message.CreatedAt) > messageEditWindow {
+		return nil, ErrMessageEditWindowExpired
+	}
+	if content == "" && message.MessageType != model.MessageTypeImage {
+		return nil, ErrConversationInvalidInput
+	}
+
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetMessage,
+		UserID:     userID,
+		Text:       content,
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	now := time.Now().UTC()
+	wasVisible := message.Status == model.ContentStatusVisible
+	message.Content = content
+	message.EditedAt = &now
+	message.Status = verdict.EditStatus(message.Status)
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Message{}).Where("id = ?", message.ID).Updates(map[string]interface{}{
+			"content":   message.Content,
+			"edited_at": now,
+			"status":    message.Status,
+		}).Error; err != nil {
+			return err
+		}
+		if wasVisible && message.Status != model.ContentStatusVisible {
+			return retractFirstResponse(tx, message)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.moderation.Finalize(ctx, screening, message.ID, verdict)
+
+	result := mapConversationMessage(message)
+	recipients, err := s.messageRecipients(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, recipients, realtime.Event{
+		Type:           realtime.EventMessageUpdated,
+		ConversationID: conversationID,
+		Data:           result,
+	})
+	if wasVisible && message.Status != model.ContentStatusVisible {
+		participants, err := s.participantIDs(ctx, conversationID)
+		if err != nil {
+			return nil, err
+		}
+		others := slices.DeleteFunc(participants, func(id int64) bool { return id == userID })
+		s.publish(ctx, others, realtime.Event{
+			Type:           realtime.EventMessageDeleted,
+			ConversationID: conversationID,
+			Data:           DeletedMessage{MessageID: message.ID, ForEveryone: true},
+		})
+	}
+	return &result, nil
+}
+
+// applyModerationStatus hides or holds a delivered message once async moderation flags it. A
+// staff reply that was its conversation's first response stops counting as one.
+func (s *ConversationService) applyModerationStatus(_ context.Context, tx *gorm.DB, messageID int64, status int16) error {
+	var message model.Message
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", messageID).Limit(1).Find(&message)
+	if result.Error != nil || result.RowsAffected == 0 || message.Status == status {
+		return result.Error
+	}
+	wasVisible := message.Status == model.ContentStatusVisible
+	if err := tx.Model(&model.Message{}).Where("id = ?", message.ID).Update("status", status).Error; err != nil {
+		return err
+	}
+	if !wasVisible || status == model.ContentStatusVisible {
+		return nil
+	}
+	return retractFirstResponse(tx, message)
+}
+
+// DeleteMessage hides a message. For everyone, only the sender may delete it: its content and
+// attachments are cleared and every participant sees a placeholder. Otherwise it is hidden
+// from the caller alone. Deleting twice is a no-op.
+func (s *ConversationService) DeleteMessage(ctx context.Context, userID, conversationID, messageID int64, forEveryone bool) error {
+	if _, err := s.membershipForUser(ctx, userID, conversationID); err != nil {
+		return err
+	}
+	message, err := s.visibleMessage(ctx, userID, conversationID, messageID)
+	if err != nil {
+		return err
+	}
+
+	recipients := []int64{userID}
+	if forEveryone {
+		if message.SenderID != userID || message.MessageType == model.MessageTypeSystem {
+			return ErrConversationForbidden
+		}
+		if message.DeletedForEveryoneAt != nil {
+			return nil
+		}
+		if err := s.db.WithContext(ctx).Model(&model.Message{}).Where("id = ?", message.ID).Updates(map[string]interface{}{
+			"content":                 "",
+			"attachments":             "[]",
+			"deleted_for_everyone_at": time.Now().UTC(),
+		}).Error; err != nil {
+			return err
+		}
+		if recipients, err = s.messageRecipients(ctx, message); err != nil {
+			return err
+		}
+	} else {
+		deletion := model.MessageDeletion{MessageID: message.ID, UserID: userID}
+		if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&deletion).Error; err != nil {
+			return err
+		}
+	}
+
+	s.publish(ctx, recipients, realtime.Event{
+		Type:           realtime.EventMessageDeleted,
+		ConversationID: conversationID,
+		Data:           DeletedMessage{MessageID: message.ID, ForEveryone: forEveryone},
+	})
+	return nil
+}
+
+// visibleMessage loads a message of the conversation that userID can currently see.
+func (s *ConversationService) visibleMessage(ctx context.Context, userID, conversationID, messageID int64) (model.Message, error) {
+	var message model.Message
+	if err := s.db.WithContext(ctx).
+		Preload("Sender.Profile").
+		Where("id = ? AND conversation_id = ?", messageID, conversationID).
+		Scopes(visibleMessages(userID)).
+		First(&message).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return message, ErrMessageNotFound
+		}
+		return message, err
+	}
+	return message, nil
+}
+
+// messageRecipients returns who hears about changes to a message: every participant, or only
+// the sender while the message is held for moderation.
+func (s *ConversationService) messageRecipients(ctx context.Context, message model.Message) ([]int64, error) {
+	if message.Status != model.ContentStatusVisible {
+		return []int64{message.SenderID}, nil
+	}
+	return s.participantIDs(ctx, message.ConversationID)
+}
+
+// visibleMessages keeps messages userID may see: approved ones and their own, minus any they
+// deleted for themselves.
+func visibleMessages(userID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Where("messages.status = ? OR messages.sender_id = ?", model.ContentStatusVisible, userID).
+			Where("NOT EXISTS (SELECT 1 FROM message_deletions WHERE message_deletions.message_id = messages.id "+
+				"AND message_deletions.user_id = ?)", userID)
+	}
+}
+
+// validateMessage checks the type against the supported set: image messages need at least one
+// attachment, every other type needs content.
+func validateMessage(messageType, content string, attachmentIDs []string) error {
+	if _, ok := messageTypes[messageType]; !ok {
+		return ErrConversationInvalidInput
+	}
+	if len(attachmentIDs) > maxMessageAttachments {
+		return ErrConversationInvalidInput
+	}
+	if messageType == model.MessageTypeImage {
+		if len(attachmentIDs) == 0 {
+			return ErrConversationInvalidInput
+		}
+		return nil
+	}
+	if content == "" {
+		return ErrConversationInvalidInput
+	}
+	return nil
+}
+
+// resolveAttachments turns media upload UUIDs into the JSON stored on the message. Every
+// upload must be approved and owned by the sender.
+func resolveAttachments(tx *gorm.DB, userID int64, mediaIDs []string) (string, error) {
+	ordered := make([]string, 0, len(mediaIDs))
+	seen := make(map[string]struct{}, len(mediaIDs))
+	for _, id := range mediaIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ordered = append(ordered, id)
+	}
+
+	attachments := make([]MessageAttachment, 0, len(ordered))
+	if len(ordered) > 0 {
+		var uploads []model.MediaUpload
+		if err := tx.Where("uuid IN ? AND user_id = ? AND status = ?", ordered, userID, model.MediaStatusApproved).
+			Find(&uploads).Error; err != nil {
+			return "", err
+		}
+		if len(uploads) != len(ordered) {
+			return "", ErrMessageAttachmentInvalid
+		}
+		byUUID := make(map[string]model.MediaUpload, len(uploads))
+		for _, upload := range uploads {
+			byUUID[upload.UUID] = upload
+		}
+		for _, id := range ordered {
+			upload := byUUID[id]
+			attachments = append(attachments, MessageAttachment{
+				MediaID:  upload.UUID,
+				URL:      upload.FileURL,
+				MimeType: upload.MimeType,
+				Width:    upload.Width,
+				Height:   upload.Height,
+			})
+		}
+	}
+	data, err := json.Marshal(attachments)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
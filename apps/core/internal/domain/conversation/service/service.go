@@ -2,17 +2,26 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
 	"gorm.io/gorm"
 )
 
 type ConversationService struct {
-	db *gorm.DB
+	db         *gorm.DB
+	moderation *moderationservice.ModerationService
+	events     realtime.Publisher
 }
 
 type ConversationSummary struct {
@@ -20,22 +29,34 @@ type ConversationSummary struct {
 	Type          string     `json:"type"`
 	Title         string     `json:"title"`
 	AvatarURL     string     `json:"avatar_url,omitempty"`
+	Role          string     `json:"role,omitempty"`
 	LastMessage   string     `json:"last_message"`
 	LastMessageAt *time.Time `json:"last_message_at,omitempty"`
 	UnreadCount   int64      `json:"unread_count"`
 	IsMuted       bool       `json:"is_muted"`
+	// Set on merchant conversations only.
+	MerchantID    *int64 `json:"merchant_id,omitempty"`
+	StoreID       *int64 `json:"store_id,omitempty"`
+	OrderID       *int64 `json:"order_id,omitempty"`
+	VoucherID     *int64 `json:"voucher_id,omitempty"`
+	SupportStatus string `json:"support_status,omitempty"`
+	AssigneeID    *int64 `json:"assignee_id,omitempty"`
 }
 
 type ConversationMessage struct {
-	ID             int64     `json:"id"`
-	ConversationID int64     `json:"conversation_id"`
-	SenderID       int64     `json:"sender_id"`
-	SenderName     string    `json:"sender_name"`
-	SenderAvatar   string    `json:"sender_avatar,omitempty"`
-	Content        string    `json:"content"`
-	MessageType    string    `json:"message_type"`
-	IsRead         bool      `json:"is_read"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             int64               `json:"id"`
+	ConversationID int64               `json:"conversation_id"`
+	SenderID       int64               `json:"sender_id"`
+	SenderName     string              `json:"sender_name"`
+	SenderAvatar   string              `json:"sender_avatar,omitempty"`
+	Content        string              `json:"content"`
+	MessageType    string              `json:"message_type"`
+	Attachments    []MessageAttachment `json:"attachments"`
+	IsRead         bool                `json:"is_read"`
+	IsEdited       bool                `json:"is_edited"`
+	EditedAt       *time.Time          `json:"edited_at,omitempty"`
+	IsDeleted      bool                `json:"is_deleted"`
+	CreatedAt      time.Time           `json:"created_at"`
 }
 
 type CreateConversationInput struct {
@@ -44,24 +65,46 @@ type CreateConversationInput struct {
 	ParticipantIDs []int64 `json:"participant_ids"`
 }
 
+// SendMessageInput is a new message. AttachmentIDs are media upload UUIDs; image messages
+// need at least one, every other type needs content.
 type SendMessageInput struct {
-	Content     string `json:"content"`
-	MessageType string `json:"message_type"`
+	Content       string   `json:"content"`
+	MessageType   string   `json:"message_type"`
+	AttachmentIDs []string `json:"attachment_ids"`
 }
 
 type UpdateConversationSettingsInput struct {
 	IsMuted *bool `json:"is_muted"`
 }
 
+// ReadReceipt reports how far a participant has read a conversation.
+type ReadReceipt struct {
+	UserID     int64     `json:"user_id"`
+	LastReadAt time.Time `json:"last_read_at"`
+}
+
+// TypingIndicator tells the other participants that a user is typing.
+type TypingIndicator struct {
+	UserID int64 `json:"user_id"`
+}
+
 var ErrConversationNotFound = errors.New("conversation not found")
 var ErrConversationForbidden = errors.New("conversation forbidden")
 var ErrConversationInvalidInput = errors.New("conversation invalid input")
+var ErrConversationBlocked = errors.New("conversation blocked")
 
-func NewConversationService(db *gorm.DB) *ConversationService {
+// NewConversationService wires the service. A nil moderation pipeline disables text screening
+// and a nil publisher disables real-time events.
+func NewConversationService(db *gorm.DB, moderation *moderationservice.ModerationService, events realtime.Publisher) *ConversationService {
 	if db == nil {
 		db = database.DB
 	}
-	return &ConversationService{db: db}
+	if moderation == nil {
+		moderation = moderationservice.NewModerationService(db, moderationservice.ModeOff, false)
+	}
+	svc := &ConversationService{db: db, moderation: moderation, events: events}
+	moderation.RegisterApplier(moderationservice.TargetMessage, svc.applyModerationStatus)
+	return svc
 }
 
 func (s *ConversationService) List(ctx context.Context, userID int64) ([]ConversationSummary, error) {
@@ -83,9 +126,6 @@ func (s *ConversationService) List(ctx context.Context, userID int64) ([]Convers
 	var conversations []model.Conversation
 	if err := s.db.WithContext(ctx).
 		Preload("Participants.User.Profile").
-		Preload("Messages", func(db *gorm.DB) *gorm.DB {
-			return db.Order("created_at desc")
-		}).
 		Where("id IN ?", conversationIDs).
 		Order("updated_at desc").
 		Find(&conversations).Error; err != nil {
@@ -112,25 +152,39 @@ func (s *ConversationService) Create(ctx context.Context, userID int64, input Cr
 
 	conversationType := strings.TrimSpace(input.Type)
 	if conversationType == "" {
-		conversationType = "group"
+		conversationType = model.ConversationTypeGroup
+	}
+	// Merchant conversations have their own entry point that links the merchant's staff.
+	if conversationType == model.ConversationTypeMerchant {
+		return nil, ErrConversationInvalidInput
 	}
 
 	participantIDs := uniqueParticipantIDs(append(input.ParticipantIDs, userID))
+	if len(participantIDs) > maxGroupMembers {
+		return nil, ErrConversationFull
+	}
 	conversation := model.Conversation{
 		Type:  conversationType,
 		Title: title,
 	}
 
 	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		blocked, err := blockedWithAny(tx, userID, participantIDs)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrConversationBlocked
+		}
 		if err := tx.Create(&conversation).Error; err != nil {
 			return err
 		}
 
 		participants := make([]model.ConversationParticipant, 0, len(participantIDs))
 		for _, participantID := range participantIDs {
-			role := "member"
+			role := roleMember
 			if participantID == userID {
-				role = "owner"
+				role = roleOwner
 			}
 			participants = append(participants, model.ConversationParticipant{
 				ConversationID: conversation.ID,
@@ -145,43 +199,59 @@ func (s *ConversationService) Create(ctx context.Context, userID int64, input Cr
 		return nil, err
 	}
 
-	return &ConversationSummary{
+	summary := &ConversationSummary{
 		ID:          conversation.ID,
 		Type:        conversation.Type,
 		Title:       conversation.Title,
 		LastMessage: "",
 		UnreadCount: 0,
 		IsMuted:     false,
-	}, nil
+	}
+	s.publish(ctx, participantIDs, realtime.Event{
+		Type:           realtime.EventConversationUpdated,
+		ConversationID: conversation.ID,
+		Data:           summary,
+	})
+	return summary, nil
 }
 
-func (s *ConversationService) Messages(ctx context.Context, userID, conversationID int64) ([]ConversationMessage, error) {
-	membership, err := s.membershipForUser(ctx, userID, conversationID)
-	if err != nil {
-		return nil, err
+// Messages returns one page of a conversation's messages, oldest first, without marking them
+// read; clients call MarkRead once the user has actually seen them. Without cursors it returns
+// the latest page; Before pages back through history and After fetches what arrived since.
+// The flag reports whether more messages lie beyond the page in that direction.
+func (s *ConversationService) Messages(ctx context.Context, userID, conversationID int64, query MessageListQuery) ([]ConversationMessage, bool, error) {
+	if _, err := s.membershipForUser(ctx, userID, conversationID); err != nil {
+		return nil, false, err
 	}
 
-	var messages []model.Message
-	if err := s.db.WithContext(ctx).
+	limit := defaultMessageLimit
+	if query.Limit > 0 {
+		limit = min(query.Limit, maxMessageLimit)
+	}
+
+	q := s.db.WithContext(ctx).
 		Preload("Sender.Profile").
 		Where("conversation_id = ?", conversationID).
-		Order("created_at asc").
-		Find(&messages).Error; err != nil {
-		return nil, err
+		Scopes(visibleMessages(userID))
+	if query.Before != nil {
+		q = q.Where("id < ?", *query.Before)
+	}
+	if query.After != nil {
+		q = q.Where("id > ?", *query.After).Order("id asc")
+	} else {
+		q = q.Order("id desc")
 	}
 
-	now := time.Now().UTC()
-	if err := s.db.WithContext(ctx).
-		Model(&model.ConversationParticipant{}).
-		Where("id = ?", membership.ID).
-		Update("last_read_at", now).Error; err != nil {
-		return nil, err
+	var messages []model.Message
+	if err := q.Limit(limit + 1).Find(&messages).Error; err != nil {
+		return nil, false, err
 	}
-	if err := s.db.WithContext(ctx).
-		Model(&model.Message{}).
-		Where("conversation_id = ? AND sender_id <> ?", conversationID, userID).
-		Update("is_read", true).Error; err != nil {
-		return nil, err
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+	if query.After == nil {
+		slices.Reverse(messages)
 	}
 
 	result := make([]ConversationMessage, 0, len(messages))
@@ -189,36 +259,56 @@ func (s *ConversationService) Messages(ctx context.Context, userID, conversation
 		result = append(result, mapConversationMessage(message))
 	}
 
-	return result, nil
+	return result, hasMore, nil
 }
 
 func (s *ConversationService) SendMessage(ctx context.Context, userID, conversationID int64, input SendMessageInput) (*ConversationMessage, error) {
-	if strings.TrimSpace(input.Content) == "" {
-		return nil, ErrConversationInvalidInput
+	content := strings.TrimSpace(input.Content)
+	messageType := strings.TrimSpace(input.MessageType)
+	if messageType == "" {
+		messageType = model.MessageTypeText
+	}
+	if err := validateMessage(messageType, content, input.AttachmentIDs); err != nil {
+		return nil, err
 	}
 
-	if _, err := s.membershipForUser(ctx, userID, conversationID); err != nil {
+	membership, err := s.membershipForUser(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDirectBlock(ctx, userID, conversationID); err != nil {
 		return nil, err
 	}
 
-	messageType := strings.TrimSpace(input.MessageType)
-	if messageType == "" {
-		messageType = "text"
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetMessage,
+		UserID:     userID,
+		Text:       content,
 	}
+	verdict := s.moderation.Screen(ctx, screening)
 
 	message := model.Message{
 		ConversationID: conversationID,
 		SenderID:       userID,
-		Content:        strings.TrimSpace(input.Content),
+		Content:        content,
 		MessageType:    messageType,
 		IsRead:         false,
+		Status:         verdict.Status(),
 		CreatedAt:      time.Now().UTC(),
 	}
 
 	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		attachments, err := resolveAttachments(tx, userID, input.AttachmentIDs)
+		if err != nil {
+			return err
+		}
+		message.Attachments = attachments
 		if err := tx.Create(&message).Error; err != nil {
 			return err
 		}
+		if err := recordSupportMessage(tx, membership, message); err != nil {
+			return err
+		}
 		return tx.Model(&model.Conversation{}).
 			Where("id = ?", conversationID).
 			Update("updated_at", message.CreatedAt).Error
@@ -226,14 +316,111 @@ func (s *ConversationService) SendMessage(ctx context.Context, userID, conversat
 		return nil, err
 	}
 
+	s.moderation.Finalize(ctx, screening, message.ID, verdict)
+
 	if err := s.db.WithContext(ctx).Preload("Sender.Profile").First(&message, message.ID).Error; err != nil {
 		return nil, err
 	}
 
 	result := mapConversationMessage(message)
+	recipients, err := s.messageRecipients(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, recipients, realtime.Event{
+		Type:           realtime.EventMessageCreated,
+		ConversationID: conversationID,
+		Data:           result,
+	})
+	if message.Status == model.ContentStatusVisible {
+		eventbus.Publish(ctx, eventbus.MessageSent{
+			MessageID:      message.ID,
+			ConversationID: conversationID,
+			SenderID:       userID,
+			MessageType:    messageType,
+			Content:        content,
+		})
+	}
 	return &result, nil
 }
 
+// MarkRead records that userID has read the conversation up to now and broadcasts the read
+// receipt to the participants.
+func (s *ConversationService) MarkRead(ctx context.Context, userID, conversationID int64) (*ReadReceipt, error) {
+	membership, err := s.membershipForUser(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.ConversationParticipant{}).
+			Where("id = ?", membership.ID).
+			Update("last_read_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Message{}).
+			Where("conversation_id = ? AND sender_id <> ? AND is_read = ? AND created_at <= ?", conversationID, userID, false, now).
+			Update("is_read", true).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	receipt := &ReadReceipt{UserID: userID, LastReadAt: now}
+	participants, err := s.participantIDs(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, participants, realtime.Event{
+		Type:           realtime.EventReadReceipt,
+		ConversationID: conversationID,
+		Data:           receipt,
+	})
+	return receipt, nil
+}
+
+// ReadReceipts returns how far each participant has read the conversation.
+func (s *ConversationService) ReadReceipts(ctx context.Context, userID, conversationID int64) ([]ReadReceipt, error) {
+	if _, err := s.membershipForUser(ctx, userID, conversationID); err != nil {
+		return nil, err
+	}
+	var participants []model.ConversationParticipant
+	if err := s.db.WithContext(ctx).
+		Where("conversation_id = ?", conversationID).
+		Order("user_id asc").
+		Find(&participants).Error; err != nil {
+		return nil, err
+	}
+	receipts := make([]ReadReceipt, 0, len(participants))
+	for _, participant := range participants {
+		receipts = append(receipts, ReadReceipt{UserID: participant.UserID, LastReadAt: participant.LastReadAt})
+	}
+	return receipts, nil
+}
+
+// Typing tells the other participants that userID is typing. Nothing is stored.
+func (s *ConversationService) Typing(ctx context.Context, userID, conversationID int64) error {
+	if _, err := s.membershipForUser(ctx, userID, conversationID); err != nil {
+		return err
+	}
+	participants, err := s.participantIDs(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	others := make([]int64, 0, len(participants))
+	for _, id := range participants {
+		if id != userID {
+			others = append(others, id)
+		}
+	}
+	s.publish(ctx, others, realtime.Event{
+		Type:           realtime.EventTyping,
+		ConversationID: conversationID,
+		Data:           TypingIndicator{UserID: userID},
+	})
+	return nil
+}
+
 func (s *ConversationService) UpdateSettings(ctx context.Context, userID, conversationID int64, input UpdateConversationSettingsInput) (*ConversationSummary, error) {
 	membership, err := s.membershipForUser(ctx, userID, conversationID)
 	if err != nil {
@@ -250,9 +437,6 @@ func (s *ConversationService) UpdateSettings(ctx context.Context, userID, conver
 	var conversation model.Conversation
 	if err := s.db.WithContext(ctx).
 		Preload("Participants.User.Profile").
-		Preload("Messages", func(db *gorm.DB) *gorm.DB {
-			return db.Order("created_at desc")
-		}).
 		First(&conversation, conversationID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrConversationNotFound
@@ -264,6 +448,12 @@ func (s *ConversationService) UpdateSettings(ctx context.Context, userID, conver
 	if err != nil {
 		return nil, err
 	}
+	// Settings are personal, so only the user's other connections hear about the change.
+	s.publish(ctx, []int64{userID}, realtime.Event{
+		Type:           realtime.EventConversationUpdated,
+		ConversationID: conversationID,
+		Data:           summary,
+	})
 	return &summary, nil
 }
 
@@ -277,59 +467,154 @@ func (s *ConversationService) loadMemberships(ctx context.Context, userID int64)
 	return memberships, nil
 }
 
+// membershipForUser returns the caller's participation in a conversation. Merchant staff who
+// are not participants yet join the merchant's conversations on first access.
 func (s *ConversationService) membershipForUser(ctx context.Context, userID, conversationID int64) (model.ConversationParticipant, error) {
 	var membership model.ConversationParticipant
 	if err := s.db.WithContext(ctx).
 		Where("user_id = ? AND conversation_id = ?", userID, conversationID).
 		First(&membership).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return membership, ErrConversationForbidden
+			return s.joinAsStaff(ctx, userID, conversationID)
 		}
 		return membership, err
 	}
 	return membership, nil
 }
 
+func (s *ConversationService) participantIDs(ctx context.Context, conversationID int64) ([]int64, error) {
+	var ids []int64
+	if err := s.db.WithContext(ctx).
+		Model(&model.ConversationParticipant{}).
+		Where("conversation_id = ?", conversationID).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// publish pushes an event to connected clients. Delivery is best effort: the change is
+// already committed and clients resync over REST, so a broker failure is only logged.
+func (s *ConversationService) publish(ctx context.Context, recipients []int64, event realtime.Event) {
+	if s.events == nil {
+		return
+	}
+	if err := s.events.Publish(ctx, recipients, event); err != nil {
+		logger.Warn(ctx, "conversation: failed to publish event",
+			"type", event.Type, "conversation_id", event.ConversationID, "error", err.Error())
+	}
+}
+
+// checkDirectBlock rejects messages in a two-person conversation whose members are on either
+// side of a block. Group conversations stay open so one block cannot silence the group.
+func (s *ConversationService) checkDirectBlock(ctx context.Context, userID, conversationID int64) error {
+	memberIDs, err := s.participantIDs(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if len(memberIDs) != 2 {
+		return nil
+	}
+	blocked, err := blockedWithAny(s.db.WithContext(ctx), userID, memberIDs)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrConversationBlocked
+	}
+	return nil
+}
+
 func (s *ConversationService) buildConversationSummary(
 	ctx context.Context,
 	userID int64,
 	conversation model.Conversation,
 	membership model.ConversationParticipant,
 ) (ConversationSummary, error) {
+	db := s.db.WithContext(ctx)
 	var unreadCount int64
-	query := s.db.WithContext(ctx).
-		Model(&model.Message{}).
-		Where("conversation_id = ? AND sender_id <> ?", conversation.ID, userID)
+	query := db.Model(&model.Message{}).
+		Where("messages.conversation_id = ? AND messages.sender_id <> ?", conversation.ID, userID).
+		Scopes(visibleMessages(userID))
 	if !membership.LastReadAt.IsZero() {
-		query = query.Where("created_at > ?", membership.LastReadAt)
+		query = query.Where("messages.created_at > ?", membership.LastReadAt)
 	}
 	if err := query.Count(&unreadCount).Error; err != nil {
 		return ConversationSummary{}, err
 	}
 
-	summary := ConversationSummary{
-		ID:          conversation.ID,
-		Type:        conversation.Type,
-		Title:       conversation.Title,
-		UnreadCount: unreadCount,
-		IsMuted:     membership.IsMuted,
+	// The preview is the latest message the user can still see, so a held or deleted message
+	// never leaks into the list.
+	var lastMessages []model.Message
+	if err := db.Where("messages.conversation_id = ? AND messages.deleted_for_everyone_at IS NULL", conversation.ID).
+		Scopes(visibleMessages(userID)).
+		Order("messages.id desc").
+		Limit(1).
+		Find(&lastMessages).Error; err != nil {
+		return ConversationSummary{}, err
 	}
 
-	if len(conversation.Messages) > 0 {
-		lastMessage := conversation.Messages[0]
+	summary := ConversationSummary{
+		ID:            conversation.ID,
+		Type:          conversation.Type,
+		Title:         conversation.Title,
+		Role:          membership.Role,
+		UnreadCount:   unreadCount,
+		IsMuted:       membership.IsMuted,
+		MerchantID:    conversation.MerchantID,
+		StoreID:       conversation.StoreID,
+		OrderID:       conversation.OrderID,
+		VoucherID:     conversation.VoucherID,
+		SupportStatus: conversation.SupportStatus,
+		AssigneeID:    conversation.AssigneeID,
+	}
+
+	if len(lastMessages) > 0 {
+		lastMessage := lastMessages[0]
 		summary.LastMessage = lastMessage.Content
 		summary.LastMessageAt = &lastMessage.CreatedAt
 	}
 
+	if conversation.Type == model.ConversationTypeMerchant {
+		// Customers see the merchant; staff see the customer they are helping.
+		if membership.Role == roleMerchantStaff {
+			customers := make([]model.ConversationParticipant, 0, 1)
+			for _, participant := range conversation.Participants {
+				if participant.Role == roleCustomer {
+					customers = append(customers, participant)
+				}
+			}
+			summary.Title = defaultConversationTitle(customers, userID)
+			summary.AvatarURL = otherParticipantAvatar(customers, userID)
+		} else if conversation.MerchantID != nil {
+			var merchant model.Merchant
+			err := db.Select("id", "logo_url").First(&merchant, *conversation.MerchantID).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return ConversationSummary{}, err
+			}
+			summary.AvatarURL = merchant.LogoURL
+		}
+		return summary, nil
+	}
+
 	if summary.Title == "" {
 		summary.Title = defaultConversationTitle(conversation.Participants, userID)
 	}
-	summary.AvatarURL = otherParticipantAvatar(conversation.Participants, userID)
+	summary.AvatarURL = conversation.AvatarURL
+	if summary.AvatarURL == "" {
+		summary.AvatarURL = otherParticipantAvatar(conversation.Participants, userID)
+	}
 
 	return summary, nil
 }
 
 func mapConversationMessage(message model.Message) ConversationMessage {
+	attachments := []MessageAttachment{}
+	if message.Attachments != "" {
+		if err := json.Unmarshal([]byte(message.Attachments), &attachments); err != nil {
+			attachments = []MessageAttachment{}
+		}
+	}
 	return ConversationMessage{
 		ID:             message.ID,
 		ConversationID: message.ConversationID,
@@ -338,7 +623,11 @@ func mapConversationMessage(message model.Message) ConversationMessage {
 		SenderAvatar:   userAvatar(message.Sender),
 		Content:        message.Content,
 		MessageType:    message.MessageType,
+		Attachments:    attachments,
 		IsRead:         message.IsRead,
+		IsEdited:       message.EditedAt != nil,
+		EditedAt:       message.EditedAt,
+		IsDeleted:      message.DeletedForEveryoneAt != nil,
 		CreatedAt:      message.CreatedAt,
 	}
 }
@@ -384,6 +673,17 @@ func userAvatar(user *model.User) string {
 	return user.Profile.AvatarURL
 }
 
+// blockedWithAny reports whether userID and any of otherIDs are on either side of a block.
+func blockedWithAny(db *gorm.DB, userID int64, otherIDs []int64) (bool, error) {
+	for _, otherID := range otherIDs {
+		blocked, err := followservice.IsBlockedBetween(db, userID, otherID)
+		if err != nil || blocked {
+			return blocked, err
+		}
+	}
+	return false, nil
+}
+
 func uniqueParticipantIDs(ids []int64) []int64 {
 	seen := make(map[int64]struct{}, len(ids))
 	result := make([]int64, 0, len(ids))
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+)
+
+type publishedEvent struct {
+	recipients []int64
+	event      realtime.Event
+}
+
+type recordingPublisher struct {
+	events []publishedEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, recipients []int64, event realtime.Event) error {
+	p.events = append(p.events, publishedEvent{recipients: recipients, event: event})
+	return nil
+}
+
+func TestConversationSummaryOnlyShowsVisibleMessages(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewConversationService(db, nil, nil)
+	ctx := context.Background()
+	users := createGroupUsers(t, db, "Ann", "Ben")
+	ann, ben := users[0], users[1]
+
+	group, err := svc.Create(ctx, ann.ID, CreateConversationInput{Title: "Brunch", ParticipantIDs: []int64{ben.ID}})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	now := time.Now().UTC()
+	deletedAt := now
+	messages := []model.Message{
+		{ConversationID: group.ID, SenderID: ben.ID, Content: "see you at ten", CreatedAt: now.Add(-3 * time.Minute)},
+		{ConversationID: group.ID, SenderID: ben.ID, Content: "held for review", Status: model.ContentStatusPending,
+			CreatedAt: now.Add(-2 * time.Minute)},
+		{ConversationID: group.ID, SenderID: ben.ID, Content: "", DeletedForEveryoneAt: &deletedAt,
+			CreatedAt: now.Add(-time.Minute)},
+	}
+	for i := range messages {
+		if err := db.Create(&messages[i]).Error; err != nil {
+			t.Fatalf("failed to create message: %v", err)
+		}
+	}
+
+	summaries, err := svc.List(ctx, ann.ID)
+	if err != nil || len(summaries) != 1 {
+		t.Fatalf("expected one conversation, got %+v (%v)", summaries, err)
+	}
+	if summaries[0].LastMessage != "see you at ten" || summaries[0].UnreadCount != 2 {
+		t.Fatalf("expected the held message to stay out of the preview and unread count, got %+v", summaries[0])
+	}
+
+	if err := db.Create(&model.MessageDeletion{MessageID: messages[0].ID, UserID: ann.ID}).Error; err != nil {
+		t.Fatalf("failed to delete message: %v", err)
+	}
+	summaries, err = svc.List(ctx, ann.ID)
+	if err != nil || summaries[0].LastMessage != "" || summaries[0].LastMessageAt != nil || summaries[0].UnreadCount != 1 {
+		t.Fatalf("expected a message deleted for Ann to leave her preview, got %+v (%v)", summaries, err)
+	}
+
+	summaries, err = svc.List(ctx, ben.ID)
+	if err != nil || summaries[0].LastMessage != "held for review" {
+		t.Fatalf("expected the sender to still see their own held message, got %+v (%v)", summaries, err)
+	}
+}
+
+func TestEditMessageNeverLiftsModerationAndRetractsHeldEdits(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	moderation := moderationservice.NewModerationService(db, moderationservice.ModeSync, false,
+		moderationservice.NewRulesEngine(config.ModerationConfig{BlockedTerms: map[string][]string{"default": {"idiot"}}}))
+	events := &recordingPublisher{}
+	svc := NewConversationService(db, moderation, events)
+	ctx := context.Background()
+	users := createGroupUsers(t, db, "Ann", "Ben", "Cat")
+	ann, ben, cat := users[0], users[1], users[2]
+
+	group, err := svc.Create(ctx, ann.ID, CreateConversationInput{Title: "Brunch", ParticipantIDs: []int64{ben.ID, cat.ID}})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	sent := model.Message{ConversationID: group.ID, SenderID: ben.ID, Content: "see you at ten", MessageType: model.MessageTypeText}
+	hidden := model.Message{ConversationID: group.ID, SenderID: ben.ID, Content: "hidden by an admin", MessageType: model.MessageTypeText,
+		Status: model.ContentStatusHidden}
+	for _, message := range []*model.Message{&sent, &hidden} {
+		if err := db.Create(message).Error; err != nil {
+			t.Fatalf("failed to create message: %v", err)
+		}
+	}
+
+	if _, err := svc.EditMessage(ctx, ben.ID, group.ID, hidden.ID, EditMessageInput{Content: "perfectly polite"}); err != nil {
+		t.Fatalf("edit failed: %v", err)
+	}
+	var reloaded model.Message
+	if err := db.First(&reloaded, hidden.ID).Error; err != nil {
+		t.Fatalf("failed to reload message: %v", err)
+	}
+	if reloaded.Status != model.ContentStatusHidden {
+		t.Fatalf("expected the hidden message to stay hidden, got status %d", reloaded.Status)
+	}
+
+	events.events = nil
+	if _, err := svc.EditMessage(ctx, ben.ID, group.ID, sent.ID, EditMessageInput{Content: "you idiot"}); err != nil {
+		t.Fatalf("edit failed: %v", err)
+	}
+	if len(events.events) != 2 {
+		t.Fatalf("expected an update for the sender and a retraction for the others, got %+v", events.events)
+	}
+	update, retraction := events.events[0], events.events[1]
+	if update.event.Type != realtime.EventMessageUpdated || len(update.recipients) != 1 || update.recipients[0] != ben.ID {
+		t.Fatalf("expected only the sender to receive the held edit, got %+v", update)
+	}
+	if retraction.event.Type != realtime.EventMessageDeleted || len(retraction.recipients) != 2 {
+		t.Fatalf("expected the other participants to drop the message, got %+v", retraction)
+	}
+	for _, id := range retraction.recipients {
+		if id == ben.ID {
+			t.Fatalf("expected the sender to keep their held message, got %+v", retraction)
+		}
+	}
+}
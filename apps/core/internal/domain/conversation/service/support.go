@@ -0,0 +1,662 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/conversation/realtime"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+// Participant roles in a merchant conversation.
+const (
+	roleCustomer      = "customer"
+	roleMerchantStaff = "merchant"
+)
+
+const (
+	defaultInboxLimit  = 20
+	maxInboxLimit      = 100
+	defaultMetricsDays = 30
+	maxMetricsDays     = 365
+)
+
+var ErrMerchantNotFound = errors.New("merchant not found")
+var ErrStoreNotFound = errors.New("store not found")
+var ErrNotMerchantStaff = errors.New("merchant account required")
+var ErrSupportContextInvalid = errors.New("order or voucher does not belong to this customer and merchant")
+var ErrMerchantUnstaffed = errors.New("merchant has no staff to answer messages")
+
+// StartMerchantConversationInput opens a support conversation from a store or merchant page.
+// StoreID implies its merchant; OrderID and VoucherID optionally give the staff context.
+type StartMerchantConversationInput struct {
+	MerchantID *int64 `json:"merchant_id"`
+	StoreID    *int64 `json:"store_id"`
+	OrderID    *int64 `json:"order_id"`
+	VoucherID  *int64 `json:"voucher_id"`
+}
+
+// MerchantInboxQuery filters the merchant's support inbox. Status is open, assigned or
+// resolved; Cursor is the id of the last conversation on the previous page.
+type MerchantInboxQuery struct {
+	StoreID *int64
+	Status  string
+	Cursor  *int64
+	Limit   int
+}
+
+type AssignConversationInput struct {
+	AssigneeID *int64 `json:"assignee_id"`
+}
+
+// SupportMetrics summarises how a merchant handles support conversations. Started, responded
+// and response times cover the last Days days; the status counts are current.
+type SupportMetrics struct {
+	Days                    int     `json:"days"`
+	ConversationsStarted    int64   `json:"conversations_started"`
+	ConversationsResponded  int64   `json:"conversations_responded"`
+	ResponseRate            float64 `json:"response_rate"`
+	AvgFirstResponseSeconds float64 `json:"avg_first_response_seconds"`
+	Open                    int64   `json:"open"`
+	Assigned                int64   `json:"assigned"`
+	Resolved                int64   `json:"resolved"`
+}
+
+// StartMerchantConversation opens a conversation between the customer and a merchant, or
+// returns the customer's unresolved one for the same merchant and store. The merchant's staff
+// join as participants so any of them can answer; a merchant nobody has claimed yet cannot be
+// messaged. The flag is true when one was created.
+func (s *ConversationService) StartMerchantConversation(ctx context.Context, userID int64, input StartMerchantConversationInput) (*ConversationSummary, bool, error) {
+	var conversation model.Conversation
+	created := false
+	var participantIDs []int64
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		merchant, store, err := resolveSupportTarget(tx, input)
+		if err != nil {
+			return err
+		}
+		staff, err := merchantStaffIDs(tx, merchant.ID)
+		if err != nil {
+			return err
+		}
+		if len(staff) == 0 {
+			return ErrMerchantUnstaffed
+		}
+		if slices.Contains(staff, userID) {
+			return ErrConversationInvalidInput
+		}
+		if err := checkSupportContext(tx, userID, merchant.ID, input); err != nil {
+			return err
+		}
+		blocked, err := blockedWithAny(tx, userID, staff)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrConversationBlocked
+		}
+		participantIDs = append([]int64{userID}, staff...)
+
+		var storeID *int64
+		title := merchant.Name
+		if store != nil {
+			storeID = &store.ID
+			title = store.Name
+		}
+
+		existing := tx.Model(&model.Conversation{}).
+			Where("type = ? AND merchant_id = ? AND support_status <> ?", model.ConversationTypeMerchant, merchant.ID, model.SupportStatusResolved).
+			Where("EXISTS (SELECT 1 FROM conversation_participants WHERE conversation_participants.conversation_id = conversations.id "+
+				"AND conversation_participants.user_id = ? AND conversation_participants.role = ?)", userID, roleCustomer)
+		if storeID == nil {
+			existing = existing.Where("store_id IS NULL")
+		} else {
+			existing = existing.Where("store_id = ?", *storeID)
+		}
+		err = existing.Order("id desc").First(&conversation).Error
+		if err == nil {
+			updates := map[string]interface{}{}
+			if input.OrderID != nil {
+				updates["order_id"] = *input.OrderID
+			}
+			if input.VoucherID != nil {
+				updates["voucher_id"] = *input.VoucherID
+			}
+			if len(updates) == 0 {
+				return nil
+			}
+			return tx.Model(&conversation).Updates(updates).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		conversation = model.Conversation{
+			Type:          model.ConversationTypeMerchant,
+			Title:         title,
+			MerchantID:    &merchant.ID,
+			StoreID:       storeID,
+			OrderID:       input.OrderID,
+			VoucherID:     input.VoucherID,
+			SupportStatus: model.SupportStatusOpen,
+		}
+		if err := tx.Create(&conversation).Error; err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		participants := make([]model.ConversationParticipant, 0, len(participantIDs))
+		for _, id := range participantIDs {
+			role := roleMerchantStaff
+			if id == userID {
+				role = roleCustomer
+			}
+			participants = append(participants, model.ConversationParticipant{
+				ConversationID: conversation.ID,
+				UserID:         id,
+				Role:           role,
+				JoinedAt:       now,
+			})
+		}
+		if err := tx.Create(&participants).Error; err != nil {
+			return err
+		}
+		created = true
+		return recordSupportAnalytics(tx, conversation.MerchantID, conversation.StoreID, conversation.CreatedAt, map[string]interface{}{
+			"conversations_started": gorm.Expr("conversations_started + 1"),
+		})
+	}); err != nil {
+		return nil, false, err
+	}
+
+	summary, err := s.summaryFor(ctx, userID, conversation.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		s.publish(ctx, participantIDs, realtime.Event{
+			Type:           realtime.EventConversationUpdated,
+			ConversationID: conversation.ID,
+			Data:           summary,
+		})
+	}
+	return summary, created, nil
+}
+
+// MerchantInbox lists the support conversations of the caller's merchant, newest first.
+func (s *ConversationService) MerchantInbox(ctx context.Context, userID int64, query MerchantInboxQuery) ([]ConversationSummary, *int64, error) {
+	db := s.db.WithContext(ctx)
+	merchant, err := staffMerchant(db, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	limit := defaultInboxLimit
+	if query.Limit > 0 {
+		limit = min(query.Limit, maxInboxLimit)
+	}
+
+	q := db.Preload("Participants.User.Profile").
+		Where("type = ? AND merchant_id = ?", model.ConversationTypeMerchant, merchant.ID)
+	if query.StoreID != nil {
+		q = q.Where("store_id = ?", *query.StoreID)
+	}
+	switch query.Status {
+	case "":
+	case model.SupportStatusOpen, model.SupportStatusAssigned, model.SupportStatusResolved:
+		q = q.Where("support_status = ?", query.Status)
+	default:
+		return nil, nil, ErrConversationInvalidInput
+	}
+	if query.Cursor != nil {
+		q = q.Where("id < ?", *query.Cursor)
+	}
+
+	var conversations []model.Conversation
+	if err := q.Order("id desc").Limit(limit + 1).Find(&conversations).Error; err != nil {
+		return nil, nil, err
+	}
+	var next *int64
+	if len(conversations) > limit {
+		conversations = conversations[:limit]
+		lastID := conversations[len(conversations)-1].ID
+		next = &lastID
+	}
+
+	summaries := make([]ConversationSummary, 0, len(conversations))
+	for _, conversation := range conversations {
+		membership := model.ConversationParticipant{UserID: userID, Role: roleMerchantStaff}
+		for _, participant := range conversation.Participants {
+			if participant.UserID == userID {
+				membership = participant
+			}
+		}
+		summary, err := s.buildConversationSummary(ctx, userID, conversation, membership)
+		if err != nil {
+			return nil, nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, next, nil
+}
+
+// AssignConversation hands a support conversation to one of the merchant's staff, the caller
+// by default. A resolved conversation is reopened.
+func (s *ConversationService) AssignConversation(ctx context.Context, userID, conversationID int64, input AssignConversationInput) (*ConversationSummary, error) {
+	assigneeID := userID
+	if input.AssigneeID != nil {
+		assigneeID = *input.AssigneeID
+	}
+	return s.updateSupport(ctx, userID, conversationID, func(conversation model.Conversation, staff []int64) (map[string]interface{}, error) {
+		if !slices.Contains(staff, assigneeID) {
+			return nil, ErrConversationInvalidInput
+		}
+		return map[string]interface{}{
+			"assignee_id":    assigneeID,
+			"support_status": model.SupportStatusAssigned,
+			"resolved_at":    nil,
+		}, nil
+	})
+}
+
+// ResolveConversation marks a support conversation resolved. A later customer message reopens it.
+func (s *ConversationService) ResolveConversation(ctx context.Context, userID, conversationID int64) (*ConversationSummary, error) {
+	return s.updateSupport(ctx, userID, conversationID, func(conversation model.Conversation, staff []int64) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"support_status": model.SupportStatusResolved,
+			"resolved_at":    time.Now().UTC(),
+		}, nil
+	})
+}
+
+// ReopenConversation puts a resolved support conversation back into the inbox.
+func (s *ConversationService) ReopenConversation(ctx context.Context, userID, conversationID int64) (*ConversationSummary, error) {
+	return s.updateSupport(ctx, userID, conversationID, func(conversation model.Conversation, staff []int64) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"support_status": reopenedStatus(conversation),
+			"resolved_at":    nil,
+		}, nil
+	})
+}
+
+// SupportMetrics reports response times and current workload for the caller's merchant,
+// optionally narrowed to one store.
+func (s *ConversationService) SupportMetrics(ctx context.Context, userID int64, storeID *int64, days int) (*SupportMetrics, error) {
+	db := s.db.WithContext(ctx)
+	merchant, err := staffMerchant(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if days <= 0 {
+		days = defaultMetricsDays
+	}
+	days = min(days, maxMetricsDays)
+	since := analyticsDay(time.Now().UTC()).AddDate(0, 0, -(days - 1))
+
+	var totals struct {
+		Started   int64
+		Responded int64
+		Seconds   int64
+	}
+	q := db.Model(&model.MerchantAnalytics{}).
+		Select("COALESCE(SUM(conversations_started), 0) AS started, "+
+			"COALESCE(SUM(conversations_responded), 0) AS responded, "+
+			"COALESCE(SUM(response_time_seconds), 0) AS seconds").
+		Where("merchant_id = ? AND date >= ?", merchant.ID, since)
+	if storeID != nil {
+		q = q.Where("store_id = ?", *storeID)
+	}
+	if err := q.Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+
+	metrics := &SupportMetrics{
+		Days:                   days,
+		ConversationsStarted:   totals.Started,
+		ConversationsResponded: totals.Responded,
+	}
+	if totals.Started > 0 {
+		metrics.ResponseRate = float64(totals.Responded) / float64(totals.Started)
+	}
+	if totals.Responded > 0 {
+		metrics.AvgFirstResponseSeconds = float64(totals.Seconds) / float64(totals.Responded)
+	}
+
+	var counts []struct {
+		SupportStatus string
+		Total         int64
+	}
+	q = db.Model(&model.Conversation{}).
+		Select("support_status, COUNT(*) AS total").
+		Where("type = ? AND merchant_id = ?", model.ConversationTypeMerchant, merchant.ID)
+	if storeID != nil {
+		q = q.Where("store_id = ?", *storeID)
+	}
+	if err := q.Group("support_status").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	for _, count := range counts {
+		switch count.SupportStatus {
+		case model.SupportStatusOpen:
+			metrics.Open = count.Total
+		case model.SupportStatusAssigned:
+			metrics.Assigned = count.Total
+		case model.SupportStatusResolved:
+			metrics.Resolved = count.Total
+		}
+	}
+	return metrics, nil
+}
+
+// updateSupport applies a staff action to one of the merchant's conversations and tells the
+// staff about the new state.
+func (s *ConversationService) updateSupport(
+	ctx context.Context,
+	userID, conversationID int64,
+	apply func(conversation model.Conversation, staff []int64) (map[string]interface{}, error),
+) (*ConversationSummary, error) {
+	var staff []int64
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		merchant, err := staffMerchant(tx, userID)
+		if err != nil {
+			return err
+		}
+		var conversation model.Conversation
+		if err := tx.Where("id = ? AND type = ? AND merchant_id = ?", conversationID, model.ConversationTypeMerchant, merchant.ID).
+			First(&conversation).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrConversationNotFound
+			}
+			return err
+		}
+		if staff, err = merchantStaffIDs(tx, merchant.ID); err != nil {
+			return err
+		}
+		updates, err := apply(conversation, staff)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&conversation).Updates(updates).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	summary, err := s.summaryFor(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, staff, realtime.Event{
+		Type:           realtime.EventConversationUpdated,
+		ConversationID: conversationID,
+		Data:           summary,
+	})
+	return summary, nil
+}
+
+// summaryFor builds userID's summary of one conversation they can access.
+func (s *ConversationService) summaryFor(ctx context.Context, userID, conversationID int64) (*ConversationSummary, error) {
+	membership, err := s.membershipForUser(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	var conversation model.Conversation
+	if err := s.db.WithContext(ctx).
+		Preload("Participants.User.Profile").
+		First(&conversation, conversationID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, err
+	}
+	summary, err := s.buildConversationSummary(ctx, userID, conversation, membership)
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// joinAsStaff adds a merchant's staff member to one of its support conversations the first
+// time they open it, so staff who joined the merchant later can still answer.
+func (s *ConversationService) joinAsStaff(ctx context.Context, userID, conversationID int64) (model.ConversationParticipant, error) {
+	db := s.db.WithContext(ctx)
+	var conversation model.Conversation
+	if err := db.Where("id = ? AND type = ?", conversationID, model.ConversationTypeMerchant).First(&conversation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ConversationParticipant{}, ErrConversationForbidden
+		}
+		return model.ConversationParticipant{}, err
+	}
+	staff, err := merchantStaffIDs(db, *conversation.MerchantID)
+	if err != nil {
+		return model.ConversationParticipant{}, err
+	}
+	if !slices.Contains(staff, userID) {
+		return model.ConversationParticipant{}, ErrConversationForbidden
+	}
+	membership := model.ConversationParticipant{
+		ConversationID: conversationID,
+		UserID:         userID,
+		Role:           roleMerchantStaff,
+		JoinedAt:       time.Now().UTC(),
+	}
+	if err := db.Create(&membership).Error; err != nil {
+		return model.ConversationParticipant{}, err
+	}
+	return membership, nil
+}
+
+// recordSupportMessage keeps a merchant conversation's support state in step with a new
+// message: the first visible staff reply sets the response time, a customer reply reopens it.
+// Only merchant conversations have customer and staff members, so the sender's role settles
+// whether there is anything to do before the conversation is loaded.
+func recordSupportMessage(tx *gorm.DB, sender model.ConversationParticipant, message model.Message) error {
+	if sender.Role != roleCustomer && sender.Role != roleMerchantStaff {
+		return nil
+	}
+	if sender.Role == roleMerchantStaff && message.Status != model.ContentStatusVisible {
+		return nil
+	}
+	var conversation model.Conversation
+	if err := tx.First(&conversation, message.ConversationID).Error; err != nil {
+		return err
+	}
+	if conversation.Type != model.ConversationTypeMerchant || conversation.MerchantID == nil {
+		return nil
+	}
+
+	if sender.Role == roleCustomer {
+		if conversation.SupportStatus != model.SupportStatusResolved {
+			return nil
+		}
+		return tx.Model(&conversation).Updates(map[string]interface{}{
+			"support_status": reopenedStatus(conversation),
+			"resolved_at":    nil,
+		}).Error
+	}
+	if conversation.FirstResponseAt != nil {
+		return nil
+	}
+	result := tx.Model(&model.Conversation{}).
+		Where("id = ? AND first_response_at IS NULL", conversation.ID).
+		Update("first_response_at", message.CreatedAt)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+	// Response times count towards the day the conversation started, so the response rate
+	// compares like with like.
+	return recordSupportAnalytics(tx, conversation.MerchantID, conversation.StoreID, conversation.CreatedAt, map[string]interface{}{
+		"conversations_responded": gorm.Expr("conversations_responded + 1"),
+		"response_time_seconds":   gorm.Expr("response_time_seconds + ?", responseSeconds(conversation, message.CreatedAt)),
+	})
+}
+
+// retractFirstResponse is called once moderation takes a visible message away. When it was
+// its conversation's first response, the earliest remaining visible staff message takes its
+// place in first_response_at and in the merchant's response analytics.
+func retractFirstResponse(tx *gorm.DB, message model.Message) error {
+	var conversation model.Conversation
+	if err := tx.First(&conversation, message.ConversationID).Error; err != nil {
+		return err
+	}
+	if conversation.Type != model.ConversationTypeMerchant || conversation.MerchantID == nil ||
+		conversation.FirstResponseAt == nil || !conversation.FirstResponseAt.Equal(message.CreatedAt) {
+		return nil
+	}
+	var staff model.ConversationParticipant
+	result := tx.Where("conversation_id = ? AND user_id = ? AND role = ?", conversation.ID, message.SenderID, roleMerchantStaff).
+		Limit(1).Find(&staff)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	var next []model.Message
+	if err := tx.Joins("JOIN conversation_participants ON conversation_participants.conversation_id = messages.conversation_id "+
+		"AND conversation_participants.user_id = messages.sender_id").
+		Where("messages.conversation_id = ? AND messages.id <> ? AND messages.status = ? AND conversation_participants.role = ?",
+			conversation.ID, message.ID, model.ContentStatusVisible, roleMerchantStaff).
+		Order("messages.created_at asc, messages.id asc").
+		Limit(1).
+		Find(&next).Error; err != nil {
+		return err
+	}
+	retracted := responseSeconds(conversation, *conversation.FirstResponseAt)
+	var firstResponseAt *time.Time
+	updates := map[string]interface{}{
+		"conversations_responded": gorm.Expr("conversations_responded - 1"),
+		"response_time_seconds":   gorm.Expr("response_time_seconds - ?", retracted),
+	}
+	if len(next) > 0 {
+		firstResponseAt = &next[0].CreatedAt
+		updates = map[string]interface{}{
+			"response_time_seconds": gorm.Expr("response_time_seconds + ?", responseSeconds(conversation, next[0].CreatedAt)-retracted),
+		}
+	}
+	if err := tx.Model(&conversation).Update("first_response_at", firstResponseAt).Error; err != nil {
+		return err
+	}
+	return recordSupportAnalytics(tx, conversation.MerchantID, conversation.StoreID, conversation.CreatedAt, updates)
+}
+
+func responseSeconds(conversation model.Conversation, respondedAt time.Time) int64 {
+	return int64(max(respondedAt.Sub(conversation.CreatedAt), 0) / time.Second)
+}
+
+// recordSupportAnalytics adds to the merchant's daily analytics row for the store and day,
+// creating the row on first use.
+func recordSupportAnalytics(tx *gorm.DB, merchantID, storeID *int64, at time.Time, updates map[string]interface{}) error {
+	day := analyticsDay(at)
+	q := tx.Where("merchant_id = ? AND date = ?", *merchantID, day)
+	if storeID == nil {
+		q = q.Where("store_id IS NULL")
+	} else {
+		q = q.Where("store_id = ?", *storeID)
+	}
+	var row model.MerchantAnalytics
+	if err := q.Attrs(model.MerchantAnalytics{MerchantID: *merchantID, StoreID: storeID, Date: day}).
+		FirstOrCreate(&row).Error; err != nil {
+		return err
+	}
+	return tx.Model(&row).UpdateColumns(updates).Error
+}
+
+func analyticsDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func reopenedStatus(conversation model.Conversation) string {
+	if conversation.AssigneeID != nil {
+		return model.SupportStatusAssigned
+	}
+	return model.SupportStatusOpen
+}
+
+// resolveSupportTarget finds the merchant, and the store when one is given, that a customer
+// wants to contact.
+func resolveSupportTarget(tx *gorm.DB, input StartMerchantConversationInput) (model.Merchant, *model.Store, error) {
+	var store *model.Store
+	var merchantID int64
+	switch {
+	case input.StoreID != nil:
+		var found model.Store
+		if err := tx.Where("id = ? AND status = ?", *input.StoreID, storeservice.StoreStatusPublished).First(&found).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.Merchant{}, nil, ErrStoreNotFound
+			}
+			return model.Merchant{}, nil, err
+		}
+		if input.MerchantID != nil && *input.MerchantID != found.MerchantID {
+			return model.Merchant{}, nil, ErrConversationInvalidInput
+		}
+		store = &found
+		merchantID = found.MerchantID
+	case input.MerchantID != nil:
+		merchantID = *input.MerchantID
+	default:
+		return model.Merchant{}, nil, ErrConversationInvalidInput
+	}
+
+	var merchant model.Merchant
+	if err := tx.First(&merchant, merchantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Merchant{}, nil, ErrMerchantNotFound
+		}
+		return model.Merchant{}, nil, err
+	}
+	return merchant, store, nil
+}
+
+// checkSupportContext makes sure a linked order or voucher is the customer's own and was
+// bought from the merchant.
+func checkSupportContext(tx *gorm.DB, userID, merchantID int64, input StartMerchantConversationInput) error {
+	if input.OrderID != nil {
+		var count int64
+		if err := tx.Model(&model.Order{}).
+			Where("id = ? AND user_id = ? AND merchant_id = ?", *input.OrderID, userID, merchantID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrSupportContextInvalid
+		}
+	}
+	if input.VoucherID != nil {
+		var count int64
+		if err := tx.Model(&model.Voucher{}).
+			Where("id = ? AND user_id = ? AND merchant_id = ?", *input.VoucherID, userID, merchantID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrSupportContextInvalid
+		}
+	}
+	return nil
+}
+
+// merchantStaffIDs returns the users who answer for a merchant. Merchants have a single
+// account today; this is where additional staff accounts would be added.
+func merchantStaffIDs(db *gorm.DB, merchantID int64) ([]int64, error) {
+	var ids []int64
+	if err := db.Model(&model.Merchant{}).
+		Where("id = ? AND user_id IS NOT NULL", merchantID).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// staffMerchant returns the merchant userID works for.
+func staffMerchant(db *gorm.DB, userID int64) (model.Merchant, error) {
+	var merchant model.Merchant
+	if err := db.Where("user_id = ?", userID).First(&merchant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Merchant{}, ErrNotMerchantStaff
+		}
+		return model.Merchant{}, err
+	}
+	return merchant, nil
+}
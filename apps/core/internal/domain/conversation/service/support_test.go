@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type supportFixture struct {
+	db       *gorm.DB
+	svc      *ConversationService
+	customer model.User
+	owner    model.User
+	merchant model.Merchant
+	store    model.Store
+}
+
+func setupSupportFixture(t *testing.T) supportFixture {
+	t.Helper()
+	db := testutil.SetupTestDB(t)
+	f := supportFixture{db: db, svc: NewConversationService(db, nil, nil)}
+	f.customer = model.User{Role: "user"}
+	f.owner = model.User{Role: "merchant"}
+	for _, user := range []*model.User{&f.customer, &f.owner} {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+	if err := db.Create(&model.UserProfile{UserID: f.customer.ID, Nickname: "Sarah"}).Error; err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	f.merchant = model.Merchant{Name: "Cafe", UserID: &f.owner.ID, LogoURL: "https://cdn.example.com/logo.png"}
+	if err := db.Create(&f.merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	f.store = model.Store{MerchantID: f.merchant.ID, Name: "Downtown", Status: storeservice.StoreStatusPublished}
+	if err := db.Create(&f.store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	return f
+}
+
+func TestMerchantConversationLifecycle(t *testing.T) {
+	f := setupSupportFixture(t)
+	ctx := context.Background()
+	order := model.Order{UserID: f.customer.ID, MerchantID: &f.merchant.ID}
+	if err := f.db.Create(&order).Error; err != nil {
+		t.Fatalf("failed to create order: %v", err)
+	}
+
+	started, created, err := f.svc.StartMerchantConversation(ctx, f.customer.ID, StartMerchantConversationInput{StoreID: &f.store.ID, OrderID: &order.ID})
+	if err != nil || !created {
+		t.Fatalf("expected a new conversation, got created=%v err=%v", created, err)
+	}
+	if started.Title != "Downtown" || started.AvatarURL != f.merchant.LogoURL || started.SupportStatus != model.SupportStatusOpen || *started.OrderID != order.ID {
+		t.Fatalf("unexpected customer summary: %+v", started)
+	}
+	again, created, err := f.svc.StartMerchantConversation(ctx, f.customer.ID, StartMerchantConversationInput{StoreID: &f.store.ID})
+	if err != nil || created || again.ID != started.ID {
+		t.Fatalf("expected the open conversation to be reused, got %+v created=%v err=%v", again, created, err)
+	}
+
+	if _, err := f.svc.SendMessage(ctx, f.customer.ID, started.ID, SendMessageInput{Content: "Is my order ready?"}); err != nil {
+		t.Fatalf("customer message failed: %v", err)
+	}
+	inbox, _, err := f.svc.MerchantInbox(ctx, f.owner.ID, MerchantInboxQuery{StoreID: &f.store.ID, Status: model.SupportStatusOpen})
+	if err != nil || len(inbox) != 1 || inbox[0].Title != "Sarah" || inbox[0].UnreadCount != 1 {
+		t.Fatalf("expected the conversation in the open inbox, got %+v (%v)", inbox, err)
+	}
+
+	f.db.Model(&model.Conversation{}).Where("id = ?", started.ID).Update("created_at", time.Now().Add(-10*time.Minute))
+	if _, err := f.svc.SendMessage(ctx, f.owner.ID, started.ID, SendMessageInput{Content: "Yes, come by."}); err != nil {
+		t.Fatalf("staff reply failed: %v", err)
+	}
+	if _, err := f.svc.SendMessage(ctx, f.owner.ID, started.ID, SendMessageInput{Content: "See you soon."}); err != nil {
+		t.Fatalf("staff reply failed: %v", err)
+	}
+
+	assigned, err := f.svc.AssignConversation(ctx, f.owner.ID, started.ID, AssignConversationInput{})
+	if err != nil || assigned.SupportStatus != model.SupportStatusAssigned || *assigned.AssigneeID != f.owner.ID {
+		t.Fatalf("expected the conversation to be assigned, got %+v (%v)", assigned, err)
+	}
+	if _, err := f.svc.AssignConversation(ctx, f.owner.ID, started.ID, AssignConversationInput{AssigneeID: &f.customer.ID}); !errors.Is(err, ErrConversationInvalidInput) {
+		t.Fatalf("expected assigning a non-staff user to fail, got %v", err)
+	}
+	resolved, err := f.svc.ResolveConversation(ctx, f.owner.ID, started.ID)
+	if err != nil || resolved.SupportStatus != model.SupportStatusResolved {
+		t.Fatalf("expected the conversation to be resolved, got %+v (%v)", resolved, err)
+	}
+	if _, err := f.svc.SendMessage(ctx, f.customer.ID, started.ID, SendMessageInput{Content: "One more thing"}); err != nil {
+		t.Fatalf("customer message failed: %v", err)
+	}
+	var conversation model.Conversation
+	f.db.First(&conversation, started.ID)
+	if conversation.SupportStatus != model.SupportStatusAssigned || conversation.ResolvedAt != nil {
+		t.Fatalf("expected a customer message to reopen the conversation, got %+v", conversation)
+	}
+
+	metrics, err := f.svc.SupportMetrics(ctx, f.owner.ID, nil, 7)
+	if err != nil {
+		t.Fatalf("metrics failed: %v", err)
+	}
+	if metrics.ConversationsStarted != 1 || metrics.ConversationsResponded != 1 || metrics.ResponseRate != 1 || metrics.Assigned != 1 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+	if metrics.AvgFirstResponseSeconds < 9*60 || metrics.AvgFirstResponseSeconds > 11*60 {
+		t.Fatalf("expected a first response after about ten minutes, got %v seconds", metrics.AvgFirstResponseSeconds)
+	}
+}
+
+func TestHeldStaffReplyIsNotAFirstResponse(t *testing.T) {
+	f := setupSupportFixture(t)
+	ctx := context.Background()
+	f.svc = NewConversationService(f.db, moderationservice.NewModerationService(f.db, moderationservice.ModeSync, false,
+		moderationservice.NewRulesEngine(config.ModerationConfig{
+			BlockedTerms: map[string][]string{"default": {"idiot"}},
+		}),
+	), nil)
+
+	started, _, err := f.svc.StartMerchantConversation(ctx, f.customer.ID, StartMerchantConversationInput{StoreID: &f.store.ID})
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if _, err := f.svc.SendMessage(ctx, f.owner.ID, started.ID, SendMessageInput{Content: "Don't be an idiot"}); err != nil {
+		t.Fatalf("staff reply failed: %v", err)
+	}
+	var conversation model.Conversation
+	f.db.First(&conversation, started.ID)
+	if conversation.FirstResponseAt != nil {
+		t.Fatalf("expected a held reply not to count as the first response, got %v", conversation.FirstResponseAt)
+	}
+
+	reply, err := f.svc.SendMessage(ctx, f.owner.ID, started.ID, SendMessageInput{Content: "Sorry, how can we help?"})
+	if err != nil {
+		t.Fatalf("staff reply failed: %v", err)
+	}
+	f.db.First(&conversation, started.ID)
+	if conversation.FirstResponseAt == nil || !conversation.FirstResponseAt.Equal(reply.CreatedAt) {
+		t.Fatalf("expected the visible reply to be the first response, got %v", conversation.FirstResponseAt)
+	}
+}
+
+func TestStartMerchantConversationValidatesContext(t *testing.T) {
+	f := setupSupportFixture(t)
+	ctx := context.Background()
+	stranger := model.User{Role: "user"}
+	if err := f.db.Create(&stranger).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	othersOrder := model.Order{UserID: stranger.ID, MerchantID: &f.merchant.ID}
+	if err := f.db.Create(&othersOrder).Error; err != nil {
+		t.Fatalf("failed to create order: %v", err)
+	}
+	missing := int64(999)
+	unclaimed := model.Merchant{Name: "Unclaimed"}
+	if err := f.db.Create(&unclaimed).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		user  int64
+		input StartMerchantConversationInput
+		want  error
+	}{
+		{"no target", f.customer.ID, StartMerchantConversationInput{}, ErrConversationInvalidInput},
+		{"unknown merchant", f.customer.ID, StartMerchantConversationInput{MerchantID: &missing}, ErrMerchantNotFound},
+		{"unknown store", f.customer.ID, StartMerchantConversationInput{StoreID: &missing}, ErrStoreNotFound},
+		{"someone else's order", f.customer.ID, StartMerchantConversationInput{MerchantID: &f.merchant.ID, OrderID: &othersOrder.ID}, ErrSupportContextInvalid},
+		{"own merchant", f.owner.ID, StartMerchantConversationInput{MerchantID: &f.merchant.ID}, ErrConversationInvalidInput},
+		{"merchant without staff", f.customer.ID, StartMerchantConversationInput{MerchantID: &unclaimed.ID}, ErrMerchantUnstaffed},
+	}
+	for _, tc := range tests {
+		if _, _, err := f.svc.StartMerchantConversation(ctx, tc.user, tc.input); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	if _, _, err := f.svc.MerchantInbox(ctx, f.customer.ID, MerchantInboxQuery{}); !errors.Is(err, ErrNotMerchantStaff) {
+		t.Fatalf("expected ErrNotMerchantStaff for a customer, got %v", err)
+	}
+	if _, err := f.svc.Create(ctx, f.customer.ID, CreateConversationInput{Title: "x", Type: model.ConversationTypeMerchant}); !errors.Is(err, ErrConversationInvalidInput) {
+		t.Fatalf("expected generic creation of a merchant conversation to fail, got %v", err)
+	}
+}
+
+func TestModeratedFirstResponseHandsOverToTheNextReply(t *testing.T) {
+	f := setupSupportFixture(t)
+	ctx := context.Background()
+	started, _, err := f.svc.StartMerchantConversation(ctx, f.customer.ID, StartMerchantConversationInput{StoreID: &f.store.ID})
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	first, err := f.svc.SendMessage(ctx, f.owner.ID, started.ID, SendMessageInput{Content: "Hello"})
+	if err != nil {
+		t.Fatalf("staff reply failed: %v", err)
+	}
+	second, err := f.svc.SendMessage(ctx, f.owner.ID, started.ID, SendMessageInput{Content: "How can we help?"})
+	if err != nil {
+		t.Fatalf("staff reply failed: %v", err)
+	}
+
+	hide := func(messageID int64) {
+		t.Helper()
+		if err := f.db.Transaction(func(tx *gorm.DB) error {
+			return f.svc.applyModerationStatus(ctx, tx, messageID, model.ContentStatusHidden)
+		}); err != nil {
+			t.Fatalf("apply failed: %v", err)
+		}
+	}
+	var conversation model.Conversation
+	var analytics model.MerchantAnalytics
+	hide(first.ID)
+	f.db.First(&conversation, started.ID)
+	f.db.Where("merchant_id = ?", f.merchant.ID).First(&analytics)
+	if conversation.FirstResponseAt == nil || !conversation.FirstResponseAt.Equal(second.CreatedAt) || analytics.ConversationsResponded != 1 {
+		t.Fatalf("expected the next reply to become the first response, got %v with %d responded", conversation.FirstResponseAt, analytics.ConversationsResponded)
+	}
+
+	hide(second.ID)
+	conversation = model.Conversation{}
+	f.db.First(&conversation, started.ID)
+	f.db.Where("merchant_id = ?", f.merchant.ID).First(&analytics)
+	if conversation.FirstResponseAt != nil || analytics.ConversationsResponded != 0 || analytics.ResponseTimeSeconds != 0 {
+		t.Fatalf("expected no first response left, got %v with %+v", conversation.FirstResponseAt, analytics)
+	}
+}
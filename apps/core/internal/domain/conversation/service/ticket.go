@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/token"
+	"gorm.io/gorm"
+)
+
+// SocketTicketTTL is how long a WebSocket ticket can be redeemed after it was issued.
+const SocketTicketTTL = 30 * time.Second
+
+var ErrInvalidSocketTicket = errors.New("invalid or expired socket ticket")
+
+// IssueSocketTicket creates a single-use ticket that opens the WebSocket as userID. The raw
+// ticket is returned once; only its hash is stored. The user's expired tickets are purged.
+func (s *ConversationService) IssueSocketTicket(ctx context.Context, userID int64) (string, time.Time, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, err
+	}
+	ticket := hex.EncodeToString(raw)
+	now := time.Now().UTC()
+	expiresAt := now.Add(SocketTicketTTL)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND expires_at <= ?", userID, now).Delete(&model.SocketTicket{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.SocketTicket{
+			UserID:    userID,
+			TokenHash: token.HashToken(ticket),
+			ExpiresAt: expiresAt,
+		}).Error
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return ticket, expiresAt, nil
+}
+
+// RedeemSocketTicket consumes a ticket and returns the user it was issued to. Deleting the
+// row is what claims it, so a ticket opens at most one socket even under concurrent use.
+func (s *ConversationService) RedeemSocketTicket(ctx context.Context, ticket string) (int64, error) {
+	if ticket == "" {
+		return 0, ErrInvalidSocketTicket
+	}
+	db := s.db.WithContext(ctx)
+	var stored model.SocketTicket
+	result := db.Where("token_hash = ?", token.HashToken(ticket)).Limit(1).Find(&stored)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, ErrInvalidSocketTicket
+	}
+	claimed := db.Where("id = ?", stored.ID).Delete(&model.SocketTicket{})
+	if claimed.Error != nil {
+		return 0, claimed.Error
+	}
+	if claimed.RowsAffected == 0 || !stored.ExpiresAt.After(time.Now().UTC()) {
+		return 0, ErrInvalidSocketTicket
+	}
+	return stored.UserID, nil
+}
@@ -1,8 +1,56 @@
 package dto
 
+import "time"
+
+// Feed item types.
+const (
+	FeedItemReview    = "review"
+	FeedItemPost      = "post"
+	FeedItemPromotion = "promotion"
+	FeedItemStore     = "store"
+)
+
+// Reasons an item was picked for the caller's feed.
+const (
+	ReasonFollowing        = "following"
+	ReasonFollowedMerchant = "followed_merchant"
+	ReasonPromotion        = "promotion"
+	ReasonTrending         = "trending"
+	ReasonPopular          = "popular"
+)
+
+// FeedItem is one entry in the home feed. Type decides which optional fields are set:
+// reviews carry a rating, stores their average rating, and promotions their end date.
 type FeedItem struct {
-	ID    string `json:"id"`
-	Type  string `json:"type"`
-	Title string `json:"title"`
-	Image string `json:"image"`
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Image        string     `json:"image"`
+	Text         string     `json:"text,omitempty"`
+	Reason       string     `json:"reason"`
+	AuthorID     string     `json:"authorId,omitempty"`
+	AuthorName   string     `json:"authorName,omitempty"`
+	AuthorAvatar string     `json:"authorAvatar,omitempty"`
+	MerchantID   string     `json:"merchantId,omitempty"`
+	StoreID      string     `json:"storeId,omitempty"`
+	Rating       float32    `json:"rating,omitempty"`
+	LikeCount    int        `json:"likeCount"`
+	CommentCount int        `json:"commentCount"`
+	EndsAt       *time.Time `json:"endsAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// HomeFeedQuery controls the home feed. Lat/Lng localise trending stores; Cursor is the
+// opaque value returned with the previous page.
+type HomeFeedQuery struct {
+	Lat    *float64
+	Lng    *float64
+	Cursor string
+	Limit  *int
+}
+
+// HomeFeedResponse is a page of the home feed.
+type HomeFeedResponse struct {
+	Data   []FeedItem `json:"data"`
+	Cursor *string    `json:"cursor"`
 }
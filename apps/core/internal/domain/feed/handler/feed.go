@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/service"
 	"github.com/gin-gonic/gin"
 )
@@ -13,24 +17,74 @@ type FeedHandler struct {
 
 func NewFeedHandler(svc *service.FeedService) *FeedHandler {
 	if svc == nil {
-		svc = service.NewFeedService(nil)
+		svc = service.NewFeedService(nil, nil, config.FeedConfig{})
 	}
 	return &FeedHandler{svc: svc}
 }
 
 // HomeFeed godoc
 // @Summary Get home feed
-// @Description Returns the home feed items
+// @Description Returns a ranked mix of reviews and posts from followed users and merchants, active promotions and trending stores. Anonymous callers get promotions, trending stores and popular content. Content by private accounts only appears for their followers. Pages of one session keep their order while engagement changes; a cursor expires after 30 minutes, after which the feed starts again without one.
 // @Tags feed
 // @Produce json
-// @Success 200 {object} map[string]interface{}
+// @Param lat query number false "Latitude used to find trending stores nearby"
+// @Param lng query number false "Longitude used to find trending stores nearby"
+// @Param cursor query string false "Cursor returned with the previous page"
+// @Param limit query int false "Page size (max 50)"
+// @Success 200 {object} dto.HomeFeedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth
 // @Router /feed/home [get]
 func (h *FeedHandler) Home(c *gin.Context) {
-	items, err := h.svc.Home(c.Request.Context())
+	query, err := parseHomeFeedQuery(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID := c.GetInt64("user_id")
+	resp, err := h.svc.Home(c.Request.Context(), userID, query)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": items})
+	c.JSON(http.StatusOK, resp)
+}
+
+func parseHomeFeedQuery(c *gin.Context) (dto.HomeFeedQuery, error) {
+	query := dto.HomeFeedQuery{Cursor: c.Query("cursor")}
+	lat, err := parseFloat64Query(c, "lat")
+	if err != nil {
+		return dto.HomeFeedQuery{}, err
+	}
+	lng, err := parseFloat64Query(c, "lng")
+	if err != nil {
+		return dto.HomeFeedQuery{}, err
+	}
+	query.Lat, query.Lng = lat, lng
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return dto.HomeFeedQuery{}, errors.New("invalid limit")
+		}
+		query.Limit = &limit
+	}
+	return query, nil
+}
+
+func parseFloat64Query(c *gin.Context, key string) (*float64, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, errors.New("invalid " + key)
+	}
+	return &parsed, nil
 }
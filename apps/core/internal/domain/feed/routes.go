@@ -4,16 +4,17 @@ import (
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/handler"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
 // RegisterRoutes registers feed routes.
-func RegisterRoutes(r *gin.RouterGroup, _ *config.Config) {
-	svc := service.NewFeedService(nil)
+func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
+	svc := service.NewFeedService(nil, service.RankerByName(cfg.Feed.Ranker), cfg.Feed)
 	h := handler.NewFeedHandler(svc)
 
 	feed := r.Group("/feed")
 	{
-		feed.GET("/home", h.Home)
+		feed.GET("/home", middleware.OptionalJWTAuth(cfg.JWT), h.Home)
 	}
 }
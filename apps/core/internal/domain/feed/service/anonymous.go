@@ -0,0 +1,86 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/dto"
+	"gorm.io/gorm"
+)
+
+const (
+	// anonymousFeedStep is how often a new anonymous feed starts. Anonymous feeds are not
+	// personalised, so every caller opening the feed within the same step shares its windows.
+	anonymousFeedStep = time.Minute
+	// maxAnonymousWindows bounds the cache; windows past it are ranked but not kept.
+	maxAnonymousWindows = 1024
+)
+
+// anonymousWindowKey identifies a ranked anonymous window: the step the feed started in,
+// how many windows back it is, and the location trending stores were picked for.
+type anonymousWindowKey struct {
+	start    int64
+	window   int
+	located  bool
+	lat, lng float64
+}
+
+type cachedWindow struct {
+	snapshot  feedSnapshot
+	expiresAt time.Time
+}
+
+// anonymousWindows caches ranked anonymous windows in process for feedSnapshotTTL, in place
+// of the FeedSnapshot rows signed-in viewers page through.
+type anonymousWindows struct {
+	mu      sync.Mutex
+	windows map[anonymousWindowKey]cachedWindow
+}
+
+// anonymousWindow returns window number window of the anonymous feed that started at
+// start: the one ending window lookbacks before start. A window missing from the cache is
+// ranked again, which is why the feed start is rounded to anonymousFeedStep.
+func (s *FeedService) anonymousWindow(db *gorm.DB, query dto.HomeFeedQuery, start time.Time, window int) (feedSnapshot, error) {
+	key := anonymousWindowKey{start: start.Unix(), window: window}
+	if query.Lat != nil && query.Lng != nil {
+		key.located, key.lat, key.lng = true, *query.Lat, *query.Lng
+	}
+	now := time.Now()
+	if snapshot, ok := s.anonymous.get(key, now); ok {
+		return snapshot, nil
+	}
+
+	asOf := start.AddDate(0, 0, -window*s.cfg.LookbackDays)
+	snapshot, err := s.buildSnapshot(db, 0, query, asOf, window == 0)
+	if err != nil {
+		return feedSnapshot{}, err
+	}
+	snapshot.start, snapshot.window = start, window
+	s.anonymous.put(key, snapshot, now)
+	return snapshot, nil
+}
+
+func (c *anonymousWindows) get(key anonymousWindowKey, now time.Time) (feedSnapshot, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.windows[key]
+	if !ok || !now.Before(cached.expiresAt) {
+		return feedSnapshot{}, false
+	}
+	return cached.snapshot, true
+}
+
+// put caches snapshot, clearing out expired windows on the way.
+func (c *anonymousWindows) put(key anonymousWindowKey, snapshot feedSnapshot, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, cached := range c.windows {
+		if !now.Before(cached.expiresAt) {
+			delete(c.windows, k)
+		}
+	}
+	if len(c.windows) >= maxAnonymousWindows {
+		return
+	}
+	c.windows[key] = cachedWindow{snapshot: snapshot, expiresAt: now.Add(feedSnapshotTTL)}
+}
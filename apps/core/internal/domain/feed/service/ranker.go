@@ -0,0 +1,74 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/dto"
+)
+
+// Candidate is a feed item waiting to be ranked. Engagement is likes plus comments for
+// reviews and posts, clicks for promotions and recent reviews for stores.
+type Candidate struct {
+	Item       dto.FeedItem
+	Engagement int
+}
+
+// Ranker scores feed candidates; the feed is ordered by descending score. Each window is
+// scored once, at asOf, and its order is kept for every page of it.
+type Ranker interface {
+	Score(c Candidate, asOf time.Time) float64
+}
+
+// RankerByName returns the ranker configured as feed.ranker, defaulting to WeightedRanker.
+func RankerByName(name string) Ranker {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "chronological":
+		return ChronologicalRanker{}
+	default:
+		return NewWeightedRanker()
+	}
+}
+
+// WeightedRanker multiplies a per-reason weight by a log engagement boost and halves the
+// score every HalfLife of age. Reasons missing from ReasonWeights weigh 1.
+type WeightedRanker struct {
+	HalfLife      time.Duration
+	ReasonWeights map[string]float64
+}
+
+// NewWeightedRanker favours content from people and merchants the caller follows over
+// promotions, trending stores and generally popular content.
+func NewWeightedRanker() WeightedRanker {
+	return WeightedRanker{
+		HalfLife: 24 * time.Hour,
+		ReasonWeights: map[string]float64{
+			dto.ReasonFollowing:        1.0,
+			dto.ReasonFollowedMerchant: 0.9,
+			dto.ReasonPromotion:        0.6,
+			dto.ReasonTrending:         0.6,
+			dto.ReasonPopular:          0.4,
+		},
+	}
+}
+
+func (r WeightedRanker) Score(c Candidate, asOf time.Time) float64 {
+	weight, ok := r.ReasonWeights[c.Item.Reason]
+	if !ok {
+		weight = 1
+	}
+	age := asOf.Sub(c.Item.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+	boost := 1 + math.Log1p(float64(max(c.Engagement, 0)))
+	return weight * boost * math.Exp2(-float64(age)/float64(r.HalfLife))
+}
+
+// ChronologicalRanker orders the feed newest first regardless of source or engagement.
+type ChronologicalRanker struct{}
+
+func (ChronologicalRanker) Score(c Candidate, _ time.Time) float64 {
+	return float64(c.Item.CreatedAt.UnixMilli())
+}
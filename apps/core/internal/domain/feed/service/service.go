@@ -2,23 +2,637 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/dto"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/geo"
 	"gorm.io/gorm"
 )
 
+const (
+	defaultFeedLimit    = 20
+	maxFeedLimit        = 50
+	defaultLookbackDays = 14
+	defaultRadiusKM     = 20.0
+	// candidatesPerSource caps how many rows each source contributes before ranking.
+	candidatesPerSource = 100
+	// feedSnapshotTTL is how long a feed session can keep paging through its snapshot.
+	feedSnapshotTTL     = 30 * time.Minute
+	marketingPostActive = "active"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 type FeedService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	ranker    Ranker
+	cfg       config.FeedConfig
+	anonymous *anonymousWindows
 }
 
-func NewFeedService(db *gorm.DB) *FeedService {
+// NewFeedService wires the feed. A nil ranker uses NewWeightedRanker.
+func NewFeedService(db *gorm.DB, ranker Ranker, cfg config.FeedConfig) *FeedService {
 	if db == nil {
 		db = database.DB
 	}
-	return &FeedService{db: db}
+	if ranker == nil {
+		ranker = NewWeightedRanker()
+	}
+	if cfg.LookbackDays <= 0 {
+		cfg.LookbackDays = defaultLookbackDays
+	}
+	if cfg.RadiusKM <= 0 {
+		cfg.RadiusKM = defaultRadiusKM
+	}
+	return &FeedService{
+		db:        db,
+		ranker:    ranker,
+		cfg:       cfg,
+		anonymous: &anonymousWindows{windows: make(map[anonymousWindowKey]cachedWindow)},
+	}
+}
+
+// feedCursor points into a frozen ranked feed: the snapshot a session is paging through, or
+// for anonymous feeds the start and window number of the cached window, and how many of its
+// items were already returned.
+type feedCursor struct {
+	Snapshot int64 `json:"id,omitempty"`
+	Start    int64 `json:"t,omitempty"`
+	Window   int   `json:"w,omitempty"`
+	Offset   int   `json:"o"`
+}
+
+// feedSnapshot is one ranked window of the feed: items created in (since, asOf]. It gets an
+// id once a cursor refers to it; anonymous windows are known by start and window instead.
+type feedSnapshot struct {
+	id     int64
+	items  []dto.FeedItem
+	since  time.Time
+	start  time.Time
+	window int
+}
+
+type rankedItem struct {
+	item  dto.FeedItem
+	key   string
+	score float64
+}
+
+// Home builds the caller's home feed from followed users and merchants, active promotions,
+// trending stores and popular content, ranked by the configured Ranker. A zero viewerID is
+// an anonymous caller, who gets the promotion, trending and popular sources only.
+//
+// The first page ranks one lookback window and freezes the result in a FeedSnapshot; later
+// pages read the snapshot, so likes or new content arriving in between never reorder, repeat
+// or skip items. Once a snapshot is paged through, the feed continues with the reviews and
+// posts of the window before it, and ends at a window with nothing in it. Anonymous feeds
+// are the same for everyone, so their windows are shared from an in-process cache instead
+// of stored, see anonymousWindow.
+func (s *FeedService) Home(ctx context.Context, viewerID int64, query dto.HomeFeedQuery) (dto.HomeFeedResponse, error) {
+	limit := defaultFeedLimit
+	if query.Limit != nil && *query.Limit > 0 {
+		limit = min(*query.Limit, maxFeedLimit)
+	}
+	db := s.db.WithContext(ctx)
+
+	var snapshot feedSnapshot
+	offset := 0
+	switch {
+	case query.Cursor == "" && viewerID == 0:
+		// Round up so everyone opening the feed within the step sees the same windows.
+		start := time.Now().Truncate(anonymousFeedStep).Add(anonymousFeedStep)
+		built, err := s.anonymousWindow(db, query, start, 0)
+		if err != nil {
+			return dto.HomeFeedResponse{}, err
+		}
+		snapshot = built
+	case query.Cursor == "":
+		built, err := s.buildSnapshot(db, viewerID, query, time.Now(), true)
+		if err != nil {
+			return dto.HomeFeedResponse{}, err
+		}
+		snapshot = built
+	default:
+		cursor, err := decodeFeedCursor(query.Cursor)
+		if err != nil {
+			return dto.HomeFeedResponse{}, err
+		}
+		var loaded feedSnapshot
+		if viewerID == 0 {
+			start := time.Unix(cursor.Start, 0)
+			if cursor.Start == 0 || !start.Equal(start.Truncate(anonymousFeedStep)) ||
+				time.Since(start) > feedSnapshotTTL || time.Until(start) > anonymousFeedStep {
+				return dto.HomeFeedResponse{}, ErrInvalidCursor
+			}
+			loaded, err = s.anonymousWindow(db, query, start, cursor.Window)
+		} else {
+			if cursor.Snapshot == 0 {
+				return dto.HomeFeedResponse{}, ErrInvalidCursor
+			}
+			loaded, err = loadSnapshot(db, viewerID, cursor.Snapshot)
+		}
+		if err != nil {
+			return dto.HomeFeedResponse{}, err
+		}
+		if cursor.Offset > len(loaded.items) {
+			return dto.HomeFeedResponse{}, ErrInvalidCursor
+		}
+		snapshot, offset = loaded, cursor.Offset
+	}
+
+	items := make([]dto.FeedItem, 0, limit)
+	var next *string
+	for {
+		n := min(limit-len(items), len(snapshot.items)-offset)
+		items = append(items, snapshot.items[offset:offset+n]...)
+		offset += n
+		if offset < len(snapshot.items) {
+			cursor := feedCursor{Offset: offset}
+			if viewerID == 0 {
+				cursor.Start, cursor.Window = snapshot.start.Unix(), snapshot.window
+			} else {
+				if err := saveSnapshot(db, viewerID, &snapshot); err != nil {
+					return dto.HomeFeedResponse{}, err
+				}
+				cursor.Snapshot = snapshot.id
+			}
+			encoded := encodeFeedCursor(cursor)
+			next = &encoded
+			break
+		}
+		var older feedSnapshot
+		var err error
+		if viewerID == 0 {
+			older, err = s.anonymousWindow(db, query, snapshot.start, snapshot.window+1)
+		} else {
+			older, err = s.buildSnapshot(db, viewerID, query, snapshot.since, false)
+		}
+		if err != nil {
+			return dto.HomeFeedResponse{}, err
+		}
+		if len(older.items) == 0 {
+			break
+		}
+		snapshot, offset = older, 0
+	}
+
+	if err := fillAuthors(db, items); err != nil {
+		return dto.HomeFeedResponse{}, err
+	}
+	return dto.HomeFeedResponse{Data: items, Cursor: next}, nil
+}
+
+// buildSnapshot ranks the window ending at asOf. Promotions and trending stores describe the
+// present, so only the first window has them; older windows hold reviews and posts.
+func (s *FeedService) buildSnapshot(db *gorm.DB, viewerID int64, query dto.HomeFeedQuery, asOf time.Time, first bool) (feedSnapshot, error) {
+	since := asOf.AddDate(0, 0, -s.cfg.LookbackDays)
+	var followedUsers, followedMerchants []int64
+	if viewerID != 0 {
+		if err := db.Model(&model.UserFollow{}).Where("follower_id = ?", viewerID).
+			Pluck("following_id", &followedUsers).Error; err != nil {
+			return feedSnapshot{}, err
+		}
+		if err := db.Model(&model.MerchantFollow{}).Where("user_id = ?", viewerID).
+			Pluck("merchant_id", &followedMerchants).Error; err != nil {
+			return feedSnapshot{}, err
+		}
+	}
+
+	// Followed content goes first because it may narrow the window, see followedContent.
+	candidates, since, err := followedContent(db, viewerID, followedUsers, followedMerchants, since, asOf)
+	if err != nil {
+		return feedSnapshot{}, err
+	}
+	sources := []func(*gorm.DB) ([]Candidate, error){
+		func(db *gorm.DB) ([]Candidate, error) {
+			return popularContent(db, viewerID, since, asOf)
+		},
+	}
+	if first {
+		sources = append(sources,
+			func(db *gorm.DB) ([]Candidate, error) {
+				return activePromotions(db, followedMerchants, asOf)
+			},
+			func(db *gorm.DB) ([]Candidate, error) {
+				return trendingStores(db, query.Lat, query.Lng, s.cfg.RadiusKM, since, asOf)
+			},
+		)
+	}
+	for _, source := range sources {
+		found, err := source(db)
+		if err != nil {
+			return feedSnapshot{}, err
+		}
+		candidates = append(candidates, found...)
+	}
+
+	ranked := s.rank(candidates, asOf)
+	snapshot := feedSnapshot{items: make([]dto.FeedItem, 0, len(ranked)), since: since}
+	for _, entry := range ranked {
+		snapshot.items = append(snapshot.items, entry.item)
+	}
+	return snapshot, nil
+}
+
+// rank scores candidates, keeps the best-scoring copy of items reached through more than
+// one source and sorts by descending score, breaking ties on the item key.
+func (s *FeedService) rank(candidates []Candidate, asOf time.Time) []rankedItem {
+	best := make(map[string]int, len(candidates))
+	ranked := make([]rankedItem, 0, len(candidates))
+	for _, candidate := range candidates {
+		entry := rankedItem{
+			item:  candidate.Item,
+			key:   candidate.Item.Type + ":" + candidate.Item.ID,
+			score: s.ranker.Score(candidate, asOf),
+		}
+		if i, ok := best[entry.key]; ok {
+			if entry.score > ranked[i].score {
+				ranked[i] = entry
+			}
+			continue
+		}
+		best[entry.key] = len(ranked)
+		ranked = append(ranked, entry)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].key > ranked[j].key
+	})
+	return ranked
+}
+
+// saveSnapshot stores snapshot for later pages unless it already is, clearing out expired
+// snapshots on the way.
+func saveSnapshot(db *gorm.DB, viewerID int64, snapshot *feedSnapshot) error {
+	if snapshot.id != 0 {
+		return nil
+	}
+	items, err := json.Marshal(snapshot.items)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := db.Where("expires_at <= ?", now).Delete(&model.FeedSnapshot{}).Error; err != nil {
+		return err
+	}
+	stored := model.FeedSnapshot{
+		UserID:    viewerID,
+		Items:     string(items),
+		Since:     snapshot.since,
+		ExpiresAt: now.Add(feedSnapshotTTL),
+	}
+	if err := db.Create(&stored).Error; err != nil {
+		return err
+	}
+	snapshot.id = stored.ID
+	return nil
+}
+
+// loadSnapshot returns the viewer's snapshot with the given id. Snapshots of other viewers
+// and expired ones read as an invalid cursor.
+func loadSnapshot(db *gorm.DB, viewerID, id int64) (feedSnapshot, error) {
+	var stored model.FeedSnapshot
+	result := db.Where("id = ? AND user_id = ? AND expires_at > ?", id, viewerID, time.Now()).Limit(1).Find(&stored)
+	if result.Error != nil {
+		return feedSnapshot{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return feedSnapshot{}, ErrInvalidCursor
+	}
+	snapshot := feedSnapshot{id: stored.ID, since: stored.Since}
+	if err := json.Unmarshal([]byte(stored.Items), &snapshot.items); err != nil {
+		return feedSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// followedContent returns reviews and posts by followed users or about followed merchants
+// created in (since, asOf], with the window start it actually covered. Each query stops at
+// candidatesPerSource rows, so when one fills up the window is narrowed to the rows it
+// returned and the rest is left for the next window instead of being skipped.
+func followedContent(db *gorm.DB, viewerID int64, users, merchants []int64, since, asOf time.Time) ([]Candidate, time.Time, error) {
+	if len(users) == 0 && len(merchants) == 0 {
+		return nil, since, nil
+	}
+	followedUser := make(map[int64]bool, len(users))
+	for _, id := range users {
+		followedUser[id] = true
+	}
+	reason := func(userID int64) string {
+		if followedUser[userID] {
+			return dto.ReasonFollowing
+		}
+		return dto.ReasonFollowedMerchant
+	}
+	match := db.Where("1 = 0")
+	if len(users) > 0 {
+		match = match.Or("user_id IN ?", users)
+	}
+	if len(merchants) > 0 {
+		match = match.Or("merchant_id IN ?", merchants)
+	}
+
+	var reviews []model.Review
+	if err := recentContent(db, viewerID, since, asOf).
+		Preload("Merchant").Preload("Store").
+		Where(match).
+		Order("created_at desc").
+		Limit(candidatesPerSource).
+		Find(&reviews).Error; err != nil {
+		return nil, since, err
+	}
+	var posts []model.Post
+	if err := recentContent(db, viewerID, since, asOf).
+		Where(match).
+		Order("created_at desc").
+		Limit(candidatesPerSource).
+		Find(&posts).Error; err != nil {
+		return nil, since, err
+	}
+	if len(reviews) == candidatesPerSource && reviews[len(reviews)-1].CreatedAt.After(since) {
+		since = reviews[len(reviews)-1].CreatedAt
+	}
+	if len(posts) == candidatesPerSource && posts[len(posts)-1].CreatedAt.After(since) {
+		since = posts[len(posts)-1].CreatedAt
+	}
+
+	candidates := make([]Candidate, 0, len(reviews)+len(posts))
+	for _, review := range reviews {
+		if review.CreatedAt.After(since) {
+			candidates = append(candidates, reviewCandidate(review, reason(review.UserID)))
+		}
+	}
+	for _, post := range posts {
+		if post.CreatedAt.After(since) {
+			candidates = append(candidates, postCandidate(post, reason(post.UserID)))
+		}
+	}
+	return candidates, since, nil
 }
 
-func (s *FeedService) Home(_ context.Context) ([]dto.FeedItem, error) {
-	return []dto.FeedItem{}, nil
+func popularContent(db *gorm.DB, viewerID int64, since, asOf time.Time) ([]Candidate, error) {
+	var reviews []model.Review
+	if err := recentContent(db, viewerID, since, asOf).
+		Preload("Merchant").Preload("Store").
+		Order("like_count + comment_count desc, id desc").
+		Limit(candidatesPerSource).
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	var posts []model.Post
+	if err := recentContent(db, viewerID, since, asOf).
+		Order("like_count + comment_count desc, id desc").
+		Limit(candidatesPerSource).
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Candidate, 0, len(reviews)+len(posts))
+	for _, review := range reviews {
+		candidates = append(candidates, reviewCandidate(review, dto.ReasonPopular))
+	}
+	for _, post := range posts {
+		candidates = append(candidates, postCandidate(post, dto.ReasonPopular))
+	}
+	return candidates, nil
+}
+
+// recentContent scopes reviews or posts to visible rows written inside the feed window by
+// someone other than the viewer whom the viewer has neither blocked nor muted, and whose
+// account is public or followed by the viewer. The author column is left unqualified so the
+// scope works on either table.
+func recentContent(db *gorm.DB, viewerID int64, since, asOf time.Time) *gorm.DB {
+	return db.Where("status = ? AND created_at > ? AND created_at <= ? AND user_id <> ?",
+		model.ContentStatusVisible, since, asOf, viewerID).
+		Scopes(followservice.ExcludeBlockedOrMuted(viewerID, "user_id"), followservice.ExcludePrivate(viewerID, "user_id"))
+}
+
+func activePromotions(db *gorm.DB, followedMerchants []int64, asOf time.Time) ([]Candidate, error) {
+	var promotions []model.MarketingPost
+	if err := db.Where("status = ? AND start_date <= ? AND end_date >= ?", marketingPostActive, asOf, asOf).
+		Order("start_date desc, id desc").
+		Limit(candidatesPerSource).
+		Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	followed := make(map[int64]bool, len(followedMerchants))
+	for _, id := range followedMerchants {
+		followed[id] = true
+	}
+
+	candidates := make([]Candidate, 0, len(promotions))
+	for _, promotion := range promotions {
+		reason := dto.ReasonPromotion
+		if followed[promotion.MerchantID] {
+			reason = dto.ReasonFollowedMerchant
+		}
+		endsAt := promotion.EndDate
+		item := dto.FeedItem{
+			ID:         fmt.Sprintf("%d", promotion.ID),
+			Type:       dto.FeedItemPromotion,
+			Title:      promotion.Title,
+			Image:      firstImage(promotion.Images),
+			Text:       promotion.Content,
+			Reason:     reason,
+			MerchantID: fmt.Sprintf("%d", promotion.MerchantID),
+			StoreID:    optionalID(promotion.StoreID),
+			EndsAt:     &endsAt,
+			CreatedAt:  promotion.StartDate,
+		}
+		candidates = append(candidates, Candidate{Item: item, Engagement: promotion.ClickCount})
+	}
+	return candidates, nil
+}
+
+// trendingStores picks published stores with the most visible reviews inside the feed
+// window, limited to radiusKM around lat/lng when both are given.
+func trendingStores(db *gorm.DB, lat, lng *float64, radiusKM float64, since, asOf time.Time) ([]Candidate, error) {
+	q := db.Table("reviews").
+		Select("reviews.store_id AS store_id, COUNT(*) AS recent, MAX(reviews.id) AS last_review_id").
+		Joins("JOIN stores ON stores.id = reviews.store_id").
+		Where("reviews.status = ? AND reviews.deleted_at IS NULL AND reviews.created_at > ? AND reviews.created_at <= ?",
+			model.ContentStatusVisible, since, asOf).
+		Where("stores.status = ? AND stores.deleted_at IS NULL", storeservice.StoreStatusPublished)
+	nearby := lat != nil && lng != nil
+	if nearby {
+		q = q.Scopes(geo.BoundingBox(*lat, *lng, radiusKM).Scope("stores.latitude", "stores.longitude"))
+	}
+	var rows []struct {
+		StoreID      int64
+		Recent       int
+		LastReviewID int64
+	}
+	if err := q.Group("reviews.store_id").
+		Order("recent desc, store_id desc").
+		Limit(candidatesPerSource).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	storeIDs := make([]int64, 0, len(rows))
+	reviewIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		storeIDs = append(storeIDs, row.StoreID)
+		reviewIDs = append(reviewIDs, row.LastReviewID)
+	}
+	var stores []model.Store
+	if err := db.Where("id IN ?", storeIDs).Find(&stores).Error; err != nil {
+		return nil, err
+	}
+	var latest []model.Review
+	if err := db.Select("id", "created_at").Where("id IN ?", reviewIDs).Find(&latest).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]model.Store, len(stores))
+	for _, store := range stores {
+		byID[store.ID] = store
+	}
+	lastActive := make(map[int64]time.Time, len(latest))
+	for _, review := range latest {
+		lastActive[review.ID] = review.CreatedAt
+	}
+
+	candidates := make([]Candidate, 0, len(rows))
+	for _, row := range rows {
+		store, ok := byID[row.StoreID]
+		if !ok {
+			continue
+		}
+		// The box also covers its corners; keep only stores really within the radius.
+		if nearby && geo.HaversineKM(*lat, *lng, store.Latitude, store.Longitude) > radiusKM {
+			continue
+		}
+		item := dto.FeedItem{
+			ID:         fmt.Sprintf("%d", store.ID),
+			Type:       dto.FeedItemStore,
+			Title:      store.Name,
+			Image:      store.CoverImageURL,
+			Text:       store.Address,
+			Reason:     dto.ReasonTrending,
+			MerchantID: fmt.Sprintf("%d", store.MerchantID),
+			StoreID:    fmt.Sprintf("%d", store.ID),
+			Rating:     store.BayesianRating,
+			CreatedAt:  lastActive[row.LastReviewID],
+		}
+		candidates = append(candidates, Candidate{Item: item, Engagement: row.Recent})
+	}
+	return candidates, nil
+}
+
+func reviewCandidate(review model.Review, reason string) Candidate {
+	title := ""
+	if review.Store != nil {
+		title = review.Store.Name
+	} else if review.Merchant != nil {
+		title = review.Merchant.Name
+		if review.Merchant.BusinessName != "" {
+			title = review.Merchant.BusinessName
+		}
+	}
+	item := dto.FeedItem{
+		ID:           fmt.Sprintf("%d", review.ID),
+		Type:         dto.FeedItemReview,
+		Title:        title,
+		Image:        firstImage(review.Images),
+		Text:         review.Content,
+		Reason:       reason,
+		AuthorID:     fmt.Sprintf("%d", review.UserID),
+		MerchantID:   fmt.Sprintf("%d", review.MerchantID),
+		StoreID:      optionalID(review.StoreID),
+		Rating:       review.Rating,
+		LikeCount:    review.LikeCount,
+		CommentCount: review.CommentCount,
+		CreatedAt:    review.CreatedAt,
+	}
+	return Candidate{Item: item, Engagement: review.LikeCount + review.CommentCount}
+}
+
+func postCandidate(post model.Post, reason string) Candidate {
+	item := dto.FeedItem{
+		ID:           fmt.Sprintf("%d", post.ID),
+		Type:         dto.FeedItemPost,
+		Title:        post.Title,
+		Image:        firstImage(post.Images),
+		Text:         post.Content,
+		Reason:       reason,
+		AuthorID:     fmt.Sprintf("%d", post.UserID),
+		MerchantID:   optionalID(post.MerchantID),
+		LikeCount:    post.LikeCount,
+		CommentCount: post.CommentCount,
+		CreatedAt:    post.CreatedAt,
+	}
+	return Candidate{Item: item, Engagement: post.LikeCount + post.CommentCount}
+}
+
+func fillAuthors(db *gorm.DB, items []dto.FeedItem) error {
+	var userIDs []int64
+	for _, item := range items {
+		if id, err := strconv.ParseInt(item.AuthorID, 10, 64); err == nil {
+			userIDs = append(userIDs, id)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	var profiles []model.UserProfile
+	if err := db.Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return err
+	}
+	byUser := make(map[string]model.UserProfile, len(profiles))
+	for _, profile := range profiles {
+		byUser[fmt.Sprintf("%d", profile.UserID)] = profile
+	}
+	for i := range items {
+		if profile, ok := byUser[items[i].AuthorID]; ok {
+			items[i].AuthorName = profile.Nickname
+			items[i].AuthorAvatar = profile.AvatarURL
+		}
+	}
+	return nil
+}
+
+func firstImage(images string) string {
+	var urls []string
+	if err := json.Unmarshal([]byte(images), &urls); err != nil || len(urls) == 0 {
+		return ""
+	}
+	return urls[0]
+}
+
+func optionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", *id)
+}
+
+func encodeFeedCursor(cursor feedCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeFeedCursor(value string) (feedCursor, error) {
+	var cursor feedCursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(raw, &cursor) != nil ||
+		cursor.Snapshot < 0 || cursor.Window < 0 || cursor.Offset < 0 {
+		return feedCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
 }
@@ -0,0 +1,437 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/feed/dto"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type feedFixture struct {
+	db       *gorm.DB
+	viewer   model.User
+	friend   model.User
+	stranger model.User
+	merchant model.Merchant
+	store    model.Store
+}
+
+func setupFeedFixture(t *testing.T) feedFixture {
+	t.Helper()
+	db := testutil.SetupTestDB(t)
+	f := feedFixture{db: db}
+	for _, user := range []*model.User{&f.viewer, &f.friend, &f.stranger} {
+		user.Role = "user"
+		if err := db.Create(user).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+	if err := db.Create(&model.UserProfile{UserID: f.friend.ID, Nickname: "friend"}).Error; err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	f.merchant = model.Merchant{Name: "Cafe"}
+	if err := db.Create(&f.merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	f.store = model.Store{MerchantID: f.merchant.ID, Name: "Downtown", Status: storeservice.StoreStatusPublished, Latitude: 40, Longitude: -74}
+	if err := db.Create(&f.store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	return f
+}
+
+func (f feedFixture) review(t *testing.T, userID int64, age time.Duration, likes int) model.Review {
+	t.Helper()
+	storeID := f.store.ID
+	review := model.Review{
+		UserID:     userID,
+		MerchantID: f.merchant.ID,
+		VenueID:    f.merchant.ID,
+		StoreID:    &storeID,
+		Rating:     4,
+		Content:    "nice",
+		LikeCount:  likes,
+		VisitDate:  time.Now(),
+		CreatedAt:  time.Now().Add(-age),
+	}
+	if err := f.db.Create(&review).Error; err != nil {
+		t.Fatalf("failed to create review: %v", err)
+	}
+	return review
+}
+
+func feedKeys(items []dto.FeedItem) map[string]dto.FeedItem {
+	keys := make(map[string]dto.FeedItem, len(items))
+	for _, item := range items {
+		keys[item.Type+":"+item.ID] = item
+	}
+	return keys
+}
+
+func TestHomeFeedMixesSourcesForFollower(t *testing.T) {
+	f := setupFeedFixture(t)
+	svc := NewFeedService(f.db, nil, config.FeedConfig{})
+	ctx := context.Background()
+
+	if err := f.db.Create(&model.UserFollow{FollowerID: f.viewer.ID, FollowingID: f.friend.ID}).Error; err != nil {
+		t.Fatalf("failed to follow user: %v", err)
+	}
+	friendReview := f.review(t, f.friend.ID, time.Hour, 0)
+	strangerReview := f.review(t, f.stranger.ID, 2*time.Hour, 3)
+	ownReview := f.review(t, f.viewer.ID, time.Minute, 10)
+	friendPost := model.Post{UserID: f.friend.ID, Title: "Hello", Content: "post", Images: `["https://cdn.example.com/p.png"]`}
+	if err := f.db.Create(&friendPost).Error; err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	promotion := model.MarketingPost{
+		MerchantID: f.merchant.ID,
+		Title:      "Half price",
+		Status:     marketingPostActive,
+		StartDate:  time.Now().Add(-time.Hour),
+		EndDate:    time.Now().Add(time.Hour),
+	}
+	expired := model.MarketingPost{
+		MerchantID: f.merchant.ID,
+		Title:      "Old deal",
+		Status:     marketingPostActive,
+		StartDate:  time.Now().Add(-48 * time.Hour),
+		EndDate:    time.Now().Add(-time.Hour),
+	}
+	if err := f.db.Create(&promotion).Error; err != nil {
+		t.Fatalf("failed to create promotion: %v", err)
+	}
+	if err := f.db.Create(&expired).Error; err != nil {
+		t.Fatalf("failed to create promotion: %v", err)
+	}
+
+	resp, err := svc.Home(ctx, f.viewer.ID, dto.HomeFeedQuery{})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	keys := feedKeys(resp.Data)
+	if len(keys) != len(resp.Data) {
+		t.Fatalf("expected deduplicated items, got %+v", resp.Data)
+	}
+	if item, ok := keys["review:"+itoa(friendReview.ID)]; !ok || item.Reason != dto.ReasonFollowing || item.AuthorName != "friend" || item.Title != "Downtown" {
+		t.Fatalf("expected friend review from following, got %+v", item)
+	}
+	if item, ok := keys["post:"+itoa(friendPost.ID)]; !ok || item.Image != "https://cdn.example.com/p.png" {
+		t.Fatalf("expected friend post with image, got %+v", item)
+	}
+	if item, ok := keys["review:"+itoa(strangerReview.ID)]; !ok || item.Reason != dto.ReasonPopular {
+		t.Fatalf("expected stranger review as popular, got %+v", item)
+	}
+	if _, ok := keys["review:"+itoa(ownReview.ID)]; ok {
+		t.Fatal("expected the caller's own review to be excluded")
+	}
+	if item, ok := keys["promotion:"+itoa(promotion.ID)]; !ok || item.EndsAt == nil {
+		t.Fatalf("expected active promotion, got %+v", item)
+	}
+	if _, ok := keys["promotion:"+itoa(expired.ID)]; ok {
+		t.Fatal("expected expired promotion to be excluded")
+	}
+	if item, ok := keys["store:"+itoa(f.store.ID)]; !ok || item.Reason != dto.ReasonTrending {
+		t.Fatalf("expected trending store, got %+v", item)
+	}
+}
+
+func TestHomeFeedSkipsBlockedAndMutedAuthors(t *testing.T) {
+	f := setupFeedFixture(t)
+	svc := NewFeedService(f.db, nil, config.FeedConfig{})
+	ctx := context.Background()
+
+	if err := f.db.Create(&model.UserFollow{FollowerID: f.viewer.ID, FollowingID: f.friend.ID}).Error; err != nil {
+		t.Fatalf("failed to follow user: %v", err)
+	}
+	friendReview := f.review(t, f.friend.ID, time.Hour, 0)
+	strangerReview := f.review(t, f.stranger.ID, time.Hour, 3)
+	if err := f.db.Create(&model.UserMute{MuterID: f.viewer.ID, MutedID: f.friend.ID}).Error; err != nil {
+		t.Fatalf("failed to mute user: %v", err)
+	}
+	if err := f.db.Create(&model.UserBlock{BlockerID: f.stranger.ID, BlockedID: f.viewer.ID}).Error; err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+
+	resp, err := svc.Home(ctx, f.viewer.ID, dto.HomeFeedQuery{})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	keys := feedKeys(resp.Data)
+	if _, ok := keys["review:"+itoa(friendReview.ID)]; ok {
+		t.Fatal("expected the muted friend's review to be excluded")
+	}
+	if _, ok := keys["review:"+itoa(strangerReview.ID)]; ok {
+		t.Fatal("expected the review of a user who blocked the viewer to be excluded")
+	}
+
+	anonymous, err := svc.Home(ctx, 0, dto.HomeFeedQuery{})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	if len(feedKeys(anonymous.Data)) < 2 {
+		t.Fatalf("expected anonymous viewers to see both reviews, got %+v", anonymous.Data)
+	}
+}
+
+func TestHomeFeedSkipsPrivateAuthorsUnlessFollowed(t *testing.T) {
+	f := setupFeedFixture(t)
+	svc := NewFeedService(f.db, nil, config.FeedConfig{})
+	ctx := context.Background()
+
+	for _, user := range []model.User{f.friend, f.stranger} {
+		privacy := model.UserPrivacy{UserID: user.ID}
+		if err := f.db.Create(&privacy).Error; err != nil {
+			t.Fatalf("failed to create privacy settings: %v", err)
+		}
+		if err := f.db.Model(&privacy).Update("is_public", false).Error; err != nil {
+			t.Fatalf("failed to make account private: %v", err)
+		}
+	}
+	if err := f.db.Create(&model.UserFollow{FollowerID: f.viewer.ID, FollowingID: f.friend.ID}).Error; err != nil {
+		t.Fatalf("failed to follow user: %v", err)
+	}
+	friendReview := f.review(t, f.friend.ID, time.Hour, 0)
+	strangerReview := f.review(t, f.stranger.ID, time.Hour, 3)
+
+	resp, err := svc.Home(ctx, f.viewer.ID, dto.HomeFeedQuery{})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	keys := feedKeys(resp.Data)
+	if _, ok := keys["review:"+itoa(friendReview.ID)]; !ok {
+		t.Fatal("expected a followed private account's review to be included")
+	}
+	if _, ok := keys["review:"+itoa(strangerReview.ID)]; ok {
+		t.Fatal("expected an unfollowed private account's review to be excluded")
+	}
+
+	anonymous, err := svc.Home(ctx, 0, dto.HomeFeedQuery{})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	for _, item := range anonymous.Data {
+		if item.Type == dto.FeedItemReview {
+			t.Fatalf("expected anonymous viewers to see no private reviews, got %+v", anonymous.Data)
+		}
+	}
+}
+
+func TestHomeFeedAnonymousPaginatesWithCursor(t *testing.T) {
+	f := setupFeedFixture(t)
+	svc := NewFeedService(f.db, ChronologicalRanker{}, config.FeedConfig{})
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		f.review(t, f.stranger.ID, time.Duration(i+1)*time.Hour, 0)
+	}
+
+	limit := 2
+	seen := map[string]bool{}
+	var cursor string
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		resp, err := svc.Home(ctx, 0, dto.HomeFeedQuery{Cursor: cursor, Limit: &limit})
+		if err != nil {
+			t.Fatalf("home failed: %v", err)
+		}
+		for _, item := range resp.Data {
+			key := item.Type + ":" + item.ID
+			if seen[key] {
+				t.Fatalf("item %s returned twice", key)
+			}
+			seen[key] = true
+		}
+		if pages == 0 {
+			// Content posted after the first page must not shift later pages.
+			f.review(t, f.stranger.ID, 0, 0)
+		}
+		if resp.Cursor == nil {
+			break
+		}
+		cursor = *resp.Cursor
+	}
+	// Five reviews plus the store they made trending.
+	if len(seen) != 6 {
+		t.Fatalf("expected 6 distinct items, got %d: %v", len(seen), seen)
+	}
+	// Anonymous windows are shared from memory rather than stored per session.
+	var snapshots int64
+	f.db.Model(&model.FeedSnapshot{}).Count(&snapshots)
+	if snapshots != 0 {
+		t.Fatalf("expected no stored snapshots for anonymous feeds, got %d", snapshots)
+	}
+	first, err := svc.Home(ctx, 0, dto.HomeFeedQuery{Limit: &limit})
+	if err != nil || first.Cursor == nil {
+		t.Fatalf("home failed: %+v (%v)", first, err)
+	}
+	if _, err := svc.Home(ctx, f.viewer.ID, dto.HomeFeedQuery{Cursor: *first.Cursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected an anonymous cursor to be rejected for a signed-in viewer, got %v", err)
+	}
+
+	if _, err := svc.Home(ctx, 0, dto.HomeFeedQuery{Cursor: "not-a-cursor"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestHomeFeedTrendingStoresRespectLocation(t *testing.T) {
+	f := setupFeedFixture(t)
+	svc := NewFeedService(f.db, nil, config.FeedConfig{RadiusKM: 5})
+	f.review(t, f.stranger.ID, time.Hour, 0)
+
+	far, near := 10.0, 40.0
+	lng := -74.0
+	resp, err := svc.Home(context.Background(), 0, dto.HomeFeedQuery{Lat: &far, Lng: &lng})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	if _, ok := feedKeys(resp.Data)["store:"+itoa(f.store.ID)]; ok {
+		t.Fatal("expected distant store to be excluded")
+	}
+	// Inside the bounding box but about 6.5 km away.
+	cornerLat, cornerLng := 40.04, -74.055
+	resp, err = svc.Home(context.Background(), 0, dto.HomeFeedQuery{Lat: &cornerLat, Lng: &cornerLng})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	if _, ok := feedKeys(resp.Data)["store:"+itoa(f.store.ID)]; ok {
+		t.Fatal("expected a store in the box corner but outside the radius to be excluded")
+	}
+	resp, err = svc.Home(context.Background(), 0, dto.HomeFeedQuery{Lat: &near, Lng: &lng})
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	if _, ok := feedKeys(resp.Data)["store:"+itoa(f.store.ID)]; !ok {
+		t.Fatal("expected nearby store to trend")
+	}
+}
+
+func TestHomeFeedPagesStayStableWhileEngagementChanges(t *testing.T) {
+	f := setupFeedFixture(t)
+	svc := NewFeedService(f.db, nil, config.FeedConfig{})
+	ctx := context.Background()
+
+	var reviews []model.Review
+	for i := 0; i < 6; i++ {
+		reviews = append(reviews, f.review(t, f.stranger.ID, time.Duration(i+1)*time.Hour, 0))
+	}
+
+	limit := 2
+	first, err := svc.Home(ctx, f.viewer.ID, dto.HomeFeedQuery{Limit: &limit})
+	if err != nil || first.Cursor == nil {
+		t.Fatalf("home failed: %+v (%v)", first, err)
+	}
+	seen := map[string]bool{}
+	for _, item := range first.Data {
+		seen[item.Type+":"+item.ID] = true
+	}
+	// The oldest review suddenly becomes the most liked; a live ranking would now put it
+	// ahead of the cursor and skip it.
+	f.db.Model(&model.Review{}).Where("id = ?", reviews[5].ID).Update("like_count", 1000)
+
+	if _, err := svc.Home(ctx, f.friend.ID, dto.HomeFeedQuery{Cursor: *first.Cursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected another viewer's cursor to be rejected, got %v", err)
+	}
+	cursor := *first.Cursor
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		resp, err := svc.Home(ctx, f.viewer.ID, dto.HomeFeedQuery{Cursor: cursor, Limit: &limit})
+		if err != nil {
+			t.Fatalf("home failed: %v", err)
+		}
+		for _, item := range resp.Data {
+			key := item.Type + ":" + item.ID
+			if seen[key] {
+				t.Fatalf("item %s returned twice", key)
+			}
+			seen[key] = true
+		}
+		if resp.Cursor == nil {
+			break
+		}
+		cursor = *resp.Cursor
+	}
+	// Six reviews plus the store they made trending.
+	if len(seen) != 7 {
+		t.Fatalf("expected 7 distinct items, got %d: %v", len(seen), seen)
+	}
+}
+
+func TestHomeFeedContinuesPastTheFirstWindow(t *testing.T) {
+	f := setupFeedFixture(t)
+	svc := NewFeedService(f.db, ChronologicalRanker{}, config.FeedConfig{LookbackDays: 1})
+	ctx := context.Background()
+
+	if err := f.db.Create(&model.UserFollow{FollowerID: f.viewer.ID, FollowingID: f.friend.ID}).Error; err != nil {
+		t.Fatalf("failed to follow user: %v", err)
+	}
+	// More followed reviews in the first window than one query returns, and two more a
+	// window further back.
+	total := candidatesPerSource + 5
+	for i := 0; i < total; i++ {
+		f.review(t, f.friend.ID, time.Duration(i+1)*5*time.Minute, 0)
+	}
+	f.review(t, f.friend.ID, 40*time.Hour, 0)
+	f.review(t, f.friend.ID, 41*time.Hour, 0)
+	total += 2
+
+	limit := maxFeedLimit
+	seen := map[string]bool{}
+	reviewsSeen := 0
+	var cursor string
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("pagination did not terminate")
+		}
+		resp, err := svc.Home(ctx, f.viewer.ID, dto.HomeFeedQuery{Cursor: cursor, Limit: &limit})
+		if err != nil {
+			t.Fatalf("home failed: %v", err)
+		}
+		for _, item := range resp.Data {
+			key := item.Type + ":" + item.ID
+			if seen[key] {
+				t.Fatalf("item %s returned twice", key)
+			}
+			seen[key] = true
+			if item.Type == dto.FeedItemReview {
+				reviewsSeen++
+			}
+		}
+		if resp.Cursor == nil {
+			break
+		}
+		cursor = *resp.Cursor
+	}
+	if reviewsSeen != total {
+		t.Fatalf("expected all %d followed reviews across windows, got %d", total, reviewsSeen)
+	}
+}
+
+func TestWeightedRankerPrefersFollowedAndRecent(t *testing.T) {
+	ranker := NewWeightedRanker()
+	now := time.Now()
+	followed := Candidate{Item: dto.FeedItem{Reason: dto.ReasonFollowing, CreatedAt: now}}
+	popular := Candidate{Item: dto.FeedItem{Reason: dto.ReasonPopular, CreatedAt: now}}
+	stale := Candidate{Item: dto.FeedItem{Reason: dto.ReasonFollowing, CreatedAt: now.Add(-72 * time.Hour)}}
+	if ranker.Score(followed, now) <= ranker.Score(popular, now) {
+		t.Fatal("expected followed content to outrank popular content of the same age")
+	}
+	if ranker.Score(followed, now) <= ranker.Score(stale, now) {
+		t.Fatal("expected newer content to outrank older content")
+	}
+}
+
+func itoa(id int64) string {
+	return fmt.Sprintf("%d", id)
+}
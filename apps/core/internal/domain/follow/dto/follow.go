@@ -0,0 +1,44 @@
+package dto
+
+// FollowUser is a user in a follow list, with flags relative to the viewer.
+type FollowUser struct {
+	UserID          int64  `json:"user_id"`
+	Nickname        string `json:"nickname"`
+	AvatarURL       string `json:"avatar_url"`
+	Intro           string `json:"intro"`
+	IsFollowing     bool   `json:"is_following"`
+	FollowsYou      bool   `json:"follows_you"`
+	FollowRequested bool   `json:"follow_requested"`
+}
+
+type FollowUserListResponse struct {
+	Users  []FollowUser `json:"users"`
+	Total  int          `json:"total"`
+	Cursor *int64       `json:"cursor,omitempty"`
+}
+
+// FollowStatusResponse reports the follow state after following or unfollowing a user.
+type FollowStatusResponse struct {
+	Status          string `json:"status"`
+	IsFollowing     bool   `json:"is_following"`
+	FollowRequested bool   `json:"follow_requested"`
+}
+
+// Suggestion reasons, strongest signal first.
+const (
+	SuggestionReasonFollowedByFollowing = "followed_by_people_you_follow"
+	SuggestionReasonReviewedSameStores  = "reviewed_same_stores"
+)
+
+// SuggestedUser is a "people you may know" entry.
+type SuggestedUser struct {
+	FollowUser
+	MutualFollowerCount int    `json:"mutual_follower_count"`
+	SharedStoreCount    int    `json:"shared_store_count"`
+	Reason              string `json:"reason"`
+}
+
+type SuggestionListResponse struct {
+	Users []SuggestedUser `json:"users"`
+	Total int             `json:"total"`
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// BlockUser godoc
+// @Summary Block user
+// @Description Blocks a user: follows and follow requests between the two are removed, and neither sees the other's content or can message them
+// @Tags follow
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/{id}/block [post]
+func (h *UserHandler) BlockUser(c *gin.Context) {
+	h.applyToUser(c, h.svc.Block)
+}
+
+// UnblockUser godoc
+// @Summary Unblock user
+// @Description Lifts a block; removed follows are not restored
+// @Tags follow
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{id}/block [delete]
+func (h *UserHandler) UnblockUser(c *gin.Context) {
+	h.applyToUser(c, h.svc.Unblock)
+}
+
+// MuteUser godoc
+// @Summary Mute user
+// @Description Hides a user's content from the authenticated user's feeds
+// @Tags follow
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/{id}/mute [post]
+func (h *UserHandler) MuteUser(c *gin.Context) {
+	h.applyToUser(c, h.svc.Mute)
+}
+
+// UnmuteUser godoc
+// @Summary Unmute user
+// @Description Shows a muted user's content in feeds again
+// @Tags follow
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{id}/mute [delete]
+func (h *UserHandler) UnmuteUser(c *gin.Context) {
+	h.applyToUser(c, h.svc.Unmute)
+}
+
+// ListBlocks godoc
+// @Summary List blocked users
+// @Description Returns the users the authenticated user has blocked, most recent first
+// @Tags follow
+// @Produce json
+// @Param cursor query int false "Cursor"
+// @Param limit query int false "Limit"
+// @Success 200 {object} dto.FollowUserListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/blocks [get]
+func (h *UserHandler) ListBlocks(c *gin.Context) {
+	h.listOwn(c, h.svc.Blocks)
+}
+
+// ListMutes godoc
+// @Summary List muted users
+// @Description Returns the users the authenticated user has muted, most recent first
+// @Tags follow
+// @Produce json
+// @Param cursor query int false "Cursor"
+// @Param limit query int false "Limit"
+// @Success 200 {object} dto.FollowUserListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/mutes [get]
+func (h *UserHandler) ListMutes(c *gin.Context) {
+	h.listOwn(c, h.svc.Mutes)
+}
+
+func (h *UserHandler) applyToUser(c *gin.Context, apply func(ctx context.Context, userID, targetID int64) error) {
+	targetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	if err := apply(c.Request.Context(), c.GetInt64("user_id"), targetID); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *UserHandler) listOwn(c *gin.Context, list func(ctx context.Context, userID int64, cursor *int64, limit int) ([]dto.FollowUser, *int64, error)) {
+	cursor, limit := parseCursorLimit(c)
+	users, next, err := list(c.Request.Context(), c.GetInt64("user_id"), cursor, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.FollowUserListResponse{Users: users, Total: len(users), Cursor: next})
+}
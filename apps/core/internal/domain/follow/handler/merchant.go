@@ -28,6 +28,7 @@ func NewMerchantHandler(svc *service.FollowService) *MerchantHandler {
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /merchants/{id}/follow [post]
 func (h *MerchantHandler) FollowMerchant(c *gin.Context) {
 	userID := c.GetInt64("user_id")
@@ -37,7 +38,7 @@ func (h *MerchantHandler) FollowMerchant(c *gin.Context) {
 		return
 	}
 	if err := h.svc.FollowMerchant(c.Request.Context(), userID, merchantID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondFollowError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	"github.com/gin-gonic/gin"
+)
+
+type StoreHandler struct {
+	svc *service.FollowService
+}
+
+func NewStoreHandler(svc *service.FollowService) *StoreHandler {
+	if svc == nil {
+		svc = service.NewFollowService(nil)
+	}
+	return &StoreHandler{svc: svc}
+}
+
+// FollowStore godoc
+// @Summary Follow store
+// @Description Follow a published store
+// @Tags follow
+// @Produce json
+// @Param id path int true "Store ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /stores/{id}/follow [post]
+func (h *StoreHandler) FollowStore(c *gin.Context) {
+	storeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid store id"})
+		return
+	}
+	if err := h.svc.FollowStore(c.Request.Context(), c.GetInt64("user_id"), storeID); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// UnfollowStore godoc
+// @Summary Unfollow store
+// @Description Unfollow a store
+// @Tags follow
+// @Produce json
+// @Param id path int true "Store ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /stores/{id}/follow [delete]
+func (h *StoreHandler) UnfollowStore(c *gin.Context) {
+	storeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid store id"})
+		return
+	}
+	if err := h.svc.UnfollowStore(c.Request.Context(), c.GetInt64("user_id"), storeID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
 	"github.com/gin-gonic/gin"
 )
@@ -21,13 +24,15 @@ func NewUserHandler(svc *service.FollowService) *UserHandler {
 
 // FollowUser godoc
 // @Summary Follow user
-// @Description Follow a user
+// @Description Follows a public user, or sends a follow request to a private one
 // @Tags follow
 // @Produce json
 // @Param id path int true "User ID"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} dto.FollowStatusResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /users/{id}/follow [post]
 func (h *UserHandler) FollowUser(c *gin.Context) {
 	userID := c.GetInt64("user_id")
@@ -37,19 +42,19 @@ func (h *UserHandler) FollowUser(c *gin.Context) {
 		return
 	}
 	if err := h.svc.FollowUser(c.Request.Context(), userID, targetID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondFollowError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	h.respondFollowStatus(c, userID, targetID)
 }
 
 // UnfollowUser godoc
 // @Summary Unfollow user
-// @Description Unfollow a user
+// @Description Unfollows a user, or withdraws a pending follow request
 // @Tags follow
 // @Produce json
 // @Param id path int true "User ID"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} dto.FollowStatusResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -65,5 +70,193 @@ func (h *UserHandler) UnfollowUser(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	h.respondFollowStatus(c, userID, targetID)
+}
+
+// ListFollowers godoc
+// @Summary List a user's followers
+// @Description Returns the followers of a user with flags relative to the viewer. Private profiles only show them to the owner and approved followers.
+// @Tags follow
+// @Produce json
+// @Param id path int true "User ID"
+// @Param cursor query int false "Cursor"
+// @Param limit query int false "Limit"
+// @Success 200 {object} dto.FollowUserListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{id}/followers [get]
+func (h *UserHandler) ListFollowers(c *gin.Context) {
+	h.listFollows(c, h.svc.Followers)
+}
+
+// ListFollowing godoc
+// @Summary List the users a user follows
+// @Description Returns the users a user follows with flags relative to the viewer. Private profiles only show them to the owner and approved followers.
+// @Tags follow
+// @Produce json
+// @Param id path int true "User ID"
+// @Param cursor query int false "Cursor"
+// @Param limit query int false "Limit"
+// @Success 200 {object} dto.FollowUserListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{id}/following [get]
+func (h *UserHandler) ListFollowing(c *gin.Context) {
+	h.listFollows(c, h.svc.Following)
+}
+
+// ListFollowRequests godoc
+// @Summary List follow requests
+// @Description Returns the pending requests to follow the authenticated user
+// @Tags follow
+// @Produce json
+// @Param cursor query int false "Cursor"
+// @Param limit query int false "Limit"
+// @Success 200 {object} dto.FollowUserListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/follow-requests [get]
+func (h *UserHandler) ListFollowRequests(c *gin.Context) {
+	cursor, limit := parseCursorLimit(c)
+	users, next, err := h.svc.FollowRequests(c.Request.Context(), c.GetInt64("user_id"), cursor, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.FollowUserListResponse{Users: users, Total: len(users), Cursor: next})
+}
+
+// ApproveFollowRequest godoc
+// @Summary Approve a follow request
+// @Description Lets the requesting user follow the authenticated user
+// @Tags follow
+// @Produce json
+// @Param id path int true "Requesting user ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /user/follow-requests/{id}/approve [post]
+func (h *UserHandler) ApproveFollowRequest(c *gin.Context) {
+	requesterID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	if err := h.svc.ApproveFollowRequest(c.Request.Context(), c.GetInt64("user_id"), requesterID); err != nil {
+		respondFollowError(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
+
+// RejectFollowRequest godoc
+// @Summary Reject a follow request
+// @Description Discards a pending request to follow the authenticated user
+// @Tags follow
+// @Produce json
+// @Param id path int true "Requesting user ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /user/follow-requests/{id} [delete]
+func (h *UserHandler) RejectFollowRequest(c *gin.Context) {
+	requesterID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	if err := h.svc.RejectFollowRequest(c.Request.Context(), c.GetInt64("user_id"), requesterID); err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// ListSuggestions godoc
+// @Summary People you may know
+// @Description Suggests users followed by people the authenticated user follows, or who reviewed the same stores
+// @Tags follow
+// @Produce json
+// @Param limit query int false "Limit"
+// @Success 200 {object} dto.SuggestionListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user/suggestions [get]
+func (h *UserHandler) ListSuggestions(c *gin.Context) {
+	_, limit := parseCursorLimit(c)
+	users, err := h.svc.Suggestions(c.Request.Context(), c.GetInt64("user_id"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuggestionListResponse{Users: users, Total: len(users)})
+}
+
+type followLister func(ctx context.Context, viewerID, userID int64, cursor *int64, limit int) ([]dto.FollowUser, *int64, error)
+
+func (h *UserHandler) listFollows(c *gin.Context, list followLister) {
+	targetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	cursor, limit := parseCursorLimit(c)
+	users, next, err := list(c.Request.Context(), c.GetInt64("user_id"), targetID, cursor, limit)
+	if err != nil {
+		respondFollowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.FollowUserListResponse{Users: users, Total: len(users), Cursor: next})
+}
+
+func (h *UserHandler) respondFollowStatus(c *gin.Context, userID, targetID int64) {
+	rel, err := h.svc.Relationship(c.Request.Context(), userID, targetID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.FollowStatusResponse{
+		Status:          "ok",
+		IsFollowing:     rel.IsFollowing,
+		FollowRequested: rel.FollowRequested,
+	})
+}
+
+func respondFollowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound),
+		errors.Is(err, service.ErrFollowRequestNotFound),
+		errors.Is(err, service.ErrMerchantNotFound),
+		errors.Is(err, service.ErrStoreNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrProfilePrivate),
+		errors.Is(err, service.ErrBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCannotFollowSelf),
+		errors.Is(err, service.ErrCannotBlockSelf),
+		errors.Is(err, service.ErrCannotMuteSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func parseCursorLimit(c *gin.Context) (*int64, int) {
+	limit := 20
+	if v := c.Query("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	var cursor *int64
+	if v := c.Query("cursor"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			cursor = &parsed
+		}
+	}
+	return cursor, limit
+}
@@ -13,12 +13,31 @@ func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
 	svc := service.NewFollowService(nil)
 	userHandler := handler.NewUserHandler(svc)
 	merchantHandler := handler.NewMerchantHandler(svc)
+	storeHandler := handler.NewStoreHandler(svc)
+
+	users := r.Group("/users", middleware.OptionalJWTAuth(cfg.JWT))
+	{
+		users.GET("/:id/followers", userHandler.ListFollowers)
+		users.GET("/:id/following", userHandler.ListFollowing)
+	}
 
 	auth := r.Group("", middleware.JWTAuth(cfg.JWT))
 	{
 		auth.POST("/users/:id/follow", userHandler.FollowUser)
 		auth.DELETE("/users/:id/follow", userHandler.UnfollowUser)
+		auth.POST("/users/:id/block", userHandler.BlockUser)
+		auth.DELETE("/users/:id/block", userHandler.UnblockUser)
+		auth.POST("/users/:id/mute", userHandler.MuteUser)
+		auth.DELETE("/users/:id/mute", userHandler.UnmuteUser)
 		auth.POST("/merchants/:id/follow", merchantHandler.FollowMerchant)
 		auth.DELETE("/merchants/:id/follow", merchantHandler.UnfollowMerchant)
+		auth.POST("/stores/:id/follow", storeHandler.FollowStore)
+		auth.DELETE("/stores/:id/follow", storeHandler.UnfollowStore)
+		auth.GET("/user/follow-requests", userHandler.ListFollowRequests)
+		auth.POST("/user/follow-requests/:id/approve", userHandler.ApproveFollowRequest)
+		auth.DELETE("/user/follow-requests/:id", userHandler.RejectFollowRequest)
+		auth.GET("/user/blocks", userHandler.ListBlocks)
+		auth.GET("/user/mutes", userHandler.ListMutes)
+		auth.GET("/user/suggestions", userHandler.ListSuggestions)
 	}
 }
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrCannotBlockSelf = errors.New("cannot block self")
+var ErrCannotMuteSelf = errors.New("cannot mute self")
+var ErrBlocked = errors.New("user is blocked")
+
+// Block blocks blockedID for blockerID. Follows and pending follow requests between the two
+// are removed in both directions. Blocking twice is a no-op.
+func (s *FollowService) Block(ctx context.Context, blockerID, blockedID int64) error {
+	if blockerID == blockedID {
+		return ErrCannotBlockSelf
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireUser(tx, blockedID); err != nil {
+			return err
+		}
+		block := model.UserBlock{BlockerID: blockerID, BlockedID: blockedID}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&block).Error; err != nil {
+			return err
+		}
+		if err := removeFollow(tx, blockerID, blockedID); err != nil {
+			return err
+		}
+		if err := removeFollow(tx, blockedID, blockerID); err != nil {
+			return err
+		}
+		return tx.Where("(requester_id = ? AND target_id = ?) OR (requester_id = ? AND target_id = ?)",
+			blockerID, blockedID, blockedID, blockerID).Delete(&model.FollowRequest{}).Error
+	})
+}
+
+// Unblock lifts blockerID's block on blockedID. Removed follows are not restored.
+func (s *FollowService) Unblock(ctx context.Context, blockerID, blockedID int64) error {
+	return s.db.WithContext(ctx).Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Delete(&model.UserBlock{}).Error
+}
+
+// Mute hides mutedID's content from muterID's feeds. Muting twice is a no-op.
+func (s *FollowService) Mute(ctx context.Context, muterID, mutedID int64) error {
+	if muterID == mutedID {
+		return ErrCannotMuteSelf
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireUser(tx, mutedID); err != nil {
+			return err
+		}
+		mute := model.UserMute{MuterID: muterID, MutedID: mutedID}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mute).Error
+	})
+}
+
+// Unmute lifts muterID's mute on mutedID.
+func (s *FollowService) Unmute(ctx context.Context, muterID, mutedID int64) error {
+	return s.db.WithContext(ctx).Where("muter_id = ? AND muted_id = ?", muterID, mutedID).
+		Delete(&model.UserMute{}).Error
+}
+
+// IsBlocked reports whether either user has blocked the other.
+func (s *FollowService) IsBlocked(ctx context.Context, userID, otherID int64) (bool, error) {
+	return IsBlockedBetween(s.db.WithContext(ctx), userID, otherID)
+}
+
+// Blocks lists the users blockerID has blocked, most recent first.
+func (s *FollowService) Blocks(ctx context.Context, blockerID int64, cursor *int64, limit int) ([]dto.FollowUser, *int64, error) {
+	q := s.db.WithContext(ctx).Where("blocker_id = ?", blockerID).Order("id desc")
+	if cursor != nil {
+		q = q.Where("id < ?", *cursor)
+	}
+	var blocks []model.UserBlock
+	if err := q.Limit(limit).Find(&blocks).Error; err != nil {
+		return nil, nil, err
+	}
+	ids := make([]int64, 0, len(blocks))
+	for _, block := range blocks {
+		ids = append(ids, block.BlockedID)
+	}
+	users, err := s.followUsers(ctx, blockerID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *int64
+	if len(blocks) == limit {
+		next = &blocks[len(blocks)-1].ID
+	}
+	return users, next, nil
+}
+
+// Mutes lists the users muterID has muted, most recent first.
+func (s *FollowService) Mutes(ctx context.Context, muterID int64, cursor *int64, limit int) ([]dto.FollowUser, *int64, error) {
+	q := s.db.WithContext(ctx).Where("muter_id = ?", muterID).Order("id desc")
+	if cursor != nil {
+		q = q.Where("id < ?", *cursor)
+	}
+	var mutes []model.UserMute
+	if err := q.Limit(limit).Find(&mutes).Error; err != nil {
+		return nil, nil, err
+	}
+	ids := make([]int64, 0, len(mutes))
+	for _, mute := range mutes {
+		ids = append(ids, mute.MutedID)
+	}
+	users, err := s.followUsers(ctx, muterID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *int64
+	if len(mutes) == limit {
+		next = &mutes[len(mutes)-1].ID
+	}
+	return users, next, nil
+}
+
+// IsBlockedBetween reports whether either user has blocked the other. It takes a *gorm.DB so
+// other services can run it inside their own transactions.
+func IsBlockedBetween(db *gorm.DB, userID, otherID int64) (bool, error) {
+	if userID == 0 || otherID == 0 || userID == otherID {
+		return false, nil
+	}
+	var count int64
+	if err := db.Model(&model.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherID, otherID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ExcludeBlocked is a query scope that drops rows whose authorColumn (e.g. "reviews.user_id")
+// is a user on either side of a block with viewerID. Anonymous viewers see everything.
+func ExcludeBlocked(viewerID int64, authorColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == 0 {
+			return db
+		}
+		return db.Where("NOT EXISTS (SELECT 1 FROM user_blocks WHERE (user_blocks.blocker_id = ? AND user_blocks.blocked_id = "+authorColumn+
+			") OR (user_blocks.blocked_id = ? AND user_blocks.blocker_id = "+authorColumn+"))", viewerID, viewerID)
+	}
+}
+
+// ExcludeBlockedOrMuted extends ExcludeBlocked with the viewer's mutes; feeds use it, while
+// direct views of a muted user's content stay available.
+func ExcludeBlockedOrMuted(viewerID int64, authorColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == 0 {
+			return db
+		}
+		return db.Scopes(ExcludeBlocked(viewerID, authorColumn)).
+			Where("NOT EXISTS (SELECT 1 FROM user_mutes WHERE user_mutes.muter_id = ? AND user_mutes.muted_id = "+authorColumn+")", viewerID)
+	}
+}
+
+func requireUser(db *gorm.DB, userID int64) error {
+	var user model.User
+	if err := db.Select("id").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func TestBlockSeversFollowsAndPreventsNewOnes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewFollowService(db)
+	ctx := context.Background()
+	alice := createFollowUser(t, db, "alice", true)
+	bob := createFollowUser(t, db, "bob", true)
+
+	if err := svc.FollowUser(ctx, alice.ID, bob.ID); err != nil {
+		t.Fatalf("follow failed: %v", err)
+	}
+	if err := svc.FollowUser(ctx, bob.ID, alice.ID); err != nil {
+		t.Fatalf("follow failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := svc.Block(ctx, alice.ID, bob.ID); err != nil {
+			t.Fatalf("block failed: %v", err)
+		}
+	}
+
+	var follows int64
+	db.Model(&model.UserFollow{}).Count(&follows)
+	if follows != 0 {
+		t.Fatalf("expected follows in both directions to be removed, got %d", follows)
+	}
+	var profile model.UserProfile
+	db.First(&profile, "user_id = ?", alice.ID)
+	if profile.FollowerCount != 0 || profile.FollowingCount != 0 {
+		t.Fatalf("expected counters back at 0, got %d/%d", profile.FollowerCount, profile.FollowingCount)
+	}
+	if err := svc.FollowUser(ctx, bob.ID, alice.ID); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected the blocked user to be unable to follow, got %v", err)
+	}
+	if err := svc.FollowUser(ctx, alice.ID, bob.ID); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected the blocker to be unable to follow, got %v", err)
+	}
+	if allowed, err := svc.CanView(ctx, bob.ID, alice.ID); err != nil || allowed {
+		t.Fatalf("expected the blocked user to lose access to the profile, got %v (%v)", allowed, err)
+	}
+	if err := svc.Block(ctx, alice.ID, alice.ID); !errors.Is(err, ErrCannotBlockSelf) {
+		t.Fatalf("expected ErrCannotBlockSelf, got %v", err)
+	}
+
+	blocks, _, err := svc.Blocks(ctx, alice.ID, nil, 20)
+	if err != nil || len(blocks) != 1 || blocks[0].UserID != bob.ID {
+		t.Fatalf("expected bob in alice's blocks, got %+v (%v)", blocks, err)
+	}
+	if err := svc.Unblock(ctx, alice.ID, bob.ID); err != nil {
+		t.Fatalf("unblock failed: %v", err)
+	}
+	if err := svc.FollowUser(ctx, bob.ID, alice.ID); err != nil {
+		t.Fatalf("expected following to work after unblocking, got %v", err)
+	}
+}
+
+func TestVisibilityScopes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewFollowService(db)
+	ctx := context.Background()
+	viewer := createFollowUser(t, db, "viewer", true)
+	blocked := createFollowUser(t, db, "blocked", true)
+	blocker := createFollowUser(t, db, "blocker", true)
+	muted := createFollowUser(t, db, "muted", true)
+	other := createFollowUser(t, db, "other", true)
+
+	if err := svc.Block(ctx, viewer.ID, blocked.ID); err != nil {
+		t.Fatalf("block failed: %v", err)
+	}
+	if err := svc.Block(ctx, blocker.ID, viewer.ID); err != nil {
+		t.Fatalf("block failed: %v", err)
+	}
+	if err := svc.Mute(ctx, viewer.ID, muted.ID); err != nil {
+		t.Fatalf("mute failed: %v", err)
+	}
+	for _, user := range []model.User{blocked, blocker, muted, other} {
+		db.Create(&model.Post{UserID: user.ID, Content: "hello"})
+	}
+
+	authors := func(scope func(*gorm.DB) *gorm.DB) map[int64]bool {
+		var ids []int64
+		if err := db.Model(&model.Post{}).Scopes(scope).Pluck("user_id", &ids).Error; err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		seen := make(map[int64]bool, len(ids))
+		for _, id := range ids {
+			seen[id] = true
+		}
+		return seen
+	}
+	visible := authors(ExcludeBlocked(viewer.ID, "posts.user_id"))
+	if visible[blocked.ID] || visible[blocker.ID] || !visible[muted.ID] || !visible[other.ID] {
+		t.Fatalf("expected both sides of a block hidden and mutes kept, got %v", visible)
+	}
+	feed := authors(ExcludeBlockedOrMuted(viewer.ID, "posts.user_id"))
+	if feed[blocked.ID] || feed[blocker.ID] || feed[muted.ID] || !feed[other.ID] {
+		t.Fatalf("expected blocks and mutes hidden from feeds, got %v", feed)
+	}
+	if anonymous := authors(ExcludeBlockedOrMuted(0, "posts.user_id")); len(anonymous) != 4 {
+		t.Fatalf("expected anonymous viewers to see every author, got %v", anonymous)
+	}
+}
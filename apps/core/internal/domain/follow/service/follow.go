@@ -4,11 +4,32 @@ import (
 	"context"
 	"errors"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var ErrCannotFollowSelf = errors.New("cannot follow self")
+var ErrUserNotFound = errors.New("user not found")
+var ErrFollowRequestNotFound = errors.New("follow request not found")
+var ErrProfilePrivate = errors.New("profile is private")
+var ErrMerchantNotFound = errors.New("merchant not found")
+var ErrStoreNotFound = errors.New("store not found")
+
+// storeStatusPublished mirrors the store domain's published status; that package imports
+// this one, so the constant cannot be shared.
+const storeStatusPublished int16 = 1
+
+// Relationship describes how a viewer relates to another user.
+type Relationship struct {
+	IsFollowing     bool `json:"is_following"`
+	FollowsYou      bool `json:"follows_you"`
+	FollowRequested bool `json:"follow_requested"`
+}
+
 type FollowService struct {
 	db *gorm.DB
 }
@@ -20,51 +41,443 @@ func NewFollowService(db *gorm.DB) *FollowService {
 	return &FollowService{db: db}
 }
 
+// FollowUser follows a public account, or files a follow request when the account is
+// private. Following or requesting twice is a no-op.
 func (s *FollowService) FollowUser(ctx context.Context, followerID, followingID int64) error {
 	if followerID == followingID {
-		return errors.New("cannot follow self")
+		return ErrCannotFollowSelf
 	}
-	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var existing model.UserFollow
-		result := tx.Where("follower_id = ? AND following_id = ?", followerID, followingID).Limit(1).Find(&existing)
-		if result.Error != nil {
-			return result.Error
-		}
-		if result.RowsAffected > 0 {
-			return nil
+	var event eventbus.Event
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireUser(tx, followingID); err != nil {
+			return err
 		}
-		follow := model.UserFollow{FollowerID: followerID, FollowingID: followingID}
-		if err := tx.Create(&follow).Error; err != nil {
+		blocked, err := IsBlockedBetween(tx, followerID, followingID)
+		if err != nil {
 			return err
 		}
-		if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", followerID).UpdateColumn("following_count", gorm.Expr("following_count + 1")).Error; err != nil {
+		if blocked {
+			return ErrBlocked
+		}
+		private, err := isPrivate(tx, followingID)
+		if err != nil {
 			return err
 		}
-		return tx.Model(&model.UserProfile{}).Where("user_id = ?", followingID).UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error
-	})
+		if private {
+			following, err := isFollowing(tx, followerID, followingID)
+			if err != nil || following {
+				return err
+			}
+			request := model.FollowRequest{RequesterID: followerID, TargetID: followingID}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&request)
+			if result.Error == nil && result.RowsAffected > 0 {
+				event = eventbus.FollowRequested{RequesterID: followerID, TargetID: followingID}
+			}
+			return result.Error
+		}
+		added, err := addFollow(tx, followerID, followingID)
+		if added {
+			event = eventbus.UserFollowed{FollowerID: followerID, FollowingID: followingID}
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+	if event != nil {
+		eventbus.Publish(ctx, event)
+	}
+	return nil
 }
 
+// UnfollowUser removes a follow, or withdraws a pending follow request.
 func (s *FollowService) UnfollowUser(ctx context.Context, followerID, followingID int64) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		result := tx.Where("follower_id = ? AND following_id = ?", followerID, followingID).Delete(&model.UserFollow{})
+		if err := tx.Where("requester_id = ? AND target_id = ?", followerID, followingID).
+			Delete(&model.FollowRequest{}).Error; err != nil {
+			return err
+		}
+		return removeFollow(tx, followerID, followingID)
+	})
+}
+
+// ApproveFollowRequest turns requesterID's pending request into a follow of userID.
+func (s *FollowService) ApproveFollowRequest(ctx context.Context, userID, requesterID int64) error {
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("requester_id = ? AND target_id = ?", requesterID, userID).Delete(&model.FollowRequest{})
 		if result.Error != nil {
 			return result.Error
 		}
 		if result.RowsAffected == 0 {
-			return nil
+			return ErrFollowRequestNotFound
 		}
-		if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", followerID).UpdateColumn("following_count", gorm.Expr("GREATEST(following_count - 1, 0)")).Error; err != nil {
-			return err
+		_, err := addFollow(tx, requesterID, userID)
+		return err
+	}); err != nil {
+		return err
+	}
+	eventbus.Publish(ctx, eventbus.FollowRequestApproved{UserID: userID, RequesterID: requesterID})
+	return nil
+}
+
+// RejectFollowRequest discards requesterID's pending request to follow userID.
+func (s *FollowService) RejectFollowRequest(ctx context.Context, userID, requesterID int64) error {
+	result := s.db.WithContext(ctx).Where("requester_id = ? AND target_id = ?", requesterID, userID).
+		Delete(&model.FollowRequest{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFollowRequestNotFound
+	}
+	return nil
+}
+
+// ApproveAllFollowRequests approves every pending request to follow userID; it runs when a
+// private account becomes public.
+func (s *FollowService) ApproveAllFollowRequests(ctx context.Context, userID int64) error {
+	var requesterIDs []int64
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		requesterIDs, err = ApprovePendingFollowRequests(tx, userID)
+		return err
+	}); err != nil {
+		return err
+	}
+	PublishFollowRequestsApproved(ctx, userID, requesterIDs)
+	return nil
+}
+
+// ApprovePendingFollowRequests turns every pending request to follow userID into a follow
+// inside tx and returns the requesters. Callers publish the approvals with
+// PublishFollowRequestsApproved once tx has committed.
+func ApprovePendingFollowRequests(tx *gorm.DB, userID int64) ([]int64, error) {
+	var requesterIDs []int64
+	if err := tx.Model(&model.FollowRequest{}).Where("target_id = ?", userID).
+		Pluck("requester_id", &requesterIDs).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("target_id = ?", userID).Delete(&model.FollowRequest{}).Error; err != nil {
+		return nil, err
+	}
+	for _, requesterID := range requesterIDs {
+		if _, err := addFollow(tx, requesterID, userID); err != nil {
+			return nil, err
 		}
-		return tx.Model(&model.UserProfile{}).Where("user_id = ?", followingID).UpdateColumn("follower_count", gorm.Expr("GREATEST(follower_count - 1, 0)")).Error
-	})
+	}
+	return requesterIDs, nil
+}
+
+// PublishFollowRequestsApproved announces requests approved by ApprovePendingFollowRequests.
+func PublishFollowRequestsApproved(ctx context.Context, userID int64, requesterIDs []int64) {
+	for _, requesterID := range requesterIDs {
+		eventbus.Publish(ctx, eventbus.FollowRequestApproved{UserID: userID, RequesterID: requesterID})
+	}
 }
 
+// IsPrivate reports whether userID has turned their profile private. Users without privacy
+// settings are public.
+func (s *FollowService) IsPrivate(ctx context.Context, userID int64) (bool, error) {
+	return isPrivate(s.db.WithContext(ctx), userID)
+}
+
+// CanView reports whether viewerID may see targetID's full profile, content and follow
+// lists: everyone may see a public account, only the owner and followers a private one, and
+// nobody on either side of a block. viewerID is 0 for anonymous viewers.
+func (s *FollowService) CanView(ctx context.Context, viewerID, targetID int64) (bool, error) {
+	if viewerID != 0 && viewerID == targetID {
+		return true, nil
+	}
+	db := s.db.WithContext(ctx)
+	blocked, err := IsBlockedBetween(db, viewerID, targetID)
+	if err != nil || blocked {
+		return false, err
+	}
+	private, err := isPrivate(db, targetID)
+	if err != nil || !private {
+		return !private, err
+	}
+	if viewerID == 0 {
+		return false, nil
+	}
+	return isFollowing(db, viewerID, targetID)
+}
+
+// ExcludePrivate is a query scope that applies CanView's privacy rule to lists: it drops rows
+// whose authorColumn (e.g. "reviews.user_id") is a private account viewerID neither is nor
+// follows. Blocks are left to ExcludeBlocked.
+func ExcludePrivate(viewerID int64, authorColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(NOT EXISTS (SELECT 1 FROM user_privacies WHERE user_privacies.user_id = "+authorColumn+
+			" AND user_privacies.is_public = ?) OR "+authorColumn+" = ? OR EXISTS (SELECT 1 FROM user_follows"+
+			" WHERE user_follows.follower_id = ? AND user_follows.following_id = "+authorColumn+"))", false, viewerID, viewerID)
+	}
+}
+
+// Relationship returns how viewerID relates to targetID.
+func (s *FollowService) Relationship(ctx context.Context, viewerID, targetID int64) (Relationship, error) {
+	relationships, err := s.Relationships(ctx, viewerID, []int64{targetID})
+	if err != nil {
+		return Relationship{}, err
+	}
+	return relationships[targetID], nil
+}
+
+// Relationships returns how viewerID relates to each of userIDs. Anonymous viewers and the
+// viewer's own entry get the zero Relationship.
+func (s *FollowService) Relationships(ctx context.Context, viewerID int64, userIDs []int64) (map[int64]Relationship, error) {
+	result := make(map[int64]Relationship, len(userIDs))
+	if viewerID == 0 || len(userIDs) == 0 {
+		return result, nil
+	}
+	db := s.db.WithContext(ctx)
+
+	var following, followers, requested []int64
+	if err := db.Model(&model.UserFollow{}).Where("follower_id = ? AND following_id IN ?", viewerID, userIDs).
+		Pluck("following_id", &following).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&model.UserFollow{}).Where("following_id = ? AND follower_id IN ?", viewerID, userIDs).
+		Pluck("follower_id", &followers).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&model.FollowRequest{}).Where("requester_id = ? AND target_id IN ?", viewerID, userIDs).
+		Pluck("target_id", &requested).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range following {
+		rel := result[id]
+		rel.IsFollowing = true
+		result[id] = rel
+	}
+	for _, id := range followers {
+		rel := result[id]
+		rel.FollowsYou = true
+		result[id] = rel
+	}
+	for _, id := range requested {
+		rel := result[id]
+		rel.FollowRequested = true
+		result[id] = rel
+	}
+	return result, nil
+}
+
+// Followers lists the users following userID, newest follow first. Private accounts only
+// show their followers to the owner and to approved followers.
+func (s *FollowService) Followers(ctx context.Context, viewerID, userID int64, cursor *int64, limit int) ([]dto.FollowUser, *int64, error) {
+	return s.followList(ctx, viewerID, userID, "following_id = ?", "follower_id", cursor, limit)
+}
+
+// Following lists the users userID follows, newest follow first, under the same privacy rule
+// as Followers.
+func (s *FollowService) Following(ctx context.Context, viewerID, userID int64, cursor *int64, limit int) ([]dto.FollowUser, *int64, error) {
+	return s.followList(ctx, viewerID, userID, "follower_id = ?", "following_id", cursor, limit)
+}
+
+// FollowRequests lists the users waiting for userID to approve their follow, newest first.
+func (s *FollowService) FollowRequests(ctx context.Context, userID int64, cursor *int64, limit int) ([]dto.FollowUser, *int64, error) {
+	q := s.db.WithContext(ctx).Where("target_id = ?", userID).Order("id desc")
+	if cursor != nil {
+		q = q.Where("id < ?", *cursor)
+	}
+	var requests []model.FollowRequest
+	if err := q.Limit(limit).Find(&requests).Error; err != nil {
+		return nil, nil, err
+	}
+	ids := make([]int64, 0, len(requests))
+	for _, request := range requests {
+		ids = append(ids, request.RequesterID)
+	}
+	users, err := s.followUsers(ctx, userID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *int64
+	if len(requests) == limit {
+		next = &requests[len(requests)-1].ID
+	}
+	return users, next, nil
+}
+
+func (s *FollowService) followList(ctx context.Context, viewerID, userID int64, condition, column string, cursor *int64, limit int) ([]dto.FollowUser, *int64, error) {
+	allowed, err := s.CanView(ctx, viewerID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !allowed {
+		return nil, nil, ErrProfilePrivate
+	}
+
+	q := s.db.WithContext(ctx).Where(condition, userID).Scopes(ExcludeBlocked(viewerID, "user_follows."+column)).Order("id desc")
+	if cursor != nil {
+		q = q.Where("id < ?", *cursor)
+	}
+	var follows []model.UserFollow
+	if err := q.Limit(limit).Find(&follows).Error; err != nil {
+		return nil, nil, err
+	}
+	ids := make([]int64, 0, len(follows))
+	for _, follow := range follows {
+		if column == "follower_id" {
+			ids = append(ids, follow.FollowerID)
+		} else {
+			ids = append(ids, follow.FollowingID)
+		}
+	}
+	users, err := s.followUsers(ctx, viewerID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *int64
+	if len(follows) == limit {
+		next = &follows[len(follows)-1].ID
+	}
+	return users, next, nil
+}
+
+// followUsers loads the profiles of ids, keeping their order, with flags relative to viewerID.
+func (s *FollowService) followUsers(ctx context.Context, viewerID int64, ids []int64) ([]dto.FollowUser, error) {
+	users := make([]dto.FollowUser, 0, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+	var profiles []model.UserProfile
+	if err := s.db.WithContext(ctx).Where("user_id IN ?", ids).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	byUser := make(map[int64]model.UserProfile, len(profiles))
+	for _, profile := range profiles {
+		byUser[profile.UserID] = profile
+	}
+	relationships, err := s.Relationships(ctx, viewerID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		profile, ok := byUser[id]
+		if !ok {
+			continue
+		}
+		rel := relationships[id]
+		users = append(users, dto.FollowUser{
+			UserID:          profile.UserID,
+			Nickname:        profile.Nickname,
+			AvatarURL:       profile.AvatarURL,
+			Intro:           profile.Intro,
+			IsFollowing:     rel.IsFollowing,
+			FollowsYou:      rel.FollowsYou,
+			FollowRequested: rel.FollowRequested,
+		})
+	}
+	return users, nil
+}
+
+// FollowMerchant follows a merchant and bumps its follower_count. Following twice is a no-op.
 func (s *FollowService) FollowMerchant(ctx context.Context, userID, merchantID int64) error {
-	follow := model.MerchantFollow{UserID: userID, MerchantID: merchantID}
-	return s.db.WithContext(ctx).FirstOrCreate(&follow, follow).Error
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var merchant model.Merchant
+		if err := tx.Select("id").First(&merchant, merchantID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMerchantNotFound
+			}
+			return err
+		}
+		follow := model.MerchantFollow{UserID: userID, MerchantID: merchantID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&follow)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&model.Merchant{}).Where("id = ?", merchantID).UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error
+	})
 }
 
+// UnfollowMerchant removes a merchant follow and rolls its follower_count back.
 func (s *FollowService) UnfollowMerchant(ctx context.Context, userID, merchantID int64) error {
-	return s.db.WithContext(ctx).Where("user_id = ? AND merchant_id = ?", userID, merchantID).Delete(&model.MerchantFollow{}).Error
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND merchant_id = ?", userID, merchantID).Delete(&model.MerchantFollow{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&model.Merchant{}).Where("id = ?", merchantID).UpdateColumn("follower_count", gorm.Expr("CASE WHEN follower_count > 0 THEN follower_count - 1 ELSE 0 END")).Error
+	})
+}
+
+// FollowStore follows a published store and bumps its follower_count. Following twice is a
+// no-op.
+func (s *FollowService) FollowStore(ctx context.Context, userID, storeID int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var store model.Store
+		if err := tx.Select("id").Where("status = ?", storeStatusPublished).First(&store, storeID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrStoreNotFound
+			}
+			return err
+		}
+		follow := model.StoreFollow{UserID: userID, StoreID: storeID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&follow)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&model.Store{}).Where("id = ?", storeID).UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error
+	})
+}
+
+// UnfollowStore removes a store follow and rolls its follower_count back. The store does not
+// have to be published any more.
+func (s *FollowService) UnfollowStore(ctx context.Context, userID, storeID int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND store_id = ?", userID, storeID).Delete(&model.StoreFollow{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&model.Store{}).Where("id = ?", storeID).UpdateColumn("follower_count", gorm.Expr("CASE WHEN follower_count > 0 THEN follower_count - 1 ELSE 0 END")).Error
+	})
+}
+
+// addFollow records a follow and bumps both users' counters. An existing follow is left alone
+// and reported as not added.
+func addFollow(tx *gorm.DB, followerID, followingID int64) (bool, error) {
+	follow := model.UserFollow{FollowerID: followerID, FollowingID: followingID}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&follow)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", followerID).UpdateColumn("following_count", gorm.Expr("following_count + 1")).Error; err != nil {
+		return false, err
+	}
+	if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", followingID).UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeFollow deletes a follow and rolls both users' counters back; a missing follow is a no-op.
+func removeFollow(tx *gorm.DB, followerID, followingID int64) error {
+	result := tx.Where("follower_id = ? AND following_id = ?", followerID, followingID).Delete(&model.UserFollow{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+	if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", followerID).UpdateColumn("following_count", gorm.Expr("CASE WHEN following_count > 0 THEN following_count - 1 ELSE 0 END")).Error; err != nil {
+		return err
+	}
+	return tx.Model(&model.UserProfile{}).Where("user_id = ?", followingID).UpdateColumn("follower_count", gorm.Expr("CASE WHEN follower_count > 0 THEN follower_count - 1 ELSE 0 END")).Error
+}
+
+func isPrivate(db *gorm.DB, userID int64) (bool, error) {
+	var privacy model.UserPrivacy
+	result := db.Where("user_id = ?", userID).Limit(1).Find(&privacy)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0 && !privacy.IsPublic, nil
+}
+
+func isFollowing(db *gorm.DB, followerID, followingID int64) (bool, error) {
+	var count int64
+	if err := db.Model(&model.UserFollow{}).Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
 }
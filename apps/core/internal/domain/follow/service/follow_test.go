@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
 )
 
 func TestFollowServiceUserFollow(t *testing.T) {
@@ -47,3 +49,147 @@ func TestFollowUpdatesCounts(t *testing.T) {
 		t.Fatalf("counts not updated correctly: %d/%d", p1.FollowingCount, p2.FollowerCount)
 	}
 }
+
+func createFollowUser(t *testing.T, db *gorm.DB, nickname string, public bool) model.User {
+	t.Helper()
+	user := model.User{Role: "user"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := db.Create(&model.UserProfile{UserID: user.ID, Nickname: nickname}).Error; err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	if err := db.Create(&model.UserPrivacy{UserID: user.ID, IsPublic: true}).Error; err != nil {
+		t.Fatalf("failed to create privacy: %v", err)
+	}
+	if !public {
+		db.Model(&model.UserPrivacy{}).Where("user_id = ?", user.ID).Update("is_public", false)
+	}
+	return user
+}
+
+func TestFollowingPrivateUserCreatesRequest(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewFollowService(db)
+	ctx := context.Background()
+	fan := createFollowUser(t, db, "fan", true)
+	owner := createFollowUser(t, db, "owner", false)
+
+	for i := 0; i < 2; i++ {
+		if err := svc.FollowUser(ctx, fan.ID, owner.ID); err != nil {
+			t.Fatalf("follow failed: %v", err)
+		}
+	}
+	rel, err := svc.Relationship(ctx, fan.ID, owner.ID)
+	if err != nil {
+		t.Fatalf("relationship failed: %v", err)
+	}
+	if rel.IsFollowing || !rel.FollowRequested {
+		t.Fatalf("expected a pending request, got %+v", rel)
+	}
+	if allowed, _ := svc.CanView(ctx, fan.ID, owner.ID); allowed {
+		t.Fatal("expected a requester to be kept out of a private profile")
+	}
+
+	requests, _, err := svc.FollowRequests(ctx, owner.ID, nil, 20)
+	if err != nil || len(requests) != 1 || requests[0].UserID != fan.ID {
+		t.Fatalf("expected one request from the fan, got %+v (%v)", requests, err)
+	}
+	if err := svc.ApproveFollowRequest(ctx, owner.ID, fan.ID); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	if err := svc.ApproveFollowRequest(ctx, owner.ID, fan.ID); !errors.Is(err, ErrFollowRequestNotFound) {
+		t.Fatalf("expected ErrFollowRequestNotFound on a second approval, got %v", err)
+	}
+
+	var fanProfile, ownerProfile model.UserProfile
+	db.First(&fanProfile, "user_id = ?", fan.ID)
+	db.First(&ownerProfile, "user_id = ?", owner.ID)
+	if fanProfile.FollowingCount != 1 || ownerProfile.FollowerCount != 1 {
+		t.Fatalf("expected counts 1/1, got %d/%d", fanProfile.FollowingCount, ownerProfile.FollowerCount)
+	}
+	if allowed, _ := svc.CanView(ctx, fan.ID, owner.ID); !allowed {
+		t.Fatal("expected an approved follower to see the private profile")
+	}
+	rel, _ = svc.Relationship(ctx, owner.ID, fan.ID)
+	if rel.IsFollowing || !rel.FollowsYou {
+		t.Fatalf("expected follows_you from the owner's side, got %+v", rel)
+	}
+
+	if err := svc.UnfollowUser(ctx, fan.ID, owner.ID); err != nil {
+		t.Fatalf("unfollow failed: %v", err)
+	}
+	db.First(&ownerProfile, "user_id = ?", owner.ID)
+	if ownerProfile.FollowerCount != 0 {
+		t.Fatalf("expected follower_count 0 after unfollow, got %d", ownerProfile.FollowerCount)
+	}
+}
+
+func TestRejectAndWithdrawFollowRequests(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewFollowService(db)
+	ctx := context.Background()
+	fan := createFollowUser(t, db, "fan", true)
+	other := createFollowUser(t, db, "other", true)
+	owner := createFollowUser(t, db, "owner", false)
+
+	svc.FollowUser(ctx, fan.ID, owner.ID)
+	svc.FollowUser(ctx, other.ID, owner.ID)
+	if err := svc.RejectFollowRequest(ctx, owner.ID, fan.ID); err != nil {
+		t.Fatalf("reject failed: %v", err)
+	}
+	if err := svc.UnfollowUser(ctx, other.ID, owner.ID); err != nil {
+		t.Fatalf("withdraw failed: %v", err)
+	}
+	var pending int64
+	db.Model(&model.FollowRequest{}).Count(&pending)
+	if pending != 0 {
+		t.Fatalf("expected no pending requests, got %d", pending)
+	}
+	if err := svc.FollowUser(ctx, fan.ID, fan.ID); !errors.Is(err, ErrCannotFollowSelf) {
+		t.Fatalf("expected ErrCannotFollowSelf, got %v", err)
+	}
+	if err := svc.FollowUser(ctx, fan.ID, 999); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestFollowListsRespectPrivacyAndCarryFlags(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewFollowService(db)
+	ctx := context.Background()
+	viewer := createFollowUser(t, db, "viewer", true)
+	friend := createFollowUser(t, db, "friend", true)
+	owner := createFollowUser(t, db, "owner", false)
+
+	db.Model(&model.UserPrivacy{}).Where("user_id = ?", owner.ID).Update("is_public", true)
+	svc.FollowUser(ctx, friend.ID, owner.ID)
+	svc.FollowUser(ctx, friend.ID, viewer.ID)
+	svc.FollowUser(ctx, viewer.ID, friend.ID)
+	db.Model(&model.UserPrivacy{}).Where("user_id = ?", owner.ID).Update("is_public", false)
+
+	if _, _, err := svc.Followers(ctx, viewer.ID, owner.ID, nil, 20); !errors.Is(err, ErrProfilePrivate) {
+		t.Fatalf("expected ErrProfilePrivate for a non-follower, got %v", err)
+	}
+	if _, _, err := svc.Following(ctx, 0, owner.ID, nil, 20); !errors.Is(err, ErrProfilePrivate) {
+		t.Fatalf("expected ErrProfilePrivate for an anonymous viewer, got %v", err)
+	}
+
+	followers, _, err := svc.Followers(ctx, owner.ID, owner.ID, nil, 20)
+	if err != nil || len(followers) != 1 {
+		t.Fatalf("expected the owner to see one follower, got %+v (%v)", followers, err)
+	}
+	if followers[0].UserID != friend.ID || !followers[0].FollowsYou || followers[0].IsFollowing {
+		t.Fatalf("unexpected flags for the owner's follower: %+v", followers[0])
+	}
+
+	following, _, err := svc.Following(ctx, viewer.ID, friend.ID, nil, 20)
+	if err != nil || len(following) != 2 {
+		t.Fatalf("expected two followed users, got %+v (%v)", following, err)
+	}
+	for _, user := range following {
+		if user.UserID == viewer.ID && (user.IsFollowing || user.FollowsYou) {
+			t.Fatalf("expected no flags on the viewer's own entry, got %+v", user)
+		}
+	}
+}
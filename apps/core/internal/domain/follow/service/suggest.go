@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSuggestionLimit = 20
+	maxSuggestionLimit     = 50
+	// suggestionCandidates caps how many users each signal contributes before ranking.
+	suggestionCandidates = 200
+	// mutualFollowerWeight ranks a shared follow above a shared store.
+	mutualFollowerWeight = 2
+)
+
+type suggestionScore struct {
+	UserID int64
+	Count  int
+}
+
+// Suggestions returns "people you may know" for userID: users followed by people they follow
+// and users who reviewed the same stores. Users already followed or requested, and users on
+// either side of a block or mute, are left out.
+func (s *FollowService) Suggestions(ctx context.Context, userID int64, limit int) ([]dto.SuggestedUser, error) {
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+	limit = min(limit, maxSuggestionLimit)
+	db := s.db.WithContext(ctx)
+
+	var mutuals []suggestionScore
+	if err := db.Table("user_follows AS f1").
+		Select("f2.following_id AS user_id, COUNT(*) AS count").
+		Joins("JOIN user_follows AS f2 ON f2.follower_id = f1.following_id").
+		Where("f1.follower_id = ? AND f2.following_id <> ?", userID, userID).
+		Scopes(notConnected(userID, "f2.following_id"), ExcludeBlockedOrMuted(userID, "f2.following_id")).
+		Group("f2.following_id").
+		Order("count desc").Order("f2.following_id asc").
+		Limit(suggestionCandidates).
+		Scan(&mutuals).Error; err != nil {
+		return nil, err
+	}
+
+	var shared []suggestionScore
+	if err := db.Table("reviews AS r1").
+		Select("r2.user_id AS user_id, COUNT(DISTINCT r2.store_id) AS count").
+		Joins("JOIN reviews AS r2 ON r2.store_id = r1.store_id").
+		Where("r1.user_id = ? AND r2.user_id <> ?", userID, userID).
+		Where("r1.store_id IS NOT NULL AND r1.deleted_at IS NULL AND r2.deleted_at IS NULL AND r2.status = ?", model.ContentStatusVisible).
+		Scopes(notConnected(userID, "r2.user_id"), ExcludeBlockedOrMuted(userID, "r2.user_id")).
+		Group("r2.user_id").
+		Order("count desc").Order("r2.user_id asc").
+		Limit(suggestionCandidates).
+		Scan(&shared).Error; err != nil {
+		return nil, err
+	}
+
+	mutualCounts := make(map[int64]int, len(mutuals))
+	sharedCounts := make(map[int64]int, len(shared))
+	var ids []int64
+	for _, row := range mutuals {
+		mutualCounts[row.UserID] = row.Count
+		ids = append(ids, row.UserID)
+	}
+	for _, row := range shared {
+		if _, seen := mutualCounts[row.UserID]; !seen {
+			ids = append(ids, row.UserID)
+		}
+		sharedCounts[row.UserID] = row.Count
+	}
+	score := func(id int64) int { return mutualCounts[id]*mutualFollowerWeight + sharedCounts[id] }
+	sort.SliceStable(ids, func(i, j int) bool {
+		if score(ids[i]) != score(ids[j]) {
+			return score(ids[i]) > score(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	users, err := s.followUsers(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	suggestions := make([]dto.SuggestedUser, 0, len(users))
+	for _, user := range users {
+		reason := dto.SuggestionReasonReviewedSameStores
+		if mutualCounts[user.UserID] > 0 {
+			reason = dto.SuggestionReasonFollowedByFollowing
+		}
+		suggestions = append(suggestions, dto.SuggestedUser{
+			FollowUser:          user,
+			MutualFollowerCount: mutualCounts[user.UserID],
+			SharedStoreCount:    sharedCounts[user.UserID],
+			Reason:              reason,
+		})
+	}
+	return suggestions, nil
+}
+
+// MutualFollowerCount counts the followers of userID whom viewerID follows.
+func (s *FollowService) MutualFollowerCount(ctx context.Context, viewerID, userID int64) (int64, error) {
+	if viewerID == 0 || viewerID == userID {
+		return 0, nil
+	}
+	db := s.db.WithContext(ctx)
+	var count int64
+	err := db.Model(&model.UserFollow{}).
+		Where("following_id = ? AND follower_id IN (?)", userID,
+			db.Model(&model.UserFollow{}).Select("following_id").Where("follower_id = ?", viewerID)).
+		Scopes(ExcludeBlocked(viewerID, "user_follows.follower_id")).
+		Count(&count).Error
+	return count, err
+}
+
+// notConnected drops users userID already follows or has asked to follow.
+func notConnected(userID int64, column string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("NOT EXISTS (SELECT 1 FROM user_follows WHERE user_follows.follower_id = ? AND user_follows.following_id = "+column+")", userID).
+			Where("NOT EXISTS (SELECT 1 FROM follow_requests WHERE follow_requests.requester_id = ? AND follow_requests.target_id = "+column+")", userID)
+	}
+}
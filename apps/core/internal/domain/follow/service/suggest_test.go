@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+)
+
+func TestMerchantAndStoreFollowsMaintainCounters(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewFollowService(db)
+	ctx := context.Background()
+	fan := createFollowUser(t, db, "fan", true)
+	merchant := model.Merchant{Name: "Cafe"}
+	db.Create(&merchant)
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown", Status: storeStatusPublished}
+	db.Create(&store)
+	draft := model.Store{MerchantID: merchant.ID, Name: "Soon"}
+	db.Create(&draft)
+
+	for i := 0; i < 2; i++ {
+		if err := svc.FollowMerchant(ctx, fan.ID, merchant.ID); err != nil {
+			t.Fatalf("follow merchant failed: %v", err)
+		}
+		if err := svc.FollowStore(ctx, fan.ID, store.ID); err != nil {
+			t.Fatalf("follow store failed: %v", err)
+		}
+	}
+	db.First(&merchant, merchant.ID)
+	db.First(&store, store.ID)
+	if merchant.FollowerCount != 1 || store.FollowerCount != 1 {
+		t.Fatalf("expected follower_count 1 on both, got %d and %d", merchant.FollowerCount, store.FollowerCount)
+	}
+	if err := svc.FollowStore(ctx, fan.ID, draft.ID); !errors.Is(err, ErrStoreNotFound) {
+		t.Fatalf("expected unpublished stores to be unfollowable, got %v", err)
+	}
+	if err := svc.FollowMerchant(ctx, fan.ID, 999); !errors.Is(err, ErrMerchantNotFound) {
+		t.Fatalf("expected ErrMerchantNotFound, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := svc.UnfollowMerchant(ctx, fan.ID, merchant.ID); err != nil {
+			t.Fatalf("unfollow merchant failed: %v", err)
+		}
+		if err := svc.UnfollowStore(ctx, fan.ID, store.ID); err != nil {
+			t.Fatalf("unfollow store failed: %v", err)
+		}
+	}
+	db.First(&merchant, merchant.ID)
+	db.First(&store, store.ID)
+	if merchant.FollowerCount != 0 || store.FollowerCount != 0 {
+		t.Fatalf("expected follower_count 0 on both, got %d and %d", merchant.FollowerCount, store.FollowerCount)
+	}
+}
+
+func TestSuggestionsRankSecondDegreeFollowsAndSharedStores(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewFollowService(db)
+	ctx := context.Background()
+	me := createFollowUser(t, db, "me", true)
+	friendA := createFollowUser(t, db, "friend-a", true)
+	friendB := createFollowUser(t, db, "friend-b", true)
+	popular := createFollowUser(t, db, "popular", true)
+	reviewer := createFollowUser(t, db, "reviewer", true)
+	blocked := createFollowUser(t, db, "blocked", true)
+
+	for _, follow := range [][2]int64{
+		{me.ID, friendA.ID}, {me.ID, friendB.ID},
+		{friendA.ID, popular.ID}, {friendB.ID, popular.ID},
+		{friendA.ID, blocked.ID}, {friendA.ID, me.ID},
+	} {
+		if err := svc.FollowUser(ctx, follow[0], follow[1]); err != nil {
+			t.Fatalf("follow failed: %v", err)
+		}
+	}
+	if err := svc.Block(ctx, me.ID, blocked.ID); err != nil {
+		t.Fatalf("block failed: %v", err)
+	}
+	merchant := model.Merchant{Name: "Cafe"}
+	db.Create(&merchant)
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown", Status: storeStatusPublished}
+	db.Create(&store)
+	for _, userID := range []int64{me.ID, reviewer.ID} {
+		db.Create(&model.Review{UserID: userID, MerchantID: merchant.ID, VenueID: merchant.ID, StoreID: &store.ID, Rating: 4, Content: "ok"})
+	}
+
+	suggestions, err := svc.Suggestions(ctx, me.ID, 10)
+	if err != nil {
+		t.Fatalf("suggestions failed: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("expected two suggestions, got %+v", suggestions)
+	}
+	if suggestions[0].UserID != popular.ID || suggestions[0].MutualFollowerCount != 2 || suggestions[0].Reason != "followed_by_people_you_follow" {
+		t.Fatalf("expected the second-degree follow first, got %+v", suggestions[0])
+	}
+	if suggestions[1].UserID != reviewer.ID || suggestions[1].SharedStoreCount != 1 || suggestions[1].Reason != "reviewed_same_stores" {
+		t.Fatalf("expected the co-reviewer second, got %+v", suggestions[1])
+	}
+
+	if count, err := svc.MutualFollowerCount(ctx, me.ID, popular.ID); err != nil || count != 2 {
+		t.Fatalf("expected 2 mutual followers, got %d (%v)", count, err)
+	}
+	if count, err := svc.MutualFollowerCount(ctx, 0, popular.ID); err != nil || count != 0 {
+		t.Fatalf("expected no mutual followers for anonymous viewers, got %d (%v)", count, err)
+	}
+}
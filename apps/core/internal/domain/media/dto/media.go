@@ -21,3 +21,19 @@ type UploadInfo struct {
 type PresignedURLResponse struct {
 	Uploads []UploadInfo `json:"uploads"`
 }
+
+// AnalysisResponse is the outcome of POST /media/:id/analysis.
+type AnalysisResponse struct {
+	ID              int64      `json:"id"`
+	Status          string     `json:"status"`
+	MimeType        string     `json:"mime_type"`
+	FileSize        int64      `json:"file_size"`
+	Width           int        `json:"width"`
+	Height          int        `json:"height"`
+	PerceptualHash  string     `json:"perceptual_hash"`
+	DuplicateOfID   *int64     `json:"duplicate_of_id,omitempty"`
+	SafetyLabel     string     `json:"safety_label"`
+	SafetyScore     float64    `json:"safety_score"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	AnalyzedAt      *time.Time `json:"analyzed_at,omitempty"`
+}
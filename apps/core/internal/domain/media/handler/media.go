@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/media/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/media/service"
 	"github.com/gin-gonic/gin"
@@ -16,7 +17,7 @@ type MediaHandler struct {
 
 func NewMediaHandler(svc *service.MediaService) *MediaHandler {
 	if svc == nil {
-		svc = service.NewMediaService(nil, nil)
+		svc = service.NewMediaService(nil, nil, nil, config.MediaConfig{})
 	}
 	return &MediaHandler{svc: svc}
 }
@@ -41,14 +42,16 @@ func (h *MediaHandler) CreateUpload(c *gin.Context) {
 
 // AnalyzeMedia godoc
 // @Summary Analyze media upload
-// @Description Triggers analysis for a media upload
+// @Description Verifies an uploaded image (type, dimensions, duplicates, safety) and approves or rejects it
 // @Tags media
 // @Produce json
 // @Param id path int true "Upload ID"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} dto.AnalysisResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /media/{id}/analysis [post]
 func (h *MediaHandler) Analyze(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -56,11 +59,22 @@ func (h *MediaHandler) Analyze(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
 		return
 	}
-	if err := h.svc.Analyze(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	userID := c.GetInt64("user_id")
+	result, err := h.svc.Analyze(c.Request.Context(), userID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUploadNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrUploadForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrStorageUnavailable), errors.Is(err, service.ErrClassifierFailed):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{})
+	c.JSON(http.StatusOK, result)
 }
 
 // CreatePresignedURLs godoc
@@ -1,9 +1,12 @@
 package media
 
 import (
+	"context"
 	"log"
+	"strings"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	aiservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/media/handler"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/media/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
@@ -25,7 +28,18 @@ func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
 		log.Printf("Warning: Failed to initialize R2 client: %v", err)
 	}
 
-	svc := service.NewMediaService(nil, r2Client)
+	var classifier aiservice.ImageClassifier
+	if strings.EqualFold(cfg.Media.SafetyClassifier, "gemini") {
+		gemini, err := aiservice.NewGeminiClassifier(context.Background(), cfg.Gemini)
+		if err != nil {
+			log.Printf("Warning: Gemini image classifier unavailable, uploads stay pending: %v", err)
+			classifier = service.UnavailableImageClassifier{Err: err}
+		} else {
+			classifier = gemini
+		}
+	}
+
+	svc := service.NewMediaService(nil, r2Client, classifier, cfg.Media)
 	h := handler.NewMediaHandler(svc)
 
 	media := r.Group("/media", middleware.JWTAuth(cfg.JWT))
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"strings"
+	"time"
+
+	aidto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/dto"
+	aiservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/media/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+// Built-in limits used when the corresponding config value is zero.
+const (
+	defaultMaxFileBytes = 10 << 20
+	defaultMinDimension = 32
+	defaultMaxDimension = 8192
+	defaultHashDistance = 4
+)
+
+// Rejection reasons stored in MediaUpload.RejectionReason.
+const (
+	RejectFileTooLarge        = "file_too_large"
+	RejectUnsupportedType     = "unsupported_type"
+	RejectContentTypeMismatch = "content_type_mismatch"
+	RejectUnreadableImage     = "unreadable_image"
+	RejectDimensions          = "dimensions_out_of_range"
+	RejectKnownBad            = "known_bad_image"
+	RejectDuplicateOfRejected = "duplicate_of_rejected"
+	RejectUnsafe              = "unsafe_content"
+)
+
+var (
+	ErrUploadNotFound     = errors.New("upload not found")
+	ErrUploadForbidden    = errors.New("upload forbidden")
+	ErrStorageUnavailable = errors.New("media storage unavailable")
+	ErrClassifierFailed   = errors.New("image safety classifier failed")
+)
+
+// ObjectFetcher reads uploaded objects back from storage; *storage.R2Client implements it.
+type ObjectFetcher interface {
+	GetObject(ctx context.Context, objectKey string, maxBytes int64) ([]byte, error)
+}
+
+// LocalImageClassifier is the development stand-in for the Gemini vision classifier: it
+// labels every image safe.
+type LocalImageClassifier struct{}
+
+func (LocalImageClassifier) ClassifyImage(context.Context, string, []byte) (aidto.Classification, error) {
+	return aidto.Classification{Label: aidto.LabelSafe, Confidence: 1, Categories: []string{}}, nil
+}
+
+var _ aiservice.ImageClassifier = LocalImageClassifier{}
+
+// UnavailableImageClassifier stands in for a configured classifier that could not be
+// built. It fails every call, so Analyze reports ErrClassifierFailed and uploads stay
+// pending instead of being approved unchecked.
+type UnavailableImageClassifier struct {
+	Err error
+}
+
+func (c UnavailableImageClassifier) ClassifyImage(context.Context, string, []byte) (aidto.Classification, error) {
+	return aidto.Classification{}, c.Err
+}
+
+var _ aiservice.ImageClassifier = UnavailableImageClassifier{}
+
+// analysisLimits is the resolved form of config.MediaConfig.
+type analysisLimits struct {
+	maxFileBytes   int64
+	minDimension   int
+	maxDimension   int
+	knownBadHashes []string
+	hashDistance   int
+}
+
+// Analyze runs the image pipeline for an upload owned by userID: size, magic-byte and
+// dimension checks, perceptual hashing for known-bad and duplicate detection, then the
+// safety classifier. The upload moves from pending to approved or rejected; analyzing an
+// upload that already left pending returns the stored result.
+func (s *MediaService) Analyze(ctx context.Context, userID, id int64) (*dto.AnalysisResponse, error) {
+	var upload model.MediaUpload
+	if err := s.db.WithContext(ctx).First(&upload, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, err
+	}
+	if upload.UserID != userID {
+		return nil, ErrUploadForbidden
+	}
+	if upload.Status != model.MediaStatusPending {
+		return analysisResponse(upload), nil
+	}
+	if s.objects == nil {
+		return nil, ErrStorageUnavailable
+	}
+
+	data, err := s.objects.GetObject(ctx, upload.ObjectKey, s.limits.maxFileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrStorageUnavailable, err)
+	}
+
+	if err := s.inspect(ctx, &upload, data); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	upload.AnalyzedAt = &now
+	if upload.RejectionReason == "" {
+		upload.Status = model.MediaStatusApproved
+	} else {
+		upload.Status = model.MediaStatusRejected
+	}
+	if err := s.db.WithContext(ctx).Model(&upload).Select(
+		"status", "file_size", "detected_mime_type", "width", "height", "perceptual_hash",
+		"duplicate_of_id", "safety_label", "safety_score", "rejection_reason", "analyzed_at",
+	).Updates(&upload).Error; err != nil {
+		return nil, err
+	}
+	return analysisResponse(upload), nil
+}
+
+// inspect fills the analysis columns on upload. A failed check sets RejectionReason and
+// stops the pipeline; only infrastructure failures are returned as errors.
+func (s *MediaService) inspect(ctx context.Context, upload *model.MediaUpload, data []byte) error {
+	upload.FileSize = int64(len(data))
+	if upload.FileSize > s.limits.maxFileBytes {
+		upload.RejectionReason = RejectFileTooLarge
+		return nil
+	}
+
+	detected := http.DetectContentType(data)
+	upload.DetectedMimeType = detected
+	if _, ok := allowedContentTypes[detected]; !ok {
+		upload.RejectionReason = RejectUnsupportedType
+		return nil
+	}
+	if upload.MimeType != "" && !strings.EqualFold(upload.MimeType, detected) {
+		upload.RejectionReason = RejectContentTypeMismatch
+		return nil
+	}
+
+	width, height, err := decodeDimensions(detected, data)
+	if err != nil {
+		upload.RejectionReason = RejectUnreadableImage
+		return nil
+	}
+	upload.Width, upload.Height = width, height
+	if width < s.limits.minDimension || height < s.limits.minDimension ||
+		width > s.limits.maxDimension || height > s.limits.maxDimension {
+		upload.RejectionReason = RejectDimensions
+		return nil
+	}
+
+	// WebP cannot be decoded with the standard library, so it skips perceptual hashing.
+	if detected != "image/webp" {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			upload.RejectionReason = RejectUnreadableImage
+			return nil
+		}
+		upload.PerceptualHash = differenceHash(img)
+		if s.matchesKnownBad(upload.PerceptualHash) {
+			upload.RejectionReason = RejectKnownBad
+			return nil
+		}
+		duplicate, err := s.findDuplicate(ctx, *upload)
+		if err != nil {
+			return err
+		}
+		if duplicate != nil {
+			upload.DuplicateOfID = &duplicate.ID
+			if duplicate.Status == model.MediaStatusRejected {
+				upload.RejectionReason = RejectDuplicateOfRejected
+				return nil
+			}
+		}
+	}
+
+	result, err := s.classifier.ClassifyImage(ctx, detected, data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrClassifierFailed, err)
+	}
+	upload.SafetyLabel = result.Label
+	upload.SafetyScore = result.Confidence
+	if result.Label == aidto.LabelUnsafe {
+		upload.RejectionReason = RejectUnsafe
+	}
+	return nil
+}
+
+func (s *MediaService) matchesKnownBad(hash string) bool {
+	for _, bad := range s.limits.knownBadHashes {
+		if d := hashDistance(hash, bad); d >= 0 && d <= s.limits.hashDistance {
+			return true
+		}
+	}
+	return false
+}
+
+// findDuplicate returns the earliest analyzed upload whose perceptual hash is within the
+// configured Hamming distance, preferring a rejected one so re-uploads of rejected images
+// stay rejected.
+func (s *MediaService) findDuplicate(ctx context.Context, upload model.MediaUpload) (*model.MediaUpload, error) {
+	query := s.db.WithContext(ctx).
+		Where("perceptual_hash <> '' AND id <> ? AND status <> ?", upload.ID, model.MediaStatusPending)
+	if bands, args := hashBands(upload.PerceptualHash, s.limits.hashDistance); bands != "" {
+		query = query.Where(bands, args...)
+	}
+	var candidates []model.MediaUpload
+	if err := query.Order("id asc").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	var duplicate *model.MediaUpload
+	for i := range candidates {
+		if d := hashDistance(upload.PerceptualHash, candidates[i].PerceptualHash); d < 0 || d > s.limits.hashDistance {
+			continue
+		}
+		if candidates[i].Status == model.MediaStatusRejected {
+			return &candidates[i], nil
+		}
+		if duplicate == nil {
+			duplicate = &candidates[i]
+		}
+	}
+	return duplicate, nil
+}
+
+// hashBands narrows duplicate candidates in SQL. The hex hash is split into distance+1
+// bands; a hash within distance bits differs in at most distance hex digits, so it matches
+// at least one band exactly. It returns no condition when the bands would be empty.
+func hashBands(hash string, distance int) (string, []interface{}) {
+	bands := distance + 1
+	if distance < 0 || bands > len(hash) {
+		return "", nil
+	}
+	conditions := make([]string, 0, bands)
+	args := make([]interface{}, 0, bands)
+	for i := 0; i < bands; i++ {
+		start, end := i*len(hash)/bands, (i+1)*len(hash)/bands
+		conditions = append(conditions, fmt.Sprintf("substr(perceptual_hash, %d, %d) = ?", start+1, end-start))
+		args = append(args, hash[start:end])
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+func analysisResponse(upload model.MediaUpload) *dto.AnalysisResponse {
+	return &dto.AnalysisResponse{
+		ID:              upload.ID,
+		Status:          upload.Status,
+		MimeType:        upload.DetectedMimeType,
+		FileSize:        upload.FileSize,
+		Width:           upload.Width,
+		Height:          upload.Height,
+		PerceptualHash:  upload.PerceptualHash,
+		DuplicateOfID:   upload.DuplicateOfID,
+		SafetyLabel:     upload.SafetyLabel,
+		SafetyScore:     upload.SafetyScore,
+		RejectionReason: upload.RejectionReason,
+		AnalyzedAt:      upload.AnalyzedAt,
+	}
+}
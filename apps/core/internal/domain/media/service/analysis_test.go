@@ -0,0 +1,252 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	aidto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type fakeFetcher map[string][]byte
+
+func (f fakeFetcher) GetObject(_ context.Context, key string, _ int64) ([]byte, error) {
+	data, ok := f[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+type fakeImageClassifier struct {
+	label string
+	calls int
+}
+
+func (f *fakeImageClassifier) ClassifyImage(context.Context, string, []byte) (aidto.Classification, error) {
+	f.calls++
+	return aidto.Classification{Label: f.label, Confidence: 0.9}, nil
+}
+
+func gradientPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8((x * 255) / width)})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func setupAnalysisTest(t *testing.T, label string, cfg config.MediaConfig) (*MediaService, *gorm.DB, fakeFetcher, *fakeImageClassifier) {
+	t.Helper()
+	db := testutil.SetupTestDB(t)
+	classifier := &fakeImageClassifier{label: label}
+	svc := NewMediaService(db, nil, classifier, cfg)
+	objects := fakeFetcher{}
+	svc.objects = objects
+	return svc, db, objects, classifier
+}
+
+func createUpload(t *testing.T, db *gorm.DB, userID int64, key, mimeType string) model.MediaUpload {
+	t.Helper()
+	upload := model.MediaUpload{
+		UUID:      key,
+		UserID:    userID,
+		ObjectKey: key,
+		FileURL:   "https://cdn.example.com/" + key,
+		MimeType:  mimeType,
+		Status:    model.MediaStatusPending,
+	}
+	if err := db.Create(&upload).Error; err != nil {
+		t.Fatalf("failed to create upload: %v", err)
+	}
+	return upload
+}
+
+func TestAnalyzeApprovesValidImage(t *testing.T) {
+	svc, db, objects, classifier := setupAnalysisTest(t, aidto.LabelSafe, config.MediaConfig{})
+	upload := createUpload(t, db, 1, "a.png", "image/png")
+	objects["a.png"] = gradientPNG(t, 64, 48)
+
+	got, err := svc.Analyze(context.Background(), 1, upload.ID)
+	if err != nil {
+		t.Fatalf("analyze failed: %v", err)
+	}
+	if got.Status != model.MediaStatusApproved || got.Width != 64 || got.Height != 48 {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+	if len(got.PerceptualHash) != 16 || classifier.calls != 1 {
+		t.Fatalf("expected hash and one classifier call, got %+v calls=%d", got, classifier.calls)
+	}
+
+	var stored model.MediaUpload
+	if err := db.First(&stored, upload.ID).Error; err != nil {
+		t.Fatalf("failed to reload upload: %v", err)
+	}
+	if stored.Status != model.MediaStatusApproved || stored.AnalyzedAt == nil {
+		t.Fatalf("expected persisted approval, got %+v", stored)
+	}
+
+	// A second call returns the stored result without re-running the pipeline.
+	if _, err := svc.Analyze(context.Background(), 1, upload.ID); err != nil {
+		t.Fatalf("repeat analyze failed: %v", err)
+	}
+	if classifier.calls != 1 {
+		t.Fatalf("expected classifier not to run again, calls=%d", classifier.calls)
+	}
+}
+
+func TestAnalyzeRejectsFailedChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		mimeType string
+		data     func(t *testing.T) []byte
+		cfg      config.MediaConfig
+		label    string
+		want     string
+	}{
+		{"content type mismatch", "image/jpeg", func(t *testing.T) []byte { return gradientPNG(t, 64, 64) }, config.MediaConfig{}, aidto.LabelSafe, RejectContentTypeMismatch},
+		{"not an image", "image/png", func(*testing.T) []byte { return []byte("plain text pretending to be an image") }, config.MediaConfig{}, aidto.LabelSafe, RejectUnsupportedType},
+		{"too small", "image/png", func(t *testing.T) []byte { return gradientPNG(t, 16, 16) }, config.MediaConfig{}, aidto.LabelSafe, RejectDimensions},
+		{"too large", "image/png", func(t *testing.T) []byte { return gradientPNG(t, 64, 64) }, config.MediaConfig{MaxFileBytes: 10}, aidto.LabelSafe, RejectFileTooLarge},
+		{"unsafe", "image/png", func(t *testing.T) []byte { return gradientPNG(t, 64, 64) }, config.MediaConfig{}, aidto.LabelUnsafe, RejectUnsafe},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, db, objects, _ := setupAnalysisTest(t, tc.label, tc.cfg)
+			upload := createUpload(t, db, 1, "x", tc.mimeType)
+			objects["x"] = tc.data(t)
+
+			got, err := svc.Analyze(context.Background(), 1, upload.ID)
+			if err != nil {
+				t.Fatalf("analyze failed: %v", err)
+			}
+			if got.Status != model.MediaStatusRejected || got.RejectionReason != tc.want {
+				t.Fatalf("expected rejection %q, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAnalyzeRejectsKnownBadAndDuplicatesOfRejected(t *testing.T) {
+	data := gradientPNG(t, 64, 64)
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to decode png: %v", err)
+	}
+	svc, db, objects, _ := setupAnalysisTest(t, aidto.LabelSafe, config.MediaConfig{
+		KnownBadHashes: []string{differenceHash(img)},
+	})
+
+	first := createUpload(t, db, 1, "bad.png", "image/png")
+	objects["bad.png"] = data
+	got, err := svc.Analyze(context.Background(), 1, first.ID)
+	if err != nil {
+		t.Fatalf("analyze failed: %v", err)
+	}
+	if got.RejectionReason != RejectKnownBad {
+		t.Fatalf("expected known-bad rejection, got %+v", got)
+	}
+
+	svc.limits.knownBadHashes = nil
+	second := createUpload(t, db, 2, "again.png", "image/png")
+	objects["again.png"] = data
+	got, err = svc.Analyze(context.Background(), 2, second.ID)
+	if err != nil {
+		t.Fatalf("analyze failed: %v", err)
+	}
+	if got.RejectionReason != RejectDuplicateOfRejected || got.DuplicateOfID == nil || *got.DuplicateOfID != first.ID {
+		t.Fatalf("expected duplicate-of-rejected, got %+v", got)
+	}
+}
+
+func TestFindDuplicateMatchesWithinHashDistance(t *testing.T) {
+	svc, db, _, _ := setupAnalysisTest(t, aidto.LabelSafe, config.MediaConfig{})
+	analyzed := func(key, hash, status string) model.MediaUpload {
+		upload := createUpload(t, db, 1, key, "image/png")
+		if err := db.Model(&upload).Updates(map[string]interface{}{"perceptual_hash": hash, "status": status}).Error; err != nil {
+			t.Fatalf("failed to update upload: %v", err)
+		}
+		return upload
+	}
+	approved := analyzed("approved.png", "00ff00ff00ff00ff", model.MediaStatusApproved)
+	_ = analyzed("far.png", "ff00ff00ff00ff00", model.MediaStatusRejected)
+
+	// Four bits off, spread over different hex digits.
+	got, err := svc.findDuplicate(context.Background(), model.MediaUpload{ID: 99, PerceptualHash: "01fe00ff01fe00ff"})
+	if err != nil || got == nil || got.ID != approved.ID {
+		t.Fatalf("expected the near-identical upload, got %+v (%v)", got, err)
+	}
+	rejected := analyzed("rejected.png", "00ff00ff00ff00fe", model.MediaStatusRejected)
+	got, err = svc.findDuplicate(context.Background(), model.MediaUpload{ID: 99, PerceptualHash: "00ff00ff00ff00ff"})
+	if err != nil || got == nil || got.ID != rejected.ID {
+		t.Fatalf("expected a rejected match to win, got %+v (%v)", got, err)
+	}
+	got, err = svc.findDuplicate(context.Background(), model.MediaUpload{ID: 99, PerceptualHash: "0f0f0f0f0f0f0f0f"})
+	if err != nil || got != nil {
+		t.Fatalf("expected no duplicate beyond the distance, got %+v (%v)", got, err)
+	}
+}
+
+func TestDifferenceHashSurvivesDownscaling(t *testing.T) {
+	decode := func(data []byte) image.Image {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("failed to decode png: %v", err)
+		}
+		return img
+	}
+	large := decode(gradientPNG(t, 2048, 1024))
+	if b := downscale(large, hashSampleSide).Bounds(); b.Dx() != hashSampleSide || b.Dy() != hashSampleSide {
+		t.Fatalf("expected a %dpx sample, got %v", hashSampleSide, b)
+	}
+	small := decode(gradientPNG(t, 64, 64))
+	if d := hashDistance(differenceHash(large), differenceHash(small)); d < 0 || d > defaultHashDistance {
+		t.Fatalf("expected resized copies to hash alike, distance %d", d)
+	}
+}
+
+func TestAnalyzeEnforcesOwnership(t *testing.T) {
+	svc, db, _, _ := setupAnalysisTest(t, aidto.LabelSafe, config.MediaConfig{})
+	upload := createUpload(t, db, 1, "a.png", "image/png")
+
+	if _, err := svc.Analyze(context.Background(), 2, upload.ID); !errors.Is(err, ErrUploadForbidden) {
+		t.Fatalf("expected forbidden, got %v", err)
+	}
+	if _, err := svc.Analyze(context.Background(), 1, upload.ID+100); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestAnalyzeKeepsUploadPendingWhenClassifierUnavailable(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewMediaService(db, nil, UnavailableImageClassifier{Err: errors.New("missing api key")}, config.MediaConfig{})
+	objects := fakeFetcher{"a.png": gradientPNG(t, 64, 48)}
+	svc.objects = objects
+	upload := createUpload(t, db, 1, "a.png", "image/png")
+
+	if _, err := svc.Analyze(context.Background(), 1, upload.ID); !errors.Is(err, ErrClassifierFailed) {
+		t.Fatalf("expected classifier failure, got %v", err)
+	}
+	var stored model.MediaUpload
+	if err := db.First(&stored, upload.ID).Error; err != nil {
+		t.Fatalf("failed to reload upload: %v", err)
+	}
+	if stored.Status != model.MediaStatusPending {
+		t.Fatalf("expected upload to stay pending, got %q", stored.Status)
+	}
+}
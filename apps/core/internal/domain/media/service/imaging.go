@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math/bits"
+	"strconv"
+)
+
+var errUnreadableImage = errors.New("unreadable image")
+
+// decodeDimensions reads only the image header. WebP is parsed by hand because the
+// standard library has no decoder for it.
+func decodeDimensions(mimeType string, data []byte) (int, int, error) {
+	if mimeType == "image/webp" {
+		return webpDimensions(data)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, errUnreadableImage
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// webpDimensions handles the three WebP bitstream variants: lossy (VP8), lossless (VP8L)
+// and extended (VP8X).
+func webpDimensions(data []byte) (int, int, error) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, errUnreadableImage
+	}
+	switch string(data[12:16]) {
+	case "VP8 ":
+		width := int(binary.LittleEndian.Uint16(data[26:28]) & 0x3fff)
+		height := int(binary.LittleEndian.Uint16(data[28:30]) & 0x3fff)
+		return width, height, nil
+	case "VP8L":
+		b := binary.LittleEndian.Uint32(data[21:25])
+		return int(b&0x3fff) + 1, int((b>>14)&0x3fff) + 1, nil
+	case "VP8X":
+		width := int(data[24]) | int(data[25])<<8 | int(data[26])<<16
+		height := int(data[27]) | int(data[28])<<8 | int(data[29])<<16
+		return width + 1, height + 1, nil
+	default:
+		return 0, 0, errUnreadableImage
+	}
+}
+
+// hashSampleSide bounds the side of the grayscale copy an image is hashed from, so hashing
+// a large photo reads a fixed number of pixels.
+const hashSampleSide = 256
+
+// differenceHash computes a 64-bit dHash: the image is reduced to a 9x8 grid of average
+// luminance and each bit records whether a cell is brighter than its right neighbour.
+// The hash survives re-encoding and resizing, so near-identical images land within a few
+// bits of each other.
+func differenceHash(img image.Image) string {
+	const cols, rows = 9, 8
+	img = downscale(img, hashSampleSide)
+	var sums [rows][cols]float64
+	var counts [rows][cols]int
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := (y - bounds.Min.Y) * rows / height
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			col := (x - bounds.Min.X) * cols / width
+			r, g, b, _ := img.At(x, y).RGBA()
+			sums[row][col] += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			counts[row][col]++
+		}
+	}
+
+	var hash uint64
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols-1; col++ {
+			hash <<= 1
+			if average(sums[row][col], counts[row][col]) < average(sums[row][col+1], counts[row][col+1]) {
+				hash |= 1
+			}
+		}
+	}
+
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], hash)
+	return hex.EncodeToString(buf[:])
+}
+
+// downscale returns a grayscale copy of img at most maxSide pixels on each side, sampling
+// the source pixel at the centre of each target pixel. Smaller images are returned as is.
+func downscale(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return img
+	}
+	targetW, targetH := min(width, maxSide), min(height, maxSide)
+	out := image.NewGray(image.Rect(0, 0, targetW, targetH))
+	for y := 0; y < targetH; y++ {
+		srcY := bounds.Min.Y + (2*y+1)*height/(2*targetH)
+		for x := 0; x < targetW; x++ {
+			srcX := bounds.Min.X + (2*x+1)*width/(2*targetW)
+			out.SetGray(x, y, color.GrayModel.Convert(img.At(srcX, srcY)).(color.Gray))
+		}
+	}
+	return out
+}
+
+func average(sum float64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// hashDistance returns the Hamming distance between two hex-encoded hashes, or -1 when
+// either is malformed.
+func hashDistance(a, b string) int {
+	x, errA := strconv.ParseUint(a, 16, 64)
+	y, errB := strconv.ParseUint(b, 16, 64)
+	if errA != nil || errB != nil {
+		return -1
+	}
+	return bits.OnesCount64(x ^ y)
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	aiservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/media/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
@@ -28,15 +30,49 @@ var allowedContentTypes = map[string]string{
 }
 
 type MediaService struct {
-	db       *gorm.DB
-	r2Client *storage.R2Client
+	db         *gorm.DB
+	r2Client   *storage.R2Client
+	objects    ObjectFetcher
+	classifier aiservice.ImageClassifier
+	limits     analysisLimits
 }
 
-func NewMediaService(db *gorm.DB, r2Client *storage.R2Client) *MediaService {
+// NewMediaService wires the service. A nil classifier falls back to LocalImageClassifier.
+func NewMediaService(db *gorm.DB, r2Client *storage.R2Client, classifier aiservice.ImageClassifier, cfg config.MediaConfig) *MediaService {
 	if db == nil {
 		db = database.DB
 	}
-	return &MediaService{db: db, r2Client: r2Client}
+	if classifier == nil {
+		classifier = LocalImageClassifier{}
+	}
+	svc := &MediaService{
+		db:         db,
+		r2Client:   r2Client,
+		classifier: classifier,
+		limits: analysisLimits{
+			maxFileBytes:   cfg.MaxFileBytes,
+			minDimension:   cfg.MinDimension,
+			maxDimension:   cfg.MaxDimension,
+			knownBadHashes: cfg.KnownBadHashes,
+			hashDistance:   cfg.HashDistance,
+		},
+	}
+	if r2Client != nil {
+		svc.objects = r2Client
+	}
+	if svc.limits.maxFileBytes <= 0 {
+		svc.limits.maxFileBytes = defaultMaxFileBytes
+	}
+	if svc.limits.minDimension <= 0 {
+		svc.limits.minDimension = defaultMinDimension
+	}
+	if svc.limits.maxDimension <= 0 {
+		svc.limits.maxDimension = defaultMaxDimension
+	}
+	if svc.limits.hashDistance <= 0 {
+		svc.limits.hashDistance = defaultHashDistance
+	}
+	return svc
 }
 
 func (s *MediaService) CreatePresignedURLs(ctx context.Context, userID int64, req *dto.PresignedURLRequest) (*dto.PresignedURLResponse, error) {
@@ -75,7 +111,8 @@ func (s *MediaService) CreatePresignedURLs(ctx context.Context, userID int64, re
 			UserID:    userID,
 			ObjectKey: objectKey,
 			FileURL:   result.FileURL,
-			Status:    "pending",
+			MimeType:  strings.ToLower(file.ContentType),
+			Status:    model.MediaStatusPending,
 		}
 
 		if err := s.db.WithContext(ctx).Create(&upload).Error; err != nil {
@@ -98,12 +135,7 @@ func (s *MediaService) CreateUpload(ctx context.Context) (model.MediaUpload, err
 		UUID:      uuid.New().String(),
 		ObjectKey: fmt.Sprintf("uploads/%d", time.Now().UnixNano()),
 		FileURL:   fmt.Sprintf("https://example.com/files/%d", time.Now().UnixNano()),
-		Status:    "pending",
+		Status:    model.MediaStatusPending,
 	}
 	return upload, s.db.WithContext(ctx).Create(&upload).Error
 }
-
-func (s *MediaService) Analyze(ctx context.Context, id int64) error {
-	var upload model.MediaUpload
-	return s.db.WithContext(ctx).First(&upload, id).Error
-}
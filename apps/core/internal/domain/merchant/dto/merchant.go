@@ -12,12 +12,41 @@ type Merchant struct {
 	BusinessName string   `json:"businessName"`
 	Category     string   `json:"category"`
 	Rating       float32  `json:"rating"`
+	Ratings      Ratings  `json:"ratings"`
+	AvgCost      int      `json:"avgCost"`
 	ReviewCount  int      `json:"reviewCount"`
 	Distance     string   `json:"distance"`
 	Tags         []string `json:"tags"`
 	CoverImage   string   `json:"coverImage"`
 }
 
+// Ratings holds per-dimension averages over visible reviews, the Bayesian-smoothed and
+// recency-weighted scores, and the number of reviews per star (index 0 is 1 star).
+type Ratings struct {
+	Environment float32 `json:"environment"`
+	Service     float32 `json:"service"`
+	Value       float32 `json:"value"`
+	Food        float32 `json:"food"`
+	Bayesian    float32 `json:"bayesian"`
+	Recent      float32 `json:"recent"`
+	Histogram   []int   `json:"histogram,omitempty"`
+}
+
+// ReplyRequest is the request body for a merchant's official reply to a review.
+type ReplyRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// ReviewInboxQuery filters the merchant review inbox. Rating selects a star bucket
+// (4 matches 4.0-4.9); Replied filters on whether an official reply exists.
+type ReviewInboxQuery struct {
+	StoreID *int64
+	Rating  *int
+	Replied *bool
+	Cursor  *int64
+	Limit   *int
+}
+
 func FromModel(m model.Merchant) Merchant {
 	name := m.Name
 	if m.BusinessName != "" {
@@ -29,9 +58,19 @@ func FromModel(m model.Merchant) Merchant {
 		BusinessName: m.BusinessName,
 		Category:     m.Category,
 		Rating:       m.AvgRating,
+		AvgCost:      m.AvgCost,
 		ReviewCount:  m.ReviewCount,
 		Distance:     "",
 		Tags:         []string{},
 		CoverImage:   m.CoverImage,
+		Ratings: Ratings{
+			Environment: m.AvgRatingEnv,
+			Service:     m.AvgRatingService,
+			Value:       m.AvgRatingValue,
+			Food:        m.AvgRatingFood,
+			Bayesian:    m.BayesianRating,
+			Recent:      m.RecentRating,
+			Histogram:   m.RatingHistogram,
+		},
 	}
 }
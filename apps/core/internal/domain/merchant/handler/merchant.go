@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/merchant/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/merchant/service"
@@ -17,7 +18,7 @@ type MerchantHandler struct {
 
 func NewMerchantHandler(svc *service.MerchantService) *MerchantHandler {
 	if svc == nil {
-		svc = service.NewMerchantService(nil)
+		svc = service.NewMerchantService(nil, nil)
 	}
 	return &MerchantHandler{svc: svc}
 }
@@ -91,7 +92,7 @@ func (h *MerchantHandler) Reviews(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
 		return
 	}
-	reviews, err := h.svc.Reviews(c.Request.Context(), id)
+	reviews, err := h.svc.Reviews(c.Request.Context(), c.GetInt64("user_id"), id)
 	if err != nil {
 		if errors.Is(err, service.ErrMerchantNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
@@ -115,3 +116,123 @@ func (h *MerchantHandler) Reviews(c *gin.Context) {
 func (h *MerchantHandler) DeleteMe(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
 }
+
+// MerchantReviewInbox godoc
+// @Summary List reviews for the current merchant
+// @Description Returns reviews of the caller's merchant, newest first, with their official replies
+// @Tags merchant
+// @Produce json
+// @Param store_id query int false "Store filter"
+// @Param rating query int false "Star bucket filter (1-5)"
+// @Param replied query bool false "Filter by whether an official reply exists"
+// @Param cursor query int false "Cursor for pagination (review id)"
+// @Param limit query int false "Page size (max 100)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /merchant/reviews [get]
+func (h *MerchantHandler) ReviewInbox(c *gin.Context) {
+	query, err := parseReviewInboxQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	reviews, cursor, err := h.svc.ReviewInbox(c.Request.Context(), c.GetInt64("user_id"), query)
+	if err != nil {
+		if errors.Is(err, service.ErrNotMerchant) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reviews"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": reviewdto.FromModels(reviews), "cursor": cursor})
+}
+
+// ReplyToReview godoc
+// @Summary Reply to a review
+// @Description Posts the merchant's official public reply to a review, or edits it if one exists
+// @Tags merchant
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param request body dto.ReplyRequest true "Reply request"
+// @Success 200 {object} reviewdto.Comment
+// @Success 201 {object} reviewdto.Comment
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /merchant/reviews/{id}/reply [post]
+func (h *MerchantHandler) ReplyToReview(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var req dto.ReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Text) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid text"})
+		return
+	}
+	reply, created, err := h.svc.ReplyToReview(c.Request.Context(), c.GetInt64("user_id"), id, req.Text)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReviewNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrNotMerchant), errors.Is(err, service.ErrReviewNotOwned):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, reviewdto.CommentFromModel(reply))
+}
+
+func parseReviewInboxQuery(c *gin.Context) (dto.ReviewInboxQuery, error) {
+	var q dto.ReviewInboxQuery
+	if raw := c.Query("store_id"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return q, errors.New("invalid store_id")
+		}
+		q.StoreID = &v
+	}
+	if raw := c.Query("rating"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > 5 {
+			return q, errors.New("invalid rating")
+		}
+		q.Rating = &v
+	}
+	if raw := c.Query("replied"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return q, errors.New("invalid replied")
+		}
+		q.Replied = &v
+	}
+	if raw := c.Query("cursor"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return q, errors.New("invalid cursor")
+		}
+		q.Cursor = &v
+	}
+	if raw := c.Query("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return q, errors.New("invalid limit")
+		}
+		q.Limit = &v
+	}
+	return q, nil
+}
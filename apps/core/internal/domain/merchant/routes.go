@@ -4,24 +4,28 @@ import (
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/merchant/handler"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/merchant/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes registers merchant routes.
-func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
-	svc := service.NewMerchantService(nil)
+// RegisterRoutes registers merchant routes. moderation is shared with the other domains that
+// screen user content.
+func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config, moderation *moderationservice.ModerationService) {
+	svc := service.NewMerchantService(nil, moderation)
 	h := handler.NewMerchantHandler(svc)
 
 	merchants := r.Group("/merchants")
 	{
 		merchants.GET("", h.List)
 		merchants.GET("/:id", h.Detail)
-		merchants.GET("/:id/reviews", h.Reviews)
+		merchants.GET("/:id/reviews", middleware.OptionalJWTAuth(cfg.JWT), h.Reviews)
 	}
 
 	merchantPrivate := r.Group("/merchant", middleware.JWTAuth(cfg.JWT))
 	{
 		merchantPrivate.DELETE("/me", h.DeleteMe)
+		merchantPrivate.GET("/reviews", h.ReviewInbox)
+		merchantPrivate.POST("/reviews/:id/reply", h.ReplyToReview)
 	}
 }
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/merchant/dto"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	reviewservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultInboxLimit = 20
+	maxInboxLimit     = 100
+)
+
+var ErrNotMerchant = errors.New("merchant account required")
+var ErrReviewNotFound = errors.New("review not found")
+var ErrReviewNotOwned = errors.New("review does not belong to this merchant")
+
+// ReplyToReview posts or edits the merchant's single official reply to a review. The
+// returned flag is true when a new reply was created; only then, and only once the reply is
+// visible, is the reviewer notified. Editing never lifts a status moderation gave the reply.
+func (s *MerchantService) ReplyToReview(ctx context.Context, userID, reviewID int64, text string) (model.ReviewComment, bool, error) {
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetReviewComment,
+		UserID:     userID,
+		Text:       text,
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	var reply model.ReviewComment
+	var review model.Review
+	created := false
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		merchant, err := ownedMerchant(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReviewNotFound
+			}
+			return err
+		}
+		if review.MerchantID != merchant.ID {
+			return ErrReviewNotOwned
+		}
+
+		err = tx.Where("review_id = ? AND is_merchant_reply = ?", review.ID, true).First(&reply).Error
+		switch {
+		case err == nil:
+			reply.Content = text
+			reply.Status = verdict.EditStatus(reply.Status)
+			if err := tx.Model(&reply).Select("content", "status").Updates(&reply).Error; err != nil {
+				return err
+			}
+			return reviewservice.SyncCommentCount(tx, review.ID)
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		reply = model.ReviewComment{
+			ReviewID:        review.ID,
+			UserID:          userID,
+			Content:         text,
+			IsMerchantReply: true,
+			Status:          verdict.Status(),
+		}
+		if err := tx.Create(&reply).Error; err != nil {
+			return err
+		}
+		created = true
+		return reviewservice.SyncCommentCount(tx, review.ID)
+	}); err != nil {
+		return model.ReviewComment{}, false, err
+	}
+
+	s.moderation.Finalize(ctx, screening, reply.ID, verdict)
+	if created && reply.Status == model.ContentStatusVisible {
+		eventbus.Publish(ctx, eventbus.MerchantReplied{
+			ReplyID:    reply.ID,
+			ReviewID:   review.ID,
+			MerchantID: review.MerchantID,
+			UserID:     userID,
+			ReviewerID: review.UserID,
+			Content:    reply.Content,
+		})
+	}
+	return reply, created, nil
+}
+
+// ReviewInbox lists reviews of the caller's merchant, newest first, with any official reply.
+func (s *MerchantService) ReviewInbox(ctx context.Context, userID int64, query dto.ReviewInboxQuery) ([]model.Review, *int64, error) {
+	db := s.db.WithContext(ctx)
+	merchant, err := ownedMerchant(db, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	limit := defaultInboxLimit
+	if query.Limit != nil && *query.Limit > 0 {
+		limit = min(*query.Limit, maxInboxLimit)
+	}
+
+	q := db.Model(&model.Review{}).
+		Preload("Store").
+		Scopes(model.PreloadMerchantReply).
+		Where("merchant_id = ? AND status = ?", merchant.ID, model.ContentStatusVisible)
+	if query.StoreID != nil {
+		q = q.Where("store_id = ?", *query.StoreID)
+	}
+	if query.Rating != nil {
+		q = q.Where("rating >= ? AND rating < ?", *query.Rating, *query.Rating+1)
+	}
+	if query.Replied != nil {
+		exists := "EXISTS (SELECT 1 FROM review_comments WHERE review_comments.review_id = reviews.id " +
+			"AND review_comments.is_merchant_reply = ? AND review_comments.deleted_at IS NULL)"
+		if *query.Replied {
+			q = q.Where(exists, true)
+		} else {
+			q = q.Where("NOT "+exists, true)
+		}
+	}
+	if query.Cursor != nil {
+		q = q.Where("reviews.id < ?", *query.Cursor)
+	}
+
+	var reviews []model.Review
+	if err := q.Order("reviews.id desc").Limit(limit + 1).Find(&reviews).Error; err != nil {
+		return nil, nil, err
+	}
+	if len(reviews) <= limit {
+		return reviews, nil, nil
+	}
+	reviews = reviews[:limit]
+	lastID := reviews[len(reviews)-1].ID
+	return reviews, &lastID, nil
+}
+
+func ownedMerchant(tx *gorm.DB, userID int64) (model.Merchant, error) {
+	var merchant model.Merchant
+	if err := tx.Where("user_id = ?", userID).First(&merchant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Merchant{}, ErrNotMerchant
+		}
+		return model.Merchant{}, err
+	}
+	return merchant, nil
+}
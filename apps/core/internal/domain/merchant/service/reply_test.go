@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/merchant/dto"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	notificationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/notification/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type replyFixture struct {
+	db         *gorm.DB
+	svc        *MerchantService
+	ownerID    int64
+	reviewerID int64
+	merchant   model.Merchant
+	store      model.Store
+}
+
+func setupReplyTest(t *testing.T) replyFixture {
+	t.Helper()
+	db := testutil.SetupTestDB(t)
+	t.Cleanup(notificationservice.NewProducer(db).Register(eventbus.Default))
+
+	owner := model.User{Role: "merchant"}
+	reviewer := model.User{Role: "user"}
+	for _, u := range []*model.User{&owner, &reviewer} {
+		if err := db.Create(u).Error; err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+	merchant := model.Merchant{Name: "Cafe", UserID: &owner.ID}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown"}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	return replyFixture{db: db, svc: NewMerchantService(db, nil), ownerID: owner.ID, reviewerID: reviewer.ID, merchant: merchant, store: store}
+}
+
+func (f replyFixture) review(t *testing.T, rating float32) model.Review {
+	t.Helper()
+	storeID := f.store.ID
+	review := model.Review{
+		UserID:     f.reviewerID,
+		VenueID:    f.merchant.ID,
+		MerchantID: f.merchant.ID,
+		StoreID:    &storeID,
+		Rating:     rating,
+		Content:    "review",
+		VisitDate:  time.Now().UTC(),
+	}
+	if err := f.db.Create(&review).Error; err != nil {
+		t.Fatalf("failed to create review: %v", err)
+	}
+	return review
+}
+
+func TestReplyToReviewCreatesOneEditableReplyAndNotifies(t *testing.T) {
+	f := setupReplyTest(t)
+	review := f.review(t, 4)
+	ctx := context.Background()
+
+	reply, created, err := f.svc.ReplyToReview(ctx, f.ownerID, review.ID, "Thanks!")
+	if err != nil || !created || !reply.IsMerchantReply {
+		t.Fatalf("expected new merchant reply, got %+v created=%v err=%v", reply, created, err)
+	}
+	edited, created, err := f.svc.ReplyToReview(ctx, f.ownerID, review.ID, "Thanks for visiting!")
+	if err != nil || created || edited.ID != reply.ID || edited.Content != "Thanks for visiting!" {
+		t.Fatalf("expected edit of existing reply, got %+v created=%v err=%v", edited, created, err)
+	}
+
+	var replies int64
+	f.db.Model(&model.ReviewComment{}).Where("review_id = ? AND is_merchant_reply = ?", review.ID, true).Count(&replies)
+	if replies != 1 {
+		t.Fatalf("expected exactly one official reply, got %d", replies)
+	}
+	var notifications []model.Notification
+	if err := f.db.Where("user_id = ?", f.reviewerID).Find(&notifications).Error; err != nil {
+		t.Fatalf("failed to load notifications: %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].Type != model.NotificationTypeReviewReply ||
+		notifications[0].Title != "Cafe replied to your review" || notifications[0].Content != "Thanks!" {
+		t.Fatalf("expected one review_reply notification, got %+v", notifications)
+	}
+
+	inbox, _, err := f.svc.ReviewInbox(ctx, f.ownerID, dto.ReviewInboxQuery{})
+	if err != nil || len(inbox) != 1 || inbox[0].MerchantReply == nil || inbox[0].MerchantReply.Content != "Thanks for visiting!" {
+		t.Fatalf("expected merchant reply to be preloaded on reviews, got %+v (%v)", inbox, err)
+	}
+}
+
+func TestReplyToReviewSkipsNotificationAcrossBlock(t *testing.T) {
+	f := setupReplyTest(t)
+	review := f.review(t, 2)
+	if err := f.db.Create(&model.UserBlock{BlockerID: f.reviewerID, BlockedID: f.ownerID}).Error; err != nil {
+		t.Fatalf("failed to create block: %v", err)
+	}
+
+	if _, _, err := f.svc.ReplyToReview(context.Background(), f.ownerID, review.ID, "Sorry to hear that"); err != nil {
+		t.Fatalf("reply failed: %v", err)
+	}
+	var notifications int64
+	f.db.Model(&model.Notification{}).Where("user_id = ?", f.reviewerID).Count(&notifications)
+	if notifications != 0 {
+		t.Fatalf("expected no notification for a reviewer who blocked the merchant, got %d", notifications)
+	}
+}
+
+func TestReplyToReviewHeldByModerationDoesNotNotify(t *testing.T) {
+	f := setupReplyTest(t)
+	review := f.review(t, 2)
+	f.svc = NewMerchantService(f.db, moderationservice.NewModerationService(f.db, moderationservice.ModeSync, false,
+		moderationservice.NewRulesEngine(config.ModerationConfig{
+			BlockedTerms: map[string][]string{"default": {"idiot"}},
+		}),
+	))
+
+	reply, created, err := f.svc.ReplyToReview(context.Background(), f.ownerID, review.ID, "You are an idiot")
+	if err != nil || !created || reply.Status == model.ContentStatusVisible {
+		t.Fatalf("expected a held reply, got %+v created=%v err=%v", reply, created, err)
+	}
+	var notifications int64
+	f.db.Model(&model.Notification{}).Where("user_id = ?", f.reviewerID).Count(&notifications)
+	if notifications != 0 {
+		t.Fatalf("expected no notification for a reply nobody can see, got %d", notifications)
+	}
+}
+
+func TestReplyToReviewEditKeepsModeration(t *testing.T) {
+	f := setupReplyTest(t)
+	review := f.review(t, 2)
+	hidden := model.ReviewComment{ReviewID: review.ID, UserID: f.ownerID, Content: "reported", IsMerchantReply: true, Status: model.ContentStatusHidden}
+	if err := f.db.Create(&hidden).Error; err != nil {
+		t.Fatalf("failed to create reply: %v", err)
+	}
+
+	edited, created, err := f.svc.ReplyToReview(context.Background(), f.ownerID, review.ID, "Sorry to hear that.")
+	if err != nil || created || edited.ID != hidden.ID {
+		t.Fatalf("expected an edit of the existing reply, got %+v created=%v err=%v", edited, created, err)
+	}
+	var reloaded model.ReviewComment
+	if err := f.db.First(&reloaded, hidden.ID).Error; err != nil {
+		t.Fatalf("failed to reload reply: %v", err)
+	}
+	if edited.Status != model.ContentStatusHidden || reloaded.Status != model.ContentStatusHidden {
+		t.Fatalf("expected the edited reply to stay hidden, got %d/%d", edited.Status, reloaded.Status)
+	}
+}
+
+func TestReplyToReviewRequiresOwningMerchant(t *testing.T) {
+	f := setupReplyTest(t)
+	review := f.review(t, 4)
+	ctx := context.Background()
+
+	if _, _, err := f.svc.ReplyToReview(ctx, f.reviewerID, review.ID, "hi"); !errors.Is(err, ErrNotMerchant) {
+		t.Fatalf("expected ErrNotMerchant, got %v", err)
+	}
+
+	otherOwner := model.User{Role: "merchant"}
+	if err := f.db.Create(&otherOwner).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := f.db.Create(&model.Merchant{Name: "Rival", UserID: &otherOwner.ID}).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	if _, _, err := f.svc.ReplyToReview(ctx, otherOwner.ID, review.ID, "hi"); !errors.Is(err, ErrReviewNotOwned) {
+		t.Fatalf("expected ErrReviewNotOwned, got %v", err)
+	}
+	if _, _, err := f.svc.ReplyToReview(ctx, f.ownerID, review.ID+100, "hi"); !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected ErrReviewNotFound, got %v", err)
+	}
+}
+
+func TestReviewInboxFilters(t *testing.T) {
+	f := setupReplyTest(t)
+	ctx := context.Background()
+	low := f.review(t, 2)
+	high := f.review(t, 5)
+	if _, _, err := f.svc.ReplyToReview(ctx, f.ownerID, high.ID, "Thanks!"); err != nil {
+		t.Fatalf("reply failed: %v", err)
+	}
+
+	replied, unreplied := true, false
+	rating := 2
+	tests := []struct {
+		name  string
+		query dto.ReviewInboxQuery
+		want  []int64
+	}{
+		{"all", dto.ReviewInboxQuery{}, []int64{high.ID, low.ID}},
+		{"replied", dto.ReviewInboxQuery{Replied: &replied}, []int64{high.ID}},
+		{"unreplied", dto.ReviewInboxQuery{Replied: &unreplied}, []int64{low.ID}},
+		{"rating", dto.ReviewInboxQuery{Rating: &rating}, []int64{low.ID}},
+		{"store", dto.ReviewInboxQuery{StoreID: &f.store.ID}, []int64{high.ID, low.ID}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviews, _, err := f.svc.ReviewInbox(ctx, f.ownerID, tc.query)
+			if err != nil {
+				t.Fatalf("inbox failed: %v", err)
+			}
+			if len(reviews) != len(tc.want) {
+				t.Fatalf("expected %d reviews, got %d", len(tc.want), len(reviews))
+			}
+			for i, id := range tc.want {
+				if reviews[i].ID != id {
+					t.Fatalf("expected review %d at %d, got %d", id, i, reviews[i].ID)
+				}
+			}
+		})
+	}
+}
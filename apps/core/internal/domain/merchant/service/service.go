@@ -4,22 +4,29 @@ import (
 	"context"
 	"errors"
 
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
 )
 
 type MerchantService struct {
-	db *gorm.DB
+	db         *gorm.DB
+	moderation *moderationservice.ModerationService
 }
 
 var ErrMerchantNotFound = errors.New("merchant not found")
 
-func NewMerchantService(db *gorm.DB) *MerchantService {
+// NewMerchantService wires the service. A nil moderation pipeline disables screening of replies.
+func NewMerchantService(db *gorm.DB, moderation *moderationservice.ModerationService) *MerchantService {
 	if db == nil {
 		db = database.DB
 	}
-	return &MerchantService{db: db}
+	if moderation == nil {
+		moderation = moderationservice.NewModerationService(db, moderationservice.ModeOff, false)
+	}
+	return &MerchantService{db: db, moderation: moderation}
 }
 
 func (s *MerchantService) List(ctx context.Context, category, search string) ([]model.Merchant, error) {
@@ -46,15 +53,26 @@ func (s *MerchantService) Detail(ctx context.Context, id int64) (*model.Merchant
 		}
 		return nil, err
 	}
+	histogram, err := model.LoadRatingHistogram(s.db.WithContext(ctx), model.RatingSubjectMerchant, merchant.ID)
+	if err != nil {
+		return nil, err
+	}
+	merchant.RatingHistogram = histogram
 	return &merchant, nil
 }
 
-func (s *MerchantService) Reviews(ctx context.Context, merchantID int64) ([]model.Review, error) {
+// Reviews lists a merchant's visible reviews, leaving out those by users on either side of a
+// block with viewerID.
+func (s *MerchantService) Reviews(ctx context.Context, viewerID, merchantID int64) ([]model.Review, error) {
 	if _, err := s.Detail(ctx, merchantID); err != nil {
 		return nil, err
 	}
 	var reviews []model.Review
-	if err := s.db.WithContext(ctx).Where("merchant_id = ?", merchantID).Order("id desc").Find(&reviews).Error; err != nil {
+	if err := s.db.WithContext(ctx).
+		Scopes(model.PreloadMerchantReply, followservice.ExcludeBlocked(viewerID, "reviews.user_id")).
+		Where("merchant_id = ? AND status = ?", merchantID, model.ContentStatusVisible).
+		Order("id desc").
+		Find(&reviews).Error; err != nil {
 		return nil, err
 	}
 	return reviews, nil
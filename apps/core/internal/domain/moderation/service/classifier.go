@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	aidto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/dto"
+	aiservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/service"
+)
+
+// blockConfidence is the minimum model confidence at which an unsafe label hides content
+// outright; less confident unsafe labels are queued for manual review instead.
+const blockConfidence = 0.8
+
+// ClassifierModerator adapts the Gemini-backed ai.TextClassifier to the Moderator interface.
+type ClassifierModerator struct {
+	client  aiservice.TextClassifier
+	timeout time.Duration
+}
+
+// NewClassifierModerator wraps the classifier. A non-positive timeout leaves the caller's
+// context deadline in charge.
+func NewClassifierModerator(client aiservice.TextClassifier, timeout time.Duration) *ClassifierModerator {
+	return &ClassifierModerator{client: client, timeout: timeout}
+}
+
+func (m *ClassifierModerator) Moderate(ctx context.Context, in Input) (Verdict, error) {
+	if m.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
+
+	result, err := m.client.ClassifyText(ctx, in.Text)
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	reasons := make([]string, 0, len(result.Categories)+1)
+	reasons = append(reasons, "llm_"+result.Label)
+	reasons = append(reasons, result.Categories...)
+
+	switch {
+	case result.Label == aidto.LabelUnsafe && result.Confidence >= blockConfidence:
+		return Verdict{Action: ActionBlock, Reasons: reasons}, nil
+	case result.Label == aidto.LabelUnsafe, result.Label == aidto.LabelQuestionable:
+		return Verdict{Action: ActionReview, Reasons: reasons}, nil
+	default:
+		return Verdict{}, nil
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+)
+
+// Action is the outcome a moderator recommends for a piece of content. Values are ordered
+// by severity so verdicts from several moderators can be merged by taking the maximum.
+type Action int
+
+const (
+	ActionAllow Action = iota
+	ActionReview
+	ActionBlock
+)
+
+// Target types understood by the pipeline. They double as Report.TargetType values.
+const (
+	TargetReview        = "review"
+	TargetReviewComment = "review_comment"
+	TargetPost          = "post"
+	TargetPostComment   = "post_comment"
+	TargetMessage       = "message"
+)
+
+// targetTables maps a target type to the table whose status column holds the verdict.
+var targetTables = map[string]string{
+	TargetReview:        "reviews",
+	TargetReviewComment: "review_comments",
+	TargetPost:          "posts",
+	TargetPostComment:   "post_comments",
+	TargetMessage:       "messages",
+}
+
+// Input is one piece of user text submitted for screening. Locale is optional; when it is
+// empty the blocked-term lists of every configured locale are checked.
+type Input struct {
+	TargetType string
+	UserID     int64
+	Text       string
+	Locale     string
+}
+
+// Verdict is the merged outcome of the pipeline together with the machine-readable
+// reasons that triggered it.
+type Verdict struct {
+	Action  Action
+	Reasons []string
+}
+
+// Flagged reports whether the content needs anything other than publication.
+func (v Verdict) Flagged() bool {
+	return v.Action != ActionAllow
+}
+
+// Status maps the verdict onto the Status column of the moderated row.
+func (v Verdict) Status() int16 {
+	switch v.Action {
+	case ActionBlock:
+		return model.ContentStatusHidden
+	case ActionReview:
+		return model.ContentStatusPending
+	default:
+		return model.ContentStatusVisible
+	}
+}
+
+// EditStatus is the status to store when content with status current is edited: the more
+// severe of current and the verdict, so an edit never lifts a hold or an admin's hide.
+func (v Verdict) EditStatus(current int16) int16 {
+	return max(current, v.Status())
+}
+
+func (v Verdict) merge(other Verdict) Verdict {
+	if other.Action > v.Action {
+		v.Action = other.Action
+	}
+	v.Reasons = append(v.Reasons, other.Reasons...)
+	return v
+}
+
+// Moderator screens a single piece of text. Implementations must be safe for concurrent
+// use; an error means the moderator could not reach a decision and is treated as allow.
+type Moderator interface {
+	Moderate(ctx context.Context, in Input) (Verdict, error)
+}
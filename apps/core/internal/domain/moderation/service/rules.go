@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+)
+
+// Built-in defaults used when the corresponding config value is zero.
+const (
+	defaultMaxLinks        = 2
+	defaultMaxPhoneNumbers = 1
+	defaultRepeatWindow    = 10 * time.Minute
+	defaultRepeatThreshold = 3
+
+	// minPhoneDigits keeps dates, prices and order numbers from counting as phone numbers.
+	minPhoneDigits = 9
+
+	// maxTrackedSubmissions caps how many recent submissions are kept per user; only the
+	// newest ones matter for reaching the repeat threshold.
+	maxTrackedSubmissions = 50
+)
+
+// Reasons reported by the rules engine.
+const (
+	ReasonBlockedTerm     = "blocked_term"
+	ReasonExcessiveLinks  = "excessive_links"
+	ReasonContactDetails  = "contact_details"
+	ReasonRepeatedContent = "repeated_content"
+)
+
+var (
+	linkPattern  = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+\.(?:com|net|org|io|co|biz|info|xyz|top|shop|link)\b`)
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?\(?\d{2,4}\)?[\s.-]?\d{3,4}[\s.-]?\d{3,4}`)
+)
+
+// RulesEngine is the built-in, dependency-free moderator: blocked-term lists per locale,
+// link and phone-number spam heuristics, and per-user repeated-content detection. The
+// repeat tracker is in-memory, so it only sees submissions handled by this process; users
+// with nothing inside the repeat window are swept out once per window.
+type RulesEngine struct {
+	blockedTerms    map[string][]string
+	maxLinks        int
+	maxPhoneNumbers int
+	repeatWindow    time.Duration
+	repeatThreshold int
+	now             func() time.Time
+
+	mu        sync.Mutex
+	recent    map[int64][]submission
+	lastSweep time.Time
+}
+
+type submission struct {
+	fingerprint uint64
+	at          time.Time
+}
+
+// NewRulesEngine builds a rules engine from configuration, applying defaults for unset limits.
+func NewRulesEngine(cfg config.ModerationConfig) *RulesEngine {
+	engine := &RulesEngine{
+		blockedTerms:    make(map[string][]string, len(cfg.BlockedTerms)),
+		maxLinks:        cfg.MaxLinks,
+		maxPhoneNumbers: cfg.MaxPhoneNumbers,
+		repeatWindow:    time.Duration(cfg.RepeatWindowMinutes) * time.Minute,
+		repeatThreshold: cfg.RepeatThreshold,
+		now:             time.Now,
+		recent:          make(map[int64][]submission),
+	}
+	if engine.maxLinks <= 0 {
+		engine.maxLinks = defaultMaxLinks
+	}
+	if engine.maxPhoneNumbers <= 0 {
+		engine.maxPhoneNumbers = defaultMaxPhoneNumbers
+	}
+	if engine.repeatWindow <= 0 {
+		engine.repeatWindow = defaultRepeatWindow
+	}
+	if engine.repeatThreshold <= 0 {
+		engine.repeatThreshold = defaultRepeatThreshold
+	}
+	for locale, terms := range cfg.BlockedTerms {
+		locale = normalizeLocale(locale)
+		for _, term := range terms {
+			term = strings.ToLower(strings.TrimSpace(term))
+			if term != "" {
+				engine.blockedTerms[locale] = append(engine.blockedTerms[locale], term)
+			}
+		}
+	}
+	return engine
+}
+
+func (e *RulesEngine) Moderate(_ context.Context, in Input) (Verdict, error) {
+	var verdict Verdict
+	text := strings.ToLower(in.Text)
+
+	if e.containsBlockedTerm(text, normalizeLocale(in.Locale)) {
+		verdict = verdict.merge(Verdict{Action: ActionBlock, Reasons: []string{ReasonBlockedTerm}})
+	}
+
+	links := len(linkPattern.FindAllString(text, -1))
+	phones := countPhoneNumbers(text)
+	switch {
+	case links > e.maxLinks && phones > e.maxPhoneNumbers:
+		verdict = verdict.merge(Verdict{Action: ActionBlock, Reasons: []string{ReasonExcessiveLinks, ReasonContactDetails}})
+	case links > e.maxLinks:
+		verdict = verdict.merge(Verdict{Action: ActionReview, Reasons: []string{ReasonExcessiveLinks}})
+	case phones > e.maxPhoneNumbers:
+		verdict = verdict.merge(Verdict{Action: ActionReview, Reasons: []string{ReasonContactDetails}})
+	}
+
+	if in.UserID != 0 && e.recordAndCountRepeats(in.UserID, text) >= e.repeatThreshold {
+		verdict = verdict.merge(Verdict{Action: ActionReview, Reasons: []string{ReasonRepeatedContent}})
+	}
+
+	return verdict, nil
+}
+
+// containsBlockedTerm checks the "default" list plus the list for the given locale, or
+// every list when the locale is unknown.
+func (e *RulesEngine) containsBlockedTerm(text, locale string) bool {
+	for listLocale, terms := range e.blockedTerms {
+		if listLocale != "default" && locale != "" && listLocale != locale {
+			continue
+		}
+		for _, term := range terms {
+			if containsTerm(text, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recordAndCountRepeats stores the submission and returns how many times the same user has
+// posted the same normalized text inside the repeat window, including this one.
+func (e *RulesEngine) recordAndCountRepeats(userID int64, text string) int {
+	fingerprint := fingerprintText(text)
+	now := e.now()
+	cutoff := now.Add(-e.repeatWindow)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if now.Sub(e.lastSweep) >= e.repeatWindow {
+		e.sweep(cutoff)
+		e.lastSweep = now
+	}
+
+	kept := e.recent[userID][:0]
+	count := 1
+	for _, item := range e.recent[userID] {
+		if item.at.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, item)
+		if item.fingerprint == fingerprint {
+			count++
+		}
+	}
+	kept = append(kept, submission{fingerprint: fingerprint, at: now})
+	if len(kept) > maxTrackedSubmissions {
+		kept = append(kept[:0], kept[len(kept)-maxTrackedSubmissions:]...)
+	}
+	e.recent[userID] = kept
+	return count
+}
+
+// sweep forgets users whose latest submission is older than cutoff. Callers hold e.mu.
+func (e *RulesEngine) sweep(cutoff time.Time) {
+	for userID, items := range e.recent {
+		if len(items) == 0 || items[len(items)-1].at.Before(cutoff) {
+			delete(e.recent, userID)
+		}
+	}
+}
+
+// containsTerm matches whole words for alphanumeric terms so that e.g. "ass" does not flag
+// "class", and falls back to substring matching for scripts without word separators.
+func containsTerm(text, term string) bool {
+	if !isWordTerm(term) {
+		return strings.Contains(text, term)
+	}
+	for offset := 0; ; {
+		idx := strings.Index(text[offset:], term)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(term)
+		if !isWordRuneBefore(text, start) && !isWordRuneAfter(text, end) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordTerm(term string) bool {
+	for _, r := range term {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRuneBefore(text string, idx int) bool {
+	if idx == 0 {
+		return false
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:idx])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isWordRuneAfter(text string, idx int) bool {
+	if idx >= len(text) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(text[idx:])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func countPhoneNumbers(text string) int {
+	count := 0
+	for _, match := range phonePattern.FindAllString(text, -1) {
+		digits := 0
+		for _, r := range match {
+			if unicode.IsDigit(r) {
+				digits++
+			}
+		}
+		if digits >= minPhoneDigits {
+			count++
+		}
+	}
+	return count
+}
+
+func fingerprintText(text string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.Join(strings.Fields(text), " ")))
+	return h.Sum64()
+}
+
+// normalizeLocale reduces "en-US" / "en_us" to "en" so lists can be keyed by language.
+func normalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if idx := strings.IndexAny(locale, "-_"); idx > 0 {
+		locale = locale[:idx]
+	}
+	return locale
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+)
+
+func moderate(t *testing.T, engine *RulesEngine, in Input) Verdict {
+	t.Helper()
+	verdict, err := engine.Moderate(context.Background(), in)
+	if err != nil {
+		t.Fatalf("rules engine returned error: %v", err)
+	}
+	return verdict
+}
+
+func TestRulesEngineAllowsOrdinaryText(t *testing.T) {
+	engine := NewRulesEngine(config.ModerationConfig{})
+
+	verdict := moderate(t, engine, Input{UserID: 1, Text: "Great latte, visited on 2024-05-01 and paid $12.50."})
+	if verdict.Flagged() {
+		t.Fatalf("expected ordinary text to pass, got %+v", verdict)
+	}
+}
+
+func TestRulesEngineBlockedTermsRespectLocaleAndWordBoundaries(t *testing.T) {
+	engine := NewRulesEngine(config.ModerationConfig{
+		BlockedTerms: map[string][]string{
+			"default": {"scam"},
+			"es":      {"estafa"},
+		},
+	})
+
+	if v := moderate(t, engine, Input{Text: "Total SCAM, avoid"}); v.Action != ActionBlock {
+		t.Fatalf("expected default-list term to block, got %+v", v)
+	}
+	if v := moderate(t, engine, Input{Text: "the scampi was great"}); v.Flagged() {
+		t.Fatalf("expected substring inside a word to pass, got %+v", v)
+	}
+	if v := moderate(t, engine, Input{Text: "es una estafa", Locale: "es-MX"}); v.Action != ActionBlock {
+		t.Fatalf("expected locale term to block for es-MX, got %+v", v)
+	}
+	if v := moderate(t, engine, Input{Text: "es una estafa", Locale: "en"}); v.Flagged() {
+		t.Fatalf("expected es term to be ignored for en locale, got %+v", v)
+	}
+	if v := moderate(t, engine, Input{Text: "es una estafa"}); v.Action != ActionBlock {
+		t.Fatalf("expected every list to apply when locale is unknown, got %+v", v)
+	}
+}
+
+func TestRulesEngineFlagsLinkAndPhoneSpam(t *testing.T) {
+	engine := NewRulesEngine(config.ModerationConfig{MaxLinks: 1, MaxPhoneNumbers: 1})
+
+	links := moderate(t, engine, Input{Text: "deals at https://a.example and www.b.example"})
+	if links.Action != ActionReview || links.Reasons[0] != ReasonExcessiveLinks {
+		t.Fatalf("expected link spam to be queued for review, got %+v", links)
+	}
+
+	phones := moderate(t, engine, Input{Text: "call +1 (415) 555-0100 or 415-555-0199"})
+	if phones.Action != ActionReview || phones.Reasons[0] != ReasonContactDetails {
+		t.Fatalf("expected phone spam to be queued for review, got %+v", phones)
+	}
+
+	both := moderate(t, engine, Input{Text: "cheap.xyz spam.top 415-555-0100 415-555-0199"})
+	if both.Action != ActionBlock {
+		t.Fatalf("expected link and phone spam together to block, got %+v", both)
+	}
+}
+
+func TestRulesEngineDetectsRepeatedContentPerUser(t *testing.T) {
+	engine := NewRulesEngine(config.ModerationConfig{RepeatThreshold: 2, RepeatWindowMinutes: 5})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+
+	if v := moderate(t, engine, Input{UserID: 1, Text: "Best  place ever"}); v.Flagged() {
+		t.Fatalf("expected first submission to pass, got %+v", v)
+	}
+	if v := moderate(t, engine, Input{UserID: 2, Text: "best place ever"}); v.Flagged() {
+		t.Fatalf("expected another user's submission to pass, got %+v", v)
+	}
+	if v := moderate(t, engine, Input{UserID: 1, Text: "best place   EVER"}); v.Action != ActionReview {
+		t.Fatalf("expected repeated submission to be queued for review, got %+v", v)
+	}
+
+	now = now.Add(10 * time.Minute)
+	if v := moderate(t, engine, Input{UserID: 1, Text: "best place ever"}); v.Flagged() {
+		t.Fatalf("expected repeat window to expire, got %+v", v)
+	}
+	if _, ok := engine.recent[2]; ok {
+		t.Fatal("expected a user idle for the whole window to be swept")
+	}
+
+	for i := 0; i < maxTrackedSubmissions+10; i++ {
+		moderate(t, engine, Input{UserID: 3, Text: fmt.Sprintf("visit %d", i)})
+	}
+	if got := len(engine.recent[3]); got != maxTrackedSubmissions {
+		t.Fatalf("expected %d tracked submissions, got %d", maxTrackedSubmissions, got)
+	}
+}
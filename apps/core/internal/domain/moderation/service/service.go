@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	aiservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Pipeline modes, see config.ModerationConfig.
+const (
+	ModeSync  = "sync"
+	ModeAsync = "async"
+	ModeOff   = "off"
+)
+
+// reportReason is the Report.Reason recorded for automatically filed reports.
+const reportReason = "automated_moderation"
+
+// ModerationService runs user text through the configured moderators and applies the
+// merged verdict. Callers screen text with Screen before persisting it, store the returned
+// status, then hand the new row to Finalize.
+type ModerationService struct {
+	db         *gorm.DB
+	mode       string
+	autoReport bool
+	moderators []Moderator
+	spawn      func(func())
+
+	mu       sync.RWMutex
+	appliers map[string]StatusApplier
+}
+
+// StatusApplier moves a row of one target type to a moderated status inside tx and repairs
+// every counter the row contributed to while it was visible. Services that keep such
+// counters register one with RegisterApplier; other targets get a plain status update.
+type StatusApplier func(ctx context.Context, tx *gorm.DB, targetID int64, status int16) error
+
+// NewModerationService wires a pipeline from explicit moderators. An empty mode means sync.
+func NewModerationService(db *gorm.DB, mode string, autoReport bool, moderators ...Moderator) *ModerationService {
+	if db == nil {
+		db = database.DB
+	}
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = ModeSync
+	}
+	return &ModerationService{
+		db:         db,
+		mode:       mode,
+		autoReport: autoReport,
+		moderators: moderators,
+		spawn:      func(f func()) { go f() },
+		appliers:   map[string]StatusApplier{},
+	}
+}
+
+// RegisterApplier sets how verdicts reached after publication are applied to targetType,
+// replacing any earlier registration.
+func (s *ModerationService) RegisterApplier(targetType string, apply StatusApplier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appliers[targetType] = apply
+}
+
+// NewModerationServiceFromConfig builds the rules engine and, when use_llm is set and
+// Gemini is configured, the LLM classifier. A Gemini construction failure is logged and
+// the pipeline keeps running on rules alone.
+func NewModerationServiceFromConfig(ctx context.Context, db *gorm.DB, cfg *config.Config) *ModerationService {
+	moderators := []Moderator{NewRulesEngine(cfg.Moderation)}
+	if cfg.Moderation.UseLLM {
+		client, err := aiservice.NewGeminiClassifier(ctx, cfg.Gemini)
+		if err != nil {
+			logger.Warn(ctx, "moderation: gemini classifier unavailable, falling back to rules only", "error", err.Error())
+		} else {
+			timeout := time.Duration(cfg.Gemini.TimeoutSeconds) * time.Second
+			moderators = append(moderators, NewClassifierModerator(client, timeout))
+		}
+	}
+	return NewModerationService(db, cfg.Moderation.Mode, cfg.Moderation.AutoReport, moderators...)
+}
+
+// Screen returns the verdict to store with new content. Only sync mode screens here; in
+// async and off modes content is always stored as visible.
+func (s *ModerationService) Screen(ctx context.Context, in Input) Verdict {
+	if s.mode != ModeSync {
+		return Verdict{}
+	}
+	return s.run(ctx, in)
+}
+
+// Finalize completes moderation once the content row exists. In sync mode it files a
+// report for flagged content; in async mode it screens the text in the background and
+// downgrades the row's status when the verdict calls for it. Failures are logged rather
+// than returned because the content has already been accepted.
+func (s *ModerationService) Finalize(ctx context.Context, in Input, targetID int64, verdict Verdict) {
+	switch s.mode {
+	case ModeSync:
+		s.record(ctx, in, targetID, verdict)
+	case ModeAsync:
+		bg := context.WithoutCancel(ctx)
+		s.spawn(func() {
+			verdict := s.run(bg, in)
+			if !verdict.Flagged() {
+				return
+			}
+			if err := s.apply(bg, in.TargetType, targetID, verdict.Status()); err != nil {
+				logger.Error(bg, "moderation: failed to apply verdict", "target_type", in.TargetType, "target_id", targetID, "error", err.Error())
+				return
+			}
+			s.record(bg, in, targetID, verdict)
+		})
+	}
+}
+
+// apply downgrades a published row through its registered applier, or by updating its status
+// column when the target keeps no counters.
+func (s *ModerationService) apply(ctx context.Context, targetType string, targetID int64, status int16) error {
+	s.mu.RLock()
+	applier := s.appliers[targetType]
+	s.mu.RUnlock()
+	if applier != nil {
+		return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return applier(ctx, tx, targetID, status)
+		})
+	}
+	table, ok := targetTables[targetType]
+	if !ok {
+		return nil
+	}
+	return s.db.WithContext(ctx).Table(table).Where("id = ?", targetID).Update("status", status).Error
+}
+
+func (s *ModerationService) run(ctx context.Context, in Input) Verdict {
+	var verdict Verdict
+	if strings.TrimSpace(in.Text) == "" {
+		return verdict
+	}
+	for _, moderator := range s.moderators {
+		result, err := moderator.Moderate(ctx, in)
+		if err != nil {
+			logger.Warn(ctx, "moderation: moderator failed, skipping", "target_type", in.TargetType, "error", err.Error())
+			continue
+		}
+		verdict = verdict.merge(result)
+	}
+	return verdict
+}
+
+func (s *ModerationService) record(ctx context.Context, in Input, targetID int64, verdict Verdict) {
+	if !verdict.Flagged() || !s.autoReport {
+		return
+	}
+	report := model.Report{
+		TargetType:  in.TargetType,
+		TargetID:    targetID,
+		Reason:      reportReason,
+		Description: strings.Join(verdict.Reasons, ", "),
+		Status:      "pending",
+	}
+	if err := s.db.WithContext(ctx).Create(&report).Error; err != nil {
+		logger.Error(ctx, "moderation: failed to file report", "target_type", in.TargetType, "target_id", targetID, "error", err.Error())
+	}
+}
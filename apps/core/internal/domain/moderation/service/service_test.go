@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	aidto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type fakeModerator struct {
+	verdict Verdict
+	err     error
+	calls   int
+}
+
+func (f *fakeModerator) Moderate(context.Context, Input) (Verdict, error) {
+	f.calls++
+	return f.verdict, f.err
+}
+
+type fakeClassifier struct {
+	result aidto.Classification
+}
+
+func (f fakeClassifier) ClassifyText(context.Context, string) (aidto.Classification, error) {
+	return f.result, nil
+}
+
+func TestScreenMergesVerdictsAndSkipsFailingModerators(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewModerationService(db, ModeSync, false,
+		&fakeModerator{verdict: Verdict{Action: ActionReview, Reasons: []string{"a"}}},
+		&fakeModerator{err: errors.New("upstream down")},
+		&fakeModerator{verdict: Verdict{Action: ActionBlock, Reasons: []string{"b"}}},
+	)
+
+	verdict := svc.Screen(context.Background(), Input{TargetType: TargetReview, Text: "hello"})
+	if verdict.Action != ActionBlock {
+		t.Fatalf("expected most severe action to win, got %v", verdict.Action)
+	}
+	if verdict.Status() != model.ContentStatusHidden {
+		t.Fatalf("expected hidden status, got %d", verdict.Status())
+	}
+	if len(verdict.Reasons) != 2 {
+		t.Fatalf("expected reasons from both deciding moderators, got %v", verdict.Reasons)
+	}
+}
+
+func TestFinalizeSyncFilesReportForFlaggedContent(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewModerationService(db, ModeSync, true)
+	in := Input{TargetType: TargetReviewComment, UserID: 7, Text: "spam"}
+
+	svc.Finalize(context.Background(), in, 42, Verdict{})
+	svc.Finalize(context.Background(), in, 43, Verdict{Action: ActionReview, Reasons: []string{ReasonExcessiveLinks}})
+
+	var reports []model.Report
+	if err := db.Find(&reports).Error; err != nil {
+		t.Fatalf("failed to load reports: %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("expected one report, got %d", len(reports))
+	}
+	got := reports[0]
+	if got.TargetType != TargetReviewComment || got.TargetID != 43 || got.ReporterID != nil {
+		t.Fatalf("unexpected report: %+v", got)
+	}
+	if got.Description != ReasonExcessiveLinks {
+		t.Fatalf("unexpected report description: %q", got.Description)
+	}
+}
+
+func TestAsyncModeStoresVisibleThenDowngradesInBackground(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	moderator := &fakeModerator{verdict: Verdict{Action: ActionBlock, Reasons: []string{ReasonBlockedTerm}}}
+	svc := NewModerationService(db, ModeAsync, true, moderator)
+	svc.spawn = func(f func()) { f() }
+
+	in := Input{TargetType: TargetPost, UserID: 1, Text: "bad words"}
+	verdict := svc.Screen(context.Background(), in)
+	if verdict.Flagged() || moderator.calls != 0 {
+		t.Fatalf("expected async screen to allow without running moderators, got %+v after %d calls", verdict, moderator.calls)
+	}
+
+	post := model.Post{UserID: 1, Content: in.Text, Status: verdict.Status()}
+	if err := db.Create(&post).Error; err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	svc.Finalize(context.Background(), in, post.ID, verdict)
+
+	var refreshed model.Post
+	if err := db.First(&refreshed, post.ID).Error; err != nil {
+		t.Fatalf("failed to reload post: %v", err)
+	}
+	if refreshed.Status != model.ContentStatusHidden {
+		t.Fatalf("expected post to be hidden, got status %d", refreshed.Status)
+	}
+	var reports int64
+	if err := db.Model(&model.Report{}).Where("target_type = ? AND target_id = ?", TargetPost, post.ID).Count(&reports).Error; err != nil {
+		t.Fatalf("failed to count reports: %v", err)
+	}
+	if reports != 1 {
+		t.Fatalf("expected one report, got %d", reports)
+	}
+}
+
+func TestAsyncModeAppliesVerdictsThroughRegisteredApplier(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	moderator := &fakeModerator{verdict: Verdict{Action: ActionReview, Reasons: []string{ReasonBlockedTerm}}}
+	svc := NewModerationService(db, ModeAsync, false, moderator)
+	svc.spawn = func(f func()) { f() }
+
+	var appliedID int64
+	var appliedStatus int16
+	svc.RegisterApplier(TargetReview, func(_ context.Context, tx *gorm.DB, targetID int64, status int16) error {
+		appliedID, appliedStatus = targetID, status
+		return nil
+	})
+	in := Input{TargetType: TargetReview, UserID: 1, Text: "suspicious"}
+	svc.Finalize(context.Background(), in, 42, svc.Screen(context.Background(), in))
+
+	if appliedID != 42 || appliedStatus != model.ContentStatusPending {
+		t.Fatalf("expected the applier to hold review 42, got id=%d status=%d", appliedID, appliedStatus)
+	}
+}
+
+func TestClassifierModeratorMapsLabels(t *testing.T) {
+	cases := []struct {
+		result aidto.Classification
+		want   Action
+	}{
+		{aidto.Classification{Label: aidto.LabelSafe, Confidence: 0.99}, ActionAllow},
+		{aidto.Classification{Label: aidto.LabelQuestionable, Confidence: 0.9}, ActionReview},
+		{aidto.Classification{Label: aidto.LabelUnsafe, Confidence: 0.5}, ActionReview},
+		{aidto.Classification{Label: aidto.LabelUnsafe, Confidence: 0.95, Categories: []string{"hate"}}, ActionBlock},
+	}
+	for _, tc := range cases {
+		m := NewClassifierModerator(fakeClassifier{result: tc.result}, 0)
+		verdict, err := m.Moderate(context.Background(), Input{Text: "x"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if verdict.Action != tc.want {
+			t.Fatalf("label %q at %.2f: got action %v want %v", tc.result.Label, tc.result.Confidence, verdict.Action, tc.want)
+		}
+	}
+}
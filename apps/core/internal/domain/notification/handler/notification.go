@@ -98,3 +98,67 @@ func (h *NotificationHandler) ReadAll(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "ok"})
 }
+
+// RegisterDevice godoc
+// @Summary Register push device
+// @Description Registers a push token for the authenticated user. Re-registering a token moves it to the caller
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param request body service.RegisterDeviceInput true "Device token and platform (ios, android or web)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /notifications/devices [post]
+func (h *NotificationHandler) RegisterDevice(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req service.RegisterDeviceInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	device, err := h.svc.RegisterDevice(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDevice) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register device"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": device})
+}
+
+// UnregisterDevice godoc
+// @Summary Unregister push device
+// @Description Removes one of the authenticated user's push tokens
+// @Tags notification
+// @Produce json
+// @Param token path string true "Device token"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /notifications/devices/{token} [delete]
+func (h *NotificationHandler) UnregisterDevice(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.svc.UnregisterDevice(c.Request.Context(), userID, c.Param("token")); err != nil {
+		if errors.Is(err, service.ErrDeviceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unregister device"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -23,7 +23,7 @@ func setupNotificationTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("failed to open test db: %v", err)
 	}
 
-	if err := db.AutoMigrate(&model.User{}, &model.Notification{}); err != nil {
+	if err := db.AutoMigrate(&model.User{}, &model.Notification{}, &model.DeviceToken{}, &model.NotificationDelivery{}); err != nil {
 		t.Fatalf("failed to migrate notification test db: %v", err)
 	}
 
@@ -124,3 +124,58 @@ func TestNotificationHandlerMarkReadUpdatesNotification(t *testing.T) {
 		t.Fatalf("expected notification to be marked read")
 	}
 }
+
+func TestNotificationHandlerRegistersAndUnregistersDevices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := setupNotificationTestDB(t)
+	seedNotificationFixture(t, db)
+	if err := db.Create(&model.User{ID: 602, Role: "user"}).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	h := NewNotificationHandler(service.NewNotificationService(db))
+
+	register := func(userID int64, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodPost, "/notifications/devices", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("user_id", userID)
+		h.RegisterDevice(c)
+		return recorder
+	}
+
+	if recorder := register(601, `{"token":"abc","platform":"blackberry"}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown platform, got %d", recorder.Code)
+	}
+	if recorder := register(601, `{"token":"abc","platform":"ios"}`); recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	// The same token registered by another account moves to that account.
+	if recorder := register(602, `{"token":"abc","platform":"android"}`); recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var devices []model.DeviceToken
+	if err := db.Find(&devices).Error; err != nil {
+		t.Fatalf("failed to load devices: %v", err)
+	}
+	if len(devices) != 1 || devices[0].UserID != 602 || devices[0].Platform != model.DevicePlatformAndroid {
+		t.Fatalf("expected one token owned by the second user, got %+v", devices)
+	}
+
+	unregister := func(userID int64) int {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Params = gin.Params{{Key: "token", Value: "abc"}}
+		c.Request = httptest.NewRequest(http.MethodDelete, "/notifications/devices/abc", nil)
+		c.Set("user_id", userID)
+		h.UnregisterDevice(c)
+		return recorder.Code
+	}
+	if code := unregister(601); code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for someone else's token, got %d", code)
+	}
+	if code := unregister(602); code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+}
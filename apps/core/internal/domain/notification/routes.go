@@ -18,5 +18,7 @@ func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
 		notifs.GET("", h.List)
 		notifs.PATCH("/:id/read", h.MarkRead)
 		notifs.POST("/read-all", h.ReadAll)
+		notifs.POST("/devices", h.RegisterDevice)
+		notifs.DELETE("/devices/:token", h.UnregisterDevice)
 	}
 }
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	deliveryBatchSize   = 100
+	maxDeliveryAttempts = 6
+	initialRetryBackoff = time.Minute
+	maxRetryBackoff     = time.Hour
+)
+
+// errDeliveryObsolete means there is nothing left to send, e.g. the device or notification is
+// gone or the notification was read while the push waited out quiet hours.
+var errDeliveryObsolete = errors.New("delivery is obsolete")
+
+// Dispatcher sends queued deliveries and queues email digests. Pushes are queued as
+// notifications are stored; see enqueuePush.
+type Dispatcher struct {
+	db          *gorm.DB
+	email       EmailSender
+	push        PushSender
+	frontendURL string
+}
+
+// NewDispatcher builds a dispatcher. A nil sender leaves that channel's deliveries queued
+// until a dispatcher with a sender picks them up.
+func NewDispatcher(db *gorm.DB, email EmailSender, push PushSender, frontendURL string) *Dispatcher {
+	if db == nil {
+		db = database.DB
+	}
+	return &Dispatcher{db: db, email: email, push: push, frontendURL: frontendURL}
+}
+
+// HasEmail reports whether the dispatcher can send email.
+func (d *Dispatcher) HasEmail() bool {
+	return d.email != nil
+}
+
+// ProcessDue attempts every pending delivery due at now, at most one batch per call, and
+// returns how many were sent. A failed attempt is retried with exponential backoff until
+// maxDeliveryAttempts is reached.
+func (d *Dispatcher) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	db := d.db.WithContext(ctx)
+	var channels []string
+	if d.email != nil {
+		channels = append(channels, model.DeliveryChannelEmail)
+	}
+	if d.push != nil {
+		channels = append(channels, model.DeliveryChannelPush)
+	}
+	if len(channels) == 0 {
+		return 0, nil
+	}
+	query := db.Where("status = ? AND next_attempt_at <= ? AND channel IN ?", model.DeliveryStatusPending, now, channels)
+	var due []model.NotificationDelivery
+	if err := query.Order("next_attempt_at asc, id asc").Limit(deliveryBatchSize).Find(&due).Error; err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, delivery := range due {
+		// Claim the row by scheduling its retry up front. Another dispatcher that read the same
+		// row loses the race on attempts and skips it; a crash mid-send just means a retry.
+		attempt := delivery.Attempts + 1
+		claim := db.Model(&model.NotificationDelivery{}).
+			Where("id = ? AND status = ? AND attempts = ?", delivery.ID, model.DeliveryStatusPending, delivery.Attempts).
+			Updates(map[string]interface{}{
+				"attempts":        attempt,
+				"next_attempt_at": now.Add(retryBackoff(attempt)),
+			})
+		if claim.Error != nil {
+			return sent, claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			continue
+		}
+
+		sendErr := d.send(ctx, delivery)
+		if sendErr == nil {
+			sent++
+		}
+		if err := d.record(ctx, delivery, attempt, now, sendErr); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery model.NotificationDelivery) error {
+	switch delivery.Channel {
+	case model.DeliveryChannelEmail:
+		return d.email.SendEmailHTML(delivery.Recipient, delivery.Subject, delivery.Body, true)
+	case model.DeliveryChannelPush:
+		if delivery.DeviceTokenID == nil || delivery.NotificationID == nil {
+			return errDeliveryObsolete
+		}
+		db := d.db.WithContext(ctx)
+		var device model.DeviceToken
+		result := db.Where("id = ? AND user_id = ?", *delivery.DeviceTokenID, delivery.UserID).Limit(1).Find(&device)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errDeliveryObsolete
+		}
+		var notification model.Notification
+		result = db.Where("id = ?", *delivery.NotificationID).Limit(1).Find(&notification)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 || notification.IsRead {
+			return errDeliveryObsolete
+		}
+		var unread int64
+		if err := db.Model(&model.Notification{}).Where("user_id = ? AND is_read = ?", delivery.UserID, false).
+			Count(&unread).Error; err != nil {
+			return err
+		}
+		return d.push.Send(ctx, device, BuildPushPayload(notification, unread))
+	default:
+		return errDeliveryObsolete
+	}
+}
+
+// record stores the outcome of one attempt. The retry time was already set when the row
+// was claimed.
+func (d *Dispatcher) record(ctx context.Context, delivery model.NotificationDelivery, attempt int, now time.Time, sendErr error) error {
+	db := d.db.WithContext(ctx)
+	updates := map[string]interface{}{}
+	switch {
+	case sendErr == nil:
+		updates["status"] = model.DeliveryStatusSent
+		updates["sent_at"] = now
+		updates["last_error"] = ""
+	case errors.Is(sendErr, ErrDeviceTokenInvalid):
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&model.NotificationDelivery{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+				"status":     model.DeliveryStatusFailed,
+				"last_error": sendErr.Error(),
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("device_token_id = ? AND status = ?", *delivery.DeviceTokenID, model.DeliveryStatusPending).
+				Delete(&model.NotificationDelivery{}).Error; err != nil {
+				return err
+			}
+			return tx.Where("id = ?", *delivery.DeviceTokenID).Delete(&model.DeviceToken{}).Error
+		})
+	case errors.Is(sendErr, errDeliveryObsolete) || attempt >= maxDeliveryAttempts:
+		updates["status"] = model.DeliveryStatusFailed
+		updates["last_error"] = sendErr.Error()
+	default:
+		updates["last_error"] = sendErr.Error()
+		logger.Warn(ctx, "Notification delivery failed; will retry",
+			"delivery_id", delivery.ID,
+			"channel", delivery.Channel,
+			"attempt", attempt,
+			"error", sendErr.Error(),
+		)
+	}
+	return db.Model(&model.NotificationDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error
+}
+
+// retryBackoff is the wait after the given failed attempt: 1m, 2m, 4m, … capped at an hour.
+func retryBackoff(attempt int) time.Duration {
+	backoff := initialRetryBackoff
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+// enqueuePush queues a push of notification to each of the recipient's devices, unless the
+// recipient turned pushes off for its type. During quiet hours the push waits until they end.
+// A device that still has a push of the same notification queued is skipped: pushes are built
+// when sent, so an aggregated notification goes out with its latest title.
+func enqueuePush(db *gorm.DB, notification model.Notification) error {
+	settings, err := loadNotificationSettings(db, notification.UserID)
+	if err != nil {
+		return err
+	}
+	if !settings.Allows(model.DeliveryChannelPush, notification.Type) {
+		return nil
+	}
+	var devices []model.DeviceToken
+	if err := db.Where("user_id = ?", notification.UserID).Find(&devices).Error; err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return nil
+	}
+
+	sendAt := quietHoursEnd(settings, time.Now().UTC())
+	for _, device := range devices {
+		var queued int64
+		if err := db.Model(&model.NotificationDelivery{}).
+			Where("notification_id = ? AND device_token_id = ? AND status = ?", notification.ID, device.ID, model.DeliveryStatusPending).
+			Count(&queued).Error; err != nil {
+			return err
+		}
+		if queued > 0 {
+			continue
+		}
+		notificationID, deviceID := notification.ID, device.ID
+		if err := db.Create(&model.NotificationDelivery{
+			UserID:         notification.UserID,
+			Channel:        model.DeliveryChannelPush,
+			NotificationID: &notificationID,
+			DeviceTokenID:  &deviceID,
+			Status:         model.DeliveryStatusPending,
+			NextAttemptAt:  sendAt,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadNotificationSettings returns the user's delivery settings, or the defaults for users
+// who never changed them.
+func loadNotificationSettings(db *gorm.DB, userID int64) (model.UserNotification, error) {
+	settings := model.UserNotification{UserID: userID}
+	result := db.Where("user_id = ?", userID).Limit(1).Find(&settings)
+	if result.Error != nil {
+		return settings, result.Error
+	}
+	if result.RowsAffected == 0 {
+		settings.PushEnabled = true
+		settings.EmailEnabled = true
+		settings.EmailDigest = model.EmailDigestDaily
+	}
+	return settings, nil
+}
+
+// quietHoursEnd returns now, or the end of the quiet hours now falls into. Quiet hours are read
+// in the user's timezone, falling back to UTC, and may wrap past midnight, e.g. 22:00–07:00.
+func quietHoursEnd(settings model.UserNotification, now time.Time) time.Time {
+	start, err := time.Parse("15:04", settings.QuietHoursStart)
+	if err != nil {
+		return now
+	}
+	end, err := time.Parse("15:04", settings.QuietHoursEnd)
+	if err != nil || start.Equal(end) {
+		return now
+	}
+	location := time.UTC
+	if settings.Timezone != "" {
+		if loaded, err := time.LoadLocation(settings.Timezone); err == nil {
+			location = loaded
+		}
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	quiet := minute >= startMinute && minute < endMinute
+	if startMinute > endMinute {
+		quiet = minute >= startMinute || minute < endMinute
+	}
+	if !quiet {
+		return now
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, location)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until.UTC()
+}
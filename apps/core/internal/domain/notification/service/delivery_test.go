@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type recordedEmail struct {
+	to, subject, body string
+}
+
+type fakeEmailSender struct {
+	sent []recordedEmail
+}
+
+func (f *fakeEmailSender) SendEmailHTML(to, subject, body string, isHTML bool) error {
+	f.sent = append(f.sent, recordedEmail{to: to, subject: subject, body: body})
+	return nil
+}
+
+type sentPush struct {
+	Device  model.DeviceToken
+	Payload PushPayload
+}
+
+// fakePushSender records pushes instead of sending them. Err, if set, is returned for every send.
+type fakePushSender struct {
+	mu   sync.Mutex
+	sent []sentPush
+	Err  error
+}
+
+func (f *fakePushSender) Send(_ context.Context, device model.DeviceToken, payload PushPayload) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	f.sent = append(f.sent, sentPush{Device: device, Payload: payload})
+	return nil
+}
+
+func (f *fakePushSender) Sent() []sentPush {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]sentPush(nil), f.sent...)
+}
+
+func registerDevice(t *testing.T, db *gorm.DB, userID int64, token string) model.DeviceToken {
+	t.Helper()
+	device, err := NewNotificationService(db).RegisterDevice(context.Background(), userID,
+		RegisterDeviceInput{Token: token, Platform: "android"})
+	if err != nil {
+		t.Fatalf("failed to register device: %v", err)
+	}
+	return *device
+}
+
+func deliveriesFor(t *testing.T, db *gorm.DB, userID int64) []model.NotificationDelivery {
+	t.Helper()
+	var deliveries []model.NotificationDelivery
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&deliveries).Error; err != nil {
+		t.Fatalf("failed to load deliveries: %v", err)
+	}
+	return deliveries
+}
+
+func TestDispatcherRetriesFailedPushWithBackoff(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	user := createNamedUser(t, db, "Ann")
+	device := registerDevice(t, db, user.ID, "token-1")
+	notification := model.Notification{UserID: user.ID, Type: model.NotificationTypeFollow, Title: "Bo started following you",
+		Data: `{"follower_id":7}`, GroupKey: "follow"}
+	if err := Notify(db, 7, &notification); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC().Add(time.Second)
+	// Without a push provider the delivery stays queued for one that has it.
+	if sent, err := NewDispatcher(db, nil, nil, "").ProcessDue(ctx, now); err != nil || sent != 0 {
+		t.Fatalf("expected nothing to be sent without a provider, sent=%d err=%v", sent, err)
+	}
+	if deliveries := deliveriesFor(t, db, user.ID); len(deliveries) != 1 || deliveries[0].Attempts != 0 {
+		t.Fatalf("expected the push to stay queued untouched, got %+v", deliveries)
+	}
+
+	push := &fakePushSender{Err: errors.New("provider unavailable")}
+	dispatcher := NewDispatcher(db, nil, push, "")
+	if sent, err := dispatcher.ProcessDue(ctx, now); err != nil || sent != 0 {
+		t.Fatalf("expected the first attempt to fail, sent=%d err=%v", sent, err)
+	}
+	deliveries := deliveriesFor(t, db, user.ID)
+	if len(deliveries) != 1 || deliveries[0].Status != model.DeliveryStatusPending || deliveries[0].Attempts != 1 ||
+		deliveries[0].LastError != "provider unavailable" || !deliveries[0].NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("expected a pending retry in a minute, got %+v", deliveries)
+	}
+
+	push.Err = nil
+	if sent, _ := dispatcher.ProcessDue(ctx, now.Add(30*time.Second)); sent != 0 {
+		t.Fatal("expected no attempt before the backoff elapsed")
+	}
+	if sent, err := dispatcher.ProcessDue(ctx, now.Add(time.Minute)); err != nil || sent != 1 {
+		t.Fatalf("expected the retry to succeed, sent=%d err=%v", sent, err)
+	}
+	pushes := push.Sent()
+	if len(pushes) != 1 || pushes[0].Device.ID != device.ID {
+		t.Fatalf("expected one push to the device, got %+v", pushes)
+	}
+	payload := pushes[0].Payload
+	if payload.Title != "Bo started following you" || payload.Badge != 1 || payload.CollapseKey != "follow" ||
+		payload.Data["follower_id"] != "7" || payload.Data["type"] != model.NotificationTypeFollow {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if deliveries := deliveriesFor(t, db, user.ID); deliveries[0].Status != model.DeliveryStatusSent || deliveries[0].SentAt == nil {
+		t.Fatalf("expected the delivery to be sent, got %+v", deliveries[0])
+	}
+}
+
+func TestDispatcherGivesUpAndForgetsInvalidTokens(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	user := createNamedUser(t, db, "Ann")
+	registerDevice(t, db, user.ID, "token-1")
+	if err := Notify(db, 0, &model.Notification{UserID: user.ID, Type: model.NotificationTypeOrderPaid, Title: "Paid"}); err != nil {
+		t.Fatal(err)
+	}
+
+	push := &fakePushSender{Err: errors.New("timeout")}
+	dispatcher := NewDispatcher(db, nil, push, "")
+	now := time.Now().UTC().Add(time.Second)
+	for attempt := 1; attempt <= maxDeliveryAttempts; attempt++ {
+		if _, err := dispatcher.ProcessDue(ctx, now); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(maxRetryBackoff)
+	}
+	deliveries := deliveriesFor(t, db, user.ID)
+	if len(deliveries) != 1 || deliveries[0].Status != model.DeliveryStatusFailed || deliveries[0].Attempts != maxDeliveryAttempts {
+		t.Fatalf("expected the delivery to fail after %d attempts, got %+v", maxDeliveryAttempts, deliveries)
+	}
+
+	if err := Notify(db, 0, &model.Notification{UserID: user.ID, Type: model.NotificationTypeOrderPaid, Title: "Paid again"}); err != nil {
+		t.Fatal(err)
+	}
+	push.Err = ErrDeviceTokenInvalid
+	if _, err := dispatcher.ProcessDue(ctx, time.Now().UTC().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	var devices int64
+	db.Model(&model.DeviceToken{}).Where("user_id = ?", user.ID).Count(&devices)
+	if devices != 0 {
+		t.Fatal("expected the rejected token to be removed")
+	}
+}
+
+func TestEnqueuePushHonoursTypePreferencesAndQuietHours(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	user := createNamedUser(t, db, "Ann")
+	registerDevice(t, db, user.ID, "token-1")
+	if err := db.Create(&model.UserNotification{
+		UserID:             user.ID,
+		PushEnabled:        true,
+		EmailEnabled:       true,
+		ChannelPreferences: `{"like":{"push":false}}`,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Notify(db, 7, &model.Notification{UserID: user.ID, Type: model.NotificationTypeLike, Title: "liked"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := deliveriesFor(t, db, user.ID); len(got) != 0 {
+		t.Fatalf("expected no push for an opted-out type, got %d", len(got))
+	}
+	if err := Notify(db, 7, &model.Notification{UserID: user.ID, Type: model.NotificationTypeComment, Title: "commented"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := deliveriesFor(t, db, user.ID); len(got) != 1 {
+		t.Fatalf("expected a push for other types, got %d", len(got))
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data unavailable")
+	}
+	settings := model.UserNotification{QuietHoursStart: "22:00", QuietHoursEnd: "07:00", Timezone: "Europe/Berlin"}
+	cases := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2026, 3, 2, 23, 30, 0, 0, berlin), time.Date(2026, 3, 3, 7, 0, 0, 0, berlin)},
+		{time.Date(2026, 3, 3, 6, 59, 0, 0, berlin), time.Date(2026, 3, 3, 7, 0, 0, 0, berlin)},
+		{time.Date(2026, 3, 3, 12, 0, 0, 0, berlin), time.Date(2026, 3, 3, 12, 0, 0, 0, berlin)},
+	}
+	for _, c := range cases {
+		if got := quietHoursEnd(settings, c.now.UTC()); !got.Equal(c.want) {
+			t.Errorf("quietHoursEnd(%v) = %v, want %v", c.now, got, c.want)
+		}
+	}
+}
+
+func TestQueueDigestsCollectsUnreadNotifications(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	user := createNamedUser(t, db, "Ann")
+	if err := db.Create(&model.UserAuth{UserID: user.ID, IdentityType: "email", Identifier: "ann@example.com"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&model.UserNotification{
+		UserID:             user.ID,
+		PushEnabled:        true,
+		EmailEnabled:       true,
+		ChannelPreferences: `{"like":{"email":false}}`,
+		EmailDigest:        model.EmailDigestWeekly,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []model.Notification{
+		{UserID: user.ID, Type: model.NotificationTypeComment, Title: "Bo commented <b>on</b> your review", Content: "nice"},
+		{UserID: user.ID, Type: model.NotificationTypeFollow, Title: "Cy started following you"},
+		{UserID: user.ID, Type: model.NotificationTypeLike, Title: "Di liked your review"},
+		{UserID: user.ID, Type: model.NotificationTypeFollow, Title: "read already", IsRead: true},
+	} {
+		if err := db.Create(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mail := &fakeEmailSender{}
+	dispatcher := NewDispatcher(db, mail, nil, "https://revieu.example/")
+	now := time.Now().UTC().Add(time.Second)
+	if queued, err := dispatcher.QueueDigests(ctx, now); err != nil || queued != 1 {
+		t.Fatalf("expected one digest, queued=%d err=%v", queued, err)
+	}
+	if queued, _ := dispatcher.QueueDigests(ctx, now.Add(24*time.Hour)); queued != 0 {
+		t.Fatal("expected a weekly digest not to repeat the next day")
+	}
+	if sent, err := dispatcher.ProcessDue(ctx, now); err != nil || sent != 1 {
+		t.Fatalf("expected the digest to be sent, sent=%d err=%v", sent, err)
+	}
+
+	if len(mail.sent) != 1 || mail.sent[0].to != "ann@example.com" || mail.sent[0].subject != "RevieU: You have 2 new notifications" {
+		t.Fatalf("unexpected emails %+v", mail.sent)
+	}
+	body := mail.sent[0].body
+	for _, want := range []string{"Bo commented &lt;b&gt;on&lt;/b&gt; your review", "Cy started following you",
+		"https://revieu.example/notifications", "weekly digest"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected digest to contain %q:\n%s", want, body)
+		}
+	}
+	for _, unwanted := range []string{"Di liked your review", "read already"} {
+		if strings.Contains(body, unwanted) {
+			t.Errorf("expected digest to leave out %q", unwanted)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxDeviceTokenLength = 512
+
+var ErrInvalidDevice = errors.New("invalid device token or platform")
+var ErrDeviceNotFound = errors.New("device not found")
+
+var devicePlatforms = map[string]bool{
+	model.DevicePlatformIOS:     true,
+	model.DevicePlatformAndroid: true,
+	model.DevicePlatformWeb:     true,
+}
+
+type RegisterDeviceInput struct {
+	Token    string `json:"token" binding:"required"`
+	Platform string `json:"platform" binding:"required"`
+}
+
+// RegisterDevice stores a push token for the user. A token already registered to someone
+// else, e.g. after signing out and in as another user, moves to the caller; pushes still
+// queued for the previous owner are dropped.
+func (s *NotificationService) RegisterDevice(ctx context.Context, userID int64, input RegisterDeviceInput) (*model.DeviceToken, error) {
+	token := strings.TrimSpace(input.Token)
+	platform := strings.ToLower(strings.TrimSpace(input.Platform))
+	if token == "" || len(token) > maxDeviceTokenLength || !devicePlatforms[platform] {
+		return nil, ErrInvalidDevice
+	}
+
+	var device model.DeviceToken
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "token"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"user_id": userID, "platform": platform, "updated_at": time.Now().UTC()}),
+		}).Create(&model.DeviceToken{UserID: userID, Platform: platform, Token: token}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("token = ?", token).First(&device).Error; err != nil {
+			return err
+		}
+		return tx.Where("device_token_id = ? AND user_id <> ? AND status = ?", device.ID, userID, model.DeliveryStatusPending).
+			Delete(&model.NotificationDelivery{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// UnregisterDevice removes one of the user's push tokens and its queued pushes.
+func (s *NotificationService) UnregisterDevice(ctx context.Context, userID int64, token string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var device model.DeviceToken
+		result := tx.Where("user_id = ? AND token = ?", userID, token).Limit(1).Find(&device)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDeviceNotFound
+		}
+		if err := tx.Where("device_token_id = ? AND status = ?", device.ID, model.DeliveryStatusPending).
+			Delete(&model.NotificationDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&device).Error
+	})
+}
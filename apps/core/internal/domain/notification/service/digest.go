@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+const maxDigestItems = 20
+
+var digestPeriods = map[string]time.Duration{
+	model.EmailDigestDaily:  24 * time.Hour,
+	model.EmailDigestWeekly: 7 * 24 * time.Hour,
+}
+
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+</head>
+<body>
+    <h2>{{.Heading}}</h2>
+    <ul>
+    {{- range .Items}}
+        <li><strong>{{.Title}}</strong>{{if .Content}}<br>{{.Content}}{{end}}</li>
+    {{- end}}
+    </ul>
+    {{- if .More}}
+    <p>…and {{.More}} more.</p>
+    {{- end}}
+    {{- if .Link}}
+    <p><a href="{{.Link}}">See all notifications</a></p>
+    {{- end}}
+    <br>
+    <p>You receive this {{.Period}} digest because email notifications are on. You can change this in your notification settings.</p>
+</body>
+</html>
+`))
+
+type digestItem struct {
+	Title   string
+	Content string
+}
+
+type digestView struct {
+	Heading string
+	Items   []digestItem
+	More    int
+	Link    string
+	Period  string
+}
+
+// QueueDigests queues an email digest for every user whose daily or weekly digest is due at
+// now, covering their unread notifications since the previous digest. Notification types the
+// user turned email off for are left out, and users with nothing to report get no email.
+// It returns the number of digests queued; ProcessDue sends them.
+func (d *Dispatcher) QueueDigests(ctx context.Context, now time.Time) (int, error) {
+	db := d.db.WithContext(ctx)
+	var userIDs []int64
+	if err := db.Model(&model.Notification{}).
+		Where("is_read = ? AND created_at > ?", false, now.Add(-digestPeriods[model.EmailDigestWeekly])).
+		Distinct("user_id").
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, userID := range userIDs {
+		ok, err := d.queueDigest(db, userID, now)
+		if err != nil {
+			return queued, err
+		}
+		if ok {
+			queued++
+		}
+	}
+	return queued, nil
+}
+
+func (d *Dispatcher) queueDigest(db *gorm.DB, userID int64, now time.Time) (bool, error) {
+	settings, err := loadNotificationSettings(db, userID)
+	if err != nil {
+		return false, err
+	}
+	period, ok := digestPeriods[settings.EmailDigest]
+	if !ok || !settings.EmailEnabled {
+		return false, nil
+	}
+	since := now.Add(-period)
+	if settings.LastDigestAt != nil {
+		if settings.LastDigestAt.After(since) {
+			return false, nil
+		}
+		since = *settings.LastDigestAt
+	}
+
+	var notifications []model.Notification
+	if err := db.Where("user_id = ? AND is_read = ? AND created_at > ? AND created_at <= ?", userID, false, since, now).
+		Order("created_at desc").
+		Find(&notifications).Error; err != nil {
+		return false, err
+	}
+	items := make([]digestItem, 0, maxDigestItems)
+	total := 0
+	for _, notification := range notifications {
+		if !settings.Allows(model.DeliveryChannelEmail, notification.Type) {
+			continue
+		}
+		total++
+		if len(items) < maxDigestItems {
+			items = append(items, digestItem{Title: notification.Title, Content: excerpt(notification.Content)})
+		}
+	}
+	if total == 0 {
+		return false, nil
+	}
+
+	var auth model.UserAuth
+	result := db.Where("user_id = ? AND identity_type = ?", userID, "email").Limit(1).Find(&auth)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 || auth.Identifier == "" {
+		return false, nil
+	}
+
+	subject, body, err := d.renderDigest(settings.EmailDigest, items, total)
+	if err != nil {
+		return false, err
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model.NotificationDelivery{
+			UserID:        userID,
+			Channel:       model.DeliveryChannelEmail,
+			Recipient:     auth.Identifier,
+			Subject:       subject,
+			Body:          body,
+			Status:        model.DeliveryStatusPending,
+			NextAttemptAt: now,
+		}).Error; err != nil {
+			return err
+		}
+		stored := model.UserNotification{UserID: userID}
+		if err := tx.FirstOrCreate(&stored, model.UserNotification{UserID: userID}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.UserNotification{}).Where("user_id = ?", userID).Update("last_digest_at", now).Error
+	})
+	return err == nil, err
+}
+
+func (d *Dispatcher) renderDigest(period string, items []digestItem, total int) (string, string, error) {
+	subject := fmt.Sprintf("You have %d new notifications", total)
+	if total == 1 {
+		subject = "You have 1 new notification"
+	}
+	view := digestView{
+		Heading: subject,
+		Items:   items,
+		More:    total - len(items),
+		Period:  period,
+	}
+	if base := strings.TrimRight(d.frontendURL, "/"); base != "" {
+		view.Link = base + "/notifications"
+	}
+	var body bytes.Buffer
+	if err := digestTemplate.Execute(&body, view); err != nil {
+		return "", "", err
+	}
+	return "RevieU: " + subject, body.String(), nil
+}
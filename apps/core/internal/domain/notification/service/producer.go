@@ -0,0 +1,579 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	contentservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/service"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// aggregationWindow is how long an unread notification keeps absorbing similar events.
+	aggregationWindow = 24 * time.Hour
+	// maxAggregatedActors caps the actor IDs kept in an aggregated notification. Later actors
+	// are still counted, but one that drops off the list is counted again if they act again.
+	maxAggregatedActors = 20
+	maxExcerptRunes     = 100
+)
+
+// targetNouns names interaction targets in notification titles.
+var targetNouns = map[string]string{
+	contentservice.TargetReview:        "review",
+	contentservice.TargetReviewComment: "comment",
+	contentservice.TargetPost:          "post",
+	contentservice.TargetPostComment:   "comment",
+}
+
+// Producer turns domain events into notifications. Nobody is notified about their own
+// actions, about users on either side of a block, about social activity of users they
+// muted, or about messages in conversations they muted.
+type Producer struct {
+	db *gorm.DB
+}
+
+func NewProducer(db *gorm.DB) *Producer {
+	if db == nil {
+		db = database.DB
+	}
+	return &Producer{db: db}
+}
+
+// Register subscribes the producer to every event it handles and returns a function that
+// removes the subscriptions.
+func (p *Producer) Register(bus *eventbus.Bus) func() {
+	names := []string{
+		eventbus.EventUserFollowed,
+		eventbus.EventFollowRequested,
+		eventbus.EventFollowRequestApproved,
+		eventbus.EventContentLiked,
+		eventbus.EventCommentCreated,
+		eventbus.EventOrderPaid,
+		eventbus.EventVoucherRedeemed,
+		eventbus.EventVerificationReviewed,
+		eventbus.EventMessageSent,
+		eventbus.EventMerchantReplied,
+	}
+	unsubscribers := make([]func(), 0, len(names))
+	for _, name := range names {
+		unsubscribers = append(unsubscribers, bus.Subscribe(name, p.Handle))
+	}
+	return func() {
+		for _, unsubscribe := range unsubscribers {
+			unsubscribe()
+		}
+	}
+}
+
+// Handle produces the notifications for one event. The change behind the event is already
+// committed, so a cancelled request does not cancel its notifications.
+func (p *Producer) Handle(ctx context.Context, event eventbus.Event) error {
+	db := p.db.WithContext(context.WithoutCancel(ctx))
+	switch e := event.(type) {
+	case eventbus.UserFollowed:
+		return userFollowed(db, e)
+	case eventbus.FollowRequested:
+		return followRequested(db, e)
+	case eventbus.FollowRequestApproved:
+		return followRequestApproved(db, e)
+	case eventbus.ContentLiked:
+		return contentLiked(db, e)
+	case eventbus.CommentCreated:
+		return commentCreated(db, e)
+	case eventbus.OrderPaid:
+		return orderPaid(db, e)
+	case eventbus.VoucherRedeemed:
+		return voucherRedeemed(db, e)
+	case eventbus.VerificationReviewed:
+		return verificationReviewed(db, e)
+	case eventbus.MessageSent:
+		return messageSent(db, e)
+	case eventbus.MerchantReplied:
+		return merchantReplied(db, e)
+	}
+	return nil
+}
+
+func userFollowed(db *gorm.DB, e eventbus.UserFollowed) error {
+	return produce(db, notice{
+		ActorID:  e.FollowerID,
+		UserID:   e.FollowingID,
+		Type:     model.NotificationTypeFollow,
+		GroupKey: "follow",
+		Social:   true,
+		Titles: func(actor string, others int) string {
+			return actorPhrase(actor, others) + " started following you"
+		},
+		Data: map[string]any{},
+	})
+}
+
+func followRequested(db *gorm.DB, e eventbus.FollowRequested) error {
+	return produce(db, notice{
+		ActorID: e.RequesterID,
+		UserID:  e.TargetID,
+		Type:    model.NotificationTypeFollowRequest,
+		Social:  true,
+		Titles: func(actor string, _ int) string {
+			return actor + " requested to follow you"
+		},
+		Data: map[string]any{"user_id": e.RequesterID},
+	})
+}
+
+func followRequestApproved(db *gorm.DB, e eventbus.FollowRequestApproved) error {
+	return produce(db, notice{
+		ActorID: e.UserID,
+		UserID:  e.RequesterID,
+		Type:    model.NotificationTypeFollowAccepted,
+		Titles: func(actor string, _ int) string {
+			return actor + " accepted your follow request"
+		},
+		Data: map[string]any{"user_id": e.UserID},
+	})
+}
+
+func contentLiked(db *gorm.DB, e eventbus.ContentLiked) error {
+	noun, ok := targetNouns[e.TargetType]
+	if !ok {
+		return nil
+	}
+	data := map[string]any{"target_type": e.TargetType, "target_id": e.TargetID}
+	// Comment likes also carry the review or post the comment belongs to, for deep links.
+	switch e.TargetType {
+	case contentservice.TargetReviewComment:
+		var reviewID int64
+		if err := db.Model(&model.ReviewComment{}).Where("id = ?", e.TargetID).Select("review_id").Scan(&reviewID).Error; err != nil {
+			return err
+		}
+		data["review_id"] = reviewID
+	case contentservice.TargetPostComment:
+		var postID int64
+		if err := db.Model(&model.PostComment{}).Where("id = ?", e.TargetID).Select("post_id").Scan(&postID).Error; err != nil {
+			return err
+		}
+		data["post_id"] = postID
+	}
+	return produce(db, notice{
+		ActorID:  e.UserID,
+		UserID:   e.OwnerID,
+		Type:     model.NotificationTypeLike,
+		GroupKey: fmt.Sprintf("like:%s:%d", e.TargetType, e.TargetID),
+		Social:   true,
+		Titles: func(actor string, others int) string {
+			return actorPhrase(actor, others) + " liked your " + noun
+		},
+		Data: data,
+	})
+}
+
+// commentCreated tells the author of the replied-to comment about the reply and the author of
+// the review or post about the comment; someone who is both only hears about the reply.
+func commentCreated(db *gorm.DB, e eventbus.CommentCreated) error {
+	noun, ok := targetNouns[e.TargetType]
+	if !ok {
+		return nil
+	}
+	data := map[string]any{
+		"target_type":        e.TargetType,
+		"target_id":          e.TargetID,
+		e.TargetType + "_id": e.TargetID,
+		"comment_id":         e.CommentID,
+	}
+	content := excerpt(e.Content)
+
+	if e.ParentAuthorID != 0 {
+		if err := produce(db, notice{
+			ActorID: e.UserID,
+			UserID:  e.ParentAuthorID,
+			Type:    model.NotificationTypeCommentReply,
+			Content: content,
+			Social:  true,
+			Titles: func(actor string, _ int) string {
+				return actor + " replied to your comment"
+			},
+			Data: data,
+		}); err != nil {
+			return err
+		}
+	}
+	if e.OwnerID == e.ParentAuthorID {
+		return nil
+	}
+	return produce(db, notice{
+		ActorID:  e.UserID,
+		UserID:   e.OwnerID,
+		Type:     model.NotificationTypeComment,
+		Content:  content,
+		GroupKey: fmt.Sprintf("comment:%s:%d", e.TargetType, e.TargetID),
+		Social:   true,
+		Titles: func(actor string, others int) string {
+			return actorPhrase(actor, others) + " commented on your " + noun
+		},
+		Data: data,
+	})
+}
+
+// orderPaid confirms the payment to the buyer and tells the merchant about the sale. The
+// merchant notice is a business record, so blocks between the two do not hold it back.
+func orderPaid(db *gorm.DB, e eventbus.OrderPaid) error {
+	var coupon model.Coupon
+	if err := db.Unscoped().Select("id", "title").Where("id = ?", e.CouponID).Limit(1).Find(&coupon).Error; err != nil {
+		return err
+	}
+	merchant, err := loadMerchant(db, e.MerchantID)
+	if err != nil {
+		return err
+	}
+	data := map[string]any{
+		"order_id":    e.OrderID,
+		"coupon_id":   e.CouponID,
+		"merchant_id": e.MerchantID,
+		"quantity":    e.Quantity,
+		"amount":      e.Amount,
+	}
+
+	if err := produce(db, notice{
+		UserID:  e.UserID,
+		Type:    model.NotificationTypeOrderPaid,
+		Title:   "Payment confirmed",
+		Content: fmt.Sprintf("%d x %s from %s", e.Quantity, coupon.Title, merchantName(merchant)),
+		Data:    data,
+	}); err != nil {
+		return err
+	}
+
+	if merchant.UserID == nil || *merchant.UserID == e.UserID {
+		return nil
+	}
+	buyer, err := displayName(db, e.UserID)
+	if err != nil {
+		return err
+	}
+	return produce(db, notice{
+		UserID:  *merchant.UserID,
+		Type:    model.NotificationTypeNewOrder,
+		Title:   "New order",
+		Content: fmt.Sprintf("%s bought %d x %s", buyer, e.Quantity, coupon.Title),
+		Data:    data,
+	})
+}
+
+func voucherRedeemed(db *gorm.DB, e eventbus.VoucherRedeemed) error {
+	var coupon model.Coupon
+	if err := db.Unscoped().Select("id", "title").Where("id = ?", e.CouponID).Limit(1).Find(&coupon).Error; err != nil {
+		return err
+	}
+	merchant, err := loadMerchant(db, e.MerchantID)
+	if err != nil {
+		return err
+	}
+	return produce(db, notice{
+		UserID:  e.UserID,
+		Type:    model.NotificationTypeVoucherRedeemed,
+		Title:   "Voucher redeemed",
+		Content: fmt.Sprintf("Your %s voucher was redeemed at %s", coupon.Title, merchantName(merchant)),
+		Data: map[string]any{
+			"voucher_id":  e.VoucherID,
+			"coupon_id":   e.CouponID,
+			"merchant_id": e.MerchantID,
+		},
+	})
+}
+
+func verificationReviewed(db *gorm.DB, e eventbus.VerificationReviewed) error {
+	n := notice{
+		UserID: e.UserID,
+		Type:   model.NotificationTypeVerificationApproved,
+		Title:  "Your business is verified",
+		Data: map[string]any{
+			"verification_id": e.VerificationID,
+			"merchant_id":     e.MerchantID,
+		},
+	}
+	if !e.Approved {
+		n.Type = model.NotificationTypeVerificationRejected
+		n.Title = "Your business verification was rejected"
+		n.Content = e.Reason
+	}
+	return produce(db, n)
+}
+
+// messageSent notifies every participant but the sender who has not muted the conversation.
+// Unread message notifications collapse into one per conversation.
+func messageSent(db *gorm.DB, e eventbus.MessageSent) error {
+	var recipientIDs []int64
+	if err := db.Model(&model.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id <> ? AND is_muted = ?", e.ConversationID, e.SenderID, false).
+		Pluck("user_id", &recipientIDs).Error; err != nil {
+		return err
+	}
+
+	content := excerpt(e.Content)
+	switch e.MessageType {
+	case model.MessageTypeImage:
+		content = "Sent a photo"
+	case model.MessageTypeVoucherShare:
+		content = "Shared a voucher"
+	case model.MessageTypeStoreCard:
+		content = "Shared a store"
+	}
+	for _, recipientID := range recipientIDs {
+		if err := produce(db, notice{
+			ActorID:  e.SenderID,
+			UserID:   recipientID,
+			Type:     model.NotificationTypeMessage,
+			Content:  content,
+			GroupKey: fmt.Sprintf("message:%d", e.ConversationID),
+			Titles: func(actor string, others int) string {
+				if others == 0 {
+					return actor + " sent you a message"
+				}
+				return actorPhrase(actor, others) + " sent you messages"
+			},
+			Data: map[string]any{
+				"conversation_id": e.ConversationID,
+				"message_id":      e.MessageID,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merchantReplied tells the reviewer the merchant answered their review, under the
+// merchant's name rather than the staff member's.
+func merchantReplied(db *gorm.DB, e eventbus.MerchantReplied) error {
+	merchant, err := loadMerchant(db, e.MerchantID)
+	if err != nil {
+		return err
+	}
+	return produce(db, notice{
+		ActorID: e.UserID,
+		UserID:  e.ReviewerID,
+		Type:    model.NotificationTypeReviewReply,
+		Title:   fmt.Sprintf("%s replied to your review", merchantName(merchant)),
+		Content: excerpt(e.Content),
+		Data: map[string]any{
+			"review_id":   e.ReviewID,
+			"merchant_id": e.MerchantID,
+			"comment_id":  e.ReplyID,
+		},
+	})
+}
+
+// notice is a notification for UserID about something ActorID did; ActorID is 0 for
+// system notices.
+type notice struct {
+	ActorID int64
+	UserID  int64
+	Type    string
+	Title   string
+	// Titles, when set, renders the title from the actor's name and how many others are
+	// folded into the same notification.
+	Titles  func(actor string, others int) string
+	Content string
+	Data    map[string]any
+	// GroupKey folds the notice into the recipient's unread notification with the same key
+	// from within the aggregation window.
+	GroupKey string
+	// Social notices are also dropped when the recipient has muted the actor.
+	Social bool
+}
+
+// actorSet is the aggregation bookkeeping stored in an aggregated notification's data.
+type actorSet struct {
+	ActorIDs   []int64 `json:"actor_ids"`
+	ActorCount int     `json:"actor_count"`
+}
+
+// add records actorID as the most recent actor; an actor already on the list is not counted twice.
+func (a actorSet) add(actorID int64) actorSet {
+	if slices.Contains(a.ActorIDs, actorID) {
+		return a
+	}
+	ids := append([]int64{actorID}, a.ActorIDs...)
+	if len(ids) > maxAggregatedActors {
+		ids = ids[:maxAggregatedActors]
+	}
+	return actorSet{ActorIDs: ids, ActorCount: a.ActorCount + 1}
+}
+
+func produce(db *gorm.DB, n notice) error {
+	if n.UserID == 0 || n.UserID == n.ActorID {
+		return nil
+	}
+	if n.Social {
+		muted, err := hasMuted(db, n.UserID, n.ActorID)
+		if err != nil || muted {
+			return err
+		}
+	}
+	if n.GroupKey == "" {
+		title, err := n.render(db, 0)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(n.Data)
+		if err != nil {
+			return err
+		}
+		return Notify(db, n.ActorID, &model.Notification{
+			UserID:  n.UserID,
+			Type:    n.Type,
+			Title:   title,
+			Content: n.Content,
+			Data:    string(data),
+		})
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return aggregate(tx, n)
+	})
+}
+
+// aggregate folds n into the recipient's latest unread notification with the same group key,
+// or starts a new one. The folded notification moves back to the top of the list and is
+// pushed again.
+func aggregate(tx *gorm.DB, n notice) error {
+	blocked, err := followservice.IsBlockedBetween(tx, n.ActorID, n.UserID)
+	if err != nil || blocked {
+		return err
+	}
+
+	var existing model.Notification
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND group_key = ? AND is_read = ? AND created_at > ?",
+			n.UserID, n.GroupKey, false, time.Now().UTC().Add(-aggregationWindow)).
+		Order("id desc").
+		Limit(1).
+		Find(&existing)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	actors := actorSet{ActorIDs: []int64{n.ActorID}, ActorCount: 1}
+	if result.RowsAffected > 0 {
+		var previous actorSet
+		if err := json.Unmarshal([]byte(existing.Data), &previous); err != nil {
+			return err
+		}
+		actors = previous.add(n.ActorID)
+	}
+
+	fields := make(map[string]any, len(n.Data)+2)
+	for k, v := range n.Data {
+		fields[k] = v
+	}
+	fields["actor_ids"] = actors.ActorIDs
+	fields["actor_count"] = actors.ActorCount
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	title, err := n.render(tx, actors.ActorCount-1)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected == 0 {
+		existing = model.Notification{
+			UserID:   n.UserID,
+			Type:     n.Type,
+			Title:    title,
+			Content:  n.Content,
+			Data:     string(data),
+			GroupKey: n.GroupKey,
+		}
+		if err := tx.Create(&existing).Error; err != nil {
+			return err
+		}
+		return enqueuePush(tx, existing)
+	}
+	if err := tx.Model(&model.Notification{}).Where("id = ?", existing.ID).Updates(map[string]interface{}{
+		"title":      title,
+		"content":    n.Content,
+		"data":       string(data),
+		"created_at": time.Now().UTC(),
+	}).Error; err != nil {
+		return err
+	}
+	return enqueuePush(tx, existing)
+}
+
+func (n notice) render(db *gorm.DB, others int) (string, error) {
+	if n.Titles == nil {
+		return n.Title, nil
+	}
+	actor, err := displayName(db, n.ActorID)
+	if err != nil {
+		return "", err
+	}
+	return n.Titles(actor, others), nil
+}
+
+// actorPhrase renders "Alice", "Alice and 1 other" or "Alice and 5 others".
+func actorPhrase(actor string, others int) string {
+	switch {
+	case others <= 0:
+		return actor
+	case others == 1:
+		return actor + " and 1 other"
+	default:
+		return fmt.Sprintf("%s and %d others", actor, others)
+	}
+}
+
+func displayName(db *gorm.DB, userID int64) (string, error) {
+	var profile model.UserProfile
+	if err := db.Select("nickname").Where("user_id = ?", userID).Limit(1).Find(&profile).Error; err != nil {
+		return "", err
+	}
+	if name := strings.TrimSpace(profile.Nickname); name != "" {
+		return name, nil
+	}
+	return "Someone", nil
+}
+
+func hasMuted(db *gorm.DB, muterID, mutedID int64) (bool, error) {
+	if mutedID == 0 {
+		return false, nil
+	}
+	var count int64
+	if err := db.Model(&model.UserMute{}).Where("muter_id = ? AND muted_id = ?", muterID, mutedID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func loadMerchant(db *gorm.DB, merchantID int64) (model.Merchant, error) {
+	var merchant model.Merchant
+	err := db.Unscoped().Where("id = ?", merchantID).Limit(1).Find(&merchant).Error
+	return merchant, err
+}
+
+func merchantName(merchant model.Merchant) string {
+	if merchant.BusinessName != "" {
+		return merchant.BusinessName
+	}
+	return merchant.Name
+}
+
+func excerpt(text string) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	if len(runes) <= maxExcerptRunes {
+		return text
+	}
+	return string(runes[:maxExcerptRunes]) + "…"
+}
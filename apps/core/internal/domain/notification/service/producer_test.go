@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	contentservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/service"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func registerProducer(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	t.Cleanup(NewProducer(db).Register(eventbus.Default))
+}
+
+func createNamedUser(t *testing.T, db *gorm.DB, nickname string) model.User {
+	t.Helper()
+	user := model.User{Role: "user"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := db.Create(&model.UserProfile{UserID: user.ID, Nickname: nickname}).Error; err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	return user
+}
+
+func notificationsFor(t *testing.T, db *gorm.DB, userID int64) []model.Notification {
+	t.Helper()
+	var notifications []model.Notification
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&notifications).Error; err != nil {
+		t.Fatalf("failed to load notifications: %v", err)
+	}
+	return notifications
+}
+
+func TestProducerAggregatesLikeBursts(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	registerProducer(t, db)
+	ctx := context.Background()
+	author := createNamedUser(t, db, "Ann")
+	review := model.Review{UserID: author.ID, MerchantID: 1, VenueID: 1, Rating: 5, Content: "great"}
+	db.Create(&review)
+
+	likes := contentservice.NewInteractionService(db)
+	if err := likes.Like(ctx, author.ID, contentservice.TargetReview, review.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := notificationsFor(t, db, author.ID); len(got) != 0 {
+		t.Fatalf("expected no notification for liking your own review, got %d", len(got))
+	}
+
+	var last model.User
+	for _, name := range []string{"Bo", "Cy", "Di"} {
+		last = createNamedUser(t, db, name)
+		if err := likes.Like(ctx, last.ID, contentservice.TargetReview, review.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Unliking and liking again must not count the same person twice.
+	if err := likes.Unlike(ctx, last.ID, contentservice.TargetReview, review.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := likes.Like(ctx, last.ID, contentservice.TargetReview, review.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	got := notificationsFor(t, db, author.ID)
+	if len(got) != 1 {
+		t.Fatalf("expected the burst to collapse into one notification, got %d", len(got))
+	}
+	if got[0].Type != model.NotificationTypeLike || got[0].Title != "Di and 2 others liked your review" {
+		t.Fatalf("unexpected notification %q %q", got[0].Type, got[0].Title)
+	}
+	var data struct {
+		ReviewID   int64   `json:"review_id"`
+		TargetID   int64   `json:"target_id"`
+		TargetType string  `json:"target_type"`
+		ActorIDs   []int64 `json:"actor_ids"`
+		ActorCount int     `json:"actor_count"`
+	}
+	if err := json.Unmarshal([]byte(got[0].Data), &data); err != nil {
+		t.Fatalf("invalid data %q: %v", got[0].Data, err)
+	}
+	if data.TargetType != contentservice.TargetReview || data.TargetID != review.ID || data.ActorCount != 3 ||
+		len(data.ActorIDs) != 3 || data.ActorIDs[0] != last.ID {
+		t.Fatalf("unexpected data %+v", data)
+	}
+
+	// Once read, the next like starts a new notification.
+	if _, err := NewNotificationService(db).MarkRead(ctx, author.ID, got[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	late := createNamedUser(t, db, "Ed")
+	if err := likes.Like(ctx, late.ID, contentservice.TargetReview, review.ID); err != nil {
+		t.Fatal(err)
+	}
+	got = notificationsFor(t, db, author.ID)
+	if len(got) != 2 || got[1].Title != "Ed liked your review" {
+		t.Fatalf("expected a fresh notification after reading, got %+v", got)
+	}
+}
+
+func TestProducerNotifiesFollowsUnlessMuted(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	registerProducer(t, db)
+	ctx := context.Background()
+	follows := followservice.NewFollowService(db)
+	owner := createNamedUser(t, db, "Ann")
+	fan := createNamedUser(t, db, "Bo")
+	muted := createNamedUser(t, db, "Cy")
+
+	if err := follows.Mute(ctx, owner.ID, muted.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := follows.FollowUser(ctx, muted.ID, owner.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := follows.FollowUser(ctx, fan.ID, owner.ID); err != nil {
+		t.Fatal(err)
+	}
+	// Following twice is a no-op and must not notify again.
+	if err := follows.FollowUser(ctx, fan.ID, owner.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	got := notificationsFor(t, db, owner.ID)
+	if len(got) != 1 || got[0].Type != model.NotificationTypeFollow || got[0].Title != "Bo started following you" {
+		t.Fatalf("expected one follow notification from the unmuted fan, got %+v", got)
+	}
+}
+
+func TestProducerSkipsSenderAndMutedConversations(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	producer := NewProducer(db)
+	sender := createNamedUser(t, db, "Ann")
+	reader := createNamedUser(t, db, "Bo")
+	muter := createNamedUser(t, db, "Cy")
+	conversation := model.Conversation{Type: model.ConversationTypeGroup}
+	db.Create(&conversation)
+	for _, participant := range []model.ConversationParticipant{
+		{ConversationID: conversation.ID, UserID: sender.ID},
+		{ConversationID: conversation.ID, UserID: reader.ID},
+		{ConversationID: conversation.ID, UserID: muter.ID, IsMuted: true},
+	} {
+		db.Create(&participant)
+	}
+
+	for i, content := range []string{"hi", "are you there?"} {
+		if err := producer.Handle(ctx, eventbus.MessageSent{
+			MessageID:      int64(i + 1),
+			ConversationID: conversation.ID,
+			SenderID:       sender.ID,
+			MessageType:    model.MessageTypeText,
+			Content:        content,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := notificationsFor(t, db, reader.ID)
+	if len(got) != 1 || got[0].Title != "Ann sent you a message" || got[0].Content != "are you there?" {
+		t.Fatalf("expected one notification with the latest message, got %+v", got)
+	}
+	if got := notificationsFor(t, db, muter.ID); len(got) != 0 {
+		t.Fatalf("expected muted participant to get nothing, got %d", len(got))
+	}
+	if got := notificationsFor(t, db, sender.ID); len(got) != 0 {
+		t.Fatalf("expected sender to get nothing, got %d", len(got))
+	}
+}
+
+func TestProducerCommentReplyNotifiesParentAuthorOnce(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	producer := NewProducer(db)
+	owner := createNamedUser(t, db, "Ann")
+	replier := createNamedUser(t, db, "Bo")
+
+	if err := producer.Handle(ctx, eventbus.CommentCreated{
+		UserID:         replier.ID,
+		CommentID:      9,
+		TargetType:     contentservice.TargetPost,
+		TargetID:       4,
+		OwnerID:        owner.ID,
+		ParentAuthorID: owner.ID,
+		Content:        "agreed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := notificationsFor(t, db, owner.ID)
+	if len(got) != 1 || got[0].Type != model.NotificationTypeCommentReply || got[0].Title != "Bo replied to your comment" {
+		t.Fatalf("expected a single reply notification, got %+v", got)
+	}
+	var data struct {
+		PostID    int64 `json:"post_id"`
+		CommentID int64 `json:"comment_id"`
+	}
+	if err := json.Unmarshal([]byte(got[0].Data), &data); err != nil || data.PostID != 4 || data.CommentID != 9 {
+		t.Fatalf("unexpected data %q: %v", got[0].Data, err)
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+)
+
+// ErrDeviceTokenInvalid is returned by a PushSender when the provider rejected the token for
+// good. The dispatcher then forgets the device instead of retrying.
+var ErrDeviceTokenInvalid = errors.New("device token is no longer valid")
+
+// PushSender delivers one payload to one device, e.g. through FCM or APNs.
+type PushSender interface {
+	Send(ctx context.Context, device model.DeviceToken, payload PushPayload) error
+}
+
+// EmailSender sends one email. *email.SMTPClient implements it.
+type EmailSender interface {
+	SendEmailHTML(to, subject, body string, isHTML bool) error
+}
+
+// PushPayload is the provider-neutral content of a push. FCMMessage and APNsPayload render it
+// in the shape each provider expects.
+type PushPayload struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data"`
+	Badge int               `json:"badge"`
+	// CollapseKey makes a newer push replace an older one on the device, so an aggregated
+	// notification that keeps growing shows up once.
+	CollapseKey string `json:"collapse_key,omitempty"`
+}
+
+// BuildPushPayload builds the push for a notification. unread is the recipient's unread count,
+// used as the app badge. Data values are flattened to strings because FCM only accepts those.
+func BuildPushPayload(notification model.Notification, unread int64) PushPayload {
+	data := map[string]string{
+		"notification_id": strconv.FormatInt(notification.ID, 10),
+		"type":            notification.Type,
+	}
+	var fields map[string]any
+	if notification.Data != "" && json.Unmarshal([]byte(notification.Data), &fields) == nil {
+		for k, v := range fields {
+			switch v := v.(type) {
+			case string:
+				data[k] = v
+			case float64:
+				data[k] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				data[k] = strconv.FormatBool(v)
+			}
+		}
+	}
+	return PushPayload{
+		Title:       notification.Title,
+		Body:        excerpt(notification.Content),
+		Data:        data,
+		Badge:       int(unread),
+		CollapseKey: notification.GroupKey,
+	}
+}
+
+// FCMMessage renders the payload as the "message" object of an FCM HTTP v1 send request.
+func (p PushPayload) FCMMessage(token string) map[string]any {
+	android := map[string]any{"priority": "high"}
+	if p.CollapseKey != "" {
+		android["collapse_key"] = p.CollapseKey
+	}
+	return map[string]any{
+		"token": token,
+		"notification": map[string]any{
+			"title": p.Title,
+			"body":  p.Body,
+		},
+		"data":    p.Data,
+		"android": android,
+	}
+}
+
+// APNsPayload renders the payload as an APNs request body. The collapse key also has to be
+// sent as the apns-collapse-id header; here it only groups the notification into a thread.
+func (p PushPayload) APNsPayload() map[string]any {
+	aps := map[string]any{
+		"alert": map[string]any{
+			"title": p.Title,
+			"body":  p.Body,
+		},
+		"badge": p.Badge,
+		"sound": "default",
+	}
+	if p.CollapseKey != "" {
+		aps["thread-id"] = p.CollapseKey
+	}
+	body := map[string]any{"aps": aps}
+	for k, v := range p.Data {
+		if k != "aps" {
+			body[k] = v
+		}
+	}
+	return body
+}
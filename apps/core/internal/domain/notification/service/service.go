@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
@@ -64,3 +65,18 @@ func (s *NotificationService) ReadAll(ctx context.Context, userID int64) error {
 			"read_at": &now,
 		}).Error
 }
+
+// Notify stores a notification for notification.UserID caused by actorID, unless the two are
+// on either side of a block, in which case nothing is stored. It takes a *gorm.DB so
+// producers can notify inside their own transactions; actorID is 0 for system notifications.
+// Pushes to the recipient's devices are queued alongside.
+func Notify(db *gorm.DB, actorID int64, notification *model.Notification) error {
+	blocked, err := followservice.IsBlockedBetween(db, actorID, notification.UserID)
+	if err != nil || blocked {
+		return err
+	}
+	if err := db.Create(notification).Error; err != nil {
+		return err
+	}
+	return enqueuePush(db, *notification)
+}
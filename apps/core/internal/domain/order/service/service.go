@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"github.com/google/uuid"
@@ -150,8 +151,11 @@ func (s *OrderService) Detail(ctx context.Context, userID, orderID int64) (*Orde
 	return &OrderDetail{Order: order, Vouchers: vouchers}, nil
 }
 
+// Pay settles a pending order and issues its vouchers. Paying an already paid order returns
+// the vouchers issued the first time; only the first payment publishes eventbus.OrderPaid.
 func (s *OrderService) Pay(ctx context.Context, userID, orderID int64) (*PayResult, error) {
 	var result *PayResult
+	var paid *eventbus.OrderPaid
 	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var order model.Order
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
@@ -266,10 +270,21 @@ func (s *OrderService) Pay(ctx context.Context, userID, orderID int64) (*PayResu
 		}
 
 		result = &PayResult{Order: order, Vouchers: vouchers}
+		paid = &eventbus.OrderPaid{
+			OrderID:    order.ID,
+			UserID:     userID,
+			MerchantID: merchantID,
+			CouponID:   coupon.ID,
+			Quantity:   order.Quantity,
+			Amount:     order.TotalPrice,
+		}
 		return nil
 	}); err != nil {
 		return nil, err
 	}
+	if paid != nil {
+		eventbus.Publish(ctx, *paid)
+	}
 	return result, nil
 }
 
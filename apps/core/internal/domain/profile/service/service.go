@@ -4,57 +4,95 @@ import (
 	"context"
 	"fmt"
 
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetPublicProfile(ctx context.Context, userID int64) (*PublicProfileResponse, error)
+	GetPublicProfile(ctx context.Context, viewerID, userID int64) (*PublicProfileResponse, error)
 }
 
 type service struct {
-	db *gorm.DB
+	db      *gorm.DB
+	follows *followservice.FollowService
 }
 
 func NewService(db *gorm.DB) Service {
 	if db == nil {
 		db = database.DB
 	}
-	return &service{db: db}
+	return &service{db: db, follows: followservice.NewFollowService(db)}
 }
 
+// PublicProfileResponse is a user's profile as seen by a viewer. When the profile is
+// private and the viewer is neither the owner nor a follower, only the user ID, nickname
+// and avatar are filled in.
 type PublicProfileResponse struct {
-	UserID         int64  `json:"user_id"`
-	Nickname       string `json:"nickname"`
-	AvatarURL      string `json:"avatar_url"`
-	Intro          string `json:"intro"`
-	Location       string `json:"location"`
-	FollowerCount  int    `json:"follower_count"`
-	FollowingCount int    `json:"following_count"`
-	PostCount      int    `json:"post_count"`
-	ReviewCount    int    `json:"review_count"`
-	LikeCount      int    `json:"like_count"`
-	IsFollowing    bool   `json:"is_following"`
+	UserID          int64  `json:"user_id"`
+	Nickname        string `json:"nickname"`
+	AvatarURL       string `json:"avatar_url"`
+	Intro           string `json:"intro"`
+	Location        string `json:"location"`
+	FollowerCount   int    `json:"follower_count"`
+	FollowingCount  int    `json:"following_count"`
+	PostCount       int    `json:"post_count"`
+	ReviewCount     int    `json:"review_count"`
+	LikeCount       int    `json:"like_count"`
+	IsPrivate       bool   `json:"is_private"`
+	IsRestricted    bool   `json:"is_restricted"`
+	IsFollowing     bool   `json:"is_following"`
+	FollowsYou      bool   `json:"follows_you"`
+	FollowRequested bool   `json:"follow_requested"`
+	// MutualFollowerCount counts the user's followers whom the viewer follows.
+	MutualFollowerCount int64 `json:"mutual_follower_count"`
 }
 
-func (s *service) GetPublicProfile(ctx context.Context, userID int64) (*PublicProfileResponse, error) {
+// GetPublicProfile returns userID's profile as seen by viewerID, which is 0 for anonymous
+// viewers.
+func (s *service) GetPublicProfile(ctx context.Context, viewerID, userID int64) (*PublicProfileResponse, error) {
 	var profile model.UserProfile
 	if err := s.db.WithContext(ctx).First(&profile, "user_id = ?", userID).Error; err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	return &PublicProfileResponse{
-		UserID:         profile.UserID,
-		Nickname:       profile.Nickname,
-		AvatarURL:      profile.AvatarURL,
-		Intro:          profile.Intro,
-		Location:       profile.Location,
-		FollowerCount:  profile.FollowerCount,
-		FollowingCount: profile.FollowingCount,
-		PostCount:      profile.PostCount,
-		ReviewCount:    profile.ReviewCount,
-		LikeCount:      profile.LikeCount,
-		IsFollowing:    false,
-	}, nil
+	private, err := s.follows.IsPrivate(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	canView, err := s.follows.CanView(ctx, viewerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	var rel followservice.Relationship
+	if viewerID != userID {
+		if rel, err = s.follows.Relationship(ctx, viewerID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &PublicProfileResponse{
+		UserID:          profile.UserID,
+		Nickname:        profile.Nickname,
+		AvatarURL:       profile.AvatarURL,
+		IsPrivate:       private,
+		IsRestricted:    !canView,
+		IsFollowing:     rel.IsFollowing,
+		FollowsYou:      rel.FollowsYou,
+		FollowRequested: rel.FollowRequested,
+	}
+	if canView {
+		resp.Intro = profile.Intro
+		resp.Location = profile.Location
+		resp.FollowerCount = profile.FollowerCount
+		resp.FollowingCount = profile.FollowingCount
+		resp.PostCount = profile.PostCount
+		resp.ReviewCount = profile.ReviewCount
+		resp.LikeCount = profile.LikeCount
+		if resp.MutualFollowerCount, err = s.follows.MutualFollowerCount(ctx, viewerID, userID); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
 }
@@ -1,7 +1,61 @@
 package service
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+)
 
 func TestPublicProfileResponseExists(t *testing.T) {
 	_ = PublicProfileResponse{}
 }
+
+func TestPrivateProfileShowsBasicInfoToNonFollowers(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewService(db)
+	ctx := context.Background()
+	owner := model.User{Role: "user"}
+	fan := model.User{Role: "user"}
+	stranger := model.User{Role: "user"}
+	db.Create(&owner)
+	db.Create(&fan)
+	db.Create(&stranger)
+	db.Create(&model.UserProfile{UserID: owner.ID, Nickname: "owner", Intro: "hello", FollowerCount: 1, PostCount: 4})
+	db.Create(&model.UserPrivacy{UserID: owner.ID, IsPublic: true})
+	db.Model(&model.UserPrivacy{}).Where("user_id = ?", owner.ID).Update("is_public", false)
+	db.Create(&model.UserFollow{FollowerID: fan.ID, FollowingID: owner.ID})
+	db.Create(&model.UserFollow{FollowerID: owner.ID, FollowingID: stranger.ID})
+
+	for _, viewerID := range []int64{0, stranger.ID} {
+		profile, err := svc.GetPublicProfile(ctx, viewerID, owner.ID)
+		if err != nil {
+			t.Fatalf("get profile failed: %v", err)
+		}
+		if !profile.IsPrivate || !profile.IsRestricted || profile.Nickname != "owner" {
+			t.Fatalf("expected a restricted private profile, got %+v", profile)
+		}
+		if profile.Intro != "" || profile.PostCount != 0 || profile.FollowerCount != 0 {
+			t.Fatalf("expected details to be withheld, got %+v", profile)
+		}
+	}
+	profile, _ := svc.GetPublicProfile(ctx, stranger.ID, owner.ID)
+	if profile.IsFollowing || !profile.FollowsYou {
+		t.Fatalf("expected follows_you for the stranger the owner follows, got %+v", profile)
+	}
+
+	for _, viewerID := range []int64{fan.ID, owner.ID} {
+		profile, err := svc.GetPublicProfile(ctx, viewerID, owner.ID)
+		if err != nil {
+			t.Fatalf("get profile failed: %v", err)
+		}
+		if profile.IsRestricted || profile.Intro != "hello" || profile.PostCount != 4 {
+			t.Fatalf("expected the full profile, got %+v", profile)
+		}
+	}
+	profile, _ = svc.GetPublicProfile(ctx, fan.ID, owner.ID)
+	if !profile.IsFollowing || profile.FollowsYou {
+		t.Fatalf("expected is_following for the fan, got %+v", profile)
+	}
+}
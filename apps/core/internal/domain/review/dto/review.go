@@ -10,28 +10,105 @@ import (
 )
 
 type Review struct {
-	ID            string   `json:"id"`
-	MerchantID    string   `json:"merchantId"`
-	VenueID       string   `json:"venueId"`
-	StoreID       string   `json:"storeId"`
-	UserID        string   `json:"userId"`
-	Rating        float64  `json:"rating"`
-	Text          string   `json:"text"`
-	Images        []string `json:"images"`
-	Tags          []string `json:"tags"`
-	VisitDate     string   `json:"visitDate"`
-	CreatedAt     string   `json:"createdAt"`
-	BusinessName  string   `json:"businessName"`
-	BusinessImage string   `json:"businessImage"`
-	Location      string   `json:"location"`
-	LikeCount     int      `json:"likeCount"`
-}
-
-// CommentRequest is the request body for adding a review comment.
+	ID            string         `json:"id"`
+	MerchantID    string         `json:"merchantId"`
+	VenueID       string         `json:"venueId"`
+	StoreID       string         `json:"storeId"`
+	UserID        string         `json:"userId"`
+	Rating        float64        `json:"rating"`
+	RatingEnv     *float64       `json:"ratingEnv,omitempty"`
+	RatingService *float64       `json:"ratingService,omitempty"`
+	RatingValue   *float64       `json:"ratingValue,omitempty"`
+	RatingFood    *float64       `json:"ratingFood,omitempty"`
+	AvgCost       *int           `json:"avgCost,omitempty"`
+	Text          string         `json:"text"`
+	Images        []string       `json:"images"`
+	MediaIDs      []string       `json:"mediaIds,omitempty"`
+	Media         []Media        `json:"media"`
+	Tags          []string       `json:"tags"`
+	VisitDate     string         `json:"visitDate"`
+	CreatedAt     string         `json:"createdAt"`
+	BusinessName  string         `json:"businessName"`
+	BusinessImage string         `json:"businessImage"`
+	Location      string         `json:"location"`
+	LikeCount     int            `json:"likeCount"`
+	Verified      bool           `json:"verified"`
+	Edited        bool           `json:"edited"`
+	EditedAt      string         `json:"editedAt,omitempty"`
+	MerchantReply *MerchantReply `json:"merchantReply,omitempty"`
+}
+
+// Media is an upload attached to a review. On create, uploads are referenced by UUID in
+// Review.MediaIDs and attached in that order.
+type Media struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	SortOrder int    `json:"sortOrder"`
+}
+
+// MerchantReply is the merchant's official public response to a review.
+type MerchantReply struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// UpdateReviewRequest is the request body for editing a review; omitted fields are left unchanged.
+// MediaIDs replaces the attached uploads, referenced by UUID as on create.
+type UpdateReviewRequest struct {
+	Rating    *float64  `json:"rating"`
+	Text      *string   `json:"text"`
+	Images    *[]string `json:"images"`
+	MediaIDs  *[]string `json:"mediaIds"`
+	VisitDate *string   `json:"visitDate"`
+}
+
+// CommentRequest is the request body for adding a review comment. ParentID makes it a reply.
 type CommentRequest struct {
+	Text     string `json:"text" binding:"required"`
+	ParentID string `json:"parentId"`
+}
+
+// UpdateCommentRequest is the request body for editing a review comment.
+type UpdateCommentRequest struct {
 	Text string `json:"text" binding:"required"`
 }
 
+// Comment is a review comment with its nested replies.
+type Comment struct {
+	ID              string    `json:"id"`
+	ReviewID        string    `json:"reviewId"`
+	UserID          string    `json:"userId"`
+	ParentID        string    `json:"parentId,omitempty"`
+	Text            string    `json:"text"`
+	UserNickname    string    `json:"userNickname"`
+	UserAvatar      string    `json:"userAvatar"`
+	IsMerchantReply bool      `json:"isMerchantReply"`
+	LikeCount       int       `json:"likeCount"`
+	CreatedAt       string    `json:"createdAt"`
+	UpdatedAt       string    `json:"updatedAt"`
+	Replies         []Comment `json:"replies"`
+}
+
+// CommentListResponse is a page of top-level comments; Cursor is nil on the last page.
+type CommentListResponse struct {
+	Data   []Comment `json:"data"`
+	Cursor *int64    `json:"cursor"`
+}
+
+func (r CommentRequest) ParentIDValue() (*int64, error) {
+	if r.ParentID == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(r.ParentID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
 func (r Review) MerchantIDValue() (int64, error) {
 	if r.MerchantID == "" {
 		return 0, errors.New("merchantId required")
@@ -83,6 +160,35 @@ func FromModel(m model.Review) Review {
 		location = m.Merchant.Address
 	}
 
+	var editedAt string
+	if m.EditedAt != nil {
+		editedAt = m.EditedAt.Format(time.RFC3339)
+	}
+
+	var merchantReply *MerchantReply
+	if m.MerchantReply != nil {
+		merchantReply = &MerchantReply{
+			ID:        strconv.FormatInt(m.MerchantReply.ID, 10),
+			Text:      m.MerchantReply.Content,
+			CreatedAt: m.MerchantReply.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: m.MerchantReply.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+
+	tags := make([]string, 0, len(m.Tags))
+	for _, tag := range m.Tags {
+		tags = append(tags, tag.Name)
+	}
+	media := make([]Media, 0, len(m.Media))
+	for _, item := range m.Media {
+		media = append(media, Media{
+			ID:        strconv.FormatInt(item.ID, 10),
+			Type:      item.MediaType,
+			URL:       item.URL,
+			SortOrder: item.SortOrder,
+		})
+	}
+
 	var storeID string
 	if m.StoreID != nil {
 		storeID = strconv.FormatInt(*m.StoreID, 10)
@@ -95,16 +201,34 @@ func FromModel(m model.Review) Review {
 		StoreID:       storeID,
 		UserID:        strconv.FormatInt(m.UserID, 10),
 		Rating:        float64(m.Rating),
+		RatingEnv:     float64Ptr(m.RatingEnv),
+		RatingService: float64Ptr(m.RatingService),
+		RatingValue:   float64Ptr(m.RatingValue),
+		RatingFood:    float64Ptr(m.RatingFood),
+		AvgCost:       m.AvgCost,
 		Text:          m.Content,
 		Images:        images,
-		Tags:          []string{},
+		Media:         media,
+		Tags:          tags,
 		VisitDate:     m.VisitDate.Format("2006-01-02"),
 		CreatedAt:     m.CreatedAt.Format(time.RFC3339),
 		BusinessName:  businessName,
 		BusinessImage: businessImage,
 		Location:      location,
 		LikeCount:     m.LikeCount,
+		Verified:      m.IsVerified,
+		Edited:        m.Edited,
+		EditedAt:      editedAt,
+		MerchantReply: merchantReply,
+	}
+}
+
+func float64Ptr(v *float32) *float64 {
+	if v == nil {
+		return nil
 	}
+	out := float64(*v)
+	return &out
 }
 
 func FromModels(items []model.Review) []Review {
@@ -114,3 +238,36 @@ func FromModels(items []model.Review) []Review {
 	}
 	return out
 }
+
+func CommentFromModel(m model.ReviewComment) Comment {
+	var parentID string
+	if m.ParentCommentID != nil {
+		parentID = strconv.FormatInt(*m.ParentCommentID, 10)
+	}
+	replies := make([]Comment, 0, len(m.Replies))
+	for _, reply := range m.Replies {
+		replies = append(replies, CommentFromModel(reply))
+	}
+	return Comment{
+		ID:              strconv.FormatInt(m.ID, 10),
+		ReviewID:        strconv.FormatInt(m.ReviewID, 10),
+		UserID:          strconv.FormatInt(m.UserID, 10),
+		ParentID:        parentID,
+		Text:            m.Content,
+		UserNickname:    m.UserNickname,
+		UserAvatar:      m.UserAvatar,
+		IsMerchantReply: m.IsMerchantReply,
+		LikeCount:       m.LikeCount,
+		CreatedAt:       m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       m.UpdatedAt.Format(time.RFC3339),
+		Replies:         replies,
+	}
+}
+
+func CommentsFromModels(items []model.ReviewComment) []Comment {
+	out := make([]Comment, 0, len(items))
+	for _, item := range items {
+		out = append(out, CommentFromModel(item))
+	}
+	return out
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/service"
 	"github.com/gin-gonic/gin"
@@ -16,7 +17,7 @@ type ReviewHandler struct {
 
 func NewReviewHandler(svc *service.ReviewService) *ReviewHandler {
 	if svc == nil {
-		svc = service.NewReviewService(nil)
+		svc = service.NewReviewService(nil, nil, config.ReviewConfig{})
 	}
 	return &ReviewHandler{svc: svc}
 }
@@ -32,7 +33,9 @@ func NewReviewHandler(svc *service.ReviewService) *ReviewHandler {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 422 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /reviews [post]
 func (h *ReviewHandler) Create(c *gin.Context) {
 	userID := c.GetInt64("user_id")
@@ -46,8 +49,13 @@ func (h *ReviewHandler) Create(c *gin.Context) {
 		switch {
 		case errors.Is(err, service.ErrMerchantNotFound), errors.Is(err, service.ErrStoreNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case errors.Is(err, service.ErrStoreMerchantMismatch):
+		case errors.Is(err, service.ErrStoreMerchantMismatch), errors.Is(err, service.ErrMediaNotApproved),
+			errors.Is(err, service.ErrMediaAlreadyAttached):
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrDuplicateReview):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrReviewRateLimited):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
@@ -72,7 +80,7 @@ func (h *ReviewHandler) Detail(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
 		return
 	}
-	review, err := h.svc.Detail(c.Request.Context(), id)
+	review, err := h.svc.Detail(c.Request.Context(), c.GetInt64("user_id"), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 		return
@@ -80,6 +88,83 @@ func (h *ReviewHandler) Detail(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.FromModel(*review))
 }
 
+// UpdateReview godoc
+// @Summary Update a review
+// @Description Edits a review owned by the authenticated user; the previous version is kept in the edit history. mediaIds replaces the attached uploads
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param request body dto.UpdateReviewRequest true "Update review request"
+// @Success 200 {object} dto.Review
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /reviews/{id} [patch]
+func (h *ReviewHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	userID := c.GetInt64("user_id")
+	var req dto.UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := h.svc.Update(c.Request.Context(), userID, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReviewNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrReviewForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrMediaNotApproved), errors.Is(err, service.ErrMediaAlreadyAttached):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, dto.FromModel(updated))
+}
+
+// DeleteReview godoc
+// @Summary Delete a review
+// @Description Soft-deletes a review owned by the authenticated user
+// @Tags review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reviews/{id} [delete]
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	userID := c.GetInt64("user_id")
+	if err := h.svc.Delete(c.Request.Context(), userID, id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrReviewNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrReviewForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
 // LikeReview godoc
 // @Summary Like a review
 // @Description Likes a review for the authenticated user
@@ -89,6 +174,7 @@ func (h *ReviewHandler) Detail(c *gin.Context) {
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /reviews/{id}/like [post]
 func (h *ReviewHandler) Like(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -98,23 +184,94 @@ func (h *ReviewHandler) Like(c *gin.Context) {
 	}
 	userID := c.GetInt64("user_id")
 	if err := h.svc.Like(c.Request.Context(), userID, id); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UnlikeReview godoc
+// @Summary Unlike a review
+// @Description Removes the authenticated user's like from a review
+// @Tags review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reviews/{id}/like [delete]
+func (h *ReviewHandler) Unlike(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	userID := c.GetInt64("user_id")
+	if err := h.svc.Unlike(c.Request.Context(), userID, id); err != nil {
+		respondCommentError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
 }
 
+// ListReviewComments godoc
+// @Summary List review comments
+// @Description Returns top-level comments on a review, newest first, with nested replies
+// @Tags review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param cursor query int false "Cursor for pagination (comment id)"
+// @Param limit query int false "Page size (max 100)"
+// @Success 200 {object} dto.CommentListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reviews/{id}/comments [get]
+func (h *ReviewHandler) Comments(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var cursor *int64
+	if raw := c.Query("cursor"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+			return
+		}
+		cursor = &parsed
+	}
+	var limit *int
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = &parsed
+	}
+
+	comments, next, err := h.svc.ListComments(c.Request.Context(), c.GetInt64("user_id"), id, cursor, limit)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.CommentListResponse{Data: dto.CommentsFromModels(comments), Cursor: next})
+}
+
 // CommentReview godoc
 // @Summary Add a review comment
-// @Description Adds a comment to a review
+// @Description Adds a comment to a review, or a reply to another comment when parentId is set
 // @Tags review
 // @Accept json
 // @Produce json
 // @Param id path int true "Review ID"
 // @Param request body dto.CommentRequest true "Comment request"
-// @Success 201 {object} map[string]string
+// @Success 201 {object} dto.Comment
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /reviews/{id}/comments [post]
 func (h *ReviewHandler) Comment(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -128,9 +285,146 @@ func (h *ReviewHandler) Comment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid text"})
 		return
 	}
-	if err := h.svc.Comment(c.Request.Context(), userID, id, req.Text); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	parentID, err := req.ParentIDValue()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid parentId"})
+		return
+	}
+	comment, err := h.svc.Comment(c.Request.Context(), userID, id, parentID, req.Text)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.CommentFromModel(comment))
+}
+
+// UpdateReviewComment godoc
+// @Summary Update a review comment
+// @Description Edits a comment owned by the authenticated user
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param commentId path int true "Comment ID"
+// @Param request body dto.UpdateCommentRequest true "Update comment request"
+// @Success 200 {object} dto.Comment
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reviews/{id}/comments/{commentId} [patch]
+func (h *ReviewHandler) UpdateComment(c *gin.Context) {
+	id, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+	var req dto.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid text"})
+		return
+	}
+	comment, err := h.svc.UpdateComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID, req.Text)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.CommentFromModel(comment))
+}
+
+// DeleteReviewComment godoc
+// @Summary Delete a review comment
+// @Description Deletes a comment owned by the authenticated user together with its replies
+// @Tags review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reviews/{id}/comments/{commentId} [delete]
+func (h *ReviewHandler) DeleteComment(c *gin.Context) {
+	id, commentID, ok := parseCommentPath(c)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{})
+	if err := h.svc.DeleteComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// LikeReviewComment godoc
+// @Summary Like a review comment
+// @Description Likes a review comment for the authenticated user
+// @Tags review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reviews/{id}/comments/{commentId}/like [post]
+func (h *ReviewHandler) LikeComment(c *gin.Context) {
+	id, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+	if err := h.svc.LikeComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UnlikeReviewComment godoc
+// @Summary Unlike a review comment
+// @Description Removes the authenticated user's like from a review comment
+// @Tags review
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reviews/{id}/comments/{commentId}/like [delete]
+func (h *ReviewHandler) UnlikeComment(c *gin.Context) {
+	id, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+	if err := h.svc.UnlikeComment(c.Request.Context(), c.GetInt64("user_id"), id, commentID); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func parseCommentPath(c *gin.Context) (int64, int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, 0, false
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return 0, 0, false
+	}
+	return id, commentID, true
+}
+
+func respondCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrReviewNotFound), errors.Is(err, service.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCommentForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
 }
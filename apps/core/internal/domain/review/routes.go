@@ -2,22 +2,32 @@ package review
 
 import (
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/handler"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes registers review routes.
-func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
-	svc := service.NewReviewService(nil)
+// RegisterRoutes registers review routes. moderation is shared with the other domains that
+// screen user content.
+func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config, moderation *moderationservice.ModerationService) {
+	svc := service.NewReviewService(nil, moderation, cfg.Reviews)
 	h := handler.NewReviewHandler(svc)
 
 	reviews := r.Group("/reviews")
 	{
 		reviews.POST("", middleware.JWTAuth(cfg.JWT), h.Create)
-		reviews.GET("/:id", h.Detail)
+		reviews.GET("/:id", middleware.OptionalJWTAuth(cfg.JWT), h.Detail)
+		reviews.PATCH("/:id", middleware.JWTAuth(cfg.JWT), h.Update)
+		reviews.DELETE("/:id", middleware.JWTAuth(cfg.JWT), h.Delete)
 		reviews.POST("/:id/like", middleware.JWTAuth(cfg.JWT), h.Like)
+		reviews.DELETE("/:id/like", middleware.JWTAuth(cfg.JWT), h.Unlike)
+		reviews.GET("/:id/comments", middleware.OptionalJWTAuth(cfg.JWT), h.Comments)
 		reviews.POST("/:id/comments", middleware.JWTAuth(cfg.JWT), h.Comment)
+		reviews.PATCH("/:id/comments/:commentId", middleware.JWTAuth(cfg.JWT), h.UpdateComment)
+		reviews.DELETE("/:id/comments/:commentId", middleware.JWTAuth(cfg.JWT), h.DeleteComment)
+		reviews.POST("/:id/comments/:commentId/like", middleware.JWTAuth(cfg.JWT), h.LikeComment)
+		reviews.DELETE("/:id/comments/:commentId/like", middleware.JWTAuth(cfg.JWT), h.UnlikeComment)
 	}
 }
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultPriorMean           = 3.5
+	defaultPriorWeight         = 10
+	defaultRecencyHalfLifeDays = 180
+)
+
+// ratingScorer maintains rating aggregates and derives the merchant and store rating
+// columns from them.
+type ratingScorer struct {
+	priorMean   float64
+	priorWeight float64
+	halfLife    time.Duration
+}
+
+func newRatingScorer(cfg config.ReviewConfig) ratingScorer {
+	scorer := ratingScorer{
+		priorMean:   cfg.PriorMean,
+		priorWeight: cfg.PriorWeight,
+		halfLife:    time.Duration(cfg.RecencyHalfLifeDays) * 24 * time.Hour,
+	}
+	if scorer.priorMean <= 0 {
+		scorer.priorMean = defaultPriorMean
+	}
+	if scorer.priorWeight <= 0 {
+		scorer.priorWeight = defaultPriorWeight
+	}
+	if scorer.halfLife <= 0 {
+		scorer.halfLife = defaultRecencyHalfLifeDays * 24 * time.Hour
+	}
+	return scorer
+}
+
+// adjustRatingAggregates adds (sign 1) or removes (sign -1) a review's contribution to its
+// merchant's and store's aggregates. Only visible reviews count, so an edit removes the
+// review as it was and adds it back as it is now.
+func (s *ReviewService) adjustRatingAggregates(tx *gorm.DB, review model.Review, sign int) error {
+	if review.Status != model.ContentStatusVisible {
+		return nil
+	}
+	now := time.Now()
+	if err := s.scorer.adjust(tx, model.RatingSubjectMerchant, review.MerchantID, func(agg *model.RatingAggregate) {
+		s.scorer.apply(agg, review, sign, now)
+	}); err != nil {
+		return err
+	}
+	if review.StoreID == nil {
+		return nil
+	}
+	return s.scorer.adjust(tx, model.RatingSubjectStore, *review.StoreID, func(agg *model.RatingAggregate) {
+		s.scorer.apply(agg, review, sign, now)
+	})
+}
+
+// RebuildRatingAggregates recomputes every merchant and store aggregate from its visible
+// reviews and returns how many subjects were rebuilt. It repairs drift and refreshes the
+// recency-weighted scores, which otherwise only move when a subject gets a new review.
+func (s *ReviewService) RebuildRatingAggregates(ctx context.Context) (int, error) {
+	db := s.db.WithContext(ctx)
+	subjects := []struct {
+		subjectType string
+		table       interface{}
+		column      string
+	}{
+		{model.RatingSubjectMerchant, &model.Merchant{}, "merchant_id"},
+		{model.RatingSubjectStore, &model.Store{}, "store_id"},
+	}
+
+	rebuilt := 0
+	for _, subject := range subjects {
+		var ids []int64
+		if err := db.Model(subject.table).Order("id asc").Pluck("id", &ids).Error; err != nil {
+			return rebuilt, err
+		}
+		for _, id := range ids {
+			if err := db.Transaction(func(tx *gorm.DB) error {
+				return s.rebuildRatingAggregate(tx, subject.subjectType, subject.column, id)
+			}); err != nil {
+				return rebuilt, err
+			}
+			rebuilt++
+		}
+	}
+	return rebuilt, nil
+}
+
+func (s *ReviewService) rebuildRatingAggregate(tx *gorm.DB, subjectType, column string, id int64) error {
+	var reviews []model.Review
+	if err := tx.Select("id", "rating", "rating_env", "rating_service", "rating_value", "rating_food", "avg_cost", "status", "created_at").
+		Where(column+" = ? AND status = ?", id, model.ContentStatusVisible).
+		Find(&reviews).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	return s.scorer.adjust(tx, subjectType, id, func(agg *model.RatingAggregate) {
+		*agg = model.RatingAggregate{SubjectType: agg.SubjectType, SubjectID: agg.SubjectID}
+		for _, review := range reviews {
+			s.scorer.apply(agg, review, 1, now)
+		}
+	})
+}
+
+// adjust locks a subject's aggregate row, creating it when missing, lets change update it
+// and writes the result back together with the subject's rating columns.
+func (sc ratingScorer) adjust(tx *gorm.DB, subjectType string, subjectID int64, change func(agg *model.RatingAggregate)) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.RatingAggregate{SubjectType: subjectType, SubjectID: subjectID}).Error; err != nil {
+		return err
+	}
+	var agg model.RatingAggregate
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("subject_type = ? AND subject_id = ?", subjectType, subjectID).
+		First(&agg).Error; err != nil {
+		return err
+	}
+	change(&agg)
+	if err := tx.Save(&agg).Error; err != nil {
+		return err
+	}
+
+	updates := sc.columns(agg)
+	switch subjectType {
+	case model.RatingSubjectMerchant:
+		updates["total_reviews"] = agg.ReviewCount
+		return tx.Model(&model.Merchant{}).Where("id = ?", subjectID).Updates(updates).Error
+	default:
+		return tx.Model(&model.Store{}).Where("id = ?", subjectID).Updates(updates).Error
+	}
+}
+
+// apply adds sign times the review to the aggregate. The recency sums are first discounted
+// to now so that every review's weight halves once per half-life since it was written.
+func (sc ratingScorer) apply(agg *model.RatingAggregate, review model.Review, sign int, now time.Time) {
+	delta := float64(sign)
+	rating := float64(review.Rating)
+
+	agg.ReviewCount += sign
+	agg.RatingSum += delta * rating
+	switch star := int(math.Round(rating)); {
+	case star <= 1:
+		agg.Star1 += sign
+	case star == 2:
+		agg.Star2 += sign
+	case star == 3:
+		agg.Star3 += sign
+	case star == 4:
+		agg.Star4 += sign
+	default:
+		agg.Star5 += sign
+	}
+	addOptional(&agg.EnvSum, &agg.EnvCount, review.RatingEnv, sign)
+	addOptional(&agg.ServiceSum, &agg.ServiceCount, review.RatingService, sign)
+	addOptional(&agg.ValueSum, &agg.ValueCount, review.RatingValue, sign)
+	addOptional(&agg.FoodSum, &agg.FoodCount, review.RatingFood, sign)
+	if review.AvgCost != nil {
+		agg.CostSum += int64(sign * *review.AvgCost)
+		agg.CostCount += sign
+	}
+
+	if agg.DecayedAt != nil {
+		factor := sc.decay(*agg.DecayedAt, now)
+		agg.DecayedSum *= factor
+		agg.DecayedWeight *= factor
+	}
+	weight := sc.decay(review.CreatedAt, now)
+	agg.DecayedSum += delta * rating * weight
+	agg.DecayedWeight += delta * weight
+	agg.DecayedAt = &now
+
+	if agg.ReviewCount <= 0 {
+		// Nothing left to average; drop any floating point residue.
+		*agg = model.RatingAggregate{SubjectType: agg.SubjectType, SubjectID: agg.SubjectID, DecayedAt: agg.DecayedAt}
+	}
+}
+
+func addOptional(sum *float64, count *int, value *float32, sign int) {
+	if value == nil {
+		return
+	}
+	*sum += float64(sign) * float64(*value)
+	*count += sign
+}
+
+// decay is the weight left after the time between from and to.
+func (sc ratingScorer) decay(from, to time.Time) float64 {
+	if !to.After(from) {
+		return 1
+	}
+	return math.Exp2(-float64(to.Sub(from)) / float64(sc.halfLife))
+}
+
+// columns maps an aggregate onto the rating columns shared by merchants and stores. The
+// Bayesian score pulls the average towards priorMean with priorWeight pseudo-reviews; the
+// recent score does the same over the decayed sums, so a subject whose reviews are all old
+// drifts back towards the prior.
+func (sc ratingScorer) columns(agg model.RatingAggregate) map[string]interface{} {
+	updates := map[string]interface{}{
+		"review_count":       agg.ReviewCount,
+		"avg_rating":         float32(ratio(agg.RatingSum, float64(agg.ReviewCount))),
+		"avg_rating_env":     float32(ratio(agg.EnvSum, float64(agg.EnvCount))),
+		"avg_rating_service": float32(ratio(agg.ServiceSum, float64(agg.ServiceCount))),
+		"avg_rating_value":   float32(ratio(agg.ValueSum, float64(agg.ValueCount))),
+		"avg_rating_food":    float32(ratio(agg.FoodSum, float64(agg.FoodCount))),
+		"avg_cost":           int(math.Round(ratio(float64(agg.CostSum), float64(agg.CostCount)))),
+		"bayesian_rating":    float32(0),
+		"recent_rating":      float32(0),
+	}
+	if agg.ReviewCount > 0 {
+		prior := sc.priorMean * sc.priorWeight
+		updates["bayesian_rating"] = float32((prior + agg.RatingSum) / (sc.priorWeight + float64(agg.ReviewCount)))
+		updates["recent_rating"] = float32((prior + agg.DecayedSum) / (sc.priorWeight + agg.DecayedWeight))
+	}
+	return updates
+}
+
+func ratio(sum, count float64) float64 {
+	if count <= 0 {
+		return 0
+	}
+	return sum / count
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+func approxEqual(a, b float64) bool { return math.Abs(a-b) < 1e-3 }
+
+func TestRatingAggregatesFollowCreateUpdateAndDelete(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	ctx := context.Background()
+
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown"}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	var ids []int64
+	for _, rating := range []float64{5, 4, 2} {
+		created, err := svc.Create(ctx, userID, dto.Review{
+			MerchantID:    fmt.Sprintf("%d", merchant.ID),
+			StoreID:       fmt.Sprintf("%d", store.ID),
+			Rating:        rating,
+			RatingService: floatPtr(rating),
+		})
+		if err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	loadStore := func() (model.Store, []int) {
+		var got model.Store
+		if err := db.First(&got, store.ID).Error; err != nil {
+			t.Fatalf("failed to load store: %v", err)
+		}
+		histogram, err := model.LoadRatingHistogram(db, model.RatingSubjectStore, store.ID)
+		if err != nil {
+			t.Fatalf("failed to load histogram: %v", err)
+		}
+		return got, histogram
+	}
+
+	got, histogram := loadStore()
+	if got.ReviewCount != 3 || !approxEqual(float64(got.AvgRating), 11.0/3) || !approxEqual(float64(got.AvgRatingService), 11.0/3) {
+		t.Fatalf("unexpected store aggregates: %+v", got)
+	}
+	// Ten pseudo-reviews at 3.5 outweigh three real ones.
+	if !approxEqual(float64(got.BayesianRating), (35.0+11)/13) {
+		t.Fatalf("unexpected bayesian rating %v", got.BayesianRating)
+	}
+	if !reflect.DeepEqual(histogram, []int{0, 1, 0, 1, 1}) {
+		t.Fatalf("unexpected histogram %v", histogram)
+	}
+
+	rating := 5.0
+	if _, err := svc.Update(ctx, userID, ids[2], dto.UpdateReviewRequest{Rating: &rating}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if err := svc.Delete(ctx, userID, ids[1]); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	got, histogram = loadStore()
+	if got.ReviewCount != 2 || !approxEqual(float64(got.AvgRating), 5) || !approxEqual(float64(got.AvgRatingService), 3.5) {
+		t.Fatalf("unexpected store aggregates after edit and delete: %+v", got)
+	}
+	if !reflect.DeepEqual(histogram, []int{0, 0, 0, 0, 2}) {
+		t.Fatalf("unexpected histogram after edit and delete %v", histogram)
+	}
+	var gotMerchant model.Merchant
+	if err := db.First(&gotMerchant, merchant.ID).Error; err != nil {
+		t.Fatalf("failed to load merchant: %v", err)
+	}
+	if gotMerchant.ReviewCount != 2 || gotMerchant.TotalReviews != 2 || !approxEqual(float64(gotMerchant.AvgRating), 5) {
+		t.Fatalf("unexpected merchant aggregates: %+v", gotMerchant)
+	}
+
+	// A rebuild from scratch must agree with the incremental result.
+	if err := db.Where("1 = 1").Delete(&model.RatingAggregate{}).Error; err != nil {
+		t.Fatalf("failed to clear aggregates: %v", err)
+	}
+	if err := db.Model(&model.Store{}).Where("id = ?", store.ID).Updates(map[string]interface{}{"review_count": 0, "avg_rating": 0}).Error; err != nil {
+		t.Fatalf("failed to reset store: %v", err)
+	}
+	rebuilt, err := svc.RebuildRatingAggregates(ctx)
+	if err != nil {
+		t.Fatalf("rebuild failed: %v", err)
+	}
+	if rebuilt != 2 {
+		t.Fatalf("expected merchant and store to be rebuilt, got %d", rebuilt)
+	}
+	rebuiltStore, rebuiltHistogram := loadStore()
+	if rebuiltStore.ReviewCount != got.ReviewCount || rebuiltStore.AvgRating != got.AvgRating ||
+		!approxEqual(float64(rebuiltStore.BayesianRating), float64(got.BayesianRating)) ||
+		!reflect.DeepEqual(rebuiltHistogram, histogram) {
+		t.Fatalf("rebuild disagrees with incremental aggregates: %+v vs %+v", rebuiltStore, got)
+	}
+}
+
+func TestModerationDowngradeRemovesReviewFromCounters(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	ctx := context.Background()
+	if err := db.Create(&model.UserProfile{UserID: userID, Nickname: "n"}).Error; err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown"}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	var ids []int64
+	for _, rating := range []float64{5, 1} {
+		created, err := svc.Create(ctx, userID, dto.Review{
+			MerchantID: fmt.Sprintf("%d", merchant.ID),
+			StoreID:    fmt.Sprintf("%d", store.ID),
+			Rating:     rating,
+			Tags:       []string{"coffee"},
+		})
+		if err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return svc.applyModerationStatus(ctx, tx, ids[1], model.ContentStatusHidden)
+	}); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	var gotStore model.Store
+	var gotMerchant model.Merchant
+	var profile model.UserProfile
+	var tag model.Tag
+	db.First(&gotStore, store.ID)
+	db.First(&gotMerchant, merchant.ID)
+	db.Where("user_id = ?", userID).First(&profile)
+	db.Where("name = ?", "coffee").First(&tag)
+	if gotStore.ReviewCount != 1 || !approxEqual(float64(gotStore.AvgRating), 5) || gotMerchant.ReviewCount != 1 {
+		t.Fatalf("expected the hidden review out of the aggregates, store=%+v merchant=%+v", gotStore, gotMerchant)
+	}
+	if profile.ReviewCount != 1 || tag.ReviewCount != 1 {
+		t.Fatalf("expected author and tag counts of 1, got %d and %d", profile.ReviewCount, tag.ReviewCount)
+	}
+}
+
+func TestRatingScorerDecaysOlderReviews(t *testing.T) {
+	scorer := newRatingScorer(config.ReviewConfig{PriorMean: 3, PriorWeight: 2, RecencyHalfLifeDays: 30})
+	now := time.Now()
+	agg := model.RatingAggregate{SubjectType: model.RatingSubjectStore, SubjectID: 1}
+
+	scorer.apply(&agg, model.Review{Rating: 1, CreatedAt: now.AddDate(0, 0, -60)}, 1, now)
+	scorer.apply(&agg, model.Review{Rating: 5, CreatedAt: now}, 1, now)
+
+	if !approxEqual(agg.DecayedWeight, 1.25) || !approxEqual(agg.DecayedSum, 5.25) {
+		t.Fatalf("unexpected decayed sums: weight=%v sum=%v", agg.DecayedWeight, agg.DecayedSum)
+	}
+	columns := scorer.columns(agg)
+	if recent := float64(columns["recent_rating"].(float32)); !approxEqual(recent, (6+5.25)/3.25) {
+		t.Fatalf("unexpected recent rating %v", recent)
+	}
+	if bayesian := float64(columns["bayesian_rating"].(float32)); !approxEqual(bayesian, (6+6)/4.0) {
+		t.Fatalf("unexpected bayesian rating %v", bayesian)
+	}
+
+	// Removing the old review takes back exactly the weight it still carries.
+	scorer.apply(&agg, model.Review{Rating: 1, CreatedAt: now.AddDate(0, 0, -60)}, -1, now)
+	if !approxEqual(agg.DecayedWeight, 1) || !approxEqual(agg.DecayedSum, 5) {
+		t.Fatalf("unexpected decayed sums after removal: weight=%v sum=%v", agg.DecayedWeight, agg.DecayedSum)
+	}
+}
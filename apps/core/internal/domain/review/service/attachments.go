@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	minRating      = 1
+	maxRating      = 5
+	maxReviewTags  = 10
+	maxTagLength   = 50
+	maxReviewMedia = 9
+	mediaTypeImage = "image"
+	mediaTypeVideo = "video"
+)
+
+var ErrInvalidRating = errors.New("ratings must be between 1 and 5")
+var ErrInvalidAvgCost = errors.New("avgCost must not be negative")
+var ErrInvalidTags = errors.New("at most 10 tags of up to 50 characters are allowed")
+var ErrTooManyMedia = errors.New("at most 9 media items can be attached to a review")
+var ErrMediaAlreadyAttached = errors.New("media is already attached to another review")
+
+func validateRatings(req dto.Review) error {
+	for _, rating := range []*float64{&req.Rating, req.RatingEnv, req.RatingService, req.RatingValue, req.RatingFood} {
+		if rating != nil && (*rating < minRating || *rating > maxRating) {
+			return ErrInvalidRating
+		}
+	}
+	if req.AvgCost != nil && *req.AvgCost < 0 {
+		return ErrInvalidAvgCost
+	}
+	return nil
+}
+
+// normalizeTags trims, lowercases and de-duplicates tag names, dropping a leading '#'.
+func normalizeTags(raw []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(raw))
+	names := make([]string, 0, len(raw))
+	for _, name := range raw {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "#")))
+		if name == "" {
+			continue
+		}
+		if len([]rune(name)) > maxTagLength {
+			return nil, ErrInvalidTags
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	if len(names) > maxReviewTags {
+		return nil, ErrInvalidTags
+	}
+	return names, nil
+}
+
+// attachTags upserts the named tags, links them to the review and refreshes their counts.
+func attachTags(tx *gorm.DB, review *model.Review, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	tags := make([]model.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, model.Tag{Name: name})
+	}
+	if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&tags).Error; err != nil {
+		return err
+	}
+	tags = tags[:0]
+	if err := tx.Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(review).Association("Tags").Append(&tags); err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	return syncTagReviewCounts(tx, ids)
+}
+
+// resolveMediaUploads loads the uploads referenced by UUID, in request order. Each must be
+// owned by the author, approved by analysis and not attached to a review other than reviewID,
+// which is 0 for a new review.
+func resolveMediaUploads(tx *gorm.DB, userID, reviewID int64, uuids []string) ([]model.MediaUpload, error) {
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+	ordered := make([]string, 0, len(uuids))
+	seen := make(map[string]struct{}, len(uuids))
+	for _, id := range uuids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ordered = append(ordered, id)
+	}
+
+	var uploads []model.MediaUpload
+	if err := tx.Where("uuid IN ? AND user_id = ? AND status = ?", ordered, userID, model.MediaStatusApproved).
+		Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+	if len(uploads) != len(ordered) {
+		return nil, ErrMediaNotApproved
+	}
+
+	urls := make([]string, 0, len(uploads))
+	byUUID := make(map[string]model.MediaUpload, len(uploads))
+	for _, upload := range uploads {
+		urls = append(urls, upload.FileURL)
+		byUUID[upload.UUID] = upload
+	}
+	var attached int64
+	if err := tx.Model(&model.ReviewMedia{}).
+		Joins("JOIN reviews ON reviews.id = review_media.review_id AND reviews.deleted_at IS NULL").
+		Where("review_media.url IN ? AND review_media.review_id <> ?", urls, reviewID).
+		Count(&attached).Error; err != nil {
+		return nil, err
+	}
+	if attached > 0 {
+		return nil, ErrMediaAlreadyAttached
+	}
+
+	out := make([]model.MediaUpload, 0, len(ordered))
+	for _, id := range ordered {
+		out = append(out, byUUID[id])
+	}
+	return out, nil
+}
+
+// replaceMedia swaps the review's attached media for uploads, keeping their order.
+func replaceMedia(tx *gorm.DB, review *model.Review, uploads []model.MediaUpload) error {
+	if err := tx.Where("review_id = ?", review.ID).Delete(&model.ReviewMedia{}).Error; err != nil {
+		return err
+	}
+	review.Media = nil
+	return attachMedia(tx, review, uploads)
+}
+
+func attachMedia(tx *gorm.DB, review *model.Review, uploads []model.MediaUpload) error {
+	if len(uploads) == 0 {
+		return nil
+	}
+	media := make([]model.ReviewMedia, 0, len(uploads))
+	for i, upload := range uploads {
+		mediaType := mediaTypeImage
+		if strings.HasPrefix(upload.MimeType, "video/") {
+			mediaType = mediaTypeVideo
+		}
+		media = append(media, model.ReviewMedia{
+			ReviewID:  review.ID,
+			MediaType: mediaType,
+			URL:       upload.FileURL,
+			SortOrder: i,
+		})
+	}
+	if err := tx.Create(&media).Error; err != nil {
+		return err
+	}
+	review.Media = media
+	return nil
+}
+
+func float32Ptr(v *float64) *float32 {
+	if v == nil {
+		return nil
+	}
+	out := float32(*v)
+	return &out
+}
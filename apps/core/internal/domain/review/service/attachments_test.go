@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestCreateReviewStoresSubRatingsTagsAndMedia(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	ctx := context.Background()
+
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown"}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	existing := model.Tag{Name: "coffee"}
+	if err := db.Create(&existing).Error; err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+	var uploads []model.MediaUpload
+	for i := 0; i < 2; i++ {
+		upload := model.MediaUpload{
+			UUID:      fmt.Sprintf("media-%d", i),
+			UserID:    userID,
+			ObjectKey: fmt.Sprintf("k%d", i),
+			FileURL:   fmt.Sprintf("https://cdn.example.com/k%d", i),
+			MimeType:  "image/png",
+			Status:    model.MediaStatusApproved,
+		}
+		if err := db.Create(&upload).Error; err != nil {
+			t.Fatalf("failed to create upload: %v", err)
+		}
+		uploads = append(uploads, upload)
+	}
+
+	cost := 30
+	created, err := svc.Create(ctx, userID, dto.Review{
+		MerchantID:    fmt.Sprintf("%d", merchant.ID),
+		StoreID:       fmt.Sprintf("%d", store.ID),
+		Rating:        4,
+		RatingService: floatPtr(5),
+		RatingFood:    floatPtr(3),
+		AvgCost:       &cost,
+		Text:          "solid",
+		Tags:          []string{" #Coffee", "brunch", "coffee"},
+		MediaIDs:      []string{uploads[1].UUID, uploads[0].UUID},
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	got, err := svc.Detail(ctx, 0, created.ID)
+	if err != nil {
+		t.Fatalf("detail failed: %v", err)
+	}
+	out := dto.FromModel(*got)
+	if out.RatingService == nil || *out.RatingService != 5 || out.RatingEnv != nil || out.AvgCost == nil || *out.AvgCost != 30 {
+		t.Fatalf("unexpected sub-ratings: %+v", out)
+	}
+	if len(out.Tags) != 2 {
+		t.Fatalf("expected two de-duplicated tags, got %v", out.Tags)
+	}
+	if len(out.Media) != 2 || out.Media[0].URL != uploads[1].FileURL || out.Media[1].SortOrder != 1 {
+		t.Fatalf("expected media in request order, got %+v", out.Media)
+	}
+
+	var coffee model.Tag
+	if err := db.Where("name = ?", "coffee").First(&coffee).Error; err != nil {
+		t.Fatalf("failed to load tag: %v", err)
+	}
+	if coffee.ID != existing.ID || coffee.ReviewCount != 1 {
+		t.Fatalf("expected existing tag reused with count 1, got %+v", coffee)
+	}
+
+	var reloaded model.Store
+	if err := db.First(&reloaded, store.ID).Error; err != nil {
+		t.Fatalf("failed to reload store: %v", err)
+	}
+	if reloaded.AvgRatingService != 5 || reloaded.AvgRatingFood != 3 || reloaded.AvgRatingEnv != 0 || reloaded.AvgCost != 30 {
+		t.Fatalf("unexpected store dimension averages: %+v", reloaded)
+	}
+
+	_, err = svc.Create(ctx, userID, dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		Rating:     4,
+		Text:       "again",
+		MediaIDs:   []string{uploads[0].UUID},
+	})
+	if !errors.Is(err, ErrMediaAlreadyAttached) {
+		t.Fatalf("expected ErrMediaAlreadyAttached, got %v", err)
+	}
+}
+
+func TestUpdateReviewReplacesAttachedMedia(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	ctx := context.Background()
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	var uploads []model.MediaUpload
+	for i := 0; i < 3; i++ {
+		upload := model.MediaUpload{
+			UUID:      fmt.Sprintf("media-%d", i),
+			UserID:    userID,
+			ObjectKey: fmt.Sprintf("k%d", i),
+			FileURL:   fmt.Sprintf("https://cdn.example.com/k%d", i),
+			MimeType:  "image/png",
+			Status:    model.MediaStatusApproved,
+		}
+		if err := db.Create(&upload).Error; err != nil {
+			t.Fatalf("failed to create upload: %v", err)
+		}
+		uploads = append(uploads, upload)
+	}
+	created, err := svc.Create(ctx, userID, dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		Rating:     4,
+		Text:       "solid",
+		MediaIDs:   []string{uploads[0].UUID, uploads[1].UUID},
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	mediaIDs := []string{uploads[1].UUID, uploads[2].UUID}
+	updated, err := svc.Update(ctx, userID, created.ID, dto.UpdateReviewRequest{MediaIDs: &mediaIDs})
+	if err != nil {
+		t.Fatalf("expected the review's own media to be reusable, got %v", err)
+	}
+	want := fmt.Sprintf(`["%s","%s"]`, uploads[1].FileURL, uploads[2].FileURL)
+	if updated.Images != want || len(updated.Media) != 2 {
+		t.Fatalf("expected images %s, got %s with media %+v", want, updated.Images, updated.Media)
+	}
+	var media []model.ReviewMedia
+	if err := db.Where("review_id = ?", created.ID).Order("sort_order").Find(&media).Error; err != nil {
+		t.Fatalf("failed to load media: %v", err)
+	}
+	if len(media) != 2 || media[0].URL != uploads[1].FileURL || media[1].URL != uploads[2].FileURL || media[1].SortOrder != 1 {
+		t.Fatalf("expected the media rows to be replaced in request order, got %+v", media)
+	}
+
+	text := "still solid"
+	if updated, err = svc.Update(ctx, userID, created.ID, dto.UpdateReviewRequest{Text: &text}); err != nil || updated.Images != want {
+		t.Fatalf("expected a text edit to keep the media, got %s (%v)", updated.Images, err)
+	}
+
+	second, err := svc.Create(ctx, userID, dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		Rating:     3,
+		Text:       "second visit",
+		MediaIDs:   []string{uploads[0].UUID},
+	})
+	if err != nil {
+		t.Fatalf("expected detached media to be attachable again, got %v", err)
+	}
+	taken := []string{uploads[0].UUID}
+	if _, err := svc.Update(ctx, userID, created.ID, dto.UpdateReviewRequest{MediaIDs: &taken}); !errors.Is(err, ErrMediaAlreadyAttached) {
+		t.Fatalf("expected ErrMediaAlreadyAttached for review %d's media, got %v", second.ID, err)
+	}
+	tooMany := make([]string, maxReviewMedia+1)
+	if _, err := svc.Update(ctx, userID, created.ID, dto.UpdateReviewRequest{MediaIDs: &tooMany}); !errors.Is(err, ErrTooManyMedia) {
+		t.Fatalf("expected ErrTooManyMedia, got %v", err)
+	}
+}
+
+func TestCreateReviewValidatesAttributes(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	other := model.MediaUpload{UUID: "foreign", UserID: userID + 1, ObjectKey: "f", FileURL: "https://cdn.example.com/f", Status: model.MediaStatusApproved}
+	if err := db.Create(&other).Error; err != nil {
+		t.Fatalf("failed to create upload: %v", err)
+	}
+
+	negative := -1
+	longTag := fmt.Sprintf("%051d", 0)
+	tests := []struct {
+		name string
+		req  dto.Review
+		want error
+	}{
+		{"sub-rating out of range", dto.Review{Rating: 4, RatingEnv: floatPtr(6)}, ErrInvalidRating},
+		{"overall out of range", dto.Review{Rating: 0}, ErrInvalidRating},
+		{"negative cost", dto.Review{Rating: 4, AvgCost: &negative}, ErrInvalidAvgCost},
+		{"tag too long", dto.Review{Rating: 4, Tags: []string{longTag}}, ErrInvalidTags},
+		{"foreign media", dto.Review{Rating: 4, MediaIDs: []string{other.UUID}}, ErrMediaNotApproved},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.MerchantID = fmt.Sprintf("%d", merchant.ID)
+			if _, err := svc.Create(context.Background(), userID, tc.req); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+var ErrDuplicateReview = errors.New("you have already reviewed this store recently")
+var ErrReviewRateLimited = errors.New("new accounts can only post a few reviews per day")
+
+// enforceReviewLimits applies the configured one-review-per-store window and the daily cap
+// for new accounts. Soft-deleted reviews still count so delete-and-repost cannot bypass them.
+func (s *ReviewService) enforceReviewLimits(tx *gorm.DB, userID, merchantID int64, storeID *int64) error {
+	now := time.Now()
+
+	if s.rules.StoreWindowDays > 0 {
+		q := tx.Unscoped().Model(&model.Review{}).
+			Where("user_id = ? AND created_at > ?", userID, now.AddDate(0, 0, -s.rules.StoreWindowDays))
+		if storeID != nil {
+			q = q.Where("store_id = ?", *storeID)
+		} else {
+			q = q.Where("merchant_id = ? AND store_id IS NULL", merchantID)
+		}
+		var recent int64
+		if err := q.Count(&recent).Error; err != nil {
+			return err
+		}
+		if recent > 0 {
+			return ErrDuplicateReview
+		}
+	}
+
+	if s.rules.NewAccountDays > 0 && s.rules.NewAccountDailyLimit > 0 {
+		var user model.User
+		if err := tx.Select("id", "created_at").First(&user, userID).Error; err != nil {
+			return err
+		}
+		if user.CreatedAt.After(now.AddDate(0, 0, -s.rules.NewAccountDays)) {
+			var today int64
+			if err := tx.Unscoped().Model(&model.Review{}).
+				Where("user_id = ? AND created_at > ?", userID, now.Add(-24*time.Hour)).
+				Count(&today).Error; err != nil {
+				return err
+			}
+			if today >= int64(s.rules.NewAccountDailyLimit) {
+				return ErrReviewRateLimited
+			}
+		}
+	}
+	return nil
+}
+
+// verifiedVisit returns the most recent voucher the user redeemed at the store that has not
+// already verified another review, or nil when the visit cannot be verified.
+func verifiedVisit(tx *gorm.DB, userID, storeID int64) (*int64, error) {
+	var voucher model.Voucher
+	err := tx.Model(&model.Voucher{}).
+		Joins("JOIN coupons ON coupons.id = vouchers.coupon_id").
+		Where("vouchers.user_id = ? AND vouchers.redeemed_at IS NOT NULL AND coupons.store_id = ?", userID, storeID).
+		Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.voucher_id = vouchers.id)").
+		Order("vouchers.redeemed_at desc").
+		First(&voucher).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &voucher.ID, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func setupAuthenticityTest(t *testing.T, rules config.ReviewConfig, accountAge time.Duration) (*ReviewService, *gorm.DB, int64, model.Merchant, model.Store) {
+	t.Helper()
+	db := testutil.SetupTestDB(t)
+	user := model.User{Role: "user"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := db.Model(&user).UpdateColumn("created_at", time.Now().Add(-accountAge)).Error; err != nil {
+		t.Fatalf("failed to backdate user: %v", err)
+	}
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown"}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	return NewReviewService(db, nil, rules), db, user.ID, merchant, store
+}
+
+func storeReview(merchant model.Merchant, store model.Store) dto.Review {
+	return dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		StoreID:    fmt.Sprintf("%d", store.ID),
+		Rating:     4,
+		Text:       "visit",
+	}
+}
+
+func TestCreateReviewEnforcesStoreWindow(t *testing.T) {
+	svc, db, userID, merchant, store := setupAuthenticityTest(t, config.ReviewConfig{StoreWindowDays: 30}, 365*24*time.Hour)
+	ctx := context.Background()
+
+	first, err := svc.Create(ctx, userID, storeReview(merchant, store))
+	if err != nil {
+		t.Fatalf("first review failed: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, storeReview(merchant, store)); !errors.Is(err, ErrDuplicateReview) {
+		t.Fatalf("expected ErrDuplicateReview, got %v", err)
+	}
+	if err := svc.Delete(ctx, userID, first.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, storeReview(merchant, store)); !errors.Is(err, ErrDuplicateReview) {
+		t.Fatalf("expected deleted review to still count, got %v", err)
+	}
+
+	if err := db.Unscoped().Model(&model.Review{}).Where("id = ?", first.ID).
+		UpdateColumn("created_at", time.Now().AddDate(0, 0, -31)).Error; err != nil {
+		t.Fatalf("failed to backdate review: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, storeReview(merchant, store)); err != nil {
+		t.Fatalf("expected review outside the window to succeed, got %v", err)
+	}
+}
+
+func TestCreateReviewLimitsNewAccounts(t *testing.T) {
+	rules := config.ReviewConfig{NewAccountDays: 7, NewAccountDailyLimit: 2}
+	svc, db, userID, merchant, _ := setupAuthenticityTest(t, rules, time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		other := model.Store{MerchantID: merchant.ID, Name: fmt.Sprintf("S%d", i)}
+		if err := db.Create(&other).Error; err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if _, err := svc.Create(ctx, userID, storeReview(merchant, other)); err != nil {
+			t.Fatalf("review %d failed: %v", i, err)
+		}
+	}
+	if _, err := svc.Create(ctx, userID, dto.Review{MerchantID: fmt.Sprintf("%d", merchant.ID), Rating: 4}); !errors.Is(err, ErrReviewRateLimited) {
+		t.Fatalf("expected ErrReviewRateLimited, got %v", err)
+	}
+
+	if err := db.Model(&model.User{}).Where("id = ?", userID).
+		UpdateColumn("created_at", time.Now().AddDate(0, 0, -8)).Error; err != nil {
+		t.Fatalf("failed to age account: %v", err)
+	}
+	if _, err := svc.Create(ctx, userID, dto.Review{MerchantID: fmt.Sprintf("%d", merchant.ID), Rating: 4}); err != nil {
+		t.Fatalf("expected established account to pass, got %v", err)
+	}
+}
+
+func TestCreateReviewMarksVerifiedVisit(t *testing.T) {
+	svc, db, userID, merchant, store := setupAuthenticityTest(t, config.ReviewConfig{}, 365*24*time.Hour)
+	ctx := context.Background()
+
+	storeID := store.ID
+	coupon := model.Coupon{MerchantID: merchant.ID, StoreID: &storeID, Title: "Latte", Type: "discount"}
+	if err := db.Create(&coupon).Error; err != nil {
+		t.Fatalf("failed to create coupon: %v", err)
+	}
+	redeemedAt := time.Now().Add(-time.Hour)
+	voucher := model.Voucher{Code: "V1", ScanToken: "t1", CouponID: coupon.ID, UserID: userID, Status: "redeemed", RedeemedAt: &redeemedAt}
+	if err := db.Create(&voucher).Error; err != nil {
+		t.Fatalf("failed to create voucher: %v", err)
+	}
+
+	verified, err := svc.Create(ctx, userID, storeReview(merchant, store))
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if !verified.IsVerified || verified.VoucherID == nil || *verified.VoucherID != voucher.ID {
+		t.Fatalf("expected review verified by voucher %d, got %+v", voucher.ID, verified)
+	}
+
+	// The same visit cannot verify a second review.
+	second, err := svc.Create(ctx, userID, storeReview(merchant, store))
+	if err != nil {
+		t.Fatalf("second create failed: %v", err)
+	}
+	if second.IsVerified {
+		t.Fatal("expected second review for the same visit to be unverified")
+	}
+}
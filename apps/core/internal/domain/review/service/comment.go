@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	contentservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+var ErrCommentForbidden = errors.New("only the author can modify this comment")
+
+var commentThread = contentservice.CommentThread[model.ReviewComment]{
+	Table:      "review_comments",
+	Owner:      "review_id",
+	OwnerTable: "reviews",
+	Node: func(comment *model.ReviewComment) contentservice.CommentNode {
+		return contentservice.CommentNode{ID: comment.ID, ParentID: comment.ParentCommentID, UserID: comment.UserID}
+	},
+	ErrNotFound:  ErrCommentNotFound,
+	ErrForbidden: ErrCommentForbidden,
+}
+
+// SyncCommentCount recomputes the review's comment_count from its visible comments, merchant
+// replies included. The caller holds the review's row lock.
+func SyncCommentCount(tx *gorm.DB, reviewID int64) error {
+	return commentThread.SyncCount(tx, reviewID)
+}
+
+// ListComments returns a page of top-level comments on a review the viewer may see, newest
+// first, each with its replies nested up to contentservice.MaxCommentDepth. Hidden comments
+// are only shown to their author, and comments by users on either side of a block with the
+// viewer are left out.
+func (s *ReviewService) ListComments(ctx context.Context, viewerID, reviewID int64, cursor *int64, limit *int) ([]model.ReviewComment, *int64, error) {
+	db := s.db.WithContext(ctx)
+	var review model.Review
+	if err := db.Select("id").Scopes(visibleReviews(viewerID)).First(&review, reviewID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrReviewNotFound
+		}
+		return nil, nil, err
+	}
+
+	roots, children, next, err := commentThread.Page(db, viewerID, reviewID, cursor, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all := make([]*model.ReviewComment, 0, len(roots))
+	for i := range roots {
+		all = append(all, &roots[i])
+	}
+	var attach func(comment *model.ReviewComment)
+	attach = func(comment *model.ReviewComment) {
+		comment.Replies = children[comment.ID]
+		for i := range comment.Replies {
+			all = append(all, &comment.Replies[i])
+			attach(&comment.Replies[i])
+		}
+	}
+	for i := range roots {
+		attach(&roots[i])
+	}
+
+	if err := fillCommentAuthors(db, all); err != nil {
+		return nil, nil, err
+	}
+	return roots, next, nil
+}
+
+// UpdateComment replaces the text of the author's comment and screens it again. Screening
+// may hold the comment but never lifts a status it already had.
+func (s *ReviewService) UpdateComment(ctx context.Context, userID, reviewID, commentID int64, text string) (model.ReviewComment, error) {
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetReviewComment,
+		UserID:     userID,
+		Text:       text,
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	var comment model.ReviewComment
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var review model.Review
+		if err := lockReview(tx, reviewID, &review); err != nil {
+			return err
+		}
+		if err := commentThread.LockOwned(tx, userID, reviewID, commentID, &comment); err != nil {
+			return err
+		}
+		comment.Content = text
+		comment.Status = verdict.EditStatus(comment.Status)
+		if err := tx.Model(&comment).Select("content", "status").Updates(&comment).Error; err != nil {
+			return err
+		}
+		return commentThread.SyncCount(tx, review.ID)
+	}); err != nil {
+		return model.ReviewComment{}, err
+	}
+
+	s.moderation.Finalize(ctx, screening, comment.ID, verdict)
+	return comment, nil
+}
+
+// DeleteComment soft-deletes the author's comment together with its replies, recounts the
+// review's comments and drops their likes from the authors' received likes.
+func (s *ReviewService) DeleteComment(ctx context.Context, userID, reviewID, commentID int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var review model.Review
+		if err := lockReview(tx, reviewID, &review); err != nil {
+			return err
+		}
+		var comment model.ReviewComment
+		if err := commentThread.LockOwned(tx, userID, reviewID, commentID, &comment); err != nil {
+			return err
+		}
+		ids, authorIDs, err := commentThread.Subtree(tx, comment.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&model.ReviewComment{}).Error; err != nil {
+			return err
+		}
+		if err := contentservice.NewInteractionService(tx).RecountReceivedLikes(ctx, authorIDs...); err != nil {
+			return err
+		}
+		return commentThread.SyncCount(tx, review.ID)
+	})
+}
+
+// LikeComment records the user's like on a visible comment of the review. The like runs in its
+// own transaction, which locks the comment, so its event is only published once committed.
+func (s *ReviewService) LikeComment(ctx context.Context, userID, reviewID, commentID int64) error {
+	var comment model.ReviewComment
+	if err := commentThread.Get(s.db.WithContext(ctx).Select("id"), reviewID, commentID, &comment); err != nil {
+		return err
+	}
+	return commentInteractionError(contentservice.NewInteractionService(s.db).Like(ctx, userID, contentservice.TargetReviewComment, comment.ID))
+}
+
+func (s *ReviewService) UnlikeComment(ctx context.Context, userID, reviewID, commentID int64) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var comment model.ReviewComment
+		if err := commentThread.Lock(tx, reviewID, commentID, &comment); err != nil {
+			return err
+		}
+		return commentInteractionError(contentservice.NewInteractionService(tx).Unlike(ctx, userID, contentservice.TargetReviewComment, comment.ID))
+	})
+}
+
+func commentInteractionError(err error) error {
+	if errors.Is(err, contentservice.ErrInteractionTargetNotFound) {
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func fillCommentAuthors(db *gorm.DB, comments []*model.ReviewComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	userIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		userIDs = append(userIDs, comment.UserID)
+	}
+	var profiles []model.UserProfile
+	if err := db.Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return err
+	}
+	byUser := make(map[int64]model.UserProfile, len(profiles))
+	for _, profile := range profiles {
+		byUser[profile.UserID] = profile
+	}
+	for _, comment := range comments {
+		if profile, ok := byUser[comment.UserID]; ok {
+			comment.UserNickname = profile.Nickname
+			comment.UserAvatar = profile.AvatarURL
+		}
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+func createTestReview(t *testing.T, db *gorm.DB, userID int64) model.Review {
+	t.Helper()
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	review := model.Review{
+		UserID:     userID,
+		VenueID:    merchant.ID,
+		MerchantID: merchant.ID,
+		Rating:     4,
+		Content:    "nice",
+		VisitDate:  time.Now().UTC(),
+	}
+	if err := db.Create(&review).Error; err != nil {
+		t.Fatalf("failed to create review: %v", err)
+	}
+	return review
+}
+
+func TestUnlikeReviewIsIdempotent(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	review := createTestReview(t, db, userID)
+	ctx := context.Background()
+
+	if err := svc.Like(ctx, userID, review.ID); err != nil {
+		t.Fatalf("like failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := svc.Unlike(ctx, userID, review.ID); err != nil {
+			t.Fatalf("unlike failed: %v", err)
+		}
+	}
+
+	var refreshed model.Review
+	if err := db.First(&refreshed, review.ID).Error; err != nil {
+		t.Fatalf("failed to reload review: %v", err)
+	}
+	if refreshed.LikeCount != 0 {
+		t.Fatalf("expected like_count 0, got %d", refreshed.LikeCount)
+	}
+	if err := svc.Unlike(ctx, userID, review.ID+100); !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected ErrReviewNotFound, got %v", err)
+	}
+}
+
+func TestCommentRepliesAreNestedAndDepthLimited(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	review := createTestReview(t, db, userID)
+	ctx := context.Background()
+
+	root, err := svc.Comment(ctx, userID, review.ID, nil, "root")
+	if err != nil {
+		t.Fatalf("root comment failed: %v", err)
+	}
+	child, err := svc.Comment(ctx, userID, review.ID, &root.ID, "child")
+	if err != nil {
+		t.Fatalf("child reply failed: %v", err)
+	}
+	grandchild, err := svc.Comment(ctx, userID, review.ID, &child.ID, "grandchild")
+	if err != nil {
+		t.Fatalf("grandchild reply failed: %v", err)
+	}
+	tooDeep, err := svc.Comment(ctx, userID, review.ID, &grandchild.ID, "too deep")
+	if err != nil {
+		t.Fatalf("deep reply failed: %v", err)
+	}
+	if tooDeep.ParentCommentID == nil || *tooDeep.ParentCommentID != child.ID {
+		t.Fatalf("expected deep reply to be attached to %d, got %v", child.ID, tooDeep.ParentCommentID)
+	}
+
+	other := createTestReview(t, db, userID)
+	if _, err := svc.Comment(ctx, userID, other.ID, &root.ID, "wrong review"); !errors.Is(err, ErrCommentNotFound) {
+		t.Fatalf("expected ErrCommentNotFound for parent on another review, got %v", err)
+	}
+
+	comments, cursor, err := svc.ListComments(ctx, 0, review.ID, nil, nil)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if cursor != nil || len(comments) != 1 {
+		t.Fatalf("expected one top-level comment and no cursor, got %d cursor=%v", len(comments), cursor)
+	}
+	replies := comments[0].Replies
+	if len(replies) != 1 || replies[0].ID != child.ID {
+		t.Fatalf("expected child reply, got %+v", replies)
+	}
+	if len(replies[0].Replies) != 2 {
+		t.Fatalf("expected two replies under child, got %d", len(replies[0].Replies))
+	}
+}
+
+func TestListCommentsPaginatesTopLevel(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	review := createTestReview(t, db, userID)
+	ctx := context.Background()
+
+	for _, text := range []string{"one", "two", "three"} {
+		if _, err := svc.Comment(ctx, userID, review.ID, nil, text); err != nil {
+			t.Fatalf("comment failed: %v", err)
+		}
+	}
+
+	limit := 2
+	page, cursor, err := svc.ListComments(ctx, 0, review.ID, nil, &limit)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(page) != 2 || page[0].Content != "three" || cursor == nil {
+		t.Fatalf("unexpected first page: %d items, cursor=%v", len(page), cursor)
+	}
+	page, cursor, err = svc.ListComments(ctx, 0, review.ID, cursor, &limit)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(page) != 1 || page[0].Content != "one" || cursor != nil {
+		t.Fatalf("unexpected second page: %d items, cursor=%v", len(page), cursor)
+	}
+}
+
+func TestCommentEditDeleteAndLikes(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	review := createTestReview(t, db, userID)
+	ctx := context.Background()
+
+	root, err := svc.Comment(ctx, userID, review.ID, nil, "root")
+	if err != nil {
+		t.Fatalf("root comment failed: %v", err)
+	}
+	if _, err := svc.Comment(ctx, userID, review.ID, &root.ID, "reply"); err != nil {
+		t.Fatalf("reply failed: %v", err)
+	}
+	kept, err := svc.Comment(ctx, userID, review.ID, nil, "kept")
+	if err != nil {
+		t.Fatalf("comment failed: %v", err)
+	}
+
+	if _, err := svc.UpdateComment(ctx, userID+1, review.ID, root.ID, "hijack"); !errors.Is(err, ErrCommentForbidden) {
+		t.Fatalf("expected forbidden edit, got %v", err)
+	}
+	edited, err := svc.UpdateComment(ctx, userID, review.ID, root.ID, "root (edited)")
+	if err != nil || edited.Content != "root (edited)" {
+		t.Fatalf("edit failed: %v %+v", err, edited)
+	}
+
+	if err := svc.LikeComment(ctx, userID, review.ID, kept.ID); err != nil {
+		t.Fatalf("like comment failed: %v", err)
+	}
+	if err := svc.LikeComment(ctx, userID, review.ID, kept.ID); err != nil {
+		t.Fatalf("repeat like comment failed: %v", err)
+	}
+	var liked model.ReviewComment
+	if err := db.First(&liked, kept.ID).Error; err != nil || liked.LikeCount != 1 {
+		t.Fatalf("expected like_count 1, got %d (%v)", liked.LikeCount, err)
+	}
+	if err := svc.UnlikeComment(ctx, userID, review.ID, kept.ID); err != nil {
+		t.Fatalf("unlike comment failed: %v", err)
+	}
+	if err := db.First(&liked, kept.ID).Error; err != nil || liked.LikeCount != 0 {
+		t.Fatalf("expected like_count 0, got %d (%v)", liked.LikeCount, err)
+	}
+
+	if err := svc.DeleteComment(ctx, userID+1, review.ID, root.ID); !errors.Is(err, ErrCommentForbidden) {
+		t.Fatalf("expected forbidden delete, got %v", err)
+	}
+	if err := svc.DeleteComment(ctx, userID, review.ID, root.ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	var refreshed model.Review
+	if err := db.First(&refreshed, review.ID).Error; err != nil {
+		t.Fatalf("failed to reload review: %v", err)
+	}
+	if refreshed.CommentCount != 1 {
+		t.Fatalf("expected comment_count 1 after deleting a thread of two, got %d", refreshed.CommentCount)
+	}
+	comments, _, err := svc.ListComments(ctx, 0, review.ID, nil, nil)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].ID != kept.ID {
+		t.Fatalf("expected only the kept comment, got %+v", comments)
+	}
+}
+
+func TestCommentsRequireAReviewTheViewerMaySee(t *testing.T) {
+	svc, db, authorID := setupReviewServiceTest(t)
+	review := createTestReview(t, db, authorID)
+	if err := db.Model(&review).Update("status", model.ContentStatusPending).Error; err != nil {
+		t.Fatalf("failed to hold review: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := svc.Comment(ctx, authorID+1, review.ID, nil, "hello"); !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected a held review to be closed to comments from others, got %v", err)
+	}
+	if _, _, err := svc.ListComments(ctx, authorID+1, review.ID, nil, nil); !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected a held review's comments to be hidden from others, got %v", err)
+	}
+	if _, err := svc.Comment(ctx, authorID, review.ID, nil, "note to self"); err != nil {
+		t.Fatalf("expected the author to comment on their held review: %v", err)
+	}
+
+	if err := db.Model(&review).Update("status", model.ContentStatusVisible).Error; err != nil {
+		t.Fatalf("failed to publish review: %v", err)
+	}
+	if err := db.Create(&model.UserBlock{BlockerID: authorID, BlockedID: authorID + 2}).Error; err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+	if _, err := svc.Comment(ctx, authorID+2, review.ID, nil, "hello"); !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected a blocked user to be unable to comment, got %v", err)
+	}
+	if _, _, err := svc.ListComments(ctx, authorID+2, review.ID, nil, nil); !errors.Is(err, ErrReviewNotFound) {
+		t.Fatalf("expected a blocked user to be unable to list comments, got %v", err)
+	}
+}
+
+func TestUpdateCommentNeverLiftsModeration(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	review := createTestReview(t, db, userID)
+	hidden := model.ReviewComment{ReviewID: review.ID, UserID: userID, Content: "reported", Status: model.ContentStatusHidden}
+	if err := db.Create(&hidden).Error; err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	updated, err := svc.UpdateComment(context.Background(), userID, review.ID, hidden.ID, "perfectly polite text")
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	var reloaded model.ReviewComment
+	if err := db.First(&reloaded, hidden.ID).Error; err != nil {
+		t.Fatalf("failed to reload comment: %v", err)
+	}
+	if updated.Status != model.ContentStatusHidden || reloaded.Status != model.ContentStatusHidden {
+		t.Fatalf("expected the edited comment to stay hidden, got %d/%d", updated.Status, reloaded.Status)
+	}
+}
+
+func TestModeratedCommentsLeaveTheCommentCount(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	review := createTestReview(t, db, userID)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, text := range []string{"first", "second"} {
+		comment, err := svc.Comment(ctx, userID, review.ID, nil, text)
+		if err != nil {
+			t.Fatalf("comment failed: %v", err)
+		}
+		ids = append(ids, comment.ID)
+	}
+	for range 2 {
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return commentThread.ApplyStatus(ctx, tx, ids[1], model.ContentStatusHidden)
+		}); err != nil {
+			t.Fatalf("apply failed: %v", err)
+		}
+	}
+
+	var refreshed model.Review
+	if err := db.First(&refreshed, review.ID).Error; err != nil {
+		t.Fatalf("failed to reload review: %v", err)
+	}
+	if refreshed.CommentCount != 1 {
+		t.Fatalf("expected the hidden comment out of comment_count, got %d", refreshed.CommentCount)
+	}
+	var hidden model.ReviewComment
+	if err := db.First(&hidden, ids[1]).Error; err != nil || hidden.Status != model.ContentStatusHidden {
+		t.Fatalf("expected the comment to be hidden, got %+v (%v)", hidden, err)
+	}
+}
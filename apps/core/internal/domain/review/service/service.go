@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"strings"
+	"time"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	contentservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/service"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
@@ -14,23 +19,82 @@ import (
 )
 
 type ReviewService struct {
-	db *gorm.DB
+	db         *gorm.DB
+	moderation *moderationservice.ModerationService
+	rules      config.ReviewConfig
+	scorer     ratingScorer
 }
 
 var ErrMerchantNotFound = errors.New("merchant not found")
 var ErrStoreNotFound = errors.New("store not found")
 var ErrStoreMerchantMismatch = errors.New("store does not belong to merchant")
+var ErrMediaNotApproved = errors.New("review images must be approved uploads owned by the author")
+var ErrReviewNotFound = errors.New("review not found")
+var ErrReviewForbidden = errors.New("only the author can modify this review")
+var ErrEmptyUpdate = errors.New("no fields to update")
 
-func NewReviewService(db *gorm.DB) *ReviewService {
+// NewReviewService wires the service. A nil moderation pipeline disables text screening;
+// zero-valued rules disable the authenticity limits and use the default rating scores.
+func NewReviewService(db *gorm.DB, moderation *moderationservice.ModerationService, rules config.ReviewConfig) *ReviewService {
 	if db == nil {
 		db = database.DB
 	}
-	return &ReviewService{db: db}
+	if moderation == nil {
+		moderation = moderationservice.NewModerationService(db, moderationservice.ModeOff, false)
+	}
+	svc := &ReviewService{db: db, moderation: moderation, rules: rules, scorer: newRatingScorer(rules)}
+	moderation.RegisterApplier(moderationservice.TargetReview, svc.applyModerationStatus)
+	moderation.RegisterApplier(moderationservice.TargetReviewComment, commentThread.ApplyStatus)
+	return svc
 }
 
-func (s *ReviewService) Detail(ctx context.Context, id int64) (*model.Review, error) {
+// applyModerationStatus hides or holds a published review once async moderation flags it, and
+// takes it out of the rating aggregates and review counts the way Delete does.
+func (s *ReviewService) applyModerationStatus(ctx context.Context, tx *gorm.DB, reviewID int64, status int16) error {
 	var review model.Review
-	if err := s.db.WithContext(ctx).Preload("Merchant").Preload("Store").First(&review, id).Error; err != nil {
+	if err := lockReview(tx, reviewID, &review); err != nil {
+		if errors.Is(err, ErrReviewNotFound) {
+			return nil
+		}
+		return err
+	}
+	if review.Status == status {
+		return nil
+	}
+	if err := tx.Model(&model.Review{}).Where("id = ?", review.ID).Update("status", status).Error; err != nil {
+		return err
+	}
+	if err := s.adjustRatingAggregates(tx, review, -1); err != nil {
+		return err
+	}
+	review.Status = status
+	if err := s.adjustRatingAggregates(tx, review, 1); err != nil {
+		return err
+	}
+	if err := syncUserReviewCount(tx, review.UserID); err != nil {
+		return err
+	}
+	var tagIDs []int64
+	if err := tx.Table("review_tags").Where("review_id = ?", review.ID).Pluck("tag_id", &tagIDs).Error; err != nil {
+		return err
+	}
+	return syncTagReviewCounts(tx, tagIDs)
+}
+
+// Detail loads a review with its venue, tags, media and merchant reply. Reviews by a user on
+// either side of a block with viewerID, and hidden or pending reviews by anyone but the
+// viewer, are treated as missing.
+func (s *ReviewService) Detail(ctx context.Context, viewerID, id int64) (*model.Review, error) {
+	var review model.Review
+	if err := s.db.WithContext(ctx).
+		Where("(reviews.status = ? OR reviews.user_id = ?)", model.ContentStatusVisible, viewerID).
+		Scopes(followservice.ExcludeBlocked(viewerID, "reviews.user_id")).
+		Preload("Merchant").
+		Preload("Store").
+		Preload("Tags").
+		Preload("Media", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order asc") }).
+		Scopes(model.PreloadMerchantReply).
+		First(&review, id).Error; err != nil {
 		return nil, err
 	}
 	return &review, nil
@@ -58,17 +122,38 @@ func (s *ReviewService) Create(ctx context.Context, userID int64, req dto.Review
 	if err != nil {
 		return model.Review{}, err
 	}
+	if err := validateRatings(req); err != nil {
+		return model.Review{}, err
+	}
+	tagNames, err := normalizeTags(req.Tags)
+	if err != nil {
+		return model.Review{}, err
+	}
+	if len(req.MediaIDs) > maxReviewMedia {
+		return model.Review{}, ErrTooManyMedia
+	}
 
-	imagesJSON, _ := json.Marshal(req.Images)
-	review := model.Review{
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetReview,
 		UserID:     userID,
-		MerchantID: merchantID,
-		VenueID:    venueID,
-		StoreID:    storeID,
-		Rating:     float32(req.Rating),
-		Content:    req.Text,
-		Images:     string(imagesJSON),
-		VisitDate:  visitDate,
+		Text:       req.Text,
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	review := model.Review{
+		UserID:        userID,
+		MerchantID:    merchantID,
+		VenueID:       venueID,
+		StoreID:       storeID,
+		Rating:        float32(req.Rating),
+		RatingEnv:     float32Ptr(req.RatingEnv),
+		RatingService: float32Ptr(req.RatingService),
+		RatingValue:   float32Ptr(req.RatingValue),
+		RatingFood:    float32Ptr(req.RatingFood),
+		AvgCost:       req.AvgCost,
+		Content:       req.Text,
+		VisitDate:     visitDate,
+		Status:        verdict.Status(),
 	}
 
 	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -93,110 +178,371 @@ func (s *ReviewService) Create(ctx context.Context, userID int64, req dto.Review
 			}
 		}
 
+		if err := s.enforceReviewLimits(tx, userID, merchantID, storeID); err != nil {
+			return err
+		}
+		if storeID != nil {
+			voucherID, err := verifiedVisit(tx, userID, *storeID)
+			if err != nil {
+				return err
+			}
+			review.VoucherID = voucherID
+			review.IsVerified = voucherID != nil
+		}
+
+		if err := ensureApprovedMedia(tx, userID, req.Images); err != nil {
+			return err
+		}
+		uploads, err := resolveMediaUploads(tx, userID, 0, req.MediaIDs)
+		if err != nil {
+			return err
+		}
+		images := append([]string{}, req.Images...)
+		for _, upload := range uploads {
+			images = append(images, upload.FileURL)
+		}
+		imagesJSON, _ := json.Marshal(images)
+		review.Images = string(imagesJSON)
+
 		if err := tx.Create(&review).Error; err != nil {
 			return err
 		}
-		if err := syncMerchantReviewAggregates(tx, merchantID); err != nil {
+		if err := attachMedia(tx, &review, uploads); err != nil {
 			return err
 		}
-		if storeID != nil {
-			if err := syncStoreReviewAggregates(tx, *storeID); err != nil {
+		if err := attachTags(tx, &review, tagNames); err != nil {
+			return err
+		}
+		if err := s.adjustRatingAggregates(tx, review, 1); err != nil {
+			return err
+		}
+		return syncUserReviewCount(tx, userID)
+	}); err != nil {
+		return model.Review{}, err
+	}
+
+	s.moderation.Finalize(ctx, screening, review.ID, verdict)
+	return review, nil
+}
+
+// Update applies the author's edits to a review. The previous version is kept in
+// review_edits and the review is flagged as edited; new text goes through moderation again,
+// which may hold the review but never lifts a status it already had.
+func (s *ReviewService) Update(ctx context.Context, userID, reviewID int64, req dto.UpdateReviewRequest) (model.Review, error) {
+	if req.Rating == nil && req.Text == nil && req.Images == nil && req.MediaIDs == nil && req.VisitDate == nil {
+		return model.Review{}, ErrEmptyUpdate
+	}
+	if req.MediaIDs != nil && len(*req.MediaIDs) > maxReviewMedia {
+		return model.Review{}, ErrTooManyMedia
+	}
+	if req.Rating != nil && (*req.Rating < minRating || *req.Rating > maxRating) {
+		return model.Review{}, ErrInvalidRating
+	}
+	var visitDate time.Time
+	if req.VisitDate != nil {
+		parsed, err := time.Parse("2006-01-02", *req.VisitDate)
+		if err != nil {
+			return model.Review{}, err
+		}
+		visitDate = parsed
+	}
+
+	var screening moderationservice.Input
+	var verdict moderationservice.Verdict
+	if req.Text != nil {
+		screening = moderationservice.Input{
+			TargetType: moderationservice.TargetReview,
+			UserID:     userID,
+			Text:       *req.Text,
+		}
+		verdict = s.moderation.Screen(ctx, screening)
+	}
+
+	var review model.Review
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := loadOwnedReview(tx, userID, reviewID, &review); err != nil {
+			return err
+		}
+		previous := review
+
+		edit := model.ReviewEdit{
+			ReviewID:  review.ID,
+			UserID:    userID,
+			Rating:    review.Rating,
+			Content:   review.Content,
+			Images:    review.Images,
+			VisitDate: review.VisitDate,
+		}
+		if err := tx.Create(&edit).Error; err != nil {
+			return err
+		}
+
+		if req.Rating != nil {
+			review.Rating = float32(*req.Rating)
+		}
+		if req.Text != nil {
+			review.Content = *req.Text
+			review.Status = verdict.EditStatus(review.Status)
+		}
+		if req.Images != nil || req.MediaIDs != nil {
+			if err := s.updateReviewMedia(tx, userID, &review, req); err != nil {
 				return err
 			}
 		}
-		return nil
+		if req.VisitDate != nil {
+			review.VisitDate = visitDate
+		}
+		now := time.Now()
+		review.Edited = true
+		review.EditedAt = &now
+
+		if err := tx.Model(&review).
+			Select("rating", "content", "images", "visit_date", "status", "edited", "edited_at").
+			Updates(&review).Error; err != nil {
+			return err
+		}
+		if err := s.adjustRatingAggregates(tx, previous, -1); err != nil {
+			return err
+		}
+		if err := s.adjustRatingAggregates(tx, review, 1); err != nil {
+			return err
+		}
+		if err := syncUserReviewCount(tx, review.UserID); err != nil {
+			return err
+		}
+		var tagIDs []int64
+		if err := tx.Table("review_tags").Where("review_id = ?", review.ID).Pluck("tag_id", &tagIDs).Error; err != nil {
+			return err
+		}
+		return syncTagReviewCounts(tx, tagIDs)
 	}); err != nil {
 		return model.Review{}, err
 	}
 
+	if req.Text != nil {
+		s.moderation.Finalize(ctx, screening, review.ID, verdict)
+	}
 	return review, nil
 }
 
-func (s *ReviewService) Like(ctx context.Context, userID, reviewID int64) error {
+// updateReviewMedia applies an edit's Images and MediaIDs. Images replaces the URLs stored
+// directly on the review; MediaIDs replaces the attached uploads. Review.Images keeps listing
+// both, so whichever part the edit leaves out is carried over.
+func (s *ReviewService) updateReviewMedia(tx *gorm.DB, userID int64, review *model.Review, req dto.UpdateReviewRequest) error {
+	var mediaURLs []string
+	if err := tx.Model(&model.ReviewMedia{}).Where("review_id = ?", review.ID).Order("sort_order asc").
+		Pluck("url", &mediaURLs).Error; err != nil {
+		return err
+	}
+	attached := make(map[string]struct{}, len(mediaURLs))
+	for _, url := range mediaURLs {
+		attached[url] = struct{}{}
+	}
+
+	images := []string{}
+	if req.Images != nil {
+		if err := ensureApprovedMedia(tx, userID, *req.Images); err != nil {
+			return err
+		}
+		images = append(images, *req.Images...)
+	} else {
+		var current []string
+		_ = json.Unmarshal([]byte(review.Images), &current)
+		for _, url := range current {
+			if _, ok := attached[url]; !ok {
+				images = append(images, url)
+			}
+		}
+	}
+
+	if req.MediaIDs != nil {
+		uploads, err := resolveMediaUploads(tx, userID, review.ID, *req.MediaIDs)
+		if err != nil {
+			return err
+		}
+		if err := replaceMedia(tx, review, uploads); err != nil {
+			return err
+		}
+		mediaURLs = mediaURLs[:0]
+		for _, upload := range uploads {
+			mediaURLs = append(mediaURLs, upload.FileURL)
+		}
+	}
+
+	imagesJSON, _ := json.Marshal(append(images, mediaURLs...))
+	review.Images = string(imagesJSON)
+	return nil
+}
+
+// Delete soft-deletes the author's review and refreshes every counter that included it.
+func (s *ReviewService) Delete(ctx context.Context, userID, reviewID int64) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var review model.Review
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+		if err := loadOwnedReview(tx, userID, reviewID, &review); err != nil {
 			return err
 		}
 
-		var existing model.Like
-		if err := tx.Where("user_id = ? AND target_type = ? AND target_id = ?", userID, "review", reviewID).
-			First(&existing).Error; err == nil {
-			return nil
-		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		var tagIDs []int64
+		if err := tx.Table("review_tags").Where("review_id = ?", review.ID).Pluck("tag_id", &tagIDs).Error; err != nil {
 			return err
 		}
 
-		like := model.Like{UserID: userID, TargetType: "review", TargetID: reviewID}
-		if err := tx.Create(&like).Error; err != nil {
-			// Concurrent duplicate like should remain idempotent.
-			if errors.Is(err, gorm.ErrDuplicatedKey) || isUniqueViolation(err) {
-				return nil
-			}
+		if err := tx.Delete(&review).Error; err != nil {
 			return err
 		}
-
-		return tx.Model(&review).UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error
+		if err := s.adjustRatingAggregates(tx, review, -1); err != nil {
+			return err
+		}
+		if err := syncUserReviewCount(tx, review.UserID); err != nil {
+			return err
+		}
+		if err := contentservice.NewInteractionService(tx).RecountReceivedLikes(ctx, review.UserID); err != nil {
+			return err
+		}
+		return syncTagReviewCounts(tx, tagIDs)
 	})
 }
 
-func (s *ReviewService) Comment(ctx context.Context, userID, reviewID int64, text string) error {
-	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+func loadOwnedReview(tx *gorm.DB, userID, reviewID int64, review *model.Review) error {
+	if err := lockReview(tx, reviewID, review); err != nil {
+		return err
+	}
+	if review.UserID != userID {
+		return ErrReviewForbidden
+	}
+	return nil
+}
+
+// Like records the user's like on a visible review; liking twice is a no-op.
+func (s *ReviewService) Like(ctx context.Context, userID, reviewID int64) error {
+	return reviewInteractionError(contentservice.NewInteractionService(s.db).Like(ctx, userID, contentservice.TargetReview, reviewID))
+}
+
+// Unlike removes the user's like from a review. Unliking a review that was never liked is a no-op.
+func (s *ReviewService) Unlike(ctx context.Context, userID, reviewID int64) error {
+	return reviewInteractionError(contentservice.NewInteractionService(s.db).Unlike(ctx, userID, contentservice.TargetReview, reviewID))
+}
+
+func reviewInteractionError(err error) error {
+	if errors.Is(err, contentservice.ErrInteractionTargetNotFound) {
+		return ErrReviewNotFound
+	}
+	return err
+}
+
+// Comment adds a comment to a review the user may see, or a reply when parentID is set.
+// Replies nested deeper than contentservice.MaxCommentDepth are attached to the deepest
+// allowed ancestor instead.
+func (s *ReviewService) Comment(ctx context.Context, userID, reviewID int64, parentID *int64, text string) (model.ReviewComment, error) {
+	screening := moderationservice.Input{
+		TargetType: moderationservice.TargetReviewComment,
+		UserID:     userID,
+		Text:       text,
+	}
+	verdict := s.moderation.Screen(ctx, screening)
+
+	comment := model.ReviewComment{ReviewID: reviewID, UserID: userID, Content: text, Status: verdict.Status()}
+	created := eventbus.CommentCreated{UserID: userID, TargetType: contentservice.TargetReview, TargetID: reviewID, Content: text}
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var review model.Review
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, reviewID).Error; err != nil {
+		if err := lockVisibleReview(tx, userID, reviewID, &review); err != nil {
 			return err
 		}
-		comment := model.ReviewComment{ReviewID: reviewID, UserID: userID, Content: text}
+		created.OwnerID = review.UserID
+		if parentID != nil {
+			parent, parentAuthorID, err := commentThread.ReplyParent(tx, reviewID, *parentID)
+			if err != nil {
+				return err
+			}
+			comment.ParentCommentID = &parent
+			created.ParentAuthorID = parentAuthorID
+		}
 		if err := tx.Create(&comment).Error; err != nil {
 			return err
 		}
-		return tx.Model(&review).UpdateColumn("comment_count", gorm.Expr("comment_count + 1")).Error
-	})
-}
+		return commentThread.SyncCount(tx, review.ID)
+	}); err != nil {
+		return model.ReviewComment{}, err
+	}
 
-func syncMerchantReviewAggregates(tx *gorm.DB, merchantID int64) error {
-	type aggregate struct {
-		Count int64
-		Avg   float64
+	s.moderation.Finalize(ctx, screening, comment.ID, verdict)
+	if comment.Status == model.ContentStatusVisible {
+		created.CommentID = comment.ID
+		eventbus.Publish(ctx, created)
 	}
-	var agg aggregate
-	if err := tx.Model(&model.Review{}).
-		Select("COUNT(*) AS count, COALESCE(AVG(rating), 0) AS avg").
-		Where("merchant_id = ?", merchantID).
-		Scan(&agg).Error; err != nil {
+	return comment, nil
+}
+
+func lockReview(tx *gorm.DB, reviewID int64, review *model.Review) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(review, reviewID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReviewNotFound
+		}
 		return err
 	}
-	updates := map[string]interface{}{
-		"review_count":  int(agg.Count),
-		"total_reviews": int(agg.Count),
-		"avg_rating":    float32(agg.Avg),
-	}
-	return tx.Model(&model.Merchant{}).Where("id = ?", merchantID).Updates(updates).Error
+	return nil
+}
+
+// lockVisibleReview locks a review the viewer may interact with: any visible review, or
+// their own, unless either side has blocked the other.
+func lockVisibleReview(tx *gorm.DB, viewerID, reviewID int64, review *model.Review) error {
+	return lockReview(tx.Scopes(visibleReviews(viewerID)), reviewID, review)
 }
 
-func syncStoreReviewAggregates(tx *gorm.DB, storeID int64) error {
-	type aggregate struct {
-		Count int64
-		Avg   float64
+// visibleReviews keeps the reviews viewerID may see: visible ones and their own, leaving
+// out those by users on either side of a block with the viewer.
+func visibleReviews(viewerID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(reviews.status = ? OR reviews.user_id = ?)", model.ContentStatusVisible, viewerID).
+			Scopes(followservice.ExcludeBlocked(viewerID, "reviews.user_id"))
 	}
-	var agg aggregate
+}
+
+func syncUserReviewCount(tx *gorm.DB, userID int64) error {
+	var count int64
 	if err := tx.Model(&model.Review{}).
-		Select("COUNT(*) AS count, COALESCE(AVG(rating), 0) AS avg").
-		Where("store_id = ?", storeID).
-		Scan(&agg).Error; err != nil {
+		Where("user_id = ? AND status = ?", userID, model.ContentStatusVisible).
+		Count(&count).Error; err != nil {
 		return err
 	}
-	updates := map[string]interface{}{
-		"review_count": int(agg.Count),
-		"avg_rating":   float32(agg.Avg),
+	return tx.Model(&model.UserProfile{}).Where("user_id = ?", userID).Update("review_count", int(count)).Error
+}
+
+func syncTagReviewCounts(tx *gorm.DB, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
 	}
-	return tx.Model(&model.Store{}).Where("id = ?", storeID).Updates(updates).Error
+	return tx.Model(&model.Tag{}).Where("id IN ?", tagIDs).Update("review_count", gorm.Expr(
+		"(SELECT COUNT(*) FROM review_tags JOIN reviews ON reviews.id = review_tags.review_id "+
+			"WHERE review_tags.tag_id = tags.id AND reviews.deleted_at IS NULL AND reviews.status = ?)",
+		model.ContentStatusVisible,
+	)).Error
 }
 
-func isUniqueViolation(err error) bool {
-	if err == nil {
-		return false
+// ensureApprovedMedia checks that every attached image URL belongs to an upload the author
+// owns and that has passed analysis.
+func ensureApprovedMedia(tx *gorm.DB, userID int64, urls []string) error {
+	if len(urls) == 0 {
+		return nil
+	}
+	unique := make(map[string]struct{}, len(urls))
+	for _, url := range urls {
+		unique[url] = struct{}{}
+	}
+	lookup := make([]string, 0, len(unique))
+	for url := range unique {
+		lookup = append(lookup, url)
+	}
+
+	var approved int64
+	if err := tx.Model(&model.MediaUpload{}).
+		Where("user_id = ? AND status = ? AND file_url IN ?", userID, model.MediaStatusApproved, lookup).
+		Distinct("file_url").
+		Count(&approved).Error; err != nil {
+		return err
+	}
+	if approved != int64(len(lookup)) {
+		return ErrMediaNotApproved
 	}
-	msg := err.Error()
-	return strings.Contains(msg, "duplicate key") || strings.Contains(msg, "UNIQUE constraint failed")
+	return nil
 }
@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
@@ -16,7 +19,7 @@ func setupReviewServiceTest(t *testing.T) (*ReviewService, *gorm.DB, int64) {
 	t.Helper()
 
 	db := testutil.SetupTestDB(t)
-	svc := NewReviewService(db)
+	svc := NewReviewService(db, nil, config.ReviewConfig{})
 
 	user := model.User{Role: "user", Status: 0}
 	if err := db.Create(&user).Error; err != nil {
@@ -113,7 +116,7 @@ func TestReviewDetailPreloadsMerchantAndStore(t *testing.T) {
 		t.Fatalf("failed to create review: %v", err)
 	}
 
-	got, err := svc.Detail(context.Background(), review.ID)
+	got, err := svc.Detail(context.Background(), 0, review.ID)
 	if err != nil {
 		t.Fatalf("detail failed: %v", err)
 	}
@@ -125,6 +128,25 @@ func TestReviewDetailPreloadsMerchantAndStore(t *testing.T) {
 	}
 }
 
+func TestReviewDetailHidesModeratedReviewsFromOthers(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewReviewService(db, nil, config.ReviewConfig{})
+	review := model.Review{UserID: 7, VenueID: 1, MerchantID: 1, Rating: 2, Content: "held", Status: model.ContentStatusPending}
+	if err := db.Create(&review).Error; err != nil {
+		t.Fatalf("failed to create review: %v", err)
+	}
+
+	if _, err := svc.Detail(context.Background(), 8, review.ID); err == nil {
+		t.Fatal("expected a pending review to be hidden from other viewers")
+	}
+	if _, err := svc.Detail(context.Background(), 0, review.ID); err == nil {
+		t.Fatal("expected a pending review to be hidden from anonymous viewers")
+	}
+	if _, err := svc.Detail(context.Background(), 7, review.ID); err != nil {
+		t.Fatalf("expected the author to see their pending review: %v", err)
+	}
+}
+
 func TestCreateReviewSyncsStoreAndMerchantAggregates(t *testing.T) {
 	svc, db, userID := setupReviewServiceTest(t)
 
@@ -242,10 +264,10 @@ func TestCommentIncrementsCommentCount(t *testing.T) {
 		t.Fatalf("failed to create review: %v", err)
 	}
 
-	if err := svc.Comment(context.Background(), userID, review.ID, "first"); err != nil {
+	if _, err := svc.Comment(context.Background(), userID, review.ID, nil, "first"); err != nil {
 		t.Fatalf("first comment failed: %v", err)
 	}
-	if err := svc.Comment(context.Background(), userID, review.ID, "second"); err != nil {
+	if _, err := svc.Comment(context.Background(), userID, review.ID, nil, "second"); err != nil {
 		t.Fatalf("second comment failed: %v", err)
 	}
 
@@ -257,3 +279,247 @@ func TestCommentIncrementsCommentCount(t *testing.T) {
 		t.Fatalf("unexpected comment_count: got %d want 2", refreshed.CommentCount)
 	}
 }
+
+func TestCreateReviewHidesBlockedTextAndExcludesItFromAggregates(t *testing.T) {
+	_, db, userID := setupReviewServiceTest(t)
+	moderation := moderationservice.NewModerationService(db, moderationservice.ModeSync, true,
+		moderationservice.NewRulesEngine(config.ModerationConfig{
+			BlockedTerms: map[string][]string{"default": {"scam"}},
+		}),
+	)
+	svc := NewReviewService(db, moderation, config.ReviewConfig{})
+
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+
+	if _, err := svc.Create(context.Background(), userID, dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		Rating:     5,
+		Text:       "lovely brunch",
+	}); err != nil {
+		t.Fatalf("create visible review failed: %v", err)
+	}
+	blocked, err := svc.Create(context.Background(), userID, dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		Rating:     1,
+		Text:       "this place is a scam",
+	})
+	if err != nil {
+		t.Fatalf("create blocked review failed: %v", err)
+	}
+	if blocked.Status != model.ContentStatusHidden {
+		t.Fatalf("expected blocked review to be hidden, got status %d", blocked.Status)
+	}
+
+	var refreshed model.Merchant
+	if err := db.First(&refreshed, merchant.ID).Error; err != nil {
+		t.Fatalf("failed to reload merchant: %v", err)
+	}
+	if refreshed.ReviewCount != 1 || refreshed.AvgRating != float32(5) {
+		t.Fatalf("expected aggregates to ignore hidden review, got count=%d avg=%.2f", refreshed.ReviewCount, refreshed.AvgRating)
+	}
+
+	var reports int64
+	if err := db.Model(&model.Report{}).
+		Where("target_type = ? AND target_id = ?", moderationservice.TargetReview, blocked.ID).
+		Count(&reports).Error; err != nil {
+		t.Fatalf("failed to count reports: %v", err)
+	}
+	if reports != 1 {
+		t.Fatalf("expected an automated report for the blocked review, got %d", reports)
+	}
+}
+
+func TestCreateReviewRequiresApprovedMedia(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	pending := model.MediaUpload{UUID: "p", UserID: userID, ObjectKey: "p", FileURL: "https://cdn.example.com/p", Status: model.MediaStatusPending}
+	approved := model.MediaUpload{UUID: "a", UserID: userID, ObjectKey: "a", FileURL: "https://cdn.example.com/a", Status: model.MediaStatusApproved}
+	for _, upload := range []*model.MediaUpload{&pending, &approved} {
+		if err := db.Create(upload).Error; err != nil {
+			t.Fatalf("failed to create upload: %v", err)
+		}
+	}
+
+	req := dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		Rating:     4,
+		Text:       "great",
+		Images:     []string{approved.FileURL, pending.FileURL},
+	}
+	if _, err := svc.Create(context.Background(), userID, req); !errors.Is(err, ErrMediaNotApproved) {
+		t.Fatalf("expected ErrMediaNotApproved, got %v", err)
+	}
+
+	req.Images = []string{approved.FileURL}
+	if _, err := svc.Create(context.Background(), userID, req); err != nil {
+		t.Fatalf("expected approved media to be accepted, got %v", err)
+	}
+}
+
+func TestUpdateReviewRecordsHistoryAndResyncsAggregates(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	created, err := svc.Create(context.Background(), userID, dto.Review{
+		MerchantID: fmt.Sprintf("%d", merchant.ID),
+		Rating:     2,
+		Text:       "slow srevice",
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	rating := 4.0
+	text := "slow service, but great coffee"
+	if _, err := svc.Update(context.Background(), userID+1, created.ID, dto.UpdateReviewRequest{Rating: &rating}); !errors.Is(err, ErrReviewForbidden) {
+		t.Fatalf("expected forbidden for non-owner, got %v", err)
+	}
+	if _, err := svc.Update(context.Background(), userID, created.ID, dto.UpdateReviewRequest{}); !errors.Is(err, ErrEmptyUpdate) {
+		t.Fatalf("expected empty update error, got %v", err)
+	}
+	for _, invalid := range []float64{0, 5.5} {
+		if _, err := svc.Update(context.Background(), userID, created.ID, dto.UpdateReviewRequest{Rating: &invalid}); !errors.Is(err, ErrInvalidRating) {
+			t.Fatalf("expected rating %v to be rejected, got %v", invalid, err)
+		}
+	}
+
+	updated, err := svc.Update(context.Background(), userID, created.ID, dto.UpdateReviewRequest{Rating: &rating, Text: &text})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if !updated.Edited || updated.EditedAt == nil || updated.Content != text {
+		t.Fatalf("expected edited review, got %+v", updated)
+	}
+
+	var edits []model.ReviewEdit
+	if err := db.Where("review_id = ?", created.ID).Find(&edits).Error; err != nil {
+		t.Fatalf("failed to load edits: %v", err)
+	}
+	if len(edits) != 1 || edits[0].Content != "slow srevice" || edits[0].Rating != 2 {
+		t.Fatalf("expected previous version in history, got %+v", edits)
+	}
+
+	var reloaded model.Merchant
+	if err := db.First(&reloaded, merchant.ID).Error; err != nil {
+		t.Fatalf("failed to reload merchant: %v", err)
+	}
+	if reloaded.AvgRating != 4 {
+		t.Fatalf("expected avg rating 4 after edit, got %v", reloaded.AvgRating)
+	}
+}
+
+func TestUpdateReviewNeverLiftsModeration(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	hidden := model.Review{UserID: userID, VenueID: merchant.ID, MerchantID: merchant.ID, Rating: 1, Content: "reported", Status: model.ContentStatusHidden}
+	if err := db.Create(&hidden).Error; err != nil {
+		t.Fatalf("failed to create review: %v", err)
+	}
+
+	text := "perfectly polite text"
+	updated, err := svc.Update(context.Background(), userID, hidden.ID, dto.UpdateReviewRequest{Text: &text})
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	var reloaded model.Review
+	if err := db.First(&reloaded, hidden.ID).Error; err != nil {
+		t.Fatalf("failed to reload review: %v", err)
+	}
+	if updated.Status != model.ContentStatusHidden || reloaded.Status != model.ContentStatusHidden {
+		t.Fatalf("expected the edited review to stay hidden, got %d/%d", updated.Status, reloaded.Status)
+	}
+}
+
+func TestDeleteReviewSoftDeletesAndKeepsCountersConsistent(t *testing.T) {
+	svc, db, userID := setupReviewServiceTest(t)
+
+	if err := db.Create(&model.UserProfile{UserID: userID, Nickname: "reviewer"}).Error; err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	merchant := model.Merchant{Name: "Cafe"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	store := model.Store{MerchantID: merchant.ID, Name: "Downtown"}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	var reviews []model.Review
+	for _, rating := range []float64{5, 3} {
+		created, err := svc.Create(context.Background(), userID, dto.Review{
+			MerchantID: fmt.Sprintf("%d", merchant.ID),
+			StoreID:    fmt.Sprintf("%d", store.ID),
+			Rating:     rating,
+			Text:       "visit",
+		})
+		if err != nil {
+			t.Fatalf("create failed: %v", err)
+		}
+		reviews = append(reviews, created)
+	}
+
+	tag := model.Tag{Name: "coffee", ReviewCount: 1}
+	if err := db.Create(&tag).Error; err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+	if err := db.Model(&reviews[0]).Association("Tags").Append(&tag); err != nil {
+		t.Fatalf("failed to tag review: %v", err)
+	}
+
+	if err := svc.Delete(context.Background(), userID+1, reviews[0].ID); !errors.Is(err, ErrReviewForbidden) {
+		t.Fatalf("expected forbidden for non-owner, got %v", err)
+	}
+	if err := svc.Delete(context.Background(), userID, reviews[0].ID); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := svc.Detail(context.Background(), 0, reviews[0].ID); err == nil {
+		t.Fatal("expected deleted review to be hidden from detail")
+	}
+	var raw int64
+	if err := db.Unscoped().Model(&model.Review{}).Where("id = ?", reviews[0].ID).Count(&raw).Error; err != nil || raw != 1 {
+		t.Fatalf("expected review row to be soft deleted, count=%d err=%v", raw, err)
+	}
+
+	var reloadedMerchant model.Merchant
+	if err := db.First(&reloadedMerchant, merchant.ID).Error; err != nil {
+		t.Fatalf("failed to reload merchant: %v", err)
+	}
+	if reloadedMerchant.ReviewCount != 1 || reloadedMerchant.AvgRating != 3 {
+		t.Fatalf("expected merchant aggregates 1/3, got %d/%v", reloadedMerchant.ReviewCount, reloadedMerchant.AvgRating)
+	}
+	var reloadedStore model.Store
+	if err := db.First(&reloadedStore, store.ID).Error; err != nil {
+		t.Fatalf("failed to reload store: %v", err)
+	}
+	if reloadedStore.ReviewCount != 1 {
+		t.Fatalf("expected store review count 1, got %d", reloadedStore.ReviewCount)
+	}
+	var profile model.UserProfile
+	if err := db.First(&profile, "user_id = ?", userID).Error; err != nil {
+		t.Fatalf("failed to reload profile: %v", err)
+	}
+	if profile.ReviewCount != 1 {
+		t.Fatalf("expected profile review count 1, got %d", profile.ReviewCount)
+	}
+	var reloadedTag model.Tag
+	if err := db.First(&reloadedTag, tag.ID).Error; err != nil {
+		t.Fatalf("failed to reload tag: %v", err)
+	}
+	if reloadedTag.ReviewCount != 0 {
+		t.Fatalf("expected tag review count 0, got %d", reloadedTag.ReviewCount)
+	}
+}
@@ -0,0 +1,64 @@
+package dto
+
+// Searchable document types.
+const (
+	TypeStore    = "store"
+	TypeMerchant = "merchant"
+	TypeReview   = "review"
+	TypePost     = "post"
+)
+
+// AllTypes lists every searchable type in facet order.
+var AllTypes = []string{TypeStore, TypeMerchant, TypeReview, TypePost}
+
+// SearchQuery is a parsed GET /search request. Types narrows the hits but not the facets.
+// ViewerID is the signed-in caller, or 0.
+type SearchQuery struct {
+	Q        string
+	Types    []string
+	Cursor   string
+	Limit    *int
+	ViewerID int64
+}
+
+// SearchHit is one matching document. Highlight is the title and Snippet an excerpt of the
+// body, both with matched terms wrapped in <mark></mark>.
+type SearchHit struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Title     string  `json:"title"`
+	Highlight string  `json:"highlight"`
+	Snippet   string  `json:"snippet"`
+	Score     float64 `json:"score"`
+}
+
+// SearchResponse is a page of hits plus the number of matches per type.
+type SearchResponse struct {
+	Data   []SearchHit      `json:"data"`
+	Facets map[string]int64 `json:"facets"`
+	Cursor *string          `json:"cursor"`
+}
+
+// Suggestion types returned by GET /search/suggest.
+const (
+	SuggestStore    = "store"
+	SuggestCategory = "category"
+	SuggestTag      = "tag"
+	SuggestCity     = "city"
+	SuggestRecent   = "recent"
+)
+
+// Suggestion is one autocomplete entry. ID is set for stores, categories and tags;
+// Popularity is the review count behind the entry.
+type Suggestion struct {
+	Type       string  `json:"type"`
+	Text       string  `json:"text"`
+	ID         *string `json:"id,omitempty"`
+	Popularity int64   `json:"popularity"`
+}
+
+// SuggestResponse lists suggestions for a prefix, or the caller's recent searches when the
+// prefix is empty.
+type SuggestResponse struct {
+	Data []Suggestion `json:"data"`
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	svc     *service.SearchService
+	suggest *service.SuggestService
+}
+
+func NewSearchHandler(svc *service.SearchService, suggest *service.SuggestService) *SearchHandler {
+	if svc == nil {
+		svc = service.NewSearchService(nil)
+	}
+	if suggest == nil {
+		suggest = service.NewSuggestService(nil, config.SearchConfig{})
+	}
+	return &SearchHandler{svc: svc, suggest: suggest}
+}
+
+// Search godoc
+// @Summary Search
+// @Description Full-text search across stores, merchants, reviews and posts. Matches on names weigh most, then categories and tags, then descriptions and review text; misspelt names still match by trigram similarity. Facets count matches per type regardless of the type filter. Reviews and posts by users the caller blocked, or who blocked the caller, are left out, as are those by private accounts the caller does not follow. Queries from signed-in callers are kept as recent searches.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search text"
+// @Param type query string false "Comma-separated types to return: store, merchant, review, post"
+// @Param cursor query string false "Cursor returned with the previous page"
+// @Param limit query int false "Page size (max 50)"
+// @Success 200 {object} dto.SearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := dto.SearchQuery{Q: c.Query("q"), Cursor: c.Query("cursor"), ViewerID: c.GetInt64("user_id")}
+	if raw := c.Query("type"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				query.Types = append(query.Types, t)
+			}
+		}
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		query.Limit = &limit
+	}
+
+	resp, err := h.svc.Search(c.Request.Context(), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyQuery),
+			errors.Is(err, service.ErrQueryTooLong),
+			errors.Is(err, service.ErrInvalidType),
+			errors.Is(err, service.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "search failed"})
+		}
+		return
+	}
+	if userID := c.GetInt64("user_id"); userID != 0 && query.Cursor == "" {
+		if err := h.suggest.RecordSearch(c.Request.Context(), userID, query.Q); err != nil {
+			logger.Warn(c.Request.Context(), "Failed to record recent search", "user_id", userID, "error", err.Error())
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Suggest godoc
+// @Summary Search autocomplete
+// @Description Suggests store names, categories, tags and cities whose name or any word in it starts with q, most reviewed first. With an empty q, returns the signed-in caller's recent searches.
+// @Tags search
+// @Produce json
+// @Param q query string false "Prefix typed so far"
+// @Param limit query int false "Maximum suggestions (max 20)"
+// @Success 200 {object} dto.SuggestResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /search/suggest [get]
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	var limit *int
+	if raw := c.Query("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = &value
+	}
+
+	resp, err := h.suggest.Suggest(c.Request.Context(), c.GetInt64("user_id"), c.Query("q"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrQueryTooLong) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load suggestions"})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ClearRecent godoc
+// @Summary Clear recent searches
+// @Description Forgets all of the current user's recent searches
+// @Tags search
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /search/recent [delete]
+func (h *SearchHandler) ClearRecent(c *gin.Context) {
+	if err := h.suggest.ClearRecent(c.Request.Context(), c.GetInt64("user_id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to clear recent searches"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "recent searches cleared"})
+}
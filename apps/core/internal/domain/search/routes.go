@@ -0,0 +1,23 @@
+package search
+
+import (
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/handler"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes registers search routes.
+func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
+	svc := service.NewSearchService(service.NewPostgresIndex(nil))
+	suggest := service.NewSuggestService(nil, cfg.Search)
+	h := handler.NewSearchHandler(svc, suggest)
+
+	search := r.Group("/search")
+	{
+		search.GET("", middleware.OptionalJWTAuth(cfg.JWT), h.Search)
+		search.GET("/suggest", middleware.OptionalJWTAuth(cfg.JWT), h.Suggest)
+		search.DELETE("/recent", middleware.JWTAuth(cfg.JWT), h.ClearRecent)
+	}
+}
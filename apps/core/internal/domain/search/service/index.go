@@ -0,0 +1,55 @@
+package service
+
+import "context"
+
+// Highlight delimiters wrapped around matched terms.
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
+// SearchIndex finds documents matching free text. Hits come back ordered by descending
+// score, then type, then descending id, starting strictly after IndexQuery.After. Facets
+// count every match per type, ignoring IndexQuery.Types.
+type SearchIndex interface {
+	Search(ctx context.Context, q IndexQuery) ([]Hit, map[string]int64, error)
+}
+
+// IndexQuery is what the service asks of an index. Reviews and posts by users on either side
+// of a block with ViewerID, or by private accounts ViewerID does not follow, are left out;
+// 0 means an anonymous viewer.
+type IndexQuery struct {
+	Text     string
+	Types    []string
+	After    *Position
+	Limit    int
+	ViewerID int64
+}
+
+// Position is a hit's place in the result order; it backs the search cursor.
+type Position struct {
+	Score float64 `json:"s"`
+	Type  string  `json:"t"`
+	ID    int64   `json:"i"`
+}
+
+// Hit is a matching document as returned by an index.
+type Hit struct {
+	Type      string
+	ID        int64
+	Title     string
+	Highlight string
+	Snippet   string
+	Score     float64
+}
+
+// after reports whether h sorts strictly after p.
+func (h Hit) after(p Position) bool {
+	if h.Score != p.Score {
+		return h.Score < p.Score
+	}
+	if h.Type != p.Type {
+		return h.Type > p.Type
+	}
+	return h.ID < p.ID
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Field weights mirror ts_rank's defaults for the A, B, C and D labels used by PostgresIndex.
+const (
+	titleWeight  = 1.0
+	labelWeight  = 0.4
+	bodyWeight   = 0.2
+	reviewWeight = 0.1
+	// trigramThreshold is pg_trgm's default similarity threshold.
+	trigramThreshold = 0.3
+	snippetWords     = 30
+)
+
+// Document is a searchable record held by MemoryIndex. Labels are category and tag names;
+// Reviews is the visible review text written about a store. AuthorID is the writer of a
+// review or post, 0 for documents nobody authored.
+type Document struct {
+	Type     string
+	ID       int64
+	Title    string
+	Labels   []string
+	Body     string
+	Reviews  []string
+	AuthorID int64
+}
+
+// relation is an ordered pair of users: blocker and blocked, or follower and followed.
+type relation struct {
+	from, to int64
+}
+
+// MemoryIndex is an in-process SearchIndex for tests and local development. It scores
+// documents like PostgresIndex: weighted term matches plus trigram similarity on titles. The
+// blocks, follows and private accounts it was told about hide authored documents the same
+// way user_blocks, user_follows and user_privacies do for PostgresIndex.
+type MemoryIndex struct {
+	mu      sync.RWMutex
+	docs    map[string]Document
+	blocks  map[relation]bool
+	follows map[relation]bool
+	private map[int64]bool
+}
+
+func NewMemoryIndex(docs ...Document) *MemoryIndex {
+	index := &MemoryIndex{
+		docs:    make(map[string]Document),
+		blocks:  make(map[relation]bool),
+		follows: make(map[relation]bool),
+		private: make(map[int64]bool),
+	}
+	index.Add(docs...)
+	return index
+}
+
+// Add inserts or replaces documents.
+func (i *MemoryIndex) Add(docs ...Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, doc := range docs {
+		i.docs[documentKey(doc.Type, doc.ID)] = doc
+	}
+}
+
+// Remove drops a document if present.
+func (i *MemoryIndex) Remove(docType string, id int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.docs, documentKey(docType, id))
+}
+
+// Block records that blockerID blocked blockedID.
+func (i *MemoryIndex) Block(blockerID, blockedID int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.blocks[relation{blockerID, blockedID}] = true
+}
+
+// Follow records that followerID follows followingID.
+func (i *MemoryIndex) Follow(followerID, followingID int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.follows[relation{followerID, followingID}] = true
+}
+
+// SetPrivate marks userID's account private or public.
+func (i *MemoryIndex) SetPrivate(userID int64, private bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if private {
+		i.private[userID] = true
+	} else {
+		delete(i.private, userID)
+	}
+}
+
+func (i *MemoryIndex) Search(_ context.Context, query IndexQuery) ([]Hit, map[string]int64, error) {
+	terms := tokenize(query.Text)
+	facets := make(map[string]int64)
+	var hits []Hit
+
+	i.mu.RLock()
+	for _, doc := range i.docs {
+		if !i.visible(doc, query.ViewerID) {
+			continue
+		}
+		score, ok := scoreDocument(doc, query.Text, terms)
+		if !ok {
+			continue
+		}
+		facets[doc.Type]++
+		if len(query.Types) > 0 && !slices.Contains(query.Types, doc.Type) {
+			continue
+		}
+		hit := Hit{Type: doc.Type, ID: doc.ID, Title: doc.Title, Score: score}
+		if query.After != nil && !hit.after(*query.After) {
+			continue
+		}
+		hit.Highlight = highlight(doc.Title, terms, false)
+		hit.Snippet = highlight(doc.Body, terms, true)
+		hits = append(hits, hit)
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(hits, func(a, b int) bool {
+		return hits[b].after(Position{Score: hits[a].Score, Type: hits[a].Type, ID: hits[a].ID})
+	})
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+	return hits, facets, nil
+}
+
+// visible applies PostgresIndex's notBlocked and notPrivate to authored documents. The
+// caller holds i.mu.
+func (i *MemoryIndex) visible(doc Document, viewerID int64) bool {
+	author := doc.AuthorID
+	if author == 0 {
+		return true
+	}
+	if i.blocks[relation{viewerID, author}] || i.blocks[relation{author, viewerID}] {
+		return false
+	}
+	return !i.private[author] || author == viewerID || i.follows[relation{viewerID, author}]
+}
+
+// scoreDocument reports whether doc matches and how well. Every query term must appear in
+// some field (as in websearch_to_tsquery), unless the title is a close trigram match.
+func scoreDocument(doc Document, text string, terms []string) (float64, bool) {
+	title := tokenize(doc.Title)
+	labels := tokenize(strings.Join(doc.Labels, " "))
+	body := tokenize(doc.Body)
+	reviews := tokenize(strings.Join(doc.Reviews, " "))
+
+	score := 0.0
+	matchedAll := len(terms) > 0
+	for _, term := range terms {
+		termScore := 0.0
+		if slices.Contains(title, term) {
+			termScore += titleWeight
+		}
+		if slices.Contains(labels, term) {
+			termScore += labelWeight
+		}
+		if slices.Contains(body, term) {
+			termScore += bodyWeight
+		}
+		if slices.Contains(reviews, term) {
+			termScore += reviewWeight
+		}
+		if termScore == 0 {
+			matchedAll = false
+		}
+		score += termScore
+	}
+	if !matchedAll {
+		score = 0
+	}
+	similarity := trigramSimilarity(strings.ToLower(doc.Title), strings.ToLower(text))
+	if !matchedAll && similarity < trigramThreshold {
+		return 0, false
+	}
+	return score + similarity, true
+}
+
+// trigramSimilarity follows pg_trgm: words are padded with two leading spaces and one
+// trailing space, and similarity is shared trigrams over all distinct trigrams.
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range tokenize(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// highlight escapes text for HTML and wraps query terms found in it with <mark></mark>. As a
+// snippet, text is cut to snippetWords words starting shortly before the first match.
+func highlight(text string, terms []string, snippet bool) string {
+	words := strings.Fields(text)
+	first := -1
+	for i, word := range words {
+		escaped := html.EscapeString(word)
+		if slices.Contains(terms, normalizeToken(word)) {
+			if first < 0 {
+				first = i
+			}
+			escaped = highlightStart + escaped + highlightStop
+		}
+		words[i] = escaped
+	}
+	if snippet && len(words) > snippetWords {
+		start := max(first-snippetWords/3, 0)
+		words = words[start:min(start+snippetWords, len(words))]
+	}
+	return strings.Join(words, " ")
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func normalizeToken(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}))
+}
+
+func documentKey(docType string, id int64) string {
+	return fmt.Sprintf("%s:%d", docType, id)
+}
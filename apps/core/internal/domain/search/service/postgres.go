@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/dto"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"gorm.io/gorm"
+)
+
+// PostgresIndex searches the weighted search_vector columns added by migration 00013.
+// Category and tag names count as weight-B labels and a store's visible review text as
+// weight D; each is matched through its own index and the matches are merged per
+// document. pg_trgm similarity on names lets misspelt queries still match.
+type PostgresIndex struct {
+	db *gorm.DB
+}
+
+func NewPostgresIndex(db *gorm.DB) *PostgresIndex {
+	if db == nil {
+		db = database.DB
+	}
+	return &PostgresIndex{db: db}
+}
+
+// labelBonus and reviewTextBonus match ts_rank's default weights for B and D, so a
+// category or tag hit counts like a secondary field and review text like minor text.
+const (
+	labelBonus      = "0.4"
+	reviewTextBonus = "0.1"
+)
+
+const (
+	titleHeadline   = "'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'"
+	snippetHeadline = "'MaxWords=30, MinWords=10, MaxFragments=1, StartSel=<mark>, StopSel=</mark>'"
+)
+
+// searchBranch is the part of the union that finds one document type. Its select list
+// yields title, body and score; where is ANDed with the type's visibility rules.
+type searchBranch struct {
+	typ   string
+	alias string
+	from  string
+	title string
+	body  string
+	score string
+	where string
+}
+
+var searchBranches = []searchBranch{
+	{
+		typ:   dto.TypeStore,
+		alias: "s",
+		from:  "(" + storeMatches + ") sm JOIN stores s ON s.id = sm.id",
+		title: "s.name",
+		body:  "COALESCE(s.description, '')",
+		score: "ts_rank(s.search_vector, q.query) + similarity(s.name, @q)" +
+			" + CASE WHEN sm.category_hit THEN " + labelBonus + " ELSE 0 END" +
+			" + CASE WHEN sm.tag_hit THEN " + labelBonus + " ELSE 0 END" +
+			" + CASE WHEN sm.review_hit THEN " + reviewTextBonus + " ELSE 0 END",
+		where: "s.status = @published AND s.deleted_at IS NULL",
+	},
+	{
+		typ:   dto.TypeMerchant,
+		alias: "m",
+		from:  "merchants m",
+		title: "COALESCE(NULLIF(m.business_name, ''), m.name)",
+		body:  "COALESCE(m.description, '')",
+		score: "ts_rank(m.search_vector, q.query) + similarity(m.name, @q)",
+		where: "m.status = 0 AND m.verification_status = 'verified' AND m.deleted_at IS NULL" +
+			" AND (m.search_vector @@ q.query OR m.name % @q)",
+	},
+	{
+		typ:   dto.TypeReview,
+		alias: "r",
+		from: "(" + reviewMatches + ") rmt JOIN reviews r ON r.id = rmt.id" +
+			" JOIN merchants rm ON rm.id = r.merchant_id LEFT JOIN stores rs ON rs.id = r.store_id",
+		title: "COALESCE(rs.name, rm.name)",
+		body:  "COALESCE(r.content, '')",
+		score: "ts_rank(r.search_vector, q.query) + CASE WHEN rmt.tag_hit THEN " + labelBonus + " ELSE 0 END",
+		where: "r.status = @visible AND r.deleted_at IS NULL AND " + notBlocked("r.user_id") + " AND " + notPrivate("r.user_id"),
+	},
+	{
+		typ:   dto.TypePost,
+		alias: "p",
+		from:  "(" + postMatches + ") pm JOIN posts p ON p.id = pm.id",
+		title: "COALESCE(p.title, '')",
+		body:  "COALESCE(p.content, '')",
+		score: "ts_rank(p.search_vector, q.query) + similarity(COALESCE(p.title, ''), @q)" +
+			" + CASE WHEN pm.tag_hit THEN " + labelBonus + " ELSE 0 END",
+		where: "p.status = @visible AND " + notBlocked("p.user_id") + " AND " + notPrivate("p.user_id"),
+	},
+}
+
+// The match queries collect candidate ids per type. Each arm of a union is answered by one
+// index (a search_vector, a trigram index, or the to_tsvector indexes on category and tag
+// names from migration 00029), so no branch scans its table; the flags record which arms
+// matched for scoring.
+const (
+	storeMatches = "SELECT id, bool_or(kind = 'category') AS category_hit, bool_or(kind = 'tag') AS tag_hit," +
+		" bool_or(kind = 'review') AS review_hit FROM (" +
+		"SELECT ss.id, 'store' AS kind FROM stores ss CROSS JOIN q WHERE ss.search_vector @@ q.query OR ss.name % @q" +
+		" UNION ALL SELECT sc.store_id, 'category' FROM categories c JOIN store_categories sc ON sc.category_id = c.id" +
+		" CROSS JOIN q WHERE " + categoryNameMatch + " OR c.name % @q" +
+		" UNION ALL SELECT tr.store_id, 'tag' FROM tags t JOIN review_tags rt ON rt.tag_id = t.id" +
+		" JOIN reviews tr ON tr.id = rt.review_id CROSS JOIN q WHERE " + tagNameMatch +
+		" AND tr.store_id IS NOT NULL AND tr.status = @visible AND tr.deleted_at IS NULL" +
+		" UNION ALL SELECT tr.store_id, 'review' FROM reviews tr CROSS JOIN q WHERE tr.search_vector @@ q.query" +
+		" AND tr.store_id IS NOT NULL AND tr.status = @visible AND tr.deleted_at IS NULL" +
+		") matches GROUP BY id"
+	reviewMatches = "SELECT id, bool_or(tag_hit) AS tag_hit FROM (" +
+		"SELECT rr.id, false AS tag_hit FROM reviews rr CROSS JOIN q WHERE rr.search_vector @@ q.query" +
+		" UNION ALL SELECT rt.review_id, true FROM tags t JOIN review_tags rt ON rt.tag_id = t.id" +
+		" CROSS JOIN q WHERE " + tagNameMatch +
+		") matches GROUP BY id"
+	postMatches = "SELECT id, bool_or(tag_hit) AS tag_hit FROM (" +
+		"SELECT pp.id, false AS tag_hit FROM posts pp CROSS JOIN q WHERE pp.search_vector @@ q.query OR pp.title % @q" +
+		" UNION ALL SELECT pt.post_id, true FROM tags t JOIN post_tags pt ON pt.tag_id = t.id" +
+		" CROSS JOIN q WHERE " + tagNameMatch +
+		") matches GROUP BY id"
+)
+
+// Category and tag name matches. The expressions must stay identical to the ones indexed
+// by migration 00029.
+const (
+	categoryNameMatch = "to_tsvector('simple', c.name) @@ q.query"
+	tagNameMatch      = "to_tsvector('simple', t.name) @@ q.query"
+)
+
+// notBlocked mirrors followservice.ExcludeBlocked for the raw union: it drops rows whose
+// authorColumn is on either side of a block with the viewer.
+func notBlocked(authorColumn string) string {
+	return "NOT EXISTS (SELECT 1 FROM user_blocks ub WHERE (ub.blocker_id = @viewer AND ub.blocked_id = " + authorColumn +
+		") OR (ub.blocked_id = @viewer AND ub.blocker_id = " + authorColumn + "))"
+}
+
+// notPrivate mirrors followservice.ExcludePrivate: it keeps rows whose authorColumn is a
+// public account, the viewer, or someone the viewer follows.
+func notPrivate(authorColumn string) string {
+	return "(NOT EXISTS (SELECT 1 FROM user_privacies up WHERE up.user_id = " + authorColumn + " AND up.is_public = false)" +
+		" OR " + authorColumn + " = @viewer" +
+		" OR EXISTS (SELECT 1 FROM user_follows uf WHERE uf.follower_id = @viewer AND uf.following_id = " + authorColumn + "))"
+}
+
+const queryCTE = "WITH q AS (SELECT websearch_to_tsquery('simple', @q) AS query) "
+
+func (i *PostgresIndex) Search(ctx context.Context, query IndexQuery) ([]Hit, map[string]int64, error) {
+	args := map[string]interface{}{
+		"q":         query.Text,
+		"visible":   model.ContentStatusVisible,
+		"published": storeservice.StoreStatusPublished,
+		"limit":     query.Limit,
+		"viewer":    query.ViewerID,
+	}
+	db := i.db.WithContext(ctx)
+
+	var facetRows []struct {
+		Type  string
+		Count int64
+	}
+	if err := db.Raw(queryCTE+"SELECT type, COUNT(*) AS count FROM ("+unionSQL(nil, false)+") AS hits GROUP BY type", args).
+		Scan(&facetRows).Error; err != nil {
+		return nil, nil, err
+	}
+	facets := make(map[string]int64, len(facetRows))
+	for _, row := range facetRows {
+		facets[row.Type] = row.Count
+	}
+
+	where := ""
+	if query.After != nil {
+		where = " WHERE hits.score < @score OR (hits.score = @score AND (hits.type > @type OR (hits.type = @type AND hits.id < @id)))"
+		args["score"] = query.After.Score
+		args["type"] = query.After.Type
+		args["id"] = query.After.ID
+	}
+	sql := queryCTE +
+		"SELECT hits.type, hits.id, hits.title, hits.score," +
+		" ts_headline('simple', " + escapeHTML("hits.title") + ", q.query, " + titleHeadline + ") AS highlight," +
+		" ts_headline('simple', " + escapeHTML("hits.body") + ", q.query, " + snippetHeadline + ") AS snippet" +
+		" FROM (" + unionSQL(query.Types, true) + ") AS hits CROSS JOIN q" + where +
+		" ORDER BY hits.score DESC, hits.type ASC, hits.id DESC LIMIT @limit"
+	var hits []Hit
+	if err := db.Raw(sql, args).Scan(&hits).Error; err != nil {
+		return nil, nil, err
+	}
+	return hits, facets, nil
+}
+
+// escapeHTML escapes column for HTML in SQL, so the only markup in a headline is the
+// highlight delimiters ts_headline adds. The parser reads the entities as such, so they are
+// never highlighted themselves.
+func escapeHTML(column string) string {
+	escaped := column
+	for _, r := range [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"'", "&#39;"}} {
+		escaped = "replace(" + escaped + ", '" + strings.ReplaceAll(r[0], "'", "''") + "', '" + r[1] + "')"
+	}
+	return escaped
+}
+
+// unionSQL combines the branches for types (all when empty). Without details only type and
+// id are selected, which is all the facet count needs.
+func unionSQL(types []string, details bool) string {
+	parts := make([]string, 0, len(searchBranches))
+	for _, branch := range searchBranches {
+		if len(types) > 0 && !slices.Contains(types, branch.typ) {
+			continue
+		}
+		columns := "'" + branch.typ + "' AS type, " + branch.alias + ".id AS id"
+		if details {
+			columns += ", " + branch.title + " AS title, " + branch.body + " AS body, (" + branch.score + ")::float8 AS score"
+		}
+		parts = append(parts, "SELECT "+columns+" FROM "+branch.from+" CROSS JOIN q WHERE "+branch.where)
+	}
+	return strings.Join(parts, " UNION ALL ")
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/dto"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	maxQueryLength     = 200
+)
+
+var ErrEmptyQuery = errors.New("query is required")
+var ErrQueryTooLong = errors.New("query is too long")
+var ErrInvalidType = errors.New("invalid type")
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type SearchService struct {
+	index SearchIndex
+}
+
+// NewSearchService wires the service to an index; a nil index uses the Postgres one on
+// the default database.
+func NewSearchService(index SearchIndex) *SearchService {
+	if index == nil {
+		index = NewPostgresIndex(nil)
+	}
+	return &SearchService{index: index}
+}
+
+// Search runs a full-text query across stores, merchants, reviews and posts.
+func (s *SearchService) Search(ctx context.Context, query dto.SearchQuery) (dto.SearchResponse, error) {
+	text := strings.TrimSpace(query.Q)
+	if text == "" {
+		return dto.SearchResponse{}, ErrEmptyQuery
+	}
+	if len(text) > maxQueryLength {
+		return dto.SearchResponse{}, ErrQueryTooLong
+	}
+	for _, t := range query.Types {
+		if !slices.Contains(dto.AllTypes, t) {
+			return dto.SearchResponse{}, ErrInvalidType
+		}
+	}
+	limit := defaultSearchLimit
+	if query.Limit != nil && *query.Limit > 0 {
+		limit = min(*query.Limit, maxSearchLimit)
+	}
+	var after *Position
+	if query.Cursor != "" {
+		position, err := decodeSearchCursor(query.Cursor)
+		if err != nil {
+			return dto.SearchResponse{}, err
+		}
+		after = &position
+	}
+
+	hits, facets, err := s.index.Search(ctx, IndexQuery{
+		Text:     text,
+		Types:    query.Types,
+		After:    after,
+		Limit:    limit + 1,
+		ViewerID: query.ViewerID,
+	})
+	if err != nil {
+		return dto.SearchResponse{}, err
+	}
+	var next *string
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[len(hits)-1]
+		encoded := encodeSearchCursor(Position{Score: last.Score, Type: last.Type, ID: last.ID})
+		next = &encoded
+	}
+
+	resp := dto.SearchResponse{Data: make([]dto.SearchHit, 0, len(hits)), Facets: map[string]int64{}, Cursor: next}
+	for _, t := range dto.AllTypes {
+		resp.Facets[t] = facets[t]
+	}
+	for _, hit := range hits {
+		resp.Data = append(resp.Data, dto.SearchHit{
+			ID:        fmt.Sprintf("%d", hit.ID),
+			Type:      hit.Type,
+			Title:     hit.Title,
+			Highlight: hit.Highlight,
+			Snippet:   hit.Snippet,
+			Score:     hit.Score,
+		})
+	}
+	return resp, nil
+}
+
+func encodeSearchCursor(position Position) string {
+	raw, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeSearchCursor(value string) (Position, error) {
+	var position Position
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(raw, &position) != nil || !slices.Contains(dto.AllTypes, position.Type) {
+		return Position{}, ErrInvalidCursor
+	}
+	return position, nil
+}
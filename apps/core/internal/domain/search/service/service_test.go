@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/dto"
+)
+
+func newTestSearchService() *SearchService {
+	return NewSearchService(NewMemoryIndex(
+		Document{Type: dto.TypeStore, ID: 1, Title: "Blue Bottle Coffee", Labels: []string{"cafe"}, Body: "Pour-over and espresso."},
+		Document{Type: dto.TypeStore, ID: 2, Title: "Noodle House", Labels: []string{"coffee"}, Body: "Hand-pulled noodles."},
+		Document{Type: dto.TypeReview, ID: 3, Title: "Noodle House", Body: "Great noodles, the coffee was weak."},
+		Document{Type: dto.TypePost, ID: 4, Title: "Weekend brunch", Body: "Pancakes and coffee with friends."},
+		Document{Type: dto.TypeMerchant, ID: 5, Title: "Tea Garden", Body: "Loose leaf teas."},
+	))
+}
+
+func TestSearchRanksByFieldWeightAndCountsFacets(t *testing.T) {
+	svc := newTestSearchService()
+
+	resp, err := svc.Search(context.Background(), dto.SearchQuery{Q: "coffee"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	var order []string
+	for _, hit := range resp.Data {
+		order = append(order, hit.Type+":"+hit.ID)
+	}
+	// Title match first, then the category label, then body text.
+	if len(order) != 4 || order[0] != "store:1" || order[1] != "store:2" {
+		t.Fatalf("unexpected order %v", order)
+	}
+	if resp.Data[0].Highlight != "Blue Bottle <mark>Coffee</mark>" {
+		t.Fatalf("unexpected highlight %q", resp.Data[0].Highlight)
+	}
+	want := map[string]int64{dto.TypeStore: 2, dto.TypeMerchant: 0, dto.TypeReview: 1, dto.TypePost: 1}
+	for k, v := range want {
+		if resp.Facets[k] != v {
+			t.Fatalf("unexpected facets %v", resp.Facets)
+		}
+	}
+
+	filtered, err := svc.Search(context.Background(), dto.SearchQuery{Q: "coffee", Types: []string{dto.TypeReview}})
+	if err != nil {
+		t.Fatalf("filtered search failed: %v", err)
+	}
+	if len(filtered.Data) != 1 || filtered.Data[0].ID != "3" || !strings.Contains(filtered.Data[0].Snippet, "<mark>coffee</mark>") {
+		t.Fatalf("unexpected filtered hits %+v", filtered.Data)
+	}
+	if filtered.Facets[dto.TypeStore] != 2 {
+		t.Fatalf("expected facets to ignore the type filter, got %v", filtered.Facets)
+	}
+}
+
+func TestSearchToleratesTyposInTitles(t *testing.T) {
+	svc := newTestSearchService()
+
+	resp, err := svc.Search(context.Background(), dto.SearchQuery{Q: "noodle hose"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(resp.Data) == 0 || resp.Data[0].Title != "Noodle House" {
+		t.Fatalf("expected trigram match on Noodle House, got %+v", resp.Data)
+	}
+}
+
+func TestSearchPaginatesWithCursor(t *testing.T) {
+	svc := newTestSearchService()
+	limit := 1
+	seen := map[string]bool{}
+	query := dto.SearchQuery{Q: "coffee", Limit: &limit}
+	for page := 0; page < 10; page++ {
+		resp, err := svc.Search(context.Background(), query)
+		if err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		for _, hit := range resp.Data {
+			key := hit.Type + ":" + hit.ID
+			if seen[key] {
+				t.Fatalf("hit %s returned twice", key)
+			}
+			seen[key] = true
+		}
+		if resp.Cursor == nil {
+			break
+		}
+		query.Cursor = *resp.Cursor
+	}
+	if len(seen) != 4 {
+		t.Fatalf("expected 4 hits across pages, got %v", seen)
+	}
+}
+
+func TestSearchValidatesQuery(t *testing.T) {
+	svc := newTestSearchService()
+	cases := []struct {
+		query dto.SearchQuery
+		err   error
+	}{
+		{dto.SearchQuery{Q: "  "}, ErrEmptyQuery},
+		{dto.SearchQuery{Q: strings.Repeat("a", maxQueryLength+1)}, ErrQueryTooLong},
+		{dto.SearchQuery{Q: "coffee", Types: []string{"user"}}, ErrInvalidType},
+		{dto.SearchQuery{Q: "coffee", Cursor: "bogus"}, ErrInvalidCursor},
+	}
+	for _, tc := range cases {
+		if _, err := svc.Search(context.Background(), tc.query); !errors.Is(err, tc.err) {
+			t.Fatalf("query %+v: expected %v, got %v", tc.query, tc.err, err)
+		}
+	}
+}
+
+func TestPostgresUnionOnlyIncludesRequestedTypes(t *testing.T) {
+	sql := unionSQL([]string{dto.TypeReview, dto.TypePost}, true)
+	if strings.Contains(sql, "FROM stores s") || strings.Contains(sql, "FROM merchants m") {
+		t.Fatalf("unexpected branches in %s", sql)
+	}
+	if strings.Count(sql, "' AS type, ") != 2 || !strings.Contains(sql, "AS score") {
+		t.Fatalf("expected two detailed branches, got %s", sql)
+	}
+}
+
+func TestSearchHighlightsEscapeHTML(t *testing.T) {
+	svc := NewSearchService(NewMemoryIndex(
+		Document{Type: dto.TypePost, ID: 1, Title: `<img src=x onerror=alert(1)> coffee`, Body: `Tom's "coffee" & <b>cake</b>`},
+	))
+
+	resp, err := svc.Search(context.Background(), dto.SearchQuery{Q: "coffee"})
+	if err != nil || len(resp.Data) != 1 {
+		t.Fatalf("search failed: %+v (%v)", resp, err)
+	}
+	if got := resp.Data[0].Highlight; got != "&lt;img src=x onerror=alert(1)&gt; <mark>coffee</mark>" {
+		t.Fatalf("unexpected highlight %q", got)
+	}
+	if got := resp.Data[0].Snippet; got != "Tom&#39;s <mark>&#34;coffee&#34;</mark> &amp; &lt;b&gt;cake&lt;/b&gt;" {
+		t.Fatalf("unexpected snippet %q", got)
+	}
+	if escaped := escapeHTML("hits.title"); !strings.Contains(escaped, "replace(hits.title, '&', '&amp;')") ||
+		!strings.Contains(escaped, "'''', '&#39;'") {
+		t.Fatalf("unexpected SQL escape %s", escaped)
+	}
+}
+
+func TestPostgresUnionHidesBlockedAuthors(t *testing.T) {
+	sql := unionSQL(nil, false)
+	for _, column := range []string{"r.user_id", "p.user_id"} {
+		if !strings.Contains(sql, notBlocked(column)) {
+			t.Fatalf("expected %s to be checked against blocks, got %s", column, sql)
+		}
+	}
+	if strings.Contains(sql, notBlocked("s.user_id")) || strings.Count(sql, "user_blocks") != 2 {
+		t.Fatalf("expected only the review and post branches to check blocks, got %s", sql)
+	}
+}
+
+func TestPostgresUnionHidesUnfollowedPrivateAuthors(t *testing.T) {
+	sql := unionSQL(nil, false)
+	for _, column := range []string{"r.user_id", "p.user_id"} {
+		if !strings.Contains(sql, notPrivate(column)) {
+			t.Fatalf("expected %s to be checked against privacy settings, got %s", column, sql)
+		}
+	}
+}
+
+func TestSearchCountsReviewTextTowardStores(t *testing.T) {
+	svc := NewSearchService(NewMemoryIndex(
+		Document{Type: dto.TypeStore, ID: 1, Title: "Noodle House", Reviews: []string{"Best dumplings in town."}},
+		Document{Type: dto.TypeStore, ID: 2, Title: "Tea Garden", Reviews: []string{"No food at all."}},
+	))
+
+	resp, err := svc.Search(context.Background(), dto.SearchQuery{Q: "dumplings"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != "1" {
+		t.Fatalf("expected the store reviewed for dumplings to match, got %+v", resp.Data)
+	}
+}
+
+func TestPostgresUnionMatchesLabelsThroughIndexedArms(t *testing.T) {
+	sql := unionSQL(nil, true)
+	if strings.Contains(sql, "EXISTS (SELECT 1 FROM store_categories") || strings.Contains(sql, "EXISTS (SELECT 1 FROM review_tags") ||
+		strings.Contains(sql, "EXISTS (SELECT 1 FROM post_tags") {
+		t.Fatalf("expected label matches to be separate union arms, got %s", sql)
+	}
+	for _, arm := range []string{categoryNameMatch, tagNameMatch, "tr.search_vector @@ q.query"} {
+		if !strings.Contains(storeMatches, arm) {
+			t.Fatalf("expected store matches to include %q, got %s", arm, storeMatches)
+		}
+	}
+	if !strings.Contains(reviewMatches, tagNameMatch) || !strings.Contains(postMatches, tagNameMatch) {
+		t.Fatalf("expected review and post matches to include tag names")
+	}
+}
+
+func TestMemoryIndexHidesBlockedAndPrivateAuthors(t *testing.T) {
+	const viewer, blocked, blocker, private, followed = 1, 2, 3, 4, 5
+	index := NewMemoryIndex(
+		Document{Type: dto.TypeStore, ID: 1, Title: "Ramen Bar"},
+		Document{Type: dto.TypeReview, ID: 2, Title: "Ramen Bar", Body: "ramen", AuthorID: blocked},
+		Document{Type: dto.TypeReview, ID: 3, Title: "Ramen Bar", Body: "ramen", AuthorID: blocker},
+		Document{Type: dto.TypePost, ID: 4, Title: "Ramen night", Body: "ramen", AuthorID: private},
+		Document{Type: dto.TypePost, ID: 5, Title: "Ramen again", Body: "ramen", AuthorID: followed},
+	)
+	index.Block(viewer, blocked)
+	index.Block(blocker, viewer)
+	index.SetPrivate(private, true)
+	index.SetPrivate(followed, true)
+	index.Follow(viewer, followed)
+	svc := NewSearchService(index)
+
+	ids := func(viewerID int64) map[string]bool {
+		t.Helper()
+		resp, err := svc.Search(context.Background(), dto.SearchQuery{Q: "ramen", ViewerID: viewerID})
+		if err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		found := make(map[string]bool)
+		for _, hit := range resp.Data {
+			found[hit.Type+":"+hit.ID] = true
+		}
+		return found
+	}
+	if got := ids(viewer); len(got) != 2 || !got["store:1"] || !got["post:5"] {
+		t.Fatalf("expected the store and the followed private author's post, got %v", got)
+	}
+	if got := ids(private); !got["post:4"] || !got["review:2"] || !got["review:3"] || got["post:5"] {
+		t.Fatalf("expected authors to see their own posts but not other private ones, got %v", got)
+	}
+}
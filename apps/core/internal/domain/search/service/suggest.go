@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/dto"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultSuggestLimit   = 10
+	maxSuggestLimit       = 20
+	defaultSuggestRefresh = 5 * time.Minute
+	defaultRecentLimit    = 10
+	// maxCachedStores bounds the cache to the most reviewed stores.
+	maxCachedStores = 10000
+)
+
+// suggestTypeOrder breaks popularity ties so broader suggestions come first.
+var suggestTypeOrder = map[string]int{
+	dto.SuggestCategory: 0,
+	dto.SuggestCity:     1,
+	dto.SuggestTag:      2,
+	dto.SuggestStore:    3,
+}
+
+type suggestEntry struct {
+	suggestion dto.Suggestion
+	lower      string
+	// wordStarts are byte offsets in lower where a word begins.
+	wordStarts []int
+}
+
+// SuggestService serves autocomplete from an in-process snapshot of store names,
+// categories, tags and cities, and keeps each user's recent searches.
+type SuggestService struct {
+	db          *gorm.DB
+	refresh     time.Duration
+	recentLimit int
+	now         func() time.Time
+
+	mu         sync.RWMutex
+	entries    []suggestEntry
+	loadedAt   time.Time
+	refreshing bool
+}
+
+func NewSuggestService(db *gorm.DB, cfg config.SearchConfig) *SuggestService {
+	if db == nil {
+		db = database.DB
+	}
+	refresh := time.Duration(cfg.SuggestRefreshSeconds) * time.Second
+	if refresh <= 0 {
+		refresh = defaultSuggestRefresh
+	}
+	recentLimit := cfg.RecentLimit
+	if recentLimit <= 0 {
+		recentLimit = defaultRecentLimit
+	}
+	return &SuggestService{db: db, refresh: refresh, recentLimit: recentLimit, now: time.Now}
+}
+
+// Suggest returns up to limit entries whose text, or any word in it, starts with q. Entries
+// that start with q outrank mid-text matches; ties go to the more reviewed entry. An empty
+// q returns the viewer's recent searches (none for anonymous callers).
+func (s *SuggestService) Suggest(ctx context.Context, viewerID int64, q string, limit *int) (dto.SuggestResponse, error) {
+	q = strings.ToLower(strings.Join(strings.Fields(q), " "))
+	if len(q) > maxQueryLength {
+		return dto.SuggestResponse{}, ErrQueryTooLong
+	}
+	n := defaultSuggestLimit
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxSuggestLimit)
+	}
+	if q == "" {
+		return s.recent(ctx, viewerID, n)
+	}
+
+	entries, err := s.snapshot(ctx)
+	if err != nil {
+		return dto.SuggestResponse{}, err
+	}
+	type match struct {
+		entry  *suggestEntry
+		prefix bool
+	}
+	var matches []match
+	for i := range entries {
+		entry := &entries[i]
+		if strings.HasPrefix(entry.lower, q) {
+			matches = append(matches, match{entry: entry, prefix: true})
+			continue
+		}
+		for _, start := range entry.wordStarts[1:] {
+			if strings.HasPrefix(entry.lower[start:], q) {
+				matches = append(matches, match{entry: entry})
+				break
+			}
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].prefix != matches[b].prefix {
+			return matches[a].prefix
+		}
+		return lessSuggestion(matches[a].entry.suggestion, matches[b].entry.suggestion)
+	})
+
+	resp := dto.SuggestResponse{Data: make([]dto.Suggestion, 0, min(len(matches), n))}
+	for _, m := range matches {
+		if len(resp.Data) == n {
+			break
+		}
+		resp.Data = append(resp.Data, m.entry.suggestion)
+	}
+	return resp, nil
+}
+
+// Refresh rebuilds the suggestion cache from the database.
+func (s *SuggestService) Refresh(ctx context.Context) error {
+	entries, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.entries = entries
+	s.loadedAt = s.now()
+	s.mu.Unlock()
+	return nil
+}
+
+// snapshot returns the cached entries. The first call loads them; afterwards a stale cache
+// is still served while a single background refresh replaces it.
+func (s *SuggestService) snapshot(ctx context.Context) ([]suggestEntry, error) {
+	s.mu.RLock()
+	entries, loadedAt, refreshing := s.entries, s.loadedAt, s.refreshing
+	s.mu.RUnlock()
+
+	if loadedAt.IsZero() {
+		if err := s.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.entries, nil
+	}
+	if !refreshing && s.now().Sub(loadedAt) >= s.refresh {
+		s.mu.Lock()
+		if !s.refreshing {
+			s.refreshing = true
+			go s.refreshInBackground()
+		}
+		s.mu.Unlock()
+	}
+	return entries, nil
+}
+
+func (s *SuggestService) refreshInBackground() {
+	ctx := context.Background()
+	defer func() {
+		s.mu.Lock()
+		s.refreshing = false
+		s.mu.Unlock()
+	}()
+	if err := s.Refresh(ctx); err != nil {
+		logger.Error(ctx, "Failed to refresh search suggestions", "error", err.Error())
+	}
+}
+
+func (s *SuggestService) load(ctx context.Context) ([]suggestEntry, error) {
+	db := s.db.WithContext(ctx)
+	published := storeservice.StoreStatusPublished
+
+	var stores []model.Store
+	if err := db.Select("id", "name", "review_count").
+		Where("status = ?", published).
+		Order("review_count DESC").Order("id ASC").
+		Limit(maxCachedStores).
+		Find(&stores).Error; err != nil {
+		return nil, err
+	}
+
+	var categories []struct {
+		ID         int64
+		Name       string
+		Popularity int64
+	}
+	if err := db.Table("categories AS c").
+		Select("c.id, c.name, COALESCE(SUM(s.review_count), 0) AS popularity").
+		Joins("LEFT JOIN store_categories sc ON sc.category_id = c.id").
+		Joins("LEFT JOIN stores s ON s.id = sc.store_id AND s.status = ? AND s.deleted_at IS NULL", published).
+		Group("c.id, c.name").
+		Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	var tags []model.Tag
+	if err := db.Select("id", "name", "review_count").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var cities []struct {
+		City       string
+		Popularity int64
+	}
+	if err := db.Model(&model.Store{}).
+		Select("city, COALESCE(SUM(review_count), 0) AS popularity").
+		Where("status = ? AND city <> ''", published).
+		Group("city").
+		Scan(&cities).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]suggestEntry, 0, len(stores)+len(categories)+len(tags)+len(cities))
+	add := func(typ, text string, id *int64, popularity int64) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		suggestion := dto.Suggestion{Type: typ, Text: text, Popularity: popularity}
+		if id != nil {
+			value := fmt.Sprintf("%d", *id)
+			suggestion.ID = &value
+		}
+		entries = append(entries, newSuggestEntry(suggestion))
+	}
+	for _, store := range stores {
+		add(dto.SuggestStore, store.Name, &store.ID, int64(store.ReviewCount))
+	}
+	for _, category := range categories {
+		add(dto.SuggestCategory, category.Name, &category.ID, category.Popularity)
+	}
+	for _, tag := range tags {
+		add(dto.SuggestTag, tag.Name, &tag.ID, int64(tag.ReviewCount))
+	}
+	for _, city := range cities {
+		add(dto.SuggestCity, city.City, nil, city.Popularity)
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		return lessSuggestion(entries[a].suggestion, entries[b].suggestion)
+	})
+	return entries, nil
+}
+
+func newSuggestEntry(suggestion dto.Suggestion) suggestEntry {
+	lower := strings.ToLower(suggestion.Text)
+	var starts []int
+	inWord := false
+	for i, r := range lower {
+		isWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		if isWord && !inWord {
+			starts = append(starts, i)
+		}
+		inWord = isWord
+	}
+	if len(starts) == 0 || starts[0] != 0 {
+		starts = append([]int{0}, starts...)
+	}
+	return suggestEntry{suggestion: suggestion, lower: lower, wordStarts: starts}
+}
+
+func lessSuggestion(a, b dto.Suggestion) bool {
+	if a.Popularity != b.Popularity {
+		return a.Popularity > b.Popularity
+	}
+	if a.Type != b.Type {
+		return suggestTypeOrder[a.Type] < suggestTypeOrder[b.Type]
+	}
+	return a.Text < b.Text
+}
+
+// RecordSearch remembers a query for userID, moving a repeated query to the top and keeping
+// only the most recent ones.
+func (s *SuggestService) RecordSearch(ctx context.Context, userID int64, query string) error {
+	query = strings.Join(strings.Fields(query), " ")
+	if userID == 0 || query == "" || len(query) > maxQueryLength {
+		return nil
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entry := model.RecentSearch{UserID: userID, Query: query, SearchedAt: s.now()}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "query"}},
+			DoUpdates: clause.AssignmentColumns([]string{"searched_at"}),
+		}).Create(&entry).Error; err != nil {
+			return err
+		}
+		keep := tx.Model(&model.RecentSearch{}).Select("id").
+			Where("user_id = ?", userID).
+			Order("searched_at DESC").Order("id DESC").
+			Limit(s.recentLimit)
+		return tx.Where("user_id = ? AND id NOT IN (?)", userID, keep).Delete(&model.RecentSearch{}).Error
+	})
+}
+
+// ClearRecent forgets all of userID's recent searches.
+func (s *SuggestService) ClearRecent(ctx context.Context, userID int64) error {
+	return s.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.RecentSearch{}).Error
+}
+
+func (s *SuggestService) recent(ctx context.Context, userID int64, limit int) (dto.SuggestResponse, error) {
+	resp := dto.SuggestResponse{Data: []dto.Suggestion{}}
+	if userID == 0 {
+		return resp, nil
+	}
+	var rows []model.RecentSearch
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("searched_at DESC").Order("id DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return dto.SuggestResponse{}, err
+	}
+	for _, row := range rows {
+		resp.Data = append(resp.Data, dto.Suggestion{Type: dto.SuggestRecent, Text: row.Query})
+	}
+	return resp, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search/dto"
+	storeservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/service"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func seedSuggestions(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	merchant := model.Merchant{Name: "Owner"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	stores := []model.Store{
+		{MerchantID: merchant.ID, Name: "Pizza Palace", City: "Paris", ReviewCount: 40, Status: storeservice.StoreStatusPublished},
+		{MerchantID: merchant.ID, Name: "Joe's Pizza", City: "Pasadena", ReviewCount: 90, Status: storeservice.StoreStatusPublished},
+		{MerchantID: merchant.ID, Name: "Pizza Draft", City: "Pisa", ReviewCount: 500},
+	}
+	if err := db.Create(&stores).Error; err != nil {
+		t.Fatalf("failed to create stores: %v", err)
+	}
+	category := model.Category{Name: "Pizzeria"}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	for _, store := range stores {
+		if err := db.Create(&model.StoreCategory{StoreID: store.ID, CategoryID: category.ID}).Error; err != nil {
+			t.Fatalf("failed to link category: %v", err)
+		}
+	}
+	if err := db.Create(&model.Tag{Name: "pizza night", ReviewCount: 60}).Error; err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+}
+
+func suggestionTexts(resp dto.SuggestResponse) []string {
+	texts := make([]string, 0, len(resp.Data))
+	for _, s := range resp.Data {
+		texts = append(texts, s.Type+":"+s.Text)
+	}
+	return texts
+}
+
+func TestSuggestRanksPrefixMatchesByPopularity(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	seedSuggestions(t, db)
+	svc := NewSuggestService(db, config.SearchConfig{})
+
+	resp, err := svc.Suggest(context.Background(), 0, "  PIZ", nil)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	// Whole-text prefixes first (category 130 reviews, tag 60, store 40), then word matches.
+	want := []string{"category:Pizzeria", "tag:pizza night", "store:Pizza Palace", "store:Joe's Pizza"}
+	got := suggestionTexts(resp)
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if resp.Data[0].Popularity != 130 || resp.Data[0].ID == nil {
+		t.Fatalf("unexpected category suggestion %+v", resp.Data[0])
+	}
+
+	cities, err := svc.Suggest(context.Background(), 0, "pa", nil)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if got := suggestionTexts(cities); len(got) != 3 || got[0] != "city:Pasadena" || got[1] != "city:Paris" || got[2] != "store:Pizza Palace" {
+		t.Fatalf("unexpected city suggestions %v", got)
+	}
+}
+
+func TestSuggestServesCacheUntilRefreshed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	seedSuggestions(t, db)
+	svc := NewSuggestService(db, config.SearchConfig{SuggestRefreshSeconds: 3600})
+	ctx := context.Background()
+
+	if _, err := svc.Suggest(ctx, 0, "bur", nil); err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if err := db.Create(&model.Tag{Name: "burgers", ReviewCount: 5}).Error; err != nil {
+		t.Fatalf("failed to create tag: %v", err)
+	}
+	resp, err := svc.Suggest(ctx, 0, "bur", nil)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if len(resp.Data) != 0 {
+		t.Fatalf("expected cached empty result, got %v", suggestionTexts(resp))
+	}
+
+	if err := svc.Refresh(ctx); err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+	resp, err = svc.Suggest(ctx, 0, "bur", nil)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if got := suggestionTexts(resp); len(got) != 1 || got[0] != "tag:burgers" {
+		t.Fatalf("expected refreshed tag, got %v", got)
+	}
+}
+
+func TestRecentSearchesReturnedForEmptyQuery(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewSuggestService(db, config.SearchConfig{RecentLimit: 2})
+	ctx := context.Background()
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return clock }
+
+	for _, q := range []string{"ramen", "tacos", "  ramen ", "sushi  bar"} {
+		clock = clock.Add(time.Minute)
+		if err := svc.RecordSearch(ctx, 7, q); err != nil {
+			t.Fatalf("record failed: %v", err)
+		}
+	}
+	if err := svc.RecordSearch(ctx, 8, "pho"); err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+
+	resp, err := svc.Suggest(ctx, 7, "", nil)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if got := suggestionTexts(resp); len(got) != 2 || got[0] != "recent:sushi bar" || got[1] != "recent:ramen" {
+		t.Fatalf("unexpected recent searches %v", got)
+	}
+	var stored int64
+	db.Model(&model.RecentSearch{}).Where("user_id = ?", 7).Count(&stored)
+	if stored != 2 {
+		t.Fatalf("expected history trimmed to 2, got %d", stored)
+	}
+
+	anonymous, err := svc.Suggest(ctx, 0, "", nil)
+	if err != nil || len(anonymous.Data) != 0 {
+		t.Fatalf("expected no recent searches for anonymous caller, got %v (%v)", anonymous.Data, err)
+	}
+
+	if err := svc.ClearRecent(ctx, 7); err != nil {
+		t.Fatalf("clear failed: %v", err)
+	}
+	resp, _ = svc.Suggest(ctx, 7, "", nil)
+	if len(resp.Data) != 0 {
+		t.Fatalf("expected cleared history, got %v", suggestionTexts(resp))
+	}
+}
@@ -9,6 +9,7 @@ type CreateStoreRequest struct {
 	State         string             `json:"state" binding:"max=100"`
 	ZipCode       string             `json:"zip_code" binding:"max=20"`
 	Country       string             `json:"country" binding:"max=50"`
+	Timezone      string             `json:"timezone" binding:"max=64"`
 	Phone         string             `json:"phone" binding:"max=50"`
 	Website       string             `json:"website" binding:"max=255"`
 	Latitude      float64            `json:"latitude"`
@@ -17,6 +18,7 @@ type CreateStoreRequest struct {
 	Images        []string           `json:"images"`
 	MenuImages    []string           `json:"menu_images"`
 	Hours         []StoreHourRequest `json:"hours"`
+	SpecialHours  []DateHourRequest  `json:"special_hours"`
 	CategoryIDs   []int64            `json:"category_ids"`
 }
 
@@ -29,6 +31,7 @@ type UpdateStoreRequest struct {
 	State         *string             `json:"state" binding:"omitempty,max=100"`
 	ZipCode       *string             `json:"zip_code" binding:"omitempty,max=20"`
 	Country       *string             `json:"country" binding:"omitempty,max=50"`
+	Timezone      *string             `json:"timezone" binding:"omitempty,max=64"`
 	Phone         *string             `json:"phone" binding:"omitempty,max=50"`
 	Website       *string             `json:"website" binding:"omitempty,max=255"`
 	Latitude      *float64            `json:"latitude"`
@@ -37,9 +40,12 @@ type UpdateStoreRequest struct {
 	Images        *[]string           `json:"images"`
 	MenuImages    *[]string           `json:"menu_images"`
 	Hours         *[]StoreHourRequest `json:"hours"`
+	SpecialHours  *[]DateHourRequest  `json:"special_hours"`
 	CategoryIDs   *[]int64            `json:"category_ids"`
 }
 
+// StoreHourRequest is one opening interval on a weekday (0 = Sunday). Times are HH:MM in
+// the store's timezone; a close time before the open time runs past midnight.
 type StoreHourRequest struct {
 	DayOfWeek int16  `json:"day_of_week"`
 	OpenTime  string `json:"open_time" binding:"max=10"`
@@ -47,19 +53,47 @@ type StoreHourRequest struct {
 	IsClosed  bool   `json:"is_closed"`
 }
 
+// DateHourRequest overrides the weekly hours on a local date (YYYY-MM-DD).
+type DateHourRequest struct {
+	Date      string `json:"date" binding:"required,max=10"`
+	OpenTime  string `json:"open_time" binding:"max=10"`
+	CloseTime string `json:"close_time" binding:"max=10"`
+	IsClosed  bool   `json:"is_closed"`
+	Note      string `json:"note" binding:"max=100"`
+}
+
 // StoreListQuery controls public store list filters.
 type StoreListQuery struct {
-	Category *string  // category name or id
-	Lat      *float64 // latitude for proximity filter
-	Lng      *float64 // longitude for proximity filter
-	Rating   *float32 // min average rating
-	RadiusKM *float64 // optional radius in KM for location filter
-	Cursor   *int64   // id cursor for pagination (id < cursor)
-	Limit    *int     // max rows
+	Category    *string  // category name or id
+	CategoryIDs []int64  // only stores in any of these categories
+	Lat         *float64 // latitude for proximity filter
+	Lng         *float64 // longitude for proximity filter
+	Rating      *float32 // min average rating
+	RadiusKM    *float64 // optional radius in KM for location filter
+	Sort        string   // "newest" (default), "distance", "rating" or "popularity"
+	OpenNow     bool     // only stores open at request time
+	Cursor      string   // opaque cursor returned with the previous page
+	Limit       *int     // max rows
 }
 
+// Store list sort orders. Distance sorting needs lat and lng.
+const (
+	StoreSortNewest     = "newest"
+	StoreSortDistance   = "distance"
+	StoreSortRating     = "rating"
+	StoreSortPopularity = "popularity"
+)
+
 // StoreReviewListQuery controls public store reviews pagination.
 type StoreReviewListQuery struct {
-	Cursor *int64 // id cursor for pagination (id < cursor)
-	Limit  *int   // max rows
+	Cursor       string // opaque cursor returned by the previous page
+	Limit        *int   // max rows
+	VerifiedOnly bool   // only reviews backed by a redeemed voucher
+	Sort         string // "recent" (default) or "ranked"
 }
+
+// Store review sort orders.
+const (
+	ReviewSortRecent = "recent"
+	ReviewSortRanked = "ranked"
+)
@@ -24,7 +24,7 @@ func NewStoreHandler(svc *service.StoreService) *StoreHandler {
 
 // ListStores godoc
 // @Summary List stores
-// @Description Returns a list of stores
+// @Description Returns a list of published stores. With lat and lng, only stores within radius_km are returned, each with distance_km.
 // @Tags store
 // @Produce json
 // @Param category query string false "Category name or ID"
@@ -32,7 +32,9 @@ func NewStoreHandler(svc *service.StoreService) *StoreHandler {
 // @Param lng query number false "Longitude"
 // @Param rating query number false "Minimum average rating"
 // @Param radius_km query number false "Search radius in KM (default 20)"
-// @Param cursor query int false "Cursor for pagination (store id)"
+// @Param sort query string false "newest (default), distance (needs lat and lng), rating or popularity"
+// @Param open_now query bool false "Only stores open right now in their own timezone; pages may then be short or empty while a cursor is returned"
+// @Param cursor query string false "Cursor returned with the previous page"
 // @Param limit query int false "Page size (max 100)"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
@@ -47,6 +49,12 @@ func (h *StoreHandler) List(c *gin.Context) {
 
 	stores, cursor, err := h.svc.ListPublishedFiltered(c.Request.Context(), query)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidSort) ||
+			errors.Is(err, service.ErrLocationRequired) ||
+			errors.Is(err, service.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list stores"})
 		return
 	}
@@ -55,7 +63,7 @@ func (h *StoreHandler) List(c *gin.Context) {
 
 // StoreDetail godoc
 // @Summary Get store detail
-// @Description Returns a store by ID
+// @Description Returns a store by ID, with is_open and next_change_at computed from its hours when it has any
 // @Tags store
 // @Produce json
 // @Param id path int true "Store ID"
@@ -87,8 +95,10 @@ func (h *StoreHandler) Detail(c *gin.Context) {
 // @Tags store
 // @Produce json
 // @Param id path int true "Store ID"
-// @Param cursor query int false "Cursor for pagination (review id)"
+// @Param cursor query string false "Cursor returned with the previous page"
 // @Param limit query int false "Page size (max 100)"
+// @Param verified query bool false "Only reviews with a verified visit"
+// @Param sort query string false "recent (default) or ranked (likes, verified visits weighted higher)"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
@@ -105,12 +115,16 @@ func (h *StoreHandler) Reviews(c *gin.Context) {
 		return
 	}
 
-	reviews, cursor, err := h.svc.ReviewsPublishedPaginated(c.Request.Context(), id, query)
+	reviews, cursor, err := h.svc.ReviewsPublishedPaginated(c.Request.Context(), c.GetInt64("user_id"), id, query)
 	if err != nil {
 		if errors.Is(err, service.ErrStoreNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 			return
 		}
+		if errors.Is(err, service.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load reviews"})
 		return
 	}
@@ -119,7 +133,7 @@ func (h *StoreHandler) Reviews(c *gin.Context) {
 
 // StoreHours godoc
 // @Summary Get store hours
-// @Description Returns operating hours for a store
+// @Description Returns a store's weekly operating hours (day_of_week 0 = Sunday, times in the store's timezone) and upcoming special hours that override them
 // @Tags store
 // @Produce json
 // @Param id path int true "Store ID"
@@ -133,7 +147,7 @@ func (h *StoreHandler) Hours(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid store id"})
 		return
 	}
-	hours, err := h.svc.HoursPublished(c.Request.Context(), id)
+	hours, special, err := h.svc.HoursPublished(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, service.ErrStoreNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
@@ -142,7 +156,7 @@ func (h *StoreHandler) Hours(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load hours"})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": hours})
+	c.JSON(http.StatusOK, gin.H{"data": hours, "special_hours": special})
 }
 
 // ListMerchantStores godoc
@@ -221,6 +235,10 @@ func (h *StoreHandler) Create(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ids"})
 			return
 		}
+		if errors.Is(err, service.ErrInvalidTimezone) || errors.Is(err, service.ErrInvalidHours) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create store"})
 		return
 	}
@@ -274,6 +292,8 @@ func (h *StoreHandler) Update(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		case errors.Is(err, service.ErrCategoryNotFound):
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ids"})
+		case errors.Is(err, service.ErrInvalidTimezone), errors.Is(err, service.ErrInvalidHours):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update store"})
 		}
@@ -445,14 +465,18 @@ func parseStoreListQuery(c *gin.Context) (dto.StoreListQuery, error) {
 		q.RadiusKM = &radiusKM
 	}
 
-	cursor, hasCursor, err := parseInt64Query(c, "cursor")
-	if err != nil {
-		return q, err
-	}
-	if hasCursor {
-		q.Cursor = &cursor
+	q.Sort = c.Query("sort")
+
+	if raw := c.Query("open_now"); raw != "" {
+		openNow, err := strconv.ParseBool(raw)
+		if err != nil {
+			return q, errors.New("invalid open_now")
+		}
+		q.OpenNow = openNow
 	}
 
+	q.Cursor = c.Query("cursor")
+
 	limit, hasLimit, err := parseIntQuery(c, "limit")
 	if err != nil {
 		return q, err
@@ -467,13 +491,7 @@ func parseStoreListQuery(c *gin.Context) (dto.StoreListQuery, error) {
 func parseStoreReviewListQuery(c *gin.Context) (dto.StoreReviewListQuery, error) {
 	var q dto.StoreReviewListQuery
 
-	cursor, hasCursor, err := parseInt64Query(c, "cursor")
-	if err != nil {
-		return q, err
-	}
-	if hasCursor {
-		q.Cursor = &cursor
-	}
+	q.Cursor = c.Query("cursor")
 
 	limit, hasLimit, err := parseIntQuery(c, "limit")
 	if err != nil {
@@ -482,27 +500,30 @@ func parseStoreReviewListQuery(c *gin.Context) (dto.StoreReviewListQuery, error)
 	if hasLimit {
 		q.Limit = &limit
 	}
-	return q, nil
-}
 
-func parseIntQuery(c *gin.Context, key string) (int, bool, error) {
-	raw := c.Query(key)
-	if raw == "" {
-		return 0, false, nil
+	if raw := c.Query("verified"); raw != "" {
+		verified, err := strconv.ParseBool(raw)
+		if err != nil {
+			return q, errors.New("invalid verified")
+		}
+		q.VerifiedOnly = verified
 	}
-	parsed, err := strconv.Atoi(raw)
-	if err != nil {
-		return 0, false, errors.New("invalid " + key)
+
+	switch sort := c.Query("sort"); sort {
+	case "", dto.ReviewSortRecent, dto.ReviewSortRanked:
+		q.Sort = sort
+	default:
+		return q, errors.New("invalid sort")
 	}
-	return parsed, true, nil
+	return q, nil
 }
 
-func parseInt64Query(c *gin.Context, key string) (int64, bool, error) {
+func parseIntQuery(c *gin.Context, key string) (int, bool, error) {
 	raw := c.Query(key)
 	if raw == "" {
 		return 0, false, nil
 	}
-	parsed, err := strconv.ParseInt(raw, 10, 64)
+	parsed, err := strconv.Atoi(raw)
 	if err != nil {
 		return 0, false, errors.New("invalid " + key)
 	}
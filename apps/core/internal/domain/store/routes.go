@@ -17,7 +17,7 @@ func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
 	{
 		stores.GET("", h.List)
 		stores.GET("/:id", h.Detail)
-		stores.GET("/:id/reviews", h.Reviews)
+		stores.GET("/:id/reviews", middleware.OptionalJWTAuth(cfg.JWT), h.Reviews)
 		stores.GET("/:id/hours", h.Hours)
 	}
 
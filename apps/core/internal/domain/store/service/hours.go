@@ -0,0 +1,328 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the IANA database so store timezones resolve on hosts without zoneinfo files.
+	_ "time/tzdata"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+	// specialHourDateLayout is the local date format of StoreSpecialHour.Date.
+	specialHourDateLayout = "2006-01-02"
+	// scheduleLookaheadDays bounds how far ahead next_change_at is searched.
+	scheduleLookaheadDays = 14
+)
+
+var ErrInvalidTimezone = errors.New("invalid timezone")
+var ErrInvalidHours = errors.New("invalid store hours")
+
+// normalizeTimezone validates an IANA timezone name; empty means UTC.
+func normalizeTimezone(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "UTC", nil
+	}
+	if name == "Local" {
+		return "", ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", ErrInvalidTimezone
+	}
+	return name, nil
+}
+
+// parseClock parses HH:MM into minutes after midnight. 24:00 is only valid as a close time.
+func parseClock(value string, closing bool) (int, error) {
+	if len(value) != 5 || value[2] != ':' {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidHours, value)
+	}
+	hour, errHour := strconv.Atoi(value[:2])
+	minute, errMinute := strconv.Atoi(value[3:])
+	if errHour != nil || errMinute != nil || hour < 0 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("%w: time %q must be HH:MM", ErrInvalidHours, value)
+	}
+	if hour > 23 && !(closing && hour == 24 && minute == 0) {
+		return 0, fmt.Errorf("%w: time %q is out of range", ErrInvalidHours, value)
+	}
+	return hour*60 + minute, nil
+}
+
+// parseInterval returns an interval's bounds in minutes after the opening day's midnight;
+// a close before the open runs into the next day.
+func parseInterval(openTime, closeTime string) (int, int, error) {
+	open, err := parseClock(openTime, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	closing, err := parseClock(closeTime, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	if closing == open {
+		return 0, 0, fmt.Errorf("%w: %s-%s is empty", ErrInvalidHours, openTime, closeTime)
+	}
+	if closing < open {
+		closing += minutesPerDay
+	}
+	return open, closing, nil
+}
+
+type minuteSpan struct {
+	start, end int
+}
+
+// checkOverlaps rejects spans that overlap. With a period, spans running past it wrap to
+// the start (the end of Saturday night runs into Sunday morning).
+func checkOverlaps(spans []minuteSpan, period int) error {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	for i := 1; i < len(spans); i++ {
+		if spans[i].start < spans[i-1].end {
+			return fmt.Errorf("%w: intervals overlap", ErrInvalidHours)
+		}
+	}
+	if period > 0 && len(spans) > 1 && spans[len(spans)-1].end-period > spans[0].start {
+		return fmt.Errorf("%w: intervals overlap", ErrInvalidHours)
+	}
+	return nil
+}
+
+// buildStoreHours validates weekly hours and converts them to rows. A day may have several
+// intervals, or a single closed entry.
+func buildStoreHours(storeID int64, req []dto.StoreHourRequest) ([]model.StoreHour, error) {
+	hours := make([]model.StoreHour, 0, len(req))
+	spans := make([]minuteSpan, 0, len(req))
+	closed := make(map[int16]bool)
+	open := make(map[int16]bool)
+	for _, h := range req {
+		if h.DayOfWeek < 0 || h.DayOfWeek > 6 {
+			return nil, fmt.Errorf("%w: day_of_week must be 0 (Sunday) to 6", ErrInvalidHours)
+		}
+		hour := model.StoreHour{StoreID: storeID, DayOfWeek: h.DayOfWeek, IsClosed: h.IsClosed}
+		if h.IsClosed {
+			closed[h.DayOfWeek] = true
+		} else {
+			start, end, err := parseInterval(h.OpenTime, h.CloseTime)
+			if err != nil {
+				return nil, err
+			}
+			open[h.DayOfWeek] = true
+			base := int(h.DayOfWeek) * minutesPerDay
+			spans = append(spans, minuteSpan{start: base + start, end: base + end})
+			hour.OpenTime, hour.CloseTime = h.OpenTime, h.CloseTime
+		}
+		hours = append(hours, hour)
+	}
+	for day := range closed {
+		if open[day] {
+			return nil, fmt.Errorf("%w: day %d is both closed and open", ErrInvalidHours, day)
+		}
+	}
+	if err := checkOverlaps(spans, minutesPerWeek); err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+// buildSpecialHours validates date overrides and converts them to rows.
+func buildSpecialHours(storeID int64, req []dto.DateHourRequest) ([]model.StoreSpecialHour, error) {
+	special := make([]model.StoreSpecialHour, 0, len(req))
+	spans := make([]minuteSpan, 0, len(req))
+	closed := make(map[string]bool)
+	open := make(map[string]bool)
+	for _, h := range req {
+		date, err := time.Parse(specialHourDateLayout, h.Date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: date %q must be YYYY-MM-DD", ErrInvalidHours, h.Date)
+		}
+		entry := model.StoreSpecialHour{StoreID: storeID, Date: h.Date, IsClosed: h.IsClosed, Note: strings.TrimSpace(h.Note)}
+		if h.IsClosed {
+			closed[h.Date] = true
+		} else {
+			start, end, err := parseInterval(h.OpenTime, h.CloseTime)
+			if err != nil {
+				return nil, err
+			}
+			open[h.Date] = true
+			base := int(date.Unix()/86400) * minutesPerDay
+			spans = append(spans, minuteSpan{start: base + start, end: base + end})
+			entry.OpenTime, entry.CloseTime = h.OpenTime, h.CloseTime
+		}
+		special = append(special, entry)
+	}
+	for date := range closed {
+		if open[date] {
+			return nil, fmt.Errorf("%w: %s is both closed and open", ErrInvalidHours, date)
+		}
+	}
+	if err := checkOverlaps(spans, 0); err != nil {
+		return nil, err
+	}
+	return special, nil
+}
+
+type openInterval struct {
+	start, end time.Time
+}
+
+// storeSchedule resolves weekly hours and date overrides into concrete open intervals in
+// the store's timezone. Hours that do not parse (legacy free-form rows) are ignored.
+type storeSchedule struct {
+	loc     *time.Location
+	weekly  map[time.Weekday][]model.StoreHour
+	special map[string][]model.StoreSpecialHour
+}
+
+func newStoreSchedule(timezone string, hours []model.StoreHour, special []model.StoreSpecialHour) storeSchedule {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		loc = time.UTC
+	}
+	sc := storeSchedule{
+		loc:     loc,
+		weekly:  make(map[time.Weekday][]model.StoreHour),
+		special: make(map[string][]model.StoreSpecialHour),
+	}
+	for _, h := range hours {
+		sc.weekly[time.Weekday(h.DayOfWeek)] = append(sc.weekly[time.Weekday(h.DayOfWeek)], h)
+	}
+	for _, h := range special {
+		sc.special[h.Date] = append(sc.special[h.Date], h)
+	}
+	return sc
+}
+
+// known reports whether there are any hours to go on.
+func (sc storeSchedule) known() bool {
+	return len(sc.weekly) > 0 || len(sc.special) > 0
+}
+
+// intervalsOn returns the intervals opening on the local date of day; a date override
+// replaces that date's weekly hours.
+func (sc storeSchedule) intervalsOn(day time.Time) []openInterval {
+	y, m, d := day.Date()
+	var intervals []openInterval
+	add := func(openTime, closeTime string, isClosed bool) {
+		if isClosed {
+			return
+		}
+		start, end, err := parseInterval(openTime, closeTime)
+		if err != nil {
+			return
+		}
+		intervals = append(intervals, openInterval{
+			start: time.Date(y, m, d, 0, start, 0, 0, sc.loc),
+			end:   time.Date(y, m, d, 0, end, 0, 0, sc.loc),
+		})
+	}
+	if special, ok := sc.special[day.Format(specialHourDateLayout)]; ok {
+		for _, h := range special {
+			add(h.OpenTime, h.CloseTime, h.IsClosed)
+		}
+		return intervals
+	}
+	for _, h := range sc.weekly[day.Weekday()] {
+		add(h.OpenTime, h.CloseTime, h.IsClosed)
+	}
+	return intervals
+}
+
+// status reports whether the store is open at now and when that next changes. The change
+// is nil when nothing changes within scheduleLookaheadDays.
+func (sc storeSchedule) status(now time.Time) (bool, *time.Time) {
+	y, m, d := now.In(sc.loc).Date()
+	var intervals []openInterval
+	// Start a day early to catch intervals running past midnight into today.
+	for offset := -1; offset <= scheduleLookaheadDays; offset++ {
+		intervals = append(intervals, sc.intervalsOn(time.Date(y, m, d+offset, 0, 0, 0, 0, sc.loc))...)
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+
+	horizon := time.Date(y, m, d+scheduleLookaheadDays+1, 0, 0, 0, 0, sc.loc)
+	for i := 0; i < len(intervals); i++ {
+		current := intervals[i]
+		// Back-to-back and overlapping intervals are one opening.
+		for i+1 < len(intervals) && !intervals[i+1].start.After(current.end) {
+			i++
+			if intervals[i].end.After(current.end) {
+				current.end = intervals[i].end
+			}
+		}
+		if !current.end.After(now) {
+			continue
+		}
+		if current.start.After(now) {
+			return false, &current.start
+		}
+		if current.end.Before(horizon) {
+			return true, &current.end
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// loadStoreSchedules loads the hours of stores, with date overrides from the day before now
+// to the end of the lookahead in any timezone.
+func loadStoreSchedules(db *gorm.DB, stores []model.Store, now time.Time) (map[int64]storeSchedule, error) {
+	schedules := make(map[int64]storeSchedule, len(stores))
+	if len(stores) == 0 {
+		return schedules, nil
+	}
+	ids := make([]int64, 0, len(stores))
+	for _, store := range stores {
+		ids = append(ids, store.ID)
+	}
+
+	var hours []model.StoreHour
+	if err := db.Where("store_id IN ?", ids).Order("day_of_week asc").Order("open_time asc").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	from := now.UTC().AddDate(0, 0, -2).Format(specialHourDateLayout)
+	to := now.UTC().AddDate(0, 0, scheduleLookaheadDays+2).Format(specialHourDateLayout)
+	var special []model.StoreSpecialHour
+	if err := db.Where("store_id IN ? AND date BETWEEN ? AND ?", ids, from, to).Find(&special).Error; err != nil {
+		return nil, err
+	}
+
+	hoursByStore := make(map[int64][]model.StoreHour)
+	for _, h := range hours {
+		hoursByStore[h.StoreID] = append(hoursByStore[h.StoreID], h)
+	}
+	specialByStore := make(map[int64][]model.StoreSpecialHour)
+	for _, h := range special {
+		specialByStore[h.StoreID] = append(specialByStore[h.StoreID], h)
+	}
+	for _, store := range stores {
+		schedules[store.ID] = newStoreSchedule(store.Timezone, hoursByStore[store.ID], specialByStore[store.ID])
+	}
+	return schedules, nil
+}
+
+// setOpenStatus fills IsOpen and NextChangeAt on stores whose hours are known.
+func setOpenStatus(db *gorm.DB, stores []model.Store, now time.Time) error {
+	schedules, err := loadStoreSchedules(db, stores, now)
+	if err != nil {
+		return err
+	}
+	for i := range stores {
+		schedule := schedules[stores[i].ID]
+		if !schedule.known() {
+			continue
+		}
+		isOpen, next := schedule.status(now)
+		stores[i].IsOpen = &isOpen
+		stores[i].NextChangeAt = next
+	}
+	return nil
+}
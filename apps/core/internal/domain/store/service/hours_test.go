@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+)
+
+func TestBuildStoreHoursValidation(t *testing.T) {
+	valid := []dto.StoreHourRequest{
+		{DayOfWeek: 1, OpenTime: "09:00", CloseTime: "12:00"},
+		{DayOfWeek: 1, OpenTime: "13:00", CloseTime: "17:00"},
+		{DayOfWeek: 5, OpenTime: "22:00", CloseTime: "02:00"},
+		{DayOfWeek: 6, OpenTime: "10:00", CloseTime: "24:00"},
+		{DayOfWeek: 0, IsClosed: true, OpenTime: "ignored"},
+	}
+	hours, err := buildStoreHours(7, valid)
+	if err != nil {
+		t.Fatalf("expected valid hours, got %v", err)
+	}
+	if len(hours) != 5 || hours[4].OpenTime != "" || hours[0].StoreID != 7 {
+		t.Fatalf("unexpected hours %+v", hours)
+	}
+
+	invalid := map[string][]dto.StoreHourRequest{
+		"bad format":       {{DayOfWeek: 1, OpenTime: "9:00", CloseTime: "17:00"}},
+		"open at 24:00":    {{DayOfWeek: 1, OpenTime: "24:00", CloseTime: "02:00"}},
+		"bad minute":       {{DayOfWeek: 1, OpenTime: "09:60", CloseTime: "17:00"}},
+		"empty interval":   {{DayOfWeek: 1, OpenTime: "09:00", CloseTime: "09:00"}},
+		"day out of range": {{DayOfWeek: 7, OpenTime: "09:00", CloseTime: "17:00"}},
+		"same-day overlap": {
+			{DayOfWeek: 2, OpenTime: "09:00", CloseTime: "13:00"},
+			{DayOfWeek: 2, OpenTime: "12:00", CloseTime: "18:00"},
+		},
+		"overnight into next day": {
+			{DayOfWeek: 3, OpenTime: "20:00", CloseTime: "03:00"},
+			{DayOfWeek: 4, OpenTime: "02:00", CloseTime: "10:00"},
+		},
+		"saturday night into sunday": {
+			{DayOfWeek: 6, OpenTime: "20:00", CloseTime: "04:00"},
+			{DayOfWeek: 0, OpenTime: "03:00", CloseTime: "10:00"},
+		},
+		"closed and open": {
+			{DayOfWeek: 2, IsClosed: true},
+			{DayOfWeek: 2, OpenTime: "09:00", CloseTime: "17:00"},
+		},
+	}
+	for name, req := range invalid {
+		if _, err := buildStoreHours(1, req); !errors.Is(err, ErrInvalidHours) {
+			t.Fatalf("%s: expected ErrInvalidHours, got %v", name, err)
+		}
+	}
+
+	if _, err := buildSpecialHours(1, []dto.DateHourRequest{{Date: "2025-12-25", IsClosed: true}}); err != nil {
+		t.Fatalf("expected valid holiday, got %v", err)
+	}
+	if _, err := buildSpecialHours(1, []dto.DateHourRequest{{Date: "25/12/2025", IsClosed: true}}); !errors.Is(err, ErrInvalidHours) {
+		t.Fatalf("expected ErrInvalidHours for bad date, got %v", err)
+	}
+	if _, err := normalizeTimezone("Mars/Olympus"); !errors.Is(err, ErrInvalidTimezone) {
+		t.Fatalf("expected ErrInvalidTimezone, got %v", err)
+	}
+	if tz, err := normalizeTimezone(""); err != nil || tz != "UTC" {
+		t.Fatalf("expected UTC default, got %q (%v)", tz, err)
+	}
+}
+
+func TestStoreScheduleStatus(t *testing.T) {
+	hours := []model.StoreHour{
+		{DayOfWeek: 1, OpenTime: "09:00", CloseTime: "12:00"},
+		{DayOfWeek: 1, OpenTime: "12:00", CloseTime: "14:00"},
+		{DayOfWeek: 1, OpenTime: "15:00", CloseTime: "17:00"},
+		{DayOfWeek: 5, OpenTime: "22:00", CloseTime: "02:00"},
+	}
+	special := []model.StoreSpecialHour{{Date: "2025-03-17", IsClosed: true}}
+	sc := newStoreSchedule("America/New_York", hours, special)
+	ny, _ := time.LoadLocation("America/New_York")
+	at := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, ny) }
+
+	cases := []struct {
+		name   string
+		now    time.Time
+		open   bool
+		change time.Time
+	}{
+		// 2025-03-10 is the Monday after the US switch to daylight saving time.
+		{"back-to-back intervals merge", at(2025, 3, 10, 10, 0), true, at(2025, 3, 10, 14, 0)},
+		{"between intervals", at(2025, 3, 10, 14, 30), false, at(2025, 3, 10, 15, 0)},
+		{"overnight from friday", at(2025, 3, 15, 1, 30), true, at(2025, 3, 15, 2, 0)},
+		{"holiday skips to friday", at(2025, 3, 17, 10, 0), false, at(2025, 3, 21, 22, 0)},
+	}
+	for _, tc := range cases {
+		open, next := sc.status(tc.now.In(time.UTC))
+		if open != tc.open || next == nil || !next.Equal(tc.change) {
+			t.Fatalf("%s: got open=%v next=%v, want open=%v next=%v", tc.name, open, next, tc.open, tc.change)
+		}
+	}
+
+	if sc := newStoreSchedule("UTC", nil, nil); sc.known() {
+		t.Fatalf("expected schedule without hours to be unknown")
+	}
+}
+
+func TestStoreServiceOpenNowUsesStoreTimezones(t *testing.T) {
+	db := setupStoreTestDB(t)
+	svc := NewStoreService(db)
+	// 15:00 UTC is 11:00 in New York and midnight in Tokyo.
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC) }
+
+	merchant := model.Merchant{Name: "Hours Merchant"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	createStore := func(name, timezone string, withHours bool) model.Store {
+		store := model.Store{MerchantID: merchant.ID, Name: name, Timezone: timezone, Status: StoreStatusPublished}
+		if err := db.Create(&store).Error; err != nil {
+			t.Fatalf("failed to create store %s: %v", name, err)
+		}
+		if withHours {
+			for day := int16(0); day < 7; day++ {
+				if err := db.Create(&model.StoreHour{StoreID: store.ID, DayOfWeek: day, OpenTime: "09:00", CloseTime: "17:00"}).Error; err != nil {
+					t.Fatalf("failed to create hours: %v", err)
+				}
+			}
+		}
+		return store
+	}
+	newYorkA := createStore("NY A", "America/New_York", true)
+	tokyo := createStore("Tokyo", "Asia/Tokyo", true)
+	_ = createStore("No Hours", "UTC", false)
+	newYorkB := createStore("NY B", "America/New_York", true)
+	holiday := createStore("NY Holiday", "America/New_York", true)
+	if err := db.Create(&model.StoreSpecialHour{StoreID: holiday.ID, Date: "2025-03-10", IsClosed: true}).Error; err != nil {
+		t.Fatalf("failed to create special hours: %v", err)
+	}
+
+	limit := 1
+	query := dto.StoreListQuery{OpenNow: true, Limit: &limit}
+	var got []int64
+	for page := 0; page < 5; page++ {
+		stores, cursor, err := svc.ListPublishedFiltered(context.Background(), query)
+		if err != nil {
+			t.Fatalf("list open stores returned error: %v", err)
+		}
+		for _, store := range stores {
+			if store.IsOpen == nil || !*store.IsOpen {
+				t.Fatalf("store %s listed without being open", store.Name)
+			}
+			got = append(got, store.ID)
+		}
+		if cursor == nil {
+			break
+		}
+		query.Cursor = *cursor
+	}
+	if len(got) != 2 || got[0] != newYorkB.ID || got[1] != newYorkA.ID {
+		t.Fatalf("expected open New York stores only, got %v", got)
+	}
+
+	detail, err := svc.DetailPublished(context.Background(), tokyo.ID)
+	if err != nil {
+		t.Fatalf("detail returned error: %v", err)
+	}
+	tokyoLoc, _ := time.LoadLocation("Asia/Tokyo")
+	if detail.IsOpen == nil || *detail.IsOpen || detail.NextChangeAt == nil ||
+		!detail.NextChangeAt.Equal(time.Date(2025, 3, 11, 9, 0, 0, 0, tokyoLoc)) {
+		t.Fatalf("unexpected Tokyo status open=%v next=%v", detail.IsOpen, detail.NextChangeAt)
+	}
+
+	_, special, err := svc.HoursPublished(context.Background(), holiday.ID)
+	if err != nil || len(special) != 1 || !special[0].IsClosed {
+		t.Fatalf("expected today's holiday override, got %+v (%v)", special, err)
+	}
+}
+
+func TestStoreServiceOpenNowBoundsTheStoresScannedPerPage(t *testing.T) {
+	db := setupStoreTestDB(t)
+	svc := NewStoreService(db)
+	svc.now = func() time.Time { return time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC) }
+
+	merchant := model.Merchant{Name: "Hours Merchant"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	open := model.Store{MerchantID: merchant.ID, Name: "Open", Timezone: "UTC", Status: StoreStatusPublished}
+	if err := db.Create(&open).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	if err := db.Create(&model.StoreHour{StoreID: open.ID, DayOfWeek: 1, OpenTime: "09:00", CloseTime: "17:00"}).Error; err != nil {
+		t.Fatalf("failed to create hours: %v", err)
+	}
+	closed := make([]model.Store, maxOpenNowBatches*openNowBatchSize)
+	for i := range closed {
+		closed[i] = model.Store{MerchantID: merchant.ID, Name: "Closed", Timezone: "UTC", Status: StoreStatusPublished}
+	}
+	if err := db.CreateInBatches(&closed, 100).Error; err != nil {
+		t.Fatalf("failed to create stores: %v", err)
+	}
+
+	limit := 1
+	query := dto.StoreListQuery{OpenNow: true, Limit: &limit}
+	stores, cursor, err := svc.ListPublishedFiltered(context.Background(), query)
+	if err != nil || len(stores) != 0 || cursor == nil || *cursor != *encodeStoreCursor(dto.StoreSortNewest, closed[0]) {
+		t.Fatalf("expected an empty page resuming after the last scanned store, got %d stores, cursor %v (%v)", len(stores), cursor, err)
+	}
+	query.Cursor = *cursor
+	stores, cursor, err = svc.ListPublishedFiltered(context.Background(), query)
+	if err != nil || len(stores) != 1 || stores[0].ID != open.ID || cursor != nil {
+		t.Fatalf("expected the open store on the next page, got %+v, cursor %v (%v)", stores, cursor, err)
+	}
+}
+
+func TestStoreServiceCreateRejectsInvalidHoursAndTimezone(t *testing.T) {
+	db := setupStoreTestDB(t)
+	svc := NewStoreService(db)
+	user := model.User{Role: "merchant"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	_, err := svc.Create(context.Background(), user.ID, dto.CreateStoreRequest{
+		Hours: []dto.StoreHourRequest{{DayOfWeek: 1, OpenTime: "9am", CloseTime: "5pm"}},
+	})
+	if !errors.Is(err, ErrInvalidHours) {
+		t.Fatalf("expected ErrInvalidHours, got %v", err)
+	}
+	_, err = svc.Create(context.Background(), user.ID, dto.CreateStoreRequest{Timezone: "Nowhere/City"})
+	if !errors.Is(err, ErrInvalidTimezone) {
+		t.Fatalf("expected ErrInvalidTimezone, got %v", err)
+	}
+
+	store, err := svc.Create(context.Background(), user.ID, dto.CreateStoreRequest{
+		Timezone:     "Europe/Paris",
+		SpecialHours: []dto.DateHourRequest{{Date: "2025-12-25", IsClosed: true, Note: "Christmas"}},
+	})
+	if err != nil {
+		t.Fatalf("create returned error: %v", err)
+	}
+	var special []model.StoreSpecialHour
+	db.Where("store_id = ?", store.ID).Find(&special)
+	if store.Timezone != "Europe/Paris" || len(special) != 1 || special[0].Note != "Christmas" {
+		t.Fatalf("unexpected store %+v special %+v", store, special)
+	}
+}
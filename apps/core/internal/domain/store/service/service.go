@@ -2,21 +2,27 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/geo"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type StoreService struct {
-	db *gorm.DB
+	db  *gorm.DB
+	now func() time.Time
 }
 
 const (
@@ -29,18 +35,23 @@ const (
 	maxPublicListLimit       = 100
 	maxStoreReviewsListLimit = 100
 	defaultRadiusKM          = 20.0
+	// verifiedReviewWeight multiplies the ranking score of reviews with a verified visit.
+	verifiedReviewWeight = 2
 )
 
 var ErrUserNotFound = errors.New("user not found")
 var ErrStoreNotFound = errors.New("store not found")
+var ErrInvalidCursor = errors.New("invalid cursor")
 var ErrStoreForbidden = errors.New("store forbidden")
 var ErrCategoryNotFound = errors.New("category not found")
+var ErrInvalidSort = errors.New("invalid sort")
+var ErrLocationRequired = errors.New("lat and lng are required to sort by distance")
 
 func NewStoreService(db *gorm.DB) *StoreService {
 	if db == nil {
 		db = database.DB
 	}
-	return &StoreService{db: db}
+	return &StoreService{db: db, now: time.Now}
 }
 
 func (s *StoreService) Create(ctx context.Context, userID int64, req dto.CreateStoreRequest) (*model.Store, error) {
@@ -76,6 +87,19 @@ func (s *StoreService) Create(ctx context.Context, userID int64, req dto.CreateS
 		return nil, err
 	}
 
+	timezone, err := normalizeTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := buildStoreHours(0, req.Hours)
+	if err != nil {
+		return nil, err
+	}
+	specialHours, err := buildSpecialHours(0, req.SpecialHours)
+	if err != nil {
+		return nil, err
+	}
+
 	storeName := req.Name
 	if storeName == "" {
 		if merchant.BusinessName != "" {
@@ -96,6 +120,7 @@ func (s *StoreService) Create(ctx context.Context, userID int64, req dto.CreateS
 		State:         req.State,
 		ZipCode:       req.ZipCode,
 		Country:       req.Country,
+		Timezone:      timezone,
 		Phone:         req.Phone,
 		Website:       req.Website,
 		Latitude:      req.Latitude,
@@ -115,21 +140,22 @@ func (s *StoreService) Create(ctx context.Context, userID int64, req dto.CreateS
 		if err := tx.Create(&store).Error; err != nil {
 			return err
 		}
-		if len(req.Hours) > 0 {
-			hours := make([]model.StoreHour, 0, len(req.Hours))
-			for _, h := range req.Hours {
-				hours = append(hours, model.StoreHour{
-					StoreID:   store.ID,
-					DayOfWeek: h.DayOfWeek,
-					OpenTime:  h.OpenTime,
-					CloseTime: h.CloseTime,
-					IsClosed:  h.IsClosed,
-				})
+		if len(hours) > 0 {
+			for i := range hours {
+				hours[i].StoreID = store.ID
 			}
 			if err := tx.Create(&hours).Error; err != nil {
 				return err
 			}
 		}
+		if len(specialHours) > 0 {
+			for i := range specialHours {
+				specialHours[i].StoreID = store.ID
+			}
+			if err := tx.Create(&specialHours).Error; err != nil {
+				return err
+			}
+		}
 		if len(categoryIDs) > 0 {
 			if err := ensureCategoriesExist(tx, categoryIDs); err != nil {
 				return err
@@ -164,57 +190,282 @@ func (s *StoreService) ListPublished(ctx context.Context) ([]model.Store, error)
 	return stores, nil
 }
 
-func (s *StoreService) ListPublishedFiltered(ctx context.Context, query dto.StoreListQuery) ([]model.Store, *int64, error) {
+// ListPublishedFiltered lists published stores. With lat/lng, stores are limited to the
+// radius by great-circle distance and carry DistanceKM. Sorting is by newest, distance,
+// Bayesian rating or review count, with ties broken by id; the cursor carries the id and
+// sort value of the last store on the previous page, so later pages resume after the key
+// that store had when the page was served even if its rating has moved since. OpenNow keeps only
+// stores whose hours say they are open, and sets IsOpen and NextChangeAt on them; such a page
+// may come back short, or empty, with a cursor when few stores are open.
+func (s *StoreService) ListPublishedFiltered(ctx context.Context, query dto.StoreListQuery) ([]model.Store, *string, error) {
 	limit := sanitizeLimit(query.Limit, defaultPublicListLimit, maxPublicListLimit)
+	sortBy := query.Sort
+	if sortBy == "" {
+		sortBy = dto.StoreSortNewest
+	}
+	hasLocation := query.Lat != nil && query.Lng != nil
+	switch sortBy {
+	case dto.StoreSortNewest, dto.StoreSortRating, dto.StoreSortPopularity:
+	case dto.StoreSortDistance:
+		if !hasLocation {
+			return nil, nil, ErrLocationRequired
+		}
+	default:
+		return nil, nil, ErrInvalidSort
+	}
 
 	dbQuery := s.db.WithContext(ctx).
 		Model(&model.Store{}).
 		Where("stores.status = ?", StoreStatusPublished)
 
 	if query.Category != nil && strings.TrimSpace(*query.Category) != "" {
-		dbQuery = dbQuery.
-			Joins("JOIN store_categories sc ON sc.store_id = stores.id").
-			Joins("JOIN categories c ON c.id = sc.category_id")
-
 		category := strings.TrimSpace(*query.Category)
+		categoryMatch := "EXISTS (SELECT 1 FROM store_categories sc JOIN categories c ON c.id = sc.category_id WHERE sc.store_id = stores.id AND "
 		if categoryID, err := strconv.ParseInt(category, 10, 64); err == nil {
-			dbQuery = dbQuery.Where("c.id = ?", categoryID)
+			dbQuery = dbQuery.Where(categoryMatch+"c.id = ?)", categoryID)
 		} else {
-			dbQuery = dbQuery.Where("LOWER(c.name) = LOWER(?)", category)
+			dbQuery = dbQuery.Where(categoryMatch+"LOWER(c.name) = LOWER(?))", category)
 		}
 	}
 
+	if len(query.CategoryIDs) > 0 {
+		dbQuery = dbQuery.Where("EXISTS (SELECT 1 FROM store_categories sc WHERE sc.store_id = stores.id AND sc.category_id IN ?)", query.CategoryIDs)
+	}
+
 	if query.Rating != nil {
 		dbQuery = dbQuery.Where("stores.avg_rating >= ?", *query.Rating)
 	}
 
-	if query.Lat != nil && query.Lng != nil {
+	var anchor *model.Store
+	if query.Cursor != "" {
+		cursor, err := decodeStoreCursor(query.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		anchor = &model.Store{ID: cursor.ID}
+		switch sortBy {
+		case dto.StoreSortRating:
+			anchor.BayesianRating = float32(cursor.Value)
+		case dto.StoreSortPopularity:
+			anchor.ReviewCount = int(cursor.Value)
+		case dto.StoreSortDistance:
+			// Distance depends on the caller's position, so it is recomputed from the store.
+			if err := s.db.WithContext(ctx).Unscoped().Select("id", "latitude", "longitude").
+				First(anchor, cursor.ID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil, ErrInvalidCursor
+				}
+				return nil, nil, err
+			}
+		}
+	}
+
+	var fetch storeFetcher
+	if hasLocation {
 		radiusKM := defaultRadiusKM
 		if query.RadiusKM != nil && *query.RadiusKM > 0 {
 			radiusKM = *query.RadiusKM
 		}
-		latDelta := radiusKM / 111.0
-		lngDelta := radiusKM / 111.0
-		dbQuery = dbQuery.
-			Where("stores.latitude BETWEEN ? AND ?", *query.Lat-latDelta, *query.Lat+latDelta).
-			Where("stores.longitude BETWEEN ? AND ?", *query.Lng-lngDelta, *query.Lng+lngDelta)
+		lat, lng := *query.Lat, *query.Lng
+		dbQuery = geo.BoundingBox(lat, lng, radiusKM).Scope("stores.latitude", "stores.longitude")(dbQuery)
+		if anchor != nil {
+			distance := geo.HaversineKM(lat, lng, anchor.Latitude, anchor.Longitude)
+			anchor.DistanceKM = &distance
+		}
+		if s.db.Dialector.Name() == "postgres" {
+			fetch = sqlDistanceFetcher(dbQuery, lat, lng, radiusKM, sortBy)
+		} else {
+			fetch = memoryDistanceFetcher(dbQuery, lat, lng, radiusKM, sortBy)
+		}
+	} else {
+		fetch = sqlStoreFetcher(dbQuery, sortBy)
+	}
+
+	if !query.OpenNow {
+		stores, err := fetch(anchor, limit+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		pageItems, cursor := sliceStorePage(stores, limit, sortBy)
+		return pageItems, cursor, nil
+	}
+	return s.listOpenStores(ctx, fetch, sortBy, anchor, limit)
+}
+
+// storeFetcher returns up to n stores after anchor (from the start when nil) in list order.
+type storeFetcher func(anchor *model.Store, n int) ([]model.Store, error)
+
+// openNowBatchSize is how many stores are checked per query when filtering by open_now, and
+// maxOpenNowBatches bounds how many batches one page may scan.
+const (
+	openNowBatchSize  = 100
+	maxOpenNowBatches = 5
+)
+
+// listOpenStores pages through fetch, keeping open stores until the page is full. Opening
+// hours depend on each store's timezone and overrides, so they are checked in Go. When
+// maxOpenNowBatches are scanned without filling the page, the open stores found so far are
+// returned with the last scanned store as the cursor, so the next page resumes there.
+func (s *StoreService) listOpenStores(ctx context.Context, fetch storeFetcher, sortBy string, anchor *model.Store, limit int) ([]model.Store, *string, error) {
+	now := s.now()
+	batchSize := max(limit+1, openNowBatchSize)
+	var open []model.Store
+	for batches := 1; ; batches++ {
+		batch, err := fetch(anchor, batchSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := setOpenStatus(s.db.WithContext(ctx), batch, now); err != nil {
+			return nil, nil, err
+		}
+		for _, store := range batch {
+			if store.IsOpen != nil && *store.IsOpen {
+				open = append(open, store)
+			}
+		}
+		if len(open) > limit || len(batch) < batchSize {
+			break
+		}
+		anchor = &batch[len(batch)-1]
+		if batches == maxOpenNowBatches {
+			return open, encodeStoreCursor(sortBy, *anchor), nil
+		}
 	}
+	if len(open) > limit+1 {
+		open = open[:limit+1]
+	}
+	pageItems, cursor := sliceStorePage(open, limit, sortBy)
+	return pageItems, cursor, nil
+}
 
-	if query.Cursor != nil {
-		dbQuery = dbQuery.Where("stores.id < ?", *query.Cursor)
+// sqlStoreFetcher pages with a keyset on the sort column, then id.
+func sqlStoreFetcher(dbQuery *gorm.DB, sortBy string) storeFetcher {
+	base := dbQuery.Session(&gorm.Session{})
+	return func(anchor *model.Store, n int) ([]model.Store, error) {
+		q := base
+		switch sortBy {
+		case dto.StoreSortRating, dto.StoreSortPopularity:
+			column := "stores.bayesian_rating"
+			if sortBy == dto.StoreSortPopularity {
+				column = "stores.review_count"
+			}
+			if anchor != nil {
+				value := storeSortColumnValue(sortBy, *anchor)
+				q = q.Where("("+column+" < ? OR ("+column+" = ? AND stores.id < ?))", value, value, anchor.ID)
+			}
+			q = q.Order(column + " desc").Order("stores.id desc")
+		default:
+			if anchor != nil {
+				q = q.Where("stores.id < ?", anchor.ID)
+			}
+			q = q.Order("stores.id desc")
+		}
+		var stores []model.Store
+		if err := q.Limit(n).Find(&stores).Error; err != nil {
+			return nil, err
+		}
+		return stores, nil
 	}
+}
+
+// sqlDistanceFetcher limits stores to the radius with the haversine distance computed in
+// Postgres, and sorts by it when asked.
+func sqlDistanceFetcher(dbQuery *gorm.DB, lat, lng, radiusKM float64, sortBy string) storeFetcher {
+	distanceSQL, distanceArgs := geo.DistanceSQL("stores.latitude", "stores.longitude", lat, lng)
+	dbQuery = dbQuery.Where(distanceSQL+" <= ?", append(append([]interface{}{}, distanceArgs...), radiusKM)...)
+	byColumn := sqlStoreFetcher(dbQuery, sortBy)
+	base := dbQuery.Session(&gorm.Session{})
+
+	return func(anchor *model.Store, n int) ([]model.Store, error) {
+		var stores []model.Store
+		if sortBy != dto.StoreSortDistance {
+			fetched, err := byColumn(anchor, n)
+			if err != nil {
+				return nil, err
+			}
+			stores = fetched
+		} else {
+			q := base
+			if anchor != nil {
+				// Compare against the anchor's distance as computed by the database so the
+				// keyset matches the ORDER BY exactly.
+				var anchorDistance float64
+				if err := base.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&model.Store{}).
+					Select(distanceSQL, distanceArgs...).
+					Where("id = ?", anchor.ID).
+					Scan(&anchorDistance).Error; err != nil {
+					return nil, err
+				}
+				args := append(append(append([]interface{}{}, distanceArgs...), anchorDistance), distanceArgs...)
+				args = append(args, anchorDistance, anchor.ID)
+				q = q.Where("("+distanceSQL+" > ? OR ("+distanceSQL+" = ? AND stores.id < ?))", args...)
+			}
+			// A single expression: gorm drops an ORDER BY expression when columns are merged in.
+			q = q.Order(clause.OrderBy{Expression: clause.Expr{SQL: distanceSQL + " ASC, stores.id DESC", Vars: distanceArgs}})
+			if err := q.Limit(n).Find(&stores).Error; err != nil {
+				return nil, err
+			}
+		}
+		for i := range stores {
+			distance := geo.HaversineKM(lat, lng, stores[i].Latitude, stores[i].Longitude)
+			stores[i].DistanceKM = &distance
+		}
+		return stores, nil
+	}
+}
 
+// memoryDistanceFetcher is the pure-Go fallback for databases without trigonometric SQL
+// functions: it loads the bounding-box candidates once, keeps those within radiusKM and
+// sorts and pages them in memory.
+func memoryDistanceFetcher(dbQuery *gorm.DB, lat, lng, radiusKM float64, sortBy string) storeFetcher {
 	var stores []model.Store
-	if err := dbQuery.
-		Distinct("stores.*").
-		Order("stores.id desc").
-		Limit(limit + 1).
-		Find(&stores).Error; err != nil {
-		return nil, nil, err
+	loaded := false
+	return func(anchor *model.Store, n int) ([]model.Store, error) {
+		if !loaded {
+			var candidates []model.Store
+			if err := dbQuery.Find(&candidates).Error; err != nil {
+				return nil, err
+			}
+			for _, store := range candidates {
+				distance := geo.HaversineKM(lat, lng, store.Latitude, store.Longitude)
+				if distance > radiusKM {
+					continue
+				}
+				store.DistanceKM = &distance
+				stores = append(stores, store)
+			}
+			sort.Slice(stores, func(i, j int) bool { return storeSortsBefore(sortBy, stores[i], stores[j]) })
+			loaded = true
+		}
+		start := 0
+		if anchor != nil {
+			start = sort.Search(len(stores), func(i int) bool { return storeSortsBefore(sortBy, *anchor, stores[i]) })
+		}
+		return stores[start:min(start+n, len(stores))], nil
 	}
+}
 
-	pageItems, cursor := sliceStorePage(stores, limit)
-	return pageItems, cursor, nil
+// storeSortsBefore mirrors the SQL ordering of store lists: by the sort key, then id desc.
+func storeSortsBefore(sortBy string, a, b model.Store) bool {
+	if sortBy == dto.StoreSortDistance {
+		if *a.DistanceKM != *b.DistanceKM {
+			return *a.DistanceKM < *b.DistanceKM
+		}
+	} else if va, vb := storeSortColumnValue(sortBy, a), storeSortColumnValue(sortBy, b); va != vb {
+		return va > vb
+	}
+	return a.ID > b.ID
+}
+
+// storeSortColumnValue is the descending sort key of rating and popularity sorts.
+func storeSortColumnValue(sortBy string, store model.Store) float64 {
+	switch sortBy {
+	case dto.StoreSortRating:
+		return float64(store.BayesianRating)
+	case dto.StoreSortPopularity:
+		return float64(store.ReviewCount)
+	}
+	return 0
 }
 
 func (s *StoreService) DetailPublished(ctx context.Context, storeID int64) (*model.Store, error) {
@@ -229,16 +480,29 @@ func (s *StoreService) DetailPublished(ctx context.Context, storeID int64) (*mod
 		}
 		return nil, err
 	}
+	histogram, err := model.LoadRatingHistogram(s.db.WithContext(ctx), model.RatingSubjectStore, store.ID)
+	if err != nil {
+		return nil, err
+	}
+	store.RatingHistogram = histogram
+	stores := []model.Store{store}
+	if err := setOpenStatus(s.db.WithContext(ctx), stores, s.now()); err != nil {
+		return nil, err
+	}
+	store.IsOpen, store.NextChangeAt = stores[0].IsOpen, stores[0].NextChangeAt
 	return &store, nil
 }
 
-func (s *StoreService) ReviewsPublished(ctx context.Context, storeID int64) ([]model.Review, error) {
+// ReviewsPublished lists a published store's visible reviews, leaving out those by users on
+// either side of a block with viewerID.
+func (s *StoreService) ReviewsPublished(ctx context.Context, viewerID, storeID int64) ([]model.Review, error) {
 	if _, err := s.DetailPublished(ctx, storeID); err != nil {
 		return nil, err
 	}
 	var reviews []model.Review
 	if err := s.db.WithContext(ctx).
-		Where("store_id = ?", storeID).
+		Scopes(model.PreloadMerchantReply, followservice.ExcludeBlocked(viewerID, "reviews.user_id")).
+		Where("store_id = ? AND status = ?", storeID, model.ContentStatusVisible).
 		Order("id desc").
 		Find(&reviews).Error; err != nil {
 		return nil, err
@@ -246,7 +510,10 @@ func (s *StoreService) ReviewsPublished(ctx context.Context, storeID int64) ([]m
 	return reviews, nil
 }
 
-func (s *StoreService) ReviewsPublishedPaginated(ctx context.Context, storeID int64, query dto.StoreReviewListQuery) ([]model.Review, *int64, error) {
+// ReviewsPublishedPaginated is the paginated, sortable form of ReviewsPublished. The ranked
+// sort resumes from the score carried in the cursor, so likes between page loads neither
+// repeat nor skip rows.
+func (s *StoreService) ReviewsPublishedPaginated(ctx context.Context, viewerID, storeID int64, query dto.StoreReviewListQuery) ([]model.Review, *string, error) {
 	if _, err := s.DetailPublished(ctx, storeID); err != nil {
 		return nil, nil, err
 	}
@@ -257,35 +524,81 @@ func (s *StoreService) ReviewsPublishedPaginated(ctx context.Context, storeID in
 		Model(&model.Review{}).
 		Preload("User").
 		Preload("User.Profile").
-		Where("store_id = ?", storeID)
+		Scopes(model.PreloadMerchantReply, followservice.ExcludeBlocked(viewerID, "reviews.user_id")).
+		Where("store_id = ? AND status = ?", storeID, model.ContentStatusVisible)
 
-	if query.Cursor != nil {
-		dbQuery = dbQuery.Where("reviews.id < ?", *query.Cursor)
+	if query.VerifiedOnly {
+		dbQuery = dbQuery.Where("reviews.is_verified = ?", true)
+	}
+
+	var after *reviewCursor
+	if query.Cursor != "" {
+		decoded, err := decodeReviewCursor(query.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		after = &decoded
+	}
+	ranked := query.Sort == dto.ReviewSortRanked
+	if ranked {
+		// Ranked order: helpfulness (likes) boosted for verified visits, newest first on ties.
+		score := fmt.Sprintf("((reviews.like_count + 1) * CASE WHEN reviews.is_verified THEN %d ELSE 1 END)", verifiedReviewWeight)
+		if after != nil {
+			if after.Score <= 0 {
+				return nil, nil, ErrInvalidCursor
+			}
+			dbQuery = dbQuery.Where("("+score+" < ? OR ("+score+" = ? AND reviews.id < ?))", after.Score, after.Score, after.ID)
+		}
+		dbQuery = dbQuery.Order(score + " desc").Order("reviews.id desc")
+	} else {
+		if after != nil {
+			dbQuery = dbQuery.Where("reviews.id < ?", after.ID)
+		}
+		dbQuery = dbQuery.Order("reviews.id desc")
 	}
 
 	var reviews []model.Review
 	if err := dbQuery.
-		Order("reviews.id desc").
 		Limit(limit + 1).
 		Find(&reviews).Error; err != nil {
 		return nil, nil, err
 	}
-	pageItems, cursor := sliceReviewPage(reviews, limit)
+	pageItems, cursor := sliceReviewPage(reviews, limit, ranked)
 	return pageItems, cursor, nil
 }
 
-func (s *StoreService) HoursPublished(ctx context.Context, storeID int64) ([]model.StoreHour, error) {
-	if _, err := s.DetailPublished(ctx, storeID); err != nil {
-		return nil, err
+// reviewRankScore mirrors the SQL score used for ranked store review lists.
+func reviewRankScore(review model.Review) int {
+	weight := 1
+	if review.IsVerified {
+		weight = verifiedReviewWeight
+	}
+	return (review.LikeCount + 1) * weight
+}
+
+// HoursPublished returns a store's weekly hours and its date overrides from today (in the
+// store's timezone) on.
+func (s *StoreService) HoursPublished(ctx context.Context, storeID int64) ([]model.StoreHour, []model.StoreSpecialHour, error) {
+	store, err := s.DetailPublished(ctx, storeID)
+	if err != nil {
+		return nil, nil, err
 	}
 	var hours []model.StoreHour
 	if err := s.db.WithContext(ctx).
 		Where("store_id = ?", storeID).
 		Order("day_of_week asc").
 		Find(&hours).Error; err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return hours, nil
+	today := s.now().In(newStoreSchedule(store.Timezone, nil, nil).loc).Format(specialHourDateLayout)
+	var special []model.StoreSpecialHour
+	if err := s.db.WithContext(ctx).
+		Where("store_id = ? AND date >= ?", storeID, today).
+		Order("date asc").Order("open_time asc").
+		Find(&special).Error; err != nil {
+		return nil, nil, err
+	}
+	return hours, special, nil
 }
 
 func (s *StoreService) ListMine(ctx context.Context, userID int64, limit *int) ([]model.Store, error) {
@@ -399,6 +712,23 @@ func (s *StoreService) Update(ctx context.Context, userID, storeID int64, req dt
 		return nil, ErrStoreForbidden
 	}
 
+	var hours []model.StoreHour
+	if req.Hours != nil {
+		built, err := buildStoreHours(storeID, *req.Hours)
+		if err != nil {
+			return nil, err
+		}
+		hours = built
+	}
+	var specialHours []model.StoreSpecialHour
+	if req.SpecialHours != nil {
+		built, err := buildSpecialHours(storeID, *req.SpecialHours)
+		if err != nil {
+			return nil, err
+		}
+		specialHours = built
+	}
+
 	updates := map[string]interface{}{}
 	if req.Name != nil {
 		updates["name"] = *req.Name
@@ -421,6 +751,13 @@ func (s *StoreService) Update(ctx context.Context, userID, storeID int64, req dt
 	if req.Country != nil {
 		updates["country"] = *req.Country
 	}
+	if req.Timezone != nil {
+		timezone, err := normalizeTimezone(*req.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		updates["timezone"] = timezone
+	}
 	if req.Phone != nil {
 		updates["phone"] = *req.Phone
 	}
@@ -474,27 +811,28 @@ func (s *StoreService) Update(ctx context.Context, userID, storeID int64, req dt
 			}
 		}
 
-		if req.Hours != nil {
+		if hours != nil {
 			if err := tx.Where("store_id = ?", storeID).Delete(&model.StoreHour{}).Error; err != nil {
 				return err
 			}
-			if len(*req.Hours) > 0 {
-				hours := make([]model.StoreHour, 0, len(*req.Hours))
-				for _, h := range *req.Hours {
-					hours = append(hours, model.StoreHour{
-						StoreID:   storeID,
-						DayOfWeek: h.DayOfWeek,
-						OpenTime:  h.OpenTime,
-						CloseTime: h.CloseTime,
-						IsClosed:  h.IsClosed,
-					})
-				}
+			if len(hours) > 0 {
 				if err := tx.Create(&hours).Error; err != nil {
 					return err
 				}
 			}
 		}
 
+		if specialHours != nil {
+			if err := tx.Where("store_id = ?", storeID).Delete(&model.StoreSpecialHour{}).Error; err != nil {
+				return err
+			}
+			if len(specialHours) > 0 {
+				if err := tx.Create(&specialHours).Error; err != nil {
+					return err
+				}
+			}
+		}
+
 		if req.CategoryIDs != nil {
 			if err := tx.Where("store_id = ?", storeID).Delete(&model.StoreCategory{}).Error; err != nil {
 				return err
@@ -538,7 +876,7 @@ func sanitizeLimit(raw *int, defaultLimit, maxLimit int) int {
 	return *raw
 }
 
-func sliceStorePage(items []model.Store, limit int) ([]model.Store, *int64) {
+func sliceStorePage(items []model.Store, limit int, sortBy string) ([]model.Store, *string) {
 	if len(items) <= limit {
 		return items, nil
 	}
@@ -546,11 +884,32 @@ func sliceStorePage(items []model.Store, limit int) ([]model.Store, *int64) {
 	if len(items) == 0 {
 		return items, nil
 	}
-	lastID := items[len(items)-1].ID
-	return items, &lastID
+	return items, encodeStoreCursor(sortBy, items[len(items)-1])
+}
+
+// storeCursor is the opaque store list cursor: the last store's id and, for rating and
+// popularity sorts, its sort value.
+type storeCursor struct {
+	ID    int64   `json:"id"`
+	Value float64 `json:"v,omitempty"`
+}
+
+func encodeStoreCursor(sortBy string, store model.Store) *string {
+	raw, _ := json.Marshal(storeCursor{ID: store.ID, Value: storeSortColumnValue(sortBy, store)})
+	cursor := base64.RawURLEncoding.EncodeToString(raw)
+	return &cursor
+}
+
+func decodeStoreCursor(value string) (storeCursor, error) {
+	var cursor storeCursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(raw, &cursor) != nil || cursor.ID <= 0 {
+		return storeCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
 }
 
-func sliceReviewPage(items []model.Review, limit int) ([]model.Review, *int64) {
+func sliceReviewPage(items []model.Review, limit int, ranked bool) ([]model.Review, *string) {
 	if len(items) <= limit {
 		return items, nil
 	}
@@ -558,8 +917,33 @@ func sliceReviewPage(items []model.Review, limit int) ([]model.Review, *int64) {
 	if len(items) == 0 {
 		return items, nil
 	}
-	lastID := items[len(items)-1].ID
-	return items, &lastID
+	return items, encodeReviewCursor(items[len(items)-1], ranked)
+}
+
+// reviewCursor is the opaque store review cursor: the last review's id and, for the ranked
+// sort, the score it had when the page was served.
+type reviewCursor struct {
+	ID    int64 `json:"id"`
+	Score int   `json:"s,omitempty"`
+}
+
+func encodeReviewCursor(review model.Review, ranked bool) *string {
+	value := reviewCursor{ID: review.ID}
+	if ranked {
+		value.Score = reviewRankScore(review)
+	}
+	raw, _ := json.Marshal(value)
+	cursor := base64.RawURLEncoding.EncodeToString(raw)
+	return &cursor
+}
+
+func decodeReviewCursor(value string) (reviewCursor, error) {
+	var cursor reviewCursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(raw, &cursor) != nil || cursor.ID <= 0 {
+		return reviewCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
 }
 
 func sanitizeCategoryIDs(raw []int64) ([]int64, error) {
@@ -27,9 +27,12 @@ func setupStoreTestDB(t *testing.T) *gorm.DB {
 		&model.Merchant{},
 		&model.Store{},
 		&model.StoreHour{},
+		&model.StoreSpecialHour{},
 		&model.Category{},
 		&model.StoreCategory{},
 		&model.Review{},
+		&model.ReviewComment{},
+		&model.RatingAggregate{},
 		&model.Coupon{},
 	); err != nil {
 		t.Fatalf("failed to migrate test db: %v", err)
@@ -923,7 +926,7 @@ func TestStoreServiceListPublishedFilteredByCategoryRatingLocationAndCursor(t *t
 		Rating:   &rating,
 		RadiusKM: &radiusKm,
 		Limit:    &limit,
-		Cursor:   cursor,
+		Cursor:   *cursor,
 	})
 	if err != nil {
 		t.Fatalf("list filtered second page returned error: %v", err)
@@ -935,7 +938,7 @@ func TestStoreServiceListPublishedFilteredByCategoryRatingLocationAndCursor(t *t
 		t.Fatalf("unexpected second-page store id: got %d, want %d", secondPage[0].ID, matchOld.ID)
 	}
 	if nextCursor != nil {
-		t.Fatalf("expected no further cursor after second page, got %d", *nextCursor)
+		t.Fatalf("expected no further cursor after second page, got %s", *nextCursor)
 	}
 }
 
@@ -990,7 +993,7 @@ func TestStoreServiceReviewsPublishedPaginatedPreloadsUser(t *testing.T) {
 	_ = addReview(u3.ID, "third")
 
 	limit := 2
-	firstPage, cursor, err := svc.ReviewsPublishedPaginated(context.Background(), store.ID, dto.StoreReviewListQuery{
+	firstPage, cursor, err := svc.ReviewsPublishedPaginated(context.Background(), 0, store.ID, dto.StoreReviewListQuery{
 		Limit: &limit,
 	})
 	if err != nil {
@@ -1006,9 +1009,9 @@ func TestStoreServiceReviewsPublishedPaginatedPreloadsUser(t *testing.T) {
 		t.Fatalf("expected user/profile to be preloaded on first review page")
 	}
 
-	secondPage, nextCursor, err := svc.ReviewsPublishedPaginated(context.Background(), store.ID, dto.StoreReviewListQuery{
+	secondPage, nextCursor, err := svc.ReviewsPublishedPaginated(context.Background(), 0, store.ID, dto.StoreReviewListQuery{
 		Limit:  &limit,
-		Cursor: cursor,
+		Cursor: *cursor,
 	})
 	if err != nil {
 		t.Fatalf("reviews second page returned error: %v", err)
@@ -1020,7 +1023,7 @@ func TestStoreServiceReviewsPublishedPaginatedPreloadsUser(t *testing.T) {
 		t.Fatalf("unexpected second-page review id: got %d, want %d", secondPage[0].ID, oldest.ID)
 	}
 	if nextCursor != nil {
-		t.Fatalf("expected no further cursor after second page, got %d", *nextCursor)
+		t.Fatalf("expected no further cursor after second page, got %s", *nextCursor)
 	}
 	if secondPage[0].User == nil || secondPage[0].User.Profile == nil {
 		t.Fatalf("expected user/profile to be preloaded on second review page")
@@ -1038,3 +1041,209 @@ func strPtr(v string) *string {
 func int64SlicePtr(v []int64) *[]int64 {
 	return &v
 }
+
+func TestStoreServiceReviewsVerifiedFilterAndRanking(t *testing.T) {
+	db := setupStoreTestDB(t)
+	svc := NewStoreService(db)
+
+	merchant := model.Merchant{Name: "Review Merchant"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	store := model.Store{MerchantID: merchant.ID, Name: "Review Store", Status: StoreStatusPublished}
+	if err := db.Create(&store).Error; err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	addReview := func(likes int, verified bool) model.Review {
+		storeID := store.ID
+		review := model.Review{
+			UserID:     1,
+			MerchantID: merchant.ID,
+			VenueID:    merchant.ID,
+			StoreID:    &storeID,
+			Rating:     4,
+			LikeCount:  likes,
+			IsVerified: verified,
+			VisitDate:  time.Now().UTC(),
+		}
+		if err := db.Create(&review).Error; err != nil {
+			t.Fatalf("failed to create review: %v", err)
+		}
+		return review
+	}
+	verifiedPopular := addReview(2, true)    // score 6
+	unverifiedPopular := addReview(4, false) // score 5
+	verifiedQuiet := addReview(0, true)      // score 2
+	plain := addReview(0, false)             // score 1
+
+	verifiedOnly, _, err := svc.ReviewsPublishedPaginated(context.Background(), 0, store.ID, dto.StoreReviewListQuery{VerifiedOnly: true})
+	if err != nil {
+		t.Fatalf("verified list failed: %v", err)
+	}
+	if len(verifiedOnly) != 2 || verifiedOnly[0].ID != verifiedQuiet.ID || verifiedOnly[1].ID != verifiedPopular.ID {
+		t.Fatalf("expected only verified reviews newest first, got %+v", verifiedOnly)
+	}
+
+	limit := 2
+	query := dto.StoreReviewListQuery{Sort: dto.ReviewSortRanked, Limit: &limit}
+	page, cursor, err := svc.ReviewsPublishedPaginated(context.Background(), 0, store.ID, query)
+	if err != nil {
+		t.Fatalf("ranked list failed: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != verifiedPopular.ID || page[1].ID != unverifiedPopular.ID || cursor == nil {
+		t.Fatalf("unexpected first ranked page: %+v cursor=%v", page, cursor)
+	}
+	// The last review of the first page gains likes and is hidden before the next page
+	// loads; the cursor still resumes after the score it was served with.
+	if err := db.Model(&unverifiedPopular).Updates(map[string]interface{}{
+		"like_count": 10,
+		"status":     model.ContentStatusHidden,
+	}).Error; err != nil {
+		t.Fatalf("failed to update review: %v", err)
+	}
+	query.Cursor = *cursor
+	page, cursor, err = svc.ReviewsPublishedPaginated(context.Background(), 0, store.ID, query)
+	if err != nil {
+		t.Fatalf("ranked list failed: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != verifiedQuiet.ID || page[1].ID != plain.ID || cursor != nil {
+		t.Fatalf("unexpected second ranked page: %+v cursor=%v", page, cursor)
+	}
+}
+
+func TestStoreServiceListPublishedFilteredSortsByDistanceWithinRadius(t *testing.T) {
+	db := setupStoreTestDB(t)
+	svc := NewStoreService(db)
+
+	merchant := model.Merchant{Name: "Geo Merchant"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	createStore := func(name string, lat, lng float64) model.Store {
+		store := model.Store{MerchantID: merchant.ID, Name: name, Status: StoreStatusPublished, Latitude: lat, Longitude: lng}
+		if err := db.Create(&store).Error; err != nil {
+			t.Fatalf("failed to create store %s: %v", name, err)
+		}
+		return store
+	}
+
+	lat, lng := 37.7750, -122.4190
+	mid := createStore("Mid North", lat+0.02, lng)
+	near := createStore("Near", lat+0.01, lng)
+	// Inside the bounding box but about 6.3 km away.
+	_ = createStore("Box Corner", lat+0.04, lng+0.05)
+	tieA := createStore("Mid South A", lat-0.02, lng)
+	tieB := createStore("Mid South B", lat-0.02, lng)
+
+	radiusKM := 5.0
+	limit := 2
+	query := dto.StoreListQuery{Lat: &lat, Lng: &lng, RadiusKM: &radiusKM, Sort: dto.StoreSortDistance, Limit: &limit}
+	var got []int64
+	for page := 0; page < 5; page++ {
+		stores, cursor, err := svc.ListPublishedFiltered(context.Background(), query)
+		if err != nil {
+			t.Fatalf("list by distance returned error: %v", err)
+		}
+		for _, store := range stores {
+			if store.DistanceKM == nil || *store.DistanceKM > radiusKM {
+				t.Fatalf("store %s has distance %v outside radius", store.Name, store.DistanceKM)
+			}
+			got = append(got, store.ID)
+		}
+		if cursor == nil {
+			break
+		}
+		query.Cursor = *cursor
+	}
+
+	want := []int64{near.ID, tieB.ID, tieA.ID, mid.ID}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected stores by distance: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected stores by distance: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestStoreServiceListPublishedFilteredSortsByRatingAndPopularity(t *testing.T) {
+	db := setupStoreTestDB(t)
+	svc := NewStoreService(db)
+
+	merchant := model.Merchant{Name: "Sort Merchant"}
+	if err := db.Create(&merchant).Error; err != nil {
+		t.Fatalf("failed to create merchant: %v", err)
+	}
+	createStore := func(name string, bayesian float32, reviews int) model.Store {
+		store := model.Store{MerchantID: merchant.ID, Name: name, Status: StoreStatusPublished, BayesianRating: bayesian, ReviewCount: reviews}
+		if err := db.Create(&store).Error; err != nil {
+			t.Fatalf("failed to create store %s: %v", name, err)
+		}
+		return store
+	}
+	a := createStore("A", 4.2, 10)
+	b := createStore("B", 3.9, 50)
+	c := createStore("C", 4.2, 5)
+	d := createStore("D", 4.7, 50)
+
+	collect := func(sortBy string) []int64 {
+		limit := 1
+		query := dto.StoreListQuery{Sort: sortBy, Limit: &limit}
+		var ids []int64
+		for page := 0; page < 10; page++ {
+			stores, cursor, err := svc.ListPublishedFiltered(context.Background(), query)
+			if err != nil {
+				t.Fatalf("list by %s returned error: %v", sortBy, err)
+			}
+			for _, store := range stores {
+				ids = append(ids, store.ID)
+			}
+			if cursor == nil {
+				break
+			}
+			query.Cursor = *cursor
+		}
+		return ids
+	}
+	assertOrder := func(sortBy string, want ...int64) {
+		got := collect(sortBy)
+		if len(got) != len(want) {
+			t.Fatalf("unexpected %s order: got %v, want %v", sortBy, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("unexpected %s order: got %v, want %v", sortBy, got, want)
+			}
+		}
+	}
+	assertOrder(dto.StoreSortRating, d.ID, c.ID, a.ID, b.ID)
+	assertOrder(dto.StoreSortPopularity, d.ID, b.ID, a.ID, c.ID)
+
+	if _, _, err := svc.ListPublishedFiltered(context.Background(), dto.StoreListQuery{Sort: dto.StoreSortDistance}); !errors.Is(err, ErrLocationRequired) {
+		t.Fatalf("expected ErrLocationRequired, got %v", err)
+	}
+	if _, _, err := svc.ListPublishedFiltered(context.Background(), dto.StoreListQuery{Sort: "cheapest"}); !errors.Is(err, ErrInvalidSort) {
+		t.Fatalf("expected ErrInvalidSort, got %v", err)
+	}
+	if _, _, err := svc.ListPublishedFiltered(context.Background(), dto.StoreListQuery{Sort: dto.StoreSortRating, Cursor: "9999"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+
+	// The next page resumes after the rating the cursor was issued with, so the last store
+	// dropping below others does not skip them.
+	limit := 1
+	first, cursor, err := svc.ListPublishedFiltered(context.Background(), dto.StoreListQuery{Sort: dto.StoreSortRating, Limit: &limit})
+	if err != nil || len(first) != 1 || first[0].ID != d.ID || cursor == nil {
+		t.Fatalf("unexpected first rating page %+v (%v)", first, err)
+	}
+	if err := db.Model(&model.Store{}).Where("id = ?", d.ID).Update("bayesian_rating", 4.0).Error; err != nil {
+		t.Fatalf("failed to update rating: %v", err)
+	}
+	limit = 10
+	rest, _, err := svc.ListPublishedFiltered(context.Background(), dto.StoreListQuery{Sort: dto.StoreSortRating, Limit: &limit, Cursor: *cursor})
+	if err != nil || len(rest) != 4 || rest[0].ID != c.ID || rest[1].ID != a.ID || rest[2].ID != d.ID || rest[3].ID != b.ID {
+		t.Fatalf("expected the remaining stores after the cursor's rating, got %+v (%v)", rest, err)
+	}
+}
@@ -1,6 +1,30 @@
 package dto
 
+import "github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+
+// QuietHours holds pushes back between Start and End ("HH:MM", may wrap past midnight) in
+// Timezone, an IANA name that defaults to UTC.
+type QuietHours struct {
+	Start    string `json:"start" example:"22:00"`
+	End      string `json:"end" example:"07:00"`
+	Timezone string `json:"timezone" example:"Europe/Berlin"`
+}
+
 type NotificationSettings struct {
 	PushEnabled  bool `json:"push_enabled"`
 	EmailEnabled bool `json:"email_enabled"`
+	// Types switches channels per notification type; missing entries are enabled.
+	Types       map[string]model.ChannelPreference `json:"types"`
+	QuietHours  *QuietHours                        `json:"quiet_hours"`
+	EmailDigest string                             `json:"email_digest" example:"daily"`
+}
+
+// UpdateNotificationSettingsRequest changes only the fields that are present. Types entries
+// are merged into the stored ones; quiet hours with an empty start and end turn them off.
+type UpdateNotificationSettingsRequest struct {
+	PushEnabled  *bool                              `json:"push_enabled"`
+	EmailEnabled *bool                              `json:"email_enabled"`
+	Types        map[string]model.ChannelPreference `json:"types"`
+	QuietHours   *QuietHours                        `json:"quiet_hours"`
+	EmailDigest  *string                            `json:"email_digest" enums:"off,daily,weekly"`
 }
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	contentdto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/content/dto"
+	followdto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/dto"
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/user/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/user/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
@@ -15,6 +18,7 @@ import (
 
 type UserHandler struct {
 	userService *service.UserService
+	follows     *followservice.FollowService
 	db          *gorm.DB
 }
 
@@ -24,6 +28,7 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
 	}
 	return &UserHandler{
 		userService: userService,
+		follows:     followservice.NewFollowService(database.DB),
 		db:          database.DB,
 	}
 }
@@ -139,11 +144,11 @@ func (h *UserHandler) GetNotifications(c *gin.Context) {
 
 // UpdateNotifications godoc
 // @Summary Update notification settings
-// @Description Updates the authenticated user's notification settings
+// @Description Updates the authenticated user's notification settings. Only fields present in the request change
 // @Tags user
 // @Accept json
 // @Produce json
-// @Param request body dto.NotificationSettings true "Notification Settings"
+// @Param request body dto.UpdateNotificationSettingsRequest true "Notification Settings"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
@@ -151,12 +156,16 @@ func (h *UserHandler) GetNotifications(c *gin.Context) {
 // @Router /user/notifications [patch]
 func (h *UserHandler) UpdateNotifications(c *gin.Context) {
 	userID := c.GetInt64("user_id")
-	var req dto.NotificationSettings
+	var req dto.UpdateNotificationSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	if err := h.userService.UpdateNotifications(c.Request.Context(), userID, req); err != nil {
+		if errors.Is(err, service.ErrInvalidNotificationSettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -324,28 +333,19 @@ func (h *UserHandler) SetDefaultAddress(c *gin.Context) {
 // @Produce json
 // @Param cursor query int false "Cursor"
 // @Param limit query int false "Limit"
-// @Success 200 {object} map[string]interface{}
+// @Success 200 {object} followdto.FollowUserListResponse
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /user/following/users [get]
 func (h *UserHandler) ListFollowingUsers(c *gin.Context) {
 	userID := c.GetInt64("user_id")
 	cursor, limit := parseCursorLimit(c)
-	var follows []model.UserFollow
-	q := h.db.WithContext(c.Request.Context()).Where("follower_id = ?", userID).Order("id desc")
-	if cursor != nil {
-		q = q.Where("id < ?", *cursor)
-	}
-	if err := q.Limit(limit).Find(&follows).Error; err != nil {
+	users, next, err := h.follows.Following(c.Request.Context(), userID, userID, cursor, limit)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	ids := make([]int64, 0, len(follows))
-	for _, f := range follows {
-		ids = append(ids, f.FollowingID)
-	}
-	profiles := h.loadUserBriefs(ids)
-	c.JSON(http.StatusOK, contentdto.FollowingUsersResponse{Users: profiles, Total: len(profiles)})
+	c.JSON(http.StatusOK, followdto.FollowUserListResponse{Users: users, Total: len(users), Cursor: next})
 }
 
 // ListFollowingMerchants godoc
@@ -387,28 +387,19 @@ func (h *UserHandler) ListFollowingMerchants(c *gin.Context) {
 // @Produce json
 // @Param cursor query int false "Cursor"
 // @Param limit query int false "Limit"
-// @Success 200 {object} map[string]interface{}
+// @Success 200 {object} followdto.FollowUserListResponse
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /user/followers [get]
 func (h *UserHandler) ListFollowers(c *gin.Context) {
 	userID := c.GetInt64("user_id")
 	cursor, limit := parseCursorLimit(c)
-	var follows []model.UserFollow
-	q := h.db.WithContext(c.Request.Context()).Where("following_id = ?", userID).Order("id desc")
-	if cursor != nil {
-		q = q.Where("id < ?", *cursor)
-	}
-	if err := q.Limit(limit).Find(&follows).Error; err != nil {
+	users, next, err := h.follows.Followers(c.Request.Context(), userID, userID, cursor, limit)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	ids := make([]int64, 0, len(follows))
-	for _, f := range follows {
-		ids = append(ids, f.FollowerID)
-	}
-	profiles := h.loadUserBriefs(ids)
-	c.JSON(http.StatusOK, contentdto.FollowersResponse{Users: profiles, Total: len(profiles)})
+	c.JSON(http.StatusOK, followdto.FollowUserListResponse{Users: users, Total: len(users), Cursor: next})
 }
 
 // RequestAccountExport godoc
@@ -468,18 +459,3 @@ func (h *UserHandler) loadMerchantBrief(id int64) *contentdto.MerchantBrief {
 	}
 	return &contentdto.MerchantBrief{ID: merchant.ID, Name: merchant.Name, Category: merchant.Category}
 }
-
-func (h *UserHandler) loadUserBriefs(ids []int64) []contentdto.UserBrief {
-	if len(ids) == 0 {
-		return []contentdto.UserBrief{}
-	}
-	var profiles []model.UserProfile
-	if err := h.db.Where("user_id IN ?", ids).Find(&profiles).Error; err != nil {
-		return []contentdto.UserBrief{}
-	}
-	result := make([]contentdto.UserBrief, 0, len(profiles))
-	for _, p := range profiles {
-		result = append(result, contentdto.UserBrief{UserID: p.UserID, Nickname: p.Nickname, AvatarURL: p.AvatarURL, Intro: p.Intro})
-	}
-	return result
-}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
+	followservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/user/dto"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
@@ -68,20 +72,144 @@ func (s *UserService) GetPrivacy(ctx context.Context, userID int64) (dto.Privacy
 	return dto.PrivacySettings{IsPublic: privacy.IsPublic}, nil
 }
 
+// UpdatePrivacy stores the user's privacy settings. Making a private profile public approves
+// every pending follow request; the approvals are announced once the change has committed.
 func (s *UserService) UpdatePrivacy(ctx context.Context, userID int64, req dto.PrivacySettings) error {
-	return s.db.WithContext(ctx).Save(&model.UserPrivacy{UserID: userID, IsPublic: req.IsPublic}).Error
+	var approved []int64
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var privacy model.UserPrivacy
+		if err := tx.FirstOrCreate(&privacy, model.UserPrivacy{UserID: userID}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&privacy).Update("is_public", req.IsPublic).Error; err != nil {
+			return err
+		}
+		if !req.IsPublic {
+			return nil
+		}
+		var err error
+		approved, err = followservice.ApprovePendingFollowRequests(tx, userID)
+		return err
+	}); err != nil {
+		return err
+	}
+	followservice.PublishFollowRequestsApproved(ctx, userID, approved)
+	return nil
 }
 
+// ErrInvalidNotificationSettings is returned for unknown notification types or digest
+// frequencies, malformed quiet hours and unknown timezones.
+var ErrInvalidNotificationSettings = errors.New("invalid notification settings")
+
 func (s *UserService) GetNotifications(ctx context.Context, userID int64) (dto.NotificationSettings, error) {
 	var notif model.UserNotification
 	if err := s.db.WithContext(ctx).FirstOrCreate(&notif, model.UserNotification{UserID: userID}).Error; err != nil {
 		return dto.NotificationSettings{}, err
 	}
-	return dto.NotificationSettings{PushEnabled: notif.PushEnabled, EmailEnabled: notif.EmailEnabled}, nil
+	return mapNotificationSettings(notif), nil
 }
 
-func (s *UserService) UpdateNotifications(ctx context.Context, userID int64, req dto.NotificationSettings) error {
-	return s.db.WithContext(ctx).Save(&model.UserNotification{UserID: userID, PushEnabled: req.PushEnabled, EmailEnabled: req.EmailEnabled}).Error
+func (s *UserService) UpdateNotifications(ctx context.Context, userID int64, req dto.UpdateNotificationSettingsRequest) error {
+	updates := map[string]interface{}{}
+	if req.PushEnabled != nil {
+		updates["push_enabled"] = *req.PushEnabled
+	}
+	if req.EmailEnabled != nil {
+		updates["email_enabled"] = *req.EmailEnabled
+	}
+	if req.EmailDigest != nil {
+		switch *req.EmailDigest {
+		case model.EmailDigestOff, model.EmailDigestDaily, model.EmailDigestWeekly:
+			updates["email_digest"] = *req.EmailDigest
+		default:
+			return ErrInvalidNotificationSettings
+		}
+	}
+	if req.QuietHours != nil {
+		quiet, err := validateQuietHours(*req.QuietHours)
+		if err != nil {
+			return err
+		}
+		updates["quiet_hours_start"] = quiet.Start
+		updates["quiet_hours_end"] = quiet.End
+		updates["timezone"] = quiet.Timezone
+	}
+	for notificationType := range req.Types {
+		if !slices.Contains(model.NotificationTypes, notificationType) {
+			return ErrInvalidNotificationSettings
+		}
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var notif model.UserNotification
+		if err := tx.FirstOrCreate(&notif, model.UserNotification{UserID: userID}).Error; err != nil {
+			return err
+		}
+		if len(req.Types) > 0 {
+			prefs := notif.Preferences()
+			for notificationType, change := range req.Types {
+				pref := prefs[notificationType]
+				if change.Push != nil {
+					pref.Push = change.Push
+				}
+				if change.Email != nil {
+					pref.Email = change.Email
+				}
+				prefs[notificationType] = pref
+			}
+			encoded, err := json.Marshal(prefs)
+			if err != nil {
+				return err
+			}
+			updates["channel_preferences"] = string(encoded)
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&model.UserNotification{}).Where("user_id = ?", userID).Updates(updates).Error
+	})
+}
+
+// validateQuietHours checks "HH:MM" times and the timezone. Empty start and end turn quiet
+// hours off.
+func validateQuietHours(quiet dto.QuietHours) (dto.QuietHours, error) {
+	quiet.Start = strings.TrimSpace(quiet.Start)
+	quiet.End = strings.TrimSpace(quiet.End)
+	quiet.Timezone = strings.TrimSpace(quiet.Timezone)
+	if quiet.Start == "" && quiet.End == "" {
+		return dto.QuietHours{}, nil
+	}
+	for _, clock := range []string{quiet.Start, quiet.End} {
+		if _, err := time.Parse("15:04", clock); err != nil || len(clock) != 5 {
+			return quiet, ErrInvalidNotificationSettings
+		}
+	}
+	if quiet.Timezone != "" {
+		if _, err := time.LoadLocation(quiet.Timezone); err != nil {
+			return quiet, ErrInvalidNotificationSettings
+		}
+	}
+	return quiet, nil
+}
+
+func mapNotificationSettings(notif model.UserNotification) dto.NotificationSettings {
+	settings := dto.NotificationSettings{
+		PushEnabled:  notif.PushEnabled,
+		EmailEnabled: notif.EmailEnabled,
+		Types:        notif.Preferences(),
+		EmailDigest:  notif.EmailDigest,
+	}
+	if settings.EmailDigest == "" {
+		settings.EmailDigest = model.EmailDigestDaily
+	}
+	if notif.QuietHoursStart != "" && notif.QuietHoursEnd != "" {
+		settings.QuietHours = &dto.QuietHours{
+			Start:    notif.QuietHoursStart,
+			End:      notif.QuietHoursEnd,
+			Timezone: notif.Timezone,
+		}
+	}
+	return settings
 }
 
 func (s *UserService) ListAddresses(ctx context.Context, userID int64) ([]model.UserAddress, error) {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	userdto "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/user/dto"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/testutil"
 	"gorm.io/gorm"
@@ -189,3 +190,96 @@ func TestUserServiceExecuteDueAccountDeletionsIsIdempotent(t *testing.T) {
 		t.Fatalf("expected second processed=0, got %d", processed)
 	}
 }
+
+func TestUserServiceUpdatePrivacyApprovesPendingRequestsWhenPublic(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewUserService(db)
+	ctx := context.Background()
+	owner := model.User{Role: "user"}
+	fan := model.User{Role: "user"}
+	db.Create(&owner)
+	db.Create(&fan)
+	db.Create(&model.UserProfile{UserID: owner.ID, Nickname: "owner"})
+	db.Create(&model.UserProfile{UserID: fan.ID, Nickname: "fan"})
+
+	if err := svc.UpdatePrivacy(ctx, owner.ID, userdto.PrivacySettings{IsPublic: false}); err != nil {
+		t.Fatalf("update privacy failed: %v", err)
+	}
+	settings, err := svc.GetPrivacy(ctx, owner.ID)
+	if err != nil || settings.IsPublic {
+		t.Fatalf("expected a private profile, got %+v (%v)", settings, err)
+	}
+
+	db.Create(&model.FollowRequest{RequesterID: fan.ID, TargetID: owner.ID})
+	var approved []eventbus.FollowRequestApproved
+	var followsAtPublish int64
+	t.Cleanup(eventbus.Subscribe(eventbus.EventFollowRequestApproved, func(_ context.Context, e eventbus.Event) error {
+		approved = append(approved, e.(eventbus.FollowRequestApproved))
+		// Handlers query outside the transaction, so they only see the follow once it has committed.
+		return db.Model(&model.UserFollow{}).Where("follower_id = ?", fan.ID).Count(&followsAtPublish).Error
+	}))
+	if err := svc.UpdatePrivacy(ctx, owner.ID, userdto.PrivacySettings{IsPublic: true}); err != nil {
+		t.Fatalf("update privacy failed: %v", err)
+	}
+	if len(approved) != 1 || approved[0].RequesterID != fan.ID || approved[0].UserID != owner.ID || followsAtPublish != 1 {
+		t.Fatalf("expected one approval published after commit, got %+v (%d follows seen)", approved, followsAtPublish)
+	}
+	var follows, pending int64
+	db.Model(&model.UserFollow{}).Where("follower_id = ? AND following_id = ?", fan.ID, owner.ID).Count(&follows)
+	db.Model(&model.FollowRequest{}).Count(&pending)
+	if follows != 1 || pending != 0 {
+		t.Fatalf("expected the request to become a follow, got %d follows and %d pending", follows, pending)
+	}
+	var profile model.UserProfile
+	db.First(&profile, "user_id = ?", owner.ID)
+	if profile.FollowerCount != 1 {
+		t.Fatalf("expected follower_count 1, got %d", profile.FollowerCount)
+	}
+}
+
+func TestUserServiceUpdateNotificationsChangesOnlyGivenFields(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	svc := NewUserService(db)
+	ctx := context.Background()
+	user := model.User{Role: "user", Status: 0}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	off, weekly := false, "weekly"
+	if err := svc.UpdateNotifications(ctx, user.ID, userdto.UpdateNotificationSettingsRequest{
+		Types:       map[string]model.ChannelPreference{model.NotificationTypeLike: {Push: &off}},
+		QuietHours:  &userdto.QuietHours{Start: "22:00", End: "07:00", Timezone: "UTC"},
+		EmailDigest: &weekly,
+	}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if err := svc.UpdateNotifications(ctx, user.ID, userdto.UpdateNotificationSettingsRequest{
+		EmailEnabled: &off,
+		Types:        map[string]model.ChannelPreference{model.NotificationTypeLike: {Email: &off}},
+	}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	settings, err := svc.GetNotifications(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	like := settings.Types[model.NotificationTypeLike]
+	if !settings.PushEnabled || settings.EmailEnabled || settings.EmailDigest != weekly ||
+		like.Push == nil || *like.Push || like.Email == nil || *like.Email ||
+		settings.QuietHours == nil || settings.QuietHours.Start != "22:00" || settings.QuietHours.End != "07:00" {
+		t.Fatalf("unexpected settings %+v", settings)
+	}
+
+	for _, req := range []userdto.UpdateNotificationSettingsRequest{
+		{QuietHours: &userdto.QuietHours{Start: "25:00", End: "07:00"}},
+		{QuietHours: &userdto.QuietHours{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"}},
+		{Types: map[string]model.ChannelPreference{"unknown": {Push: &off}}},
+		{EmailDigest: new(string)},
+	} {
+		if err := svc.UpdateNotifications(ctx, user.ID, req); !errors.Is(err, ErrInvalidNotificationSettings) {
+			t.Fatalf("expected invalid settings error for %+v, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/verification/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewVerification godoc
+// @Summary Review merchant verification
+// @Description Approves or rejects a pending merchant verification. Rejections need a reason. Admin only
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Param id path int true "Verification ID"
+// @Param request body service.ReviewVerificationInput true "Decision"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /admin/verifications/{id}/review [post]
+func (h *VerificationHandler) Review(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req service.ReviewVerificationInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	verification, err := h.svc.Review(c.Request.Context(), userID, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVerificationInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "rejection reason is required"})
+		case errors.Is(err, service.ErrVerificationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrVerificationNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to review verification"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": verification})
+}
@@ -132,3 +132,48 @@ func TestVerificationHandlerSubmitCreatesSubmission(t *testing.T) {
 		t.Fatalf("expected document url to persist, got %q", verification.DocumentURL)
 	}
 }
+
+func TestVerificationHandlerReviewDecidesPendingSubmission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := setupVerificationTestDB(t)
+	seedVerificationFixture(t, db)
+	h := NewVerificationHandler(service.NewVerificationService(db))
+
+	review := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodPost, "/admin/verifications/9201/review", bytes.NewReader([]byte(body)))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "9201"}}
+		c.Set("user_id", int64(1))
+		h.Review(c)
+		return recorder
+	}
+
+	if recorder := review(`{"approved":false}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected a rejection without reason to be refused, got %d", recorder.Code)
+	}
+	if recorder := review(`{"approved":true}`); recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var merchant model.Merchant
+	if err := db.First(&merchant, 9101).Error; err != nil {
+		t.Fatalf("failed to load merchant: %v", err)
+	}
+	if merchant.VerificationStatus != "verified" {
+		t.Fatalf("expected merchant to be verified, got %q", merchant.VerificationStatus)
+	}
+	var verification model.MerchantVerification
+	if err := db.First(&verification, 9201).Error; err != nil {
+		t.Fatalf("failed to load verification: %v", err)
+	}
+	if verification.Status != "approved" || verification.ReviewedBy == nil || *verification.ReviewedBy != 1 {
+		t.Fatalf("expected approved verification reviewed by 1, got %+v", verification)
+	}
+
+	if recorder := review(`{"approved":false,"reason":"blurry"}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected a decided verification to be final, got %d", recorder.Code)
+	}
+}
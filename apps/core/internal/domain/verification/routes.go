@@ -18,4 +18,9 @@ func RegisterRoutes(r *gin.RouterGroup, cfg *config.Config) {
 		merchantVerify.POST("", h.Submit)
 		merchantVerify.GET("", h.Status)
 	}
+
+	adminVerify := r.Group("/admin/verifications", middleware.JWTAuth(cfg.JWT), middleware.RequireRole("admin"))
+	{
+		adminVerify.POST("/:id/review", h.Review)
+	}
 }
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrVerificationNotFound = errors.New("verification not found")
+var ErrVerificationNotPending = errors.New("verification is not pending")
+
+type ReviewVerificationInput struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+// Review records an admin's decision on a pending verification and moves its merchant to
+// verified or rejected. Rejections need a reason, which the merchant is shown.
+func (s *VerificationService) Review(ctx context.Context, reviewerID, verificationID int64, input ReviewVerificationInput) (*VerificationStatusView, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if !input.Approved && reason == "" {
+		return nil, ErrVerificationInvalidInput
+	}
+
+	var verification model.MerchantVerification
+	var merchant model.Merchant
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&verification, verificationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrVerificationNotFound
+			}
+			return err
+		}
+		if verification.Status != "pending" {
+			return ErrVerificationNotPending
+		}
+		if err := tx.First(&merchant, verification.MerchantID).Error; err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		verification.Status = "rejected"
+		merchant.VerificationStatus = "rejected"
+		if input.Approved {
+			verification.Status = "approved"
+			merchant.VerificationStatus = "verified"
+			reason = ""
+		}
+		verification.RejectionReason = reason
+		verification.ReviewedBy = &reviewerID
+		verification.ReviewedAt = &now
+		if err := tx.Model(&model.MerchantVerification{}).Where("id = ?", verification.ID).Updates(map[string]interface{}{
+			"status":           verification.Status,
+			"rejection_reason": verification.RejectionReason,
+			"reviewed_by":      reviewerID,
+			"reviewed_at":      now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Merchant{}).Where("id = ?", merchant.ID).
+			Update("verification_status", merchant.VerificationStatus).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	if merchant.UserID != nil {
+		eventbus.Publish(ctx, eventbus.VerificationReviewed{
+			VerificationID: verification.ID,
+			MerchantID:     merchant.ID,
+			UserID:         *merchant.UserID,
+			ReviewerID:     reviewerID,
+			Approved:       input.Approved,
+			Reason:         reason,
+		})
+	}
+
+	view := mapVerificationView(verification, &merchant)
+	return &view, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/eventbus"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/model"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/database"
 	"gorm.io/gorm"
@@ -203,7 +204,8 @@ func (s *VoucherService) PreviewRedeemByToken(ctx context.Context, merchantUserI
 }
 
 func (s *VoucherService) RedeemByMerchantToken(ctx context.Context, merchantUserID int64, scanToken string) error {
-	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	var redeemed eventbus.VoucherRedeemed
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var merchant model.Merchant
 		if err := tx.Where("user_id = ?", merchantUserID).First(&merchant).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -254,14 +256,26 @@ func (s *VoucherService) RedeemByMerchantToken(ctx context.Context, merchantUser
 			return err
 		}
 
+		redeemed = eventbus.VoucherRedeemed{
+			VoucherID:  voucher.ID,
+			UserID:     voucher.UserID,
+			MerchantID: merchant.ID,
+			CouponID:   coupon.ID,
+			RedeemedBy: merchantUserID,
+		}
 		return tx.Unscoped().Model(&model.Coupon{}).
 			Where("id = ?", coupon.ID).
 			UpdateColumn("redeemed_count", gorm.Expr("redeemed_count + 1")).Error
-	})
+	}); err != nil {
+		return err
+	}
+	eventbus.Publish(ctx, redeemed)
+	return nil
 }
 
 func (s *VoucherService) RedeemByMerchant(ctx context.Context, userID, voucherID int64) error {
-	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	var redeemed eventbus.VoucherRedeemed
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var merchant model.Merchant
 		if err := tx.Where("user_id = ?", userID).First(&merchant).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -310,10 +324,21 @@ func (s *VoucherService) RedeemByMerchant(ctx context.Context, userID, voucherID
 			return err
 		}
 
+		redeemed = eventbus.VoucherRedeemed{
+			VoucherID:  voucher.ID,
+			UserID:     voucher.UserID,
+			MerchantID: merchant.ID,
+			CouponID:   coupon.ID,
+			RedeemedBy: userID,
+		}
 		return tx.Unscoped().Model(&model.Coupon{}).
 			Where("id = ?", coupon.ID).
 			UpdateColumn("redeemed_count", gorm.Expr("redeemed_count + 1")).Error
-	})
+	}); err != nil {
+		return err
+	}
+	eventbus.Publish(ctx, redeemed)
+	return nil
 }
 
 func generateVoucherScanToken() (string, error) {
@@ -0,0 +1,82 @@
+// Package eventbus is the in-process domain event bus. Services publish an event once the
+// change it describes has committed; subscribers such as the notification producer react to
+// it without the publishing domain knowing about them.
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/RevieU-Corp/revieu-backend/apps/core/pkg/logger"
+)
+
+// Event is a fact about something that already happened.
+type Event interface {
+	EventName() string
+}
+
+// Handler reacts to a published event. A handler error is logged; it never reaches the
+// publisher, whose change is already committed.
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	id      int
+	handler Handler
+}
+
+// Bus delivers events synchronously, in subscription order, to the handlers subscribed to
+// their name.
+type Bus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[string][]subscription
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]subscription)}
+}
+
+// Subscribe registers handler for events named name and returns a function that removes it.
+func (b *Bus) Subscribe(name string, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[name] = append(b.handlers[name], subscription{id: id, handler: handler})
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.handlers[name]
+		for i, sub := range subs {
+			if sub.id == id {
+				b.handlers[name] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish runs every handler subscribed to the event in the caller's goroutine.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	subs := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+	for _, sub := range subs {
+		if err := sub.handler(ctx, event); err != nil {
+			logger.Warn(ctx, "eventbus: handler failed", "event", event.EventName(), "error", err.Error())
+		}
+	}
+}
+
+// Default is the process-wide bus domain services publish to.
+var Default = NewBus()
+
+// Publish publishes event on the default bus.
+func Publish(ctx context.Context, event Event) {
+	Default.Publish(ctx, event)
+}
+
+// Subscribe subscribes handler to the default bus.
+func Subscribe(name string, handler Handler) func() {
+	return Default.Subscribe(name, handler)
+}
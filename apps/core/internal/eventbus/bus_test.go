@@ -0,0 +1,39 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBusDeliversInOrderAndSurvivesHandlerErrors(t *testing.T) {
+	bus := NewBus()
+	var calls []string
+	bus.Subscribe(EventUserFollowed, func(_ context.Context, _ Event) error {
+		calls = append(calls, "first")
+		return errors.New("boom")
+	})
+	unsubscribe := bus.Subscribe(EventUserFollowed, func(_ context.Context, event Event) error {
+		if followed, ok := event.(UserFollowed); !ok || followed.FollowerID != 1 {
+			t.Fatalf("unexpected event %#v", event)
+		}
+		calls = append(calls, "second")
+		return nil
+	})
+	bus.Subscribe(EventContentLiked, func(_ context.Context, _ Event) error {
+		calls = append(calls, "other")
+		return nil
+	})
+
+	bus.Publish(context.Background(), UserFollowed{FollowerID: 1, FollowingID: 2})
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Fatalf("expected both follow handlers in order, got %v", calls)
+	}
+
+	unsubscribe()
+	calls = nil
+	bus.Publish(context.Background(), UserFollowed{FollowerID: 1, FollowingID: 2})
+	if len(calls) != 1 || calls[0] != "first" {
+		t.Fatalf("expected only the remaining handler, got %v", calls)
+	}
+}
@@ -0,0 +1,124 @@
+package eventbus
+
+// Event names.
+const (
+	EventUserFollowed          = "user.followed"
+	EventFollowRequested       = "follow.requested"
+	EventFollowRequestApproved = "follow.approved"
+	EventContentLiked          = "content.liked"
+	EventCommentCreated        = "comment.created"
+	EventOrderPaid             = "order.paid"
+	EventVoucherRedeemed       = "voucher.redeemed"
+	EventVerificationReviewed  = "verification.reviewed"
+	EventMessageSent           = "message.sent"
+	EventMerchantReplied       = "merchant.replied"
+)
+
+// UserFollowed is published when FollowerID starts following FollowingID.
+type UserFollowed struct {
+	FollowerID  int64
+	FollowingID int64
+}
+
+func (UserFollowed) EventName() string { return EventUserFollowed }
+
+// FollowRequested is published when RequesterID asks to follow the private account TargetID.
+type FollowRequested struct {
+	RequesterID int64
+	TargetID    int64
+}
+
+func (FollowRequested) EventName() string { return EventFollowRequested }
+
+// FollowRequestApproved is published when UserID accepts RequesterID's follow request.
+type FollowRequestApproved struct {
+	UserID      int64
+	RequesterID int64
+}
+
+func (FollowRequestApproved) EventName() string { return EventFollowRequestApproved }
+
+// ContentLiked is published when UserID newly likes content authored by OwnerID. TargetType
+// is one of the interaction target types, e.g. "review" or "post_comment".
+type ContentLiked struct {
+	UserID     int64
+	OwnerID    int64
+	TargetType string
+	TargetID   int64
+}
+
+func (ContentLiked) EventName() string { return EventContentLiked }
+
+// CommentCreated is published when a visible comment is added to a review or post.
+// ParentAuthorID is the author of the comment being replied to, or 0 for top-level comments.
+type CommentCreated struct {
+	UserID         int64
+	CommentID      int64
+	TargetType     string
+	TargetID       int64
+	OwnerID        int64
+	ParentAuthorID int64
+	Content        string
+}
+
+func (CommentCreated) EventName() string { return EventCommentCreated }
+
+// OrderPaid is published once when an order moves from pending to paid.
+type OrderPaid struct {
+	OrderID    int64
+	UserID     int64
+	MerchantID int64
+	CouponID   int64
+	Quantity   int
+	Amount     float64
+}
+
+func (OrderPaid) EventName() string { return EventOrderPaid }
+
+// VoucherRedeemed is published when merchant staff RedeemedBy redeem a customer's voucher.
+type VoucherRedeemed struct {
+	VoucherID  int64
+	UserID     int64
+	MerchantID int64
+	CouponID   int64
+	RedeemedBy int64
+}
+
+func (VoucherRedeemed) EventName() string { return EventVoucherRedeemed }
+
+// VerificationReviewed is published when an admin approves or rejects a merchant's
+// verification. UserID is the merchant's account.
+type VerificationReviewed struct {
+	VerificationID int64
+	MerchantID     int64
+	UserID         int64
+	ReviewerID     int64
+	Approved       bool
+	Reason         string
+}
+
+func (VerificationReviewed) EventName() string { return EventVerificationReviewed }
+
+// MessageSent is published when a visible message is sent to a conversation.
+type MessageSent struct {
+	MessageID      int64
+	ConversationID int64
+	SenderID       int64
+	MessageType    string
+	Content        string
+}
+
+func (MessageSent) EventName() string { return EventMessageSent }
+
+// MerchantReplied is published when merchant staff UserID posts the merchant's visible
+// official reply to ReviewerID's review.
+type MerchantReplied struct {
+	ReplyID    int64
+	ReviewID   int64
+	MerchantID int64
+	UserID     int64
+	ReviewerID int64
+	Content    string
+}
+
+func (MerchantReplied) EventName() string { return EventMerchantReplied }
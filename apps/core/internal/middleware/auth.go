@@ -59,3 +59,35 @@ func JWTAuth(jwtCfg config.JWTConfig) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalJWTAuth authenticates the caller when an Authorization header is present and
+// lets anonymous requests through otherwise. A malformed or expired token is still rejected
+// so clients notice they need to sign in again.
+func OptionalJWTAuth(jwtCfg config.JWTConfig) gin.HandlerFunc {
+	required := JWTAuth(jwtCfg)
+
+	return func(c *gin.Context) {
+		if c.GetHeader(AuthorizationHeader) == "" {
+			c.Next()
+			return
+		}
+		required(c)
+	}
+}
+
+// RequireRole rejects callers whose token role is not one of roles. It must run after
+// JWTAuth, which puts the role in the context.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(UserRoleKey)
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "forbidden",
+		})
+	}
+}
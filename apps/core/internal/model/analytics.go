@@ -13,7 +13,12 @@ type MerchantAnalytics struct {
 	CouponsRedeemed int       `gorm:"default:0" json:"coupons_redeemed"`
 	Revenue         float64   `gorm:"type:numeric(12,2);default:0" json:"revenue"`
 	AvgRating       float32   `gorm:"default:0" json:"avg_rating"`
-	CreatedAt       time.Time `json:"created_at"`
+	// Support conversations opened that day, how many got a first staff response, and the
+	// summed seconds to that response.
+	ConversationsStarted   int       `gorm:"default:0" json:"conversations_started"`
+	ConversationsResponded int       `gorm:"default:0" json:"conversations_responded"`
+	ResponseTimeSeconds    int64     `gorm:"default:0" json:"response_time_seconds"`
+	CreatedAt              time.Time `json:"created_at"`
 }
 
 func (ma *MerchantAnalytics) TableName() string { return "merchant_analytics" }
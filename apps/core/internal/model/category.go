@@ -18,3 +18,13 @@ type StoreCategory struct {
 }
 
 func (sc *StoreCategory) TableName() string { return "store_categories" }
+
+// CategoryTranslation is a category's name in one locale ("es", "zh-tw"); categories
+// without one for the requested locale fall back to Category.Name.
+type CategoryTranslation struct {
+	CategoryID int64  `gorm:"primaryKey" json:"category_id"`
+	Locale     string `gorm:"primaryKey;type:varchar(16)" json:"locale"`
+	Name       string `gorm:"type:varchar(50);not null" json:"name"`
+}
+
+func (ct *CategoryTranslation) TableName() string { return "category_translations" }
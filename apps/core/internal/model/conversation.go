@@ -2,13 +2,39 @@ package model
 
 import "time"
 
+// Conversation types.
+const (
+	ConversationTypeDirect   = "direct"
+	ConversationTypeGroup    = "group"
+	ConversationTypeMerchant = "merchant"
+)
+
+// Support states of a merchant conversation.
+const (
+	SupportStatusOpen     = "open"
+	SupportStatusAssigned = "assigned"
+	SupportStatusResolved = "resolved"
+)
+
 type Conversation struct {
 	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	Type      string    `gorm:"type:varchar(20);default:'direct'" json:"type"`
 	Title     string    `gorm:"type:varchar(100)" json:"title"`
+	AvatarURL string    `gorm:"type:varchar(255)" json:"avatar_url"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// Support fields are only set on merchant conversations, where the customer talks to a
+	// merchant rather than a user and any of its staff may answer.
+	MerchantID      *int64     `gorm:"index" json:"merchant_id,omitempty"`
+	StoreID         *int64     `gorm:"index" json:"store_id,omitempty"`
+	OrderID         *int64     `json:"order_id,omitempty"`
+	VoucherID       *int64     `json:"voucher_id,omitempty"`
+	SupportStatus   string     `gorm:"type:varchar(20)" json:"support_status,omitempty"`
+	AssigneeID      *int64     `gorm:"index" json:"assignee_id,omitempty"`
+	FirstResponseAt *time.Time `json:"first_response_at,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+
 	Participants []ConversationParticipant `gorm:"foreignKey:ConversationID" json:"participants,omitempty"`
 	Messages     []Message                `gorm:"foreignKey:ConversationID" json:"messages,omitempty"`
 }
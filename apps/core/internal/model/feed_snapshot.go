@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// FeedSnapshot freezes one ranked window of a home feed so its pages stay stable while the
+// content underneath gains likes or new posts arrive. Items holds the ranked feed items as
+// JSON; Since is where the window starts, and so where the next, older window ends. Only
+// signed-in viewers get snapshots; anonymous feeds are shared from an in-process cache.
+type FeedSnapshot struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64     `gorm:"not null;index" json:"user_id"`
+	Items     string    `gorm:"type:jsonb;not null" json:"items"`
+	Since     time.Time `gorm:"not null" json:"since"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (s *FeedSnapshot) TableName() string {
+	return "feed_snapshots"
+}
@@ -23,3 +23,50 @@ type MerchantFollow struct {
 func (m *MerchantFollow) TableName() string {
 	return "merchant_follows"
 }
+
+// FollowRequest is a pending follow of a private account, waiting for the target's approval.
+type FollowRequest struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	RequesterID int64     `gorm:"not null;uniqueIndex:idx_follow_request" json:"requester_id"`
+	TargetID    int64     `gorm:"not null;uniqueIndex:idx_follow_request;index" json:"target_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (f *FollowRequest) TableName() string {
+	return "follow_requests"
+}
+
+// UserBlock hides each user from the other and severs the follow relationship between them.
+type UserBlock struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	BlockerID int64     `gorm:"not null;uniqueIndex:idx_user_block" json:"blocker_id"`
+	BlockedID int64     `gorm:"not null;uniqueIndex:idx_user_block;index" json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (u *UserBlock) TableName() string {
+	return "user_blocks"
+}
+
+// UserMute hides the muted user's content from the muter's feeds without telling either side.
+type UserMute struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	MuterID   int64     `gorm:"not null;uniqueIndex:idx_user_mute" json:"muter_id"`
+	MutedID   int64     `gorm:"not null;uniqueIndex:idx_user_mute" json:"muted_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (u *UserMute) TableName() string {
+	return "user_mutes"
+}
+
+type StoreFollow struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:idx_store_follow" json:"user_id"`
+	StoreID   int64     `gorm:"not null;uniqueIndex:idx_store_follow;index" json:"store_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *StoreFollow) TableName() string {
+	return "store_follows"
+}
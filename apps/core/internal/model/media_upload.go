@@ -2,18 +2,35 @@ package model
 
 import "time"
 
+// Media upload lifecycle: uploads start pending and move to approved or rejected once
+// POST /media/:id/analysis has run.
+const (
+	MediaStatusPending  = "pending"
+	MediaStatusApproved = "approved"
+	MediaStatusRejected = "rejected"
+)
+
 type MediaUpload struct {
-	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	UUID      string    `gorm:"type:varchar(36);uniqueIndex;not null" json:"uuid"`
-	UserID    int64     `gorm:"index;not null" json:"user_id"`
-	ObjectKey string    `gorm:"type:varchar(512);not null" json:"object_key"`
-	FileURL   string    `gorm:"type:varchar(512)" json:"file_url"`
-	FileSize  int64     `json:"file_size"`
-	MimeType  string    `gorm:"type:varchar(100)" json:"mime_type"`
-	R2Bucket  string    `gorm:"type:varchar(100)" json:"r2_bucket"`
-	Status    string    `gorm:"type:varchar(20);default:'pending'" json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UUID             string     `gorm:"type:varchar(36);uniqueIndex;not null" json:"uuid"`
+	UserID           int64      `gorm:"index;not null" json:"user_id"`
+	ObjectKey        string     `gorm:"type:varchar(512);not null" json:"object_key"`
+	FileURL          string     `gorm:"type:varchar(512)" json:"file_url"`
+	FileSize         int64      `json:"file_size"`
+	MimeType         string     `gorm:"type:varchar(100)" json:"mime_type"`
+	R2Bucket         string     `gorm:"type:varchar(100)" json:"r2_bucket"`
+	Status           string     `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	DetectedMimeType string     `gorm:"type:varchar(100)" json:"detected_mime_type"`
+	Width            int        `gorm:"default:0" json:"width"`
+	Height           int        `gorm:"default:0" json:"height"`
+	PerceptualHash   string     `gorm:"type:varchar(16);index" json:"perceptual_hash"`
+	DuplicateOfID    *int64     `json:"duplicate_of_id"`
+	SafetyLabel      string     `gorm:"type:varchar(20)" json:"safety_label"`
+	SafetyScore      float64    `gorm:"default:0" json:"safety_score"`
+	RejectionReason  string     `gorm:"type:varchar(50)" json:"rejection_reason"`
+	AnalyzedAt       *time.Time `json:"analyzed_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 func (m *MediaUpload) TableName() string { return "media_uploads" }
@@ -23,6 +23,14 @@ type Merchant struct {
 	CoverImage         string         `gorm:"type:varchar(255)" json:"cover_image"`
 	Description        string         `gorm:"type:text" json:"description"`
 	AvgRating          float32        `gorm:"default:0" json:"avg_rating"`
+	AvgRatingEnv       float32        `gorm:"default:0" json:"avg_rating_env"`
+	AvgRatingService   float32        `gorm:"default:0" json:"avg_rating_service"`
+	AvgRatingValue     float32        `gorm:"default:0" json:"avg_rating_value"`
+	AvgRatingFood      float32        `gorm:"default:0" json:"avg_rating_food"`
+	AvgCost            int            `gorm:"default:0" json:"avg_cost"`
+	BayesianRating     float32        `gorm:"default:0" json:"bayesian_rating"`
+	RecentRating       float32        `gorm:"default:0" json:"recent_rating"`
+	RatingHistogram    []int          `gorm:"-" json:"rating_histogram,omitempty"`
 	ReviewCount        int            `gorm:"default:0" json:"review_count"`
 	FollowerCount      int            `gorm:"default:0" json:"follower_count"`
 	TotalStores        int            `gorm:"default:0" json:"total_stores"`
@@ -2,6 +2,16 @@ package model
 
 import "time"
 
+// Message types a client may send.
+const (
+	MessageTypeText         = "text"
+	MessageTypeImage        = "image"
+	MessageTypeVoucherShare = "voucher_share"
+	MessageTypeStoreCard    = "store_card"
+	// MessageTypeSystem is written by the server for membership changes; clients cannot send it.
+	MessageTypeSystem = "system"
+)
+
 type Message struct {
 	ID             int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	ConversationID int64     `gorm:"not null;index" json:"conversation_id"`
@@ -10,9 +20,25 @@ type Message struct {
 	MessageType    string    `gorm:"type:varchar(20);default:'text'" json:"message_type"`
 	Attachments    string    `gorm:"type:jsonb;default:'[]'" json:"attachments"`
 	IsRead         bool      `gorm:"default:false" json:"is_read"`
+	Status         int16     `gorm:"default:0" json:"status"`
 	CreatedAt      time.Time `json:"created_at"`
+	// EditedAt is set when the sender last edited the content.
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	// DeletedForEveryoneAt is set when the sender deleted the message for all participants;
+	// its content and attachments are cleared and only the placeholder remains.
+	DeletedForEveryoneAt *time.Time `json:"deleted_for_everyone_at,omitempty"`
 
 	Sender *User `gorm:"foreignKey:SenderID" json:"sender,omitempty"`
 }
 
 func (m *Message) TableName() string { return "messages" }
+
+// MessageDeletion hides a message from one participant ("delete for me").
+type MessageDeletion struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	MessageID int64     `gorm:"not null;uniqueIndex:idx_message_deletion" json:"message_id"`
+	UserID    int64     `gorm:"not null;uniqueIndex:idx_message_deletion;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (d *MessageDeletion) TableName() string { return "message_deletions" }
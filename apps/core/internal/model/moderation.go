@@ -0,0 +1,9 @@
+package model
+
+// Moderation states stored in the Status column of user-generated content: reviews,
+// review comments, posts, post comments and messages.
+const (
+	ContentStatusVisible int16 = 0
+	ContentStatusPending int16 = 1
+	ContentStatusHidden  int16 = 2
+)
@@ -2,6 +2,40 @@ package model
 
 import "time"
 
+// Notification.Type values.
+const (
+	NotificationTypeReviewReply          = "review_reply"
+	NotificationTypeFollow               = "follow"
+	NotificationTypeFollowRequest        = "follow_request"
+	NotificationTypeFollowAccepted       = "follow_accepted"
+	NotificationTypeLike                 = "like"
+	NotificationTypeComment              = "comment"
+	NotificationTypeCommentReply         = "comment_reply"
+	NotificationTypeOrderPaid            = "order_paid"
+	NotificationTypeNewOrder             = "new_order"
+	NotificationTypeVoucherRedeemed      = "voucher_redeemed"
+	NotificationTypeVerificationApproved = "verification_approved"
+	NotificationTypeVerificationRejected = "verification_rejected"
+	NotificationTypeMessage              = "message"
+)
+
+// NotificationTypes lists every Notification.Type users can set channel preferences for.
+var NotificationTypes = []string{
+	NotificationTypeReviewReply,
+	NotificationTypeFollow,
+	NotificationTypeFollowRequest,
+	NotificationTypeFollowAccepted,
+	NotificationTypeLike,
+	NotificationTypeComment,
+	NotificationTypeCommentReply,
+	NotificationTypeOrderPaid,
+	NotificationTypeNewOrder,
+	NotificationTypeVoucherRedeemed,
+	NotificationTypeVerificationApproved,
+	NotificationTypeVerificationRejected,
+	NotificationTypeMessage,
+}
+
 type Notification struct {
 	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	UserID     int64     `gorm:"not null;index" json:"user_id"`
@@ -10,6 +44,8 @@ type Notification struct {
 	Content    string    `gorm:"type:text" json:"content"`
 	Data       string    `gorm:"type:jsonb;default:'{}'" json:"data"`
 	IsRead     bool      `gorm:"default:false" json:"is_read"`
+	// GroupKey lets an unread notification absorb similar events, e.g. every like of one review.
+	GroupKey   string    `gorm:"type:varchar(100);index" json:"-"`
 	ReadAt     *time.Time `json:"read_at"`
 	CreatedAt  time.Time `json:"created_at"`
 }
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// DeviceToken.Platform values.
+const (
+	DevicePlatformIOS     = "ios"
+	DevicePlatformAndroid = "android"
+	DevicePlatformWeb     = "web"
+)
+
+// DeviceToken is a push token registered by one of a user's devices. A token belongs to a
+// single user at a time; registering it again moves it to the caller.
+type DeviceToken struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64     `gorm:"not null;index" json:"user_id"`
+	Platform  string    `gorm:"type:varchar(10);not null" json:"platform"`
+	Token     string    `gorm:"type:varchar(512);not null;uniqueIndex" json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (d *DeviceToken) TableName() string { return "device_tokens" }
+
+// NotificationDelivery.Channel values.
+const (
+	DeliveryChannelPush  = "push"
+	DeliveryChannelEmail = "email"
+)
+
+// NotificationDelivery.Status values.
+const (
+	DeliveryStatusPending = "pending"
+	DeliveryStatusSent    = "sent"
+	DeliveryStatusFailed  = "failed"
+)
+
+// NotificationDelivery is one queued send: a push of NotificationID to DeviceTokenID, or an
+// email digest already rendered into Subject and Body for Recipient. Failed sends stay
+// pending with a later NextAttemptAt until they run out of attempts.
+type NotificationDelivery struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID         int64      `gorm:"not null;index" json:"user_id"`
+	Channel        string     `gorm:"type:varchar(10);not null" json:"channel"`
+	NotificationID *int64     `gorm:"index" json:"notification_id"`
+	DeviceTokenID  *int64     `gorm:"index" json:"device_token_id"`
+	Recipient      string     `gorm:"type:varchar(255)" json:"recipient"`
+	Subject        string     `gorm:"type:varchar(255)" json:"subject"`
+	Body           string     `gorm:"type:text" json:"-"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	LastError      string     `gorm:"type:text" json:"last_error"`
+	SentAt         *time.Time `json:"sent_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (d *NotificationDelivery) TableName() string { return "notification_deliveries" }
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rating aggregate subjects.
+const (
+	RatingSubjectMerchant = "merchant"
+	RatingSubjectStore    = "store"
+)
+
+// RatingAggregate holds the running sums behind a merchant's or store's rating columns so
+// they can be maintained incrementally as visible reviews come and go. Sub-ratings and cost
+// keep their own counts because reviews may leave them blank. DecayedSum and DecayedWeight
+// are the recency-weighted rating sum and weight, both discounted to DecayedAt.
+type RatingAggregate struct {
+	SubjectType   string     `gorm:"type:varchar(20);primaryKey" json:"subject_type"`
+	SubjectID     int64      `gorm:"primaryKey" json:"subject_id"`
+	ReviewCount   int        `gorm:"default:0" json:"review_count"`
+	RatingSum     float64    `gorm:"default:0" json:"rating_sum"`
+	Star1         int        `gorm:"column:star_1;default:0" json:"star_1"`
+	Star2         int        `gorm:"column:star_2;default:0" json:"star_2"`
+	Star3         int        `gorm:"column:star_3;default:0" json:"star_3"`
+	Star4         int        `gorm:"column:star_4;default:0" json:"star_4"`
+	Star5         int        `gorm:"column:star_5;default:0" json:"star_5"`
+	EnvSum        float64    `gorm:"default:0" json:"env_sum"`
+	EnvCount      int        `gorm:"default:0" json:"env_count"`
+	ServiceSum    float64    `gorm:"default:0" json:"service_sum"`
+	ServiceCount  int        `gorm:"default:0" json:"service_count"`
+	ValueSum      float64    `gorm:"default:0" json:"value_sum"`
+	ValueCount    int        `gorm:"default:0" json:"value_count"`
+	FoodSum       float64    `gorm:"default:0" json:"food_sum"`
+	FoodCount     int        `gorm:"default:0" json:"food_count"`
+	CostSum       int64      `gorm:"default:0" json:"cost_sum"`
+	CostCount     int        `gorm:"default:0" json:"cost_count"`
+	DecayedSum    float64    `gorm:"default:0" json:"decayed_sum"`
+	DecayedWeight float64    `gorm:"default:0" json:"decayed_weight"`
+	DecayedAt     *time.Time `json:"decayed_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (ra *RatingAggregate) TableName() string { return "rating_aggregates" }
+
+// Histogram returns the number of visible reviews per star, index 0 holding 1-star reviews.
+func (ra *RatingAggregate) Histogram() []int {
+	return []int{ra.Star1, ra.Star2, ra.Star3, ra.Star4, ra.Star5}
+}
+
+// LoadRatingHistogram reads a subject's star histogram, returning zero counts when no
+// aggregate row exists yet.
+func LoadRatingHistogram(db *gorm.DB, subjectType string, subjectID int64) ([]int, error) {
+	var agg RatingAggregate
+	if err := db.Where("subject_type = ? AND subject_id = ?", subjectType, subjectID).
+		Limit(1).Find(&agg).Error; err != nil {
+		return nil, err
+	}
+	return agg.Histogram(), nil
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// RecentSearch is a query a signed-in user ran on /search; repeating a query bumps SearchedAt.
+type RecentSearch struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     int64     `gorm:"not null;uniqueIndex:idx_recent_searches_user_query,priority:1;index:idx_recent_searches_user_time,priority:1" json:"user_id"`
+	Query      string    `gorm:"type:varchar(200);not null;uniqueIndex:idx_recent_searches_user_query,priority:2" json:"query"`
+	SearchedAt time.Time `gorm:"not null;index:idx_recent_searches_user_time,priority:2" json:"searched_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (rs *RecentSearch) TableName() string { return "recent_searches" }
@@ -4,7 +4,7 @@ import "time"
 
 type Report struct {
 	ID           int64      `gorm:"primaryKey;autoIncrement" json:"id"`
-	ReporterID   int64      `gorm:"not null;index" json:"reporter_id"`
+	ReporterID   *int64     `gorm:"index" json:"reporter_id"` // nil for reports filed by automated moderation
 	TargetType   string     `gorm:"type:varchar(20);not null" json:"target_type"`
 	TargetID     int64      `gorm:"not null" json:"target_id"`
 	Reason       string     `gorm:"type:varchar(50);not null" json:"reason"`
@@ -1,37 +1,70 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Review struct {
-	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID        int64     `gorm:"not null;index" json:"user_id"`
-	VenueID       int64     `gorm:"not null;index" json:"venue_id"`
-	MerchantID    int64     `gorm:"not null;index" json:"merchant_id"`
-	StoreID       *int64    `gorm:"index" json:"store_id"`
-	Rating        float32   `gorm:"not null" json:"rating"`
-	RatingEnv     *float32  `json:"rating_env"`
-	RatingService *float32  `json:"rating_service"`
-	RatingValue   *float32  `json:"rating_value"`
-	RatingFood    *float32  `json:"rating_food"`
-	Content       string    `gorm:"type:text" json:"content"`
-	Images        string    `gorm:"type:jsonb;default:'[]'" json:"images"`
-	VisitDate     time.Time `gorm:"not null;type:date" json:"visit_date"`
-	AvgCost       *int      `json:"avg_cost"`
-	LikeCount     int       `gorm:"default:0" json:"like_count"`
-	CommentCount  int       `gorm:"default:0" json:"comment_count"`
-	UserNickname  string    `gorm:"-" json:"user_nickname,omitempty"`
-	UserAvatar    string    `gorm:"-" json:"user_avatar,omitempty"`
-	StoreName     string    `gorm:"-" json:"store_name,omitempty"`
-	Status        int16     `gorm:"default:0" json:"status"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-
-	User     *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Merchant *Merchant `gorm:"foreignKey:MerchantID" json:"merchant,omitempty"`
-	Store    *Store    `gorm:"foreignKey:StoreID" json:"store,omitempty"`
-	Tags     []Tag     `gorm:"many2many:review_tags" json:"tags,omitempty"`
+	ID            int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        int64          `gorm:"not null;index" json:"user_id"`
+	VenueID       int64          `gorm:"not null;index" json:"venue_id"`
+	MerchantID    int64          `gorm:"not null;index" json:"merchant_id"`
+	StoreID       *int64         `gorm:"index" json:"store_id"`
+	Rating        float32        `gorm:"not null" json:"rating"`
+	RatingEnv     *float32       `json:"rating_env"`
+	RatingService *float32       `json:"rating_service"`
+	RatingValue   *float32       `json:"rating_value"`
+	RatingFood    *float32       `json:"rating_food"`
+	Content       string         `gorm:"type:text" json:"content"`
+	Images        string         `gorm:"type:jsonb;default:'[]'" json:"images"`
+	VisitDate     time.Time      `gorm:"not null;type:date" json:"visit_date"`
+	AvgCost       *int           `json:"avg_cost"`
+	LikeCount     int            `gorm:"default:0" json:"like_count"`
+	CommentCount  int            `gorm:"default:0" json:"comment_count"`
+	UserNickname  string         `gorm:"-" json:"user_nickname,omitempty"`
+	UserAvatar    string         `gorm:"-" json:"user_avatar,omitempty"`
+	StoreName     string         `gorm:"-" json:"store_name,omitempty"`
+	Status        int16          `gorm:"default:0" json:"status"`
+	IsVerified    bool           `gorm:"default:false;index" json:"is_verified"`
+	VoucherID     *int64         `gorm:"uniqueIndex" json:"voucher_id,omitempty"`
+	Edited        bool           `gorm:"default:false" json:"edited"`
+	EditedAt      *time.Time     `json:"edited_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	User     *User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Merchant *Merchant     `gorm:"foreignKey:MerchantID" json:"merchant,omitempty"`
+	Store    *Store        `gorm:"foreignKey:StoreID" json:"store,omitempty"`
+	Tags     []Tag         `gorm:"many2many:review_tags" json:"tags,omitempty"`
+	Media    []ReviewMedia `gorm:"foreignKey:ReviewID" json:"media,omitempty"`
+	// MerchantReply is the official merchant response; load it with PreloadMerchantReply.
+	MerchantReply *ReviewComment `gorm:"foreignKey:ReviewID" json:"merchant_reply,omitempty"`
+}
+
+// PreloadMerchantReply loads the visible official merchant reply onto each review.
+func PreloadMerchantReply(db *gorm.DB) *gorm.DB {
+	return db.Preload("MerchantReply", "is_merchant_reply = ? AND status = ?", true, ContentStatusVisible)
 }
 
 func (r *Review) TableName() string {
 	return "reviews"
 }
+
+// ReviewEdit keeps the previous version of a review each time its author edits it.
+type ReviewEdit struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReviewID  int64     `gorm:"not null;index" json:"review_id"`
+	UserID    int64     `gorm:"not null" json:"user_id"`
+	Rating    float32   `gorm:"not null" json:"rating"`
+	Content   string    `gorm:"type:text" json:"content"`
+	Images    string    `gorm:"type:jsonb;default:'[]'" json:"images"`
+	VisitDate time.Time `gorm:"not null;type:date" json:"visit_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e *ReviewEdit) TableName() string {
+	return "review_edits"
+}
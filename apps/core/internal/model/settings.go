@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type UserPrivacy struct {
 	UserID   int64 `gorm:"primaryKey" json:"user_id"`
@@ -11,16 +14,68 @@ func (u *UserPrivacy) TableName() string {
 	return "user_privacies"
 }
 
+// UserNotification.EmailDigest values.
+const (
+	EmailDigestOff    = "off"
+	EmailDigestDaily  = "daily"
+	EmailDigestWeekly = "weekly"
+)
+
+// UserNotification holds a user's delivery settings. ChannelPreferences maps a notification
+// type to per-channel switches, e.g. {"like":{"push":false}}; missing entries are enabled.
+// Quiet hours are "HH:MM" in Timezone and hold pushes back until they end.
 type UserNotification struct {
-	UserID       int64 `gorm:"primaryKey" json:"user_id"`
-	PushEnabled  bool  `gorm:"default:true" json:"push_enabled"`
-	EmailEnabled bool  `gorm:"default:true" json:"email_enabled"`
+	UserID             int64      `gorm:"primaryKey" json:"user_id"`
+	PushEnabled        bool       `gorm:"default:true" json:"push_enabled"`
+	EmailEnabled       bool       `gorm:"default:true" json:"email_enabled"`
+	ChannelPreferences string     `gorm:"type:jsonb;default:'{}'" json:"channel_preferences"`
+	QuietHoursStart    string     `gorm:"type:varchar(5)" json:"quiet_hours_start"`
+	QuietHoursEnd      string     `gorm:"type:varchar(5)" json:"quiet_hours_end"`
+	Timezone           string     `gorm:"type:varchar(64)" json:"timezone"`
+	EmailDigest        string     `gorm:"type:varchar(10);default:'daily'" json:"email_digest"`
+	LastDigestAt       *time.Time `json:"last_digest_at"`
 }
 
 func (u *UserNotification) TableName() string {
 	return "user_notifications"
 }
 
+// ChannelPreference switches one notification type on or off per channel. Nil means enabled.
+type ChannelPreference struct {
+	Push  *bool `json:"push,omitempty"`
+	Email *bool `json:"email,omitempty"`
+}
+
+// Preferences decodes ChannelPreferences, treating malformed JSON as no preferences.
+func (u *UserNotification) Preferences() map[string]ChannelPreference {
+	prefs := map[string]ChannelPreference{}
+	if u.ChannelPreferences != "" {
+		_ = json.Unmarshal([]byte(u.ChannelPreferences), &prefs)
+	}
+	return prefs
+}
+
+// Allows reports whether notifications of the given type may be sent over channel, taking
+// both the channel's master switch and the per-type preference into account.
+func (u *UserNotification) Allows(channel, notificationType string) bool {
+	var pref *bool
+	switch channel {
+	case DeliveryChannelPush:
+		if !u.PushEnabled {
+			return false
+		}
+		pref = u.Preferences()[notificationType].Push
+	case DeliveryChannelEmail:
+		if !u.EmailEnabled {
+			return false
+		}
+		pref = u.Preferences()[notificationType].Email
+	default:
+		return false
+	}
+	return pref == nil || *pref
+}
+
 type AccountDeletion struct {
 	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	UserID      int64     `gorm:"not null;uniqueIndex" json:"user_id"`
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// SocketTicket is a short-lived, single-use credential for opening the WebSocket. Browsers
+// cannot set headers on a WebSocket handshake, so they trade their JWT for a ticket over
+// REST and pass the ticket in the URL instead of the JWT. Only the ticket's hash is stored.
+type SocketTicket struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    int64     `gorm:"not null;index" json:"user_id"`
+	TokenHash string    `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (t *SocketTicket) TableName() string {
+	return "socket_tickets"
+}
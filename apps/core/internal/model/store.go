@@ -7,29 +7,41 @@ import (
 )
 
 type Store struct {
-	ID            int64          `gorm:"primaryKey;autoIncrement" json:"id"`
-	MerchantID    int64          `gorm:"not null;index" json:"merchant_id"`
-	Name          string         `gorm:"type:varchar(255);not null" json:"name"`
-	Description   string         `gorm:"type:text" json:"description"`
-	Address       string         `gorm:"type:varchar(255)" json:"address"`
-	City          string         `gorm:"type:varchar(100)" json:"city"`
-	State         string         `gorm:"type:varchar(100)" json:"state"`
-	ZipCode       string         `gorm:"type:varchar(20)" json:"zip_code"`
-	Country       string         `gorm:"type:varchar(50)" json:"country"`
-	Phone         string         `gorm:"type:varchar(50)" json:"phone"`
-	Website       string         `gorm:"type:varchar(255)" json:"website"`
-	Latitude      float64        `json:"latitude"`
-	Longitude     float64        `json:"longitude"`
-	CoverImageURL string         `gorm:"type:varchar(255)" json:"cover_image_url"`
-	Images        string         `gorm:"type:jsonb;default:'[]'" json:"images"`
-	MenuImages    string         `gorm:"type:jsonb;default:'[]'" json:"menu_images"`
-	AvgRating     float32        `gorm:"default:0" json:"avg_rating"`
-	ReviewCount   int            `gorm:"default:0" json:"review_count"`
-	FollowerCount int            `gorm:"default:0" json:"follower_count"`
-	Status        int16          `gorm:"default:0" json:"status"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID               int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	MerchantID       int64          `gorm:"not null;index" json:"merchant_id"`
+	Name             string         `gorm:"type:varchar(255);not null" json:"name"`
+	Description      string         `gorm:"type:text" json:"description"`
+	Address          string         `gorm:"type:varchar(255)" json:"address"`
+	City             string         `gorm:"type:varchar(100)" json:"city"`
+	State            string         `gorm:"type:varchar(100)" json:"state"`
+	ZipCode          string         `gorm:"type:varchar(20)" json:"zip_code"`
+	Country          string         `gorm:"type:varchar(50)" json:"country"`
+	Timezone         string         `gorm:"type:varchar(64);not null;default:'UTC'" json:"timezone"`
+	Phone            string         `gorm:"type:varchar(50)" json:"phone"`
+	Website          string         `gorm:"type:varchar(255)" json:"website"`
+	Latitude         float64        `json:"latitude"`
+	Longitude        float64        `json:"longitude"`
+	DistanceKM       *float64       `gorm:"-" json:"distance_km,omitempty"`
+	CoverImageURL    string         `gorm:"type:varchar(255)" json:"cover_image_url"`
+	Images           string         `gorm:"type:jsonb;default:'[]'" json:"images"`
+	MenuImages       string         `gorm:"type:jsonb;default:'[]'" json:"menu_images"`
+	AvgRating        float32        `gorm:"default:0" json:"avg_rating"`
+	AvgRatingEnv     float32        `gorm:"default:0" json:"avg_rating_env"`
+	AvgRatingService float32        `gorm:"default:0" json:"avg_rating_service"`
+	AvgRatingValue   float32        `gorm:"default:0" json:"avg_rating_value"`
+	AvgRatingFood    float32        `gorm:"default:0" json:"avg_rating_food"`
+	AvgCost          int            `gorm:"default:0" json:"avg_cost"`
+	BayesianRating   float32        `gorm:"default:0" json:"bayesian_rating"`
+	RecentRating     float32        `gorm:"default:0" json:"recent_rating"`
+	RatingHistogram  []int          `gorm:"-" json:"rating_histogram,omitempty"`
+	IsOpen           *bool          `gorm:"-" json:"is_open,omitempty"`
+	NextChangeAt     *time.Time     `gorm:"-" json:"next_change_at,omitempty"`
+	ReviewCount      int            `gorm:"default:0" json:"review_count"`
+	FollowerCount    int            `gorm:"default:0" json:"follower_count"`
+	Status           int16          `gorm:"default:0" json:"status"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	Merchant   *Merchant   `gorm:"foreignKey:MerchantID" json:"merchant,omitempty"`
 	Categories []Category  `gorm:"many2many:store_categories" json:"categories,omitempty"`
@@ -48,3 +60,18 @@ type StoreHour struct {
 }
 
 func (sh *StoreHour) TableName() string { return "store_hours" }
+
+// StoreSpecialHour overrides a store's weekly hours on one local date (YYYY-MM-DD), e.g. for
+// a holiday. A date may have several intervals; an IsClosed row closes the store all day.
+type StoreSpecialHour struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	StoreID   int64     `gorm:"not null;index:idx_store_special_hours_store_date,priority:1" json:"store_id"`
+	Date      string    `gorm:"type:varchar(10);not null;index:idx_store_special_hours_store_date,priority:2" json:"date"`
+	OpenTime  string    `gorm:"type:varchar(10)" json:"open_time"`
+	CloseTime string    `gorm:"type:varchar(10)" json:"close_time"`
+	IsClosed  bool      `gorm:"default:false" json:"is_closed"`
+	Note      string    `gorm:"type:varchar(100)" json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (sh *StoreSpecialHour) TableName() string { return "store_special_hours" }
@@ -580,7 +580,7 @@ func TestStoreListSupportsFilteringAndCursorPagination(t *testing.T) {
 
 	var firstPage struct {
 		Data   []model.Store `json:"data"`
-		Cursor *int64        `json:"cursor"`
+		Cursor *string       `json:"cursor"`
 	}
 	if err := json.Unmarshal(w.Body.Bytes(), &firstPage); err != nil {
 		t.Fatalf("failed to decode first-page response: %v", err)
@@ -596,7 +596,7 @@ func TestStoreListSupportsFilteringAndCursorPagination(t *testing.T) {
 	}
 
 	w = httptest.NewRecorder()
-	req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/stores?category=Cafe&rating=4&lat=37.7750&lng=-122.4190&radius_km=5&limit=1&cursor=%d", *firstPage.Cursor), nil)
+	req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/stores?category=Cafe&rating=4&lat=37.7750&lng=-122.4190&radius_km=5&limit=1&cursor=%s", *firstPage.Cursor), nil)
 	r.ServeHTTP(w, req)
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected second page 200, got %d", w.Code)
@@ -604,7 +604,7 @@ func TestStoreListSupportsFilteringAndCursorPagination(t *testing.T) {
 
 	var secondPage struct {
 		Data   []model.Store `json:"data"`
-		Cursor *int64        `json:"cursor"`
+		Cursor *string       `json:"cursor"`
 	}
 	if err := json.Unmarshal(w.Body.Bytes(), &secondPage); err != nil {
 		t.Fatalf("failed to decode second-page response: %v", err)
@@ -678,7 +678,7 @@ func TestStoreReviewsSupportsCursorPaginationAndUserPreload(t *testing.T) {
 
 	var firstPage struct {
 		Data   []model.Review `json:"data"`
-		Cursor *int64         `json:"cursor"`
+		Cursor *string        `json:"cursor"`
 	}
 	if err := json.Unmarshal(w.Body.Bytes(), &firstPage); err != nil {
 		t.Fatalf("failed to decode first-page reviews: %v", err)
@@ -694,7 +694,7 @@ func TestStoreReviewsSupportsCursorPaginationAndUserPreload(t *testing.T) {
 	}
 
 	w = httptest.NewRecorder()
-	req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/stores/%d/reviews?limit=2&cursor=%d", store.ID, *firstPage.Cursor), nil)
+	req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/stores/%d/reviews?limit=2&cursor=%s", store.ID, *firstPage.Cursor), nil)
 	r.ServeHTTP(w, req)
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected second page 200, got %d", w.Code)
@@ -702,7 +702,7 @@ func TestStoreReviewsSupportsCursorPaginationAndUserPreload(t *testing.T) {
 
 	var secondPage struct {
 		Data   []model.Review `json:"data"`
-		Cursor *int64         `json:"cursor"`
+		Cursor *string        `json:"cursor"`
 	}
 	if err := json.Unmarshal(w.Body.Bytes(), &secondPage); err != nil {
 		t.Fatalf("failed to decode second-page reviews: %v", err)
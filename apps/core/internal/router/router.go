@@ -1,6 +1,8 @@
 package router
 
 import (
+	"context"
+
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/config"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/admin"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/ai"
@@ -13,11 +15,13 @@ import (
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/follow"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/media"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/merchant"
+	moderationservice "github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/moderation/service"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/notification"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/order"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/payment"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/profile"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/review"
+	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/search"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/store"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/user"
 	"github.com/RevieU-Corp/revieu-backend/apps/core/internal/domain/verification"
@@ -28,25 +32,29 @@ import (
 // Setup registers all domain routes under the API base path.
 func Setup(router *gin.Engine, cfg *config.Config) {
 	api := router.Group(cfg.Server.APIBasePath)
+	// One moderation pipeline for all user content, so the repeat tracker sees every
+	// submission and late verdicts reach whichever domain registered the target type.
+	moderation := moderationservice.NewModerationServiceFromConfig(context.Background(), nil, cfg)
 
 	auth.RegisterRoutes(api, cfg)
 	ai.RegisterRoutes(api, cfg)
 	user.RegisterRoutes(api, cfg)
 	profile.RegisterRoutes(api, cfg)
 	follow.RegisterRoutes(api, cfg)
-	content.RegisterRoutes(api, cfg)
+	content.RegisterRoutes(api, cfg, moderation)
 	coupon.RegisterRoutes(api, cfg)
 	feed.RegisterRoutes(api, cfg)
-	merchant.RegisterRoutes(api, cfg)
+	merchant.RegisterRoutes(api, cfg, moderation)
 	media.RegisterRoutes(api, cfg)
 	payment.RegisterRoutes(api, cfg)
-	review.RegisterRoutes(api, cfg)
+	review.RegisterRoutes(api, cfg, moderation)
 	voucher.RegisterRoutes(api, cfg)
 	store.RegisterRoutes(api, cfg)
 	category.RegisterRoutes(api, cfg)
-	conversation.RegisterRoutes(api, cfg)
+	conversation.RegisterRoutes(api, cfg, moderation)
 	notification.RegisterRoutes(api, cfg)
 	verification.RegisterRoutes(api, cfg)
 	admin.RegisterRoutes(api, cfg)
 	order.RegisterRoutes(api, cfg)
+	search.RegisterRoutes(api, cfg)
 }
@@ -26,26 +26,49 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&model.Merchant{},
 		&model.Store{},
 		&model.StoreHour{},
+		&model.StoreSpecialHour{},
 		&model.Category{},
+		&model.CategoryTranslation{},
 		&model.StoreCategory{},
 		&model.Tag{},
 		&model.Post{},
+		&model.PostComment{},
 		&model.Review{},
 		&model.ReviewComment{},
+		&model.ReviewEdit{},
+		&model.RatingAggregate{},
+		&model.ReviewMedia{},
 		&model.Package{},
 		&model.Coupon{},
 		&model.Order{},
 		&model.Voucher{},
 		&model.Payment{},
 		&model.MediaUpload{},
+		&model.MarketingPost{},
+		&model.MerchantAnalytics{},
+		&model.Conversation{},
+		&model.ConversationParticipant{},
+		&model.SocketTicket{},
+		&model.FeedSnapshot{},
+		&model.Message{},
+		&model.MessageDeletion{},
 		&model.UserFollow{},
 		&model.MerchantFollow{},
+		&model.FollowRequest{},
+		&model.UserBlock{},
+		&model.UserMute{},
+		&model.StoreFollow{},
 		&model.Like{},
 		&model.Favorite{},
 		&model.UserAddress{},
 		&model.UserPrivacy{},
 		&model.UserNotification{},
+		&model.Notification{},
+		&model.DeviceToken{},
+		&model.NotificationDelivery{},
 		&model.AccountDeletion{},
+		&model.Report{},
+		&model.RecentSearch{},
 	); err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
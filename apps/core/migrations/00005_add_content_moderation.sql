@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS status SMALLINT NOT NULL DEFAULT 0;
+
+-- Reports filed by the automated moderation pipeline have no human reporter.
+ALTER TABLE reports ALTER COLUMN reporter_id DROP NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports (target_type, target_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_reports_target;
+DELETE FROM reports WHERE reporter_id IS NULL;
+ALTER TABLE reports ALTER COLUMN reporter_id SET NOT NULL;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS status;
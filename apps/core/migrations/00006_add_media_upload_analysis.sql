@@ -0,0 +1,25 @@
+-- +goose Up
+
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS detected_mime_type VARCHAR(100);
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS perceptual_hash VARCHAR(16);
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS duplicate_of_id BIGINT REFERENCES media_uploads(id) ON DELETE SET NULL;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS safety_label VARCHAR(20);
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS safety_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS rejection_reason VARCHAR(50);
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS analyzed_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_media_uploads_perceptual_hash ON media_uploads (perceptual_hash);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_media_uploads_perceptual_hash;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS analyzed_at;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS rejection_reason;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS safety_score;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS safety_label;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS duplicate_of_id;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS perceptual_hash;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS height;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS width;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS detected_mime_type;
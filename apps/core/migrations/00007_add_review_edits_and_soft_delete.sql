@@ -0,0 +1,27 @@
+-- +goose Up
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS edited BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_reviews_deleted_at ON reviews (deleted_at);
+
+CREATE TABLE IF NOT EXISTS review_edits (
+    id BIGSERIAL PRIMARY KEY,
+    review_id BIGINT NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating REAL NOT NULL,
+    content TEXT,
+    images JSONB DEFAULT '[]',
+    visit_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_review_edits_review_id ON review_edits (review_id);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS review_edits;
+
+DROP INDEX IF EXISTS idx_reviews_deleted_at;
+ALTER TABLE reviews DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE reviews DROP COLUMN IF EXISTS edited_at;
+ALTER TABLE reviews DROP COLUMN IF EXISTS edited;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+ALTER TABLE review_comments ADD COLUMN IF NOT EXISTS like_count INT DEFAULT 0;
+ALTER TABLE review_comments ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_review_comments_deleted_at ON review_comments (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_review_comments_parent_comment_id ON review_comments (parent_comment_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_review_comments_parent_comment_id;
+DROP INDEX IF EXISTS idx_review_comments_deleted_at;
+ALTER TABLE review_comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE review_comments DROP COLUMN IF EXISTS like_count;
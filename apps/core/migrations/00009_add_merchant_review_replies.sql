@@ -0,0 +1,9 @@
+-- +goose Up
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_review_comments_merchant_reply
+    ON review_comments (review_id)
+    WHERE is_merchant_reply AND deleted_at IS NULL;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_review_comments_merchant_reply;
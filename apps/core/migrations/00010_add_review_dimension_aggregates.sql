@@ -0,0 +1,27 @@
+-- +goose Up
+
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS avg_rating_env REAL DEFAULT 0;
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS avg_rating_service REAL DEFAULT 0;
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS avg_rating_value REAL DEFAULT 0;
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS avg_rating_food REAL DEFAULT 0;
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS avg_cost INT DEFAULT 0;
+
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS avg_rating_env REAL DEFAULT 0;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS avg_rating_service REAL DEFAULT 0;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS avg_rating_value REAL DEFAULT 0;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS avg_rating_food REAL DEFAULT 0;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS avg_cost INT DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE stores DROP COLUMN IF EXISTS avg_cost;
+ALTER TABLE stores DROP COLUMN IF EXISTS avg_rating_food;
+ALTER TABLE stores DROP COLUMN IF EXISTS avg_rating_value;
+ALTER TABLE stores DROP COLUMN IF EXISTS avg_rating_service;
+ALTER TABLE stores DROP COLUMN IF EXISTS avg_rating_env;
+
+ALTER TABLE merchants DROP COLUMN IF EXISTS avg_cost;
+ALTER TABLE merchants DROP COLUMN IF EXISTS avg_rating_food;
+ALTER TABLE merchants DROP COLUMN IF EXISTS avg_rating_value;
+ALTER TABLE merchants DROP COLUMN IF EXISTS avg_rating_service;
+ALTER TABLE merchants DROP COLUMN IF EXISTS avg_rating_env;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS voucher_id BIGINT REFERENCES vouchers(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_reviews_is_verified ON reviews (is_verified);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_voucher_id ON reviews (voucher_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_user_store_created ON reviews (user_id, store_id, created_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_reviews_user_store_created;
+DROP INDEX IF EXISTS idx_reviews_voucher_id;
+DROP INDEX IF EXISTS idx_reviews_is_verified;
+ALTER TABLE reviews DROP COLUMN IF EXISTS voucher_id;
+ALTER TABLE reviews DROP COLUMN IF EXISTS is_verified;
@@ -0,0 +1,44 @@
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS rating_aggregates (
+    subject_type VARCHAR(20) NOT NULL,
+    subject_id BIGINT NOT NULL,
+    review_count INT NOT NULL DEFAULT 0,
+    rating_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    star_1 INT NOT NULL DEFAULT 0,
+    star_2 INT NOT NULL DEFAULT 0,
+    star_3 INT NOT NULL DEFAULT 0,
+    star_4 INT NOT NULL DEFAULT 0,
+    star_5 INT NOT NULL DEFAULT 0,
+    env_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    env_count INT NOT NULL DEFAULT 0,
+    service_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    service_count INT NOT NULL DEFAULT 0,
+    value_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    value_count INT NOT NULL DEFAULT 0,
+    food_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    food_count INT NOT NULL DEFAULT 0,
+    cost_sum BIGINT NOT NULL DEFAULT 0,
+    cost_count INT NOT NULL DEFAULT 0,
+    decayed_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    decayed_weight DOUBLE PRECISION NOT NULL DEFAULT 0,
+    decayed_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (subject_type, subject_id)
+);
+
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS bayesian_rating REAL DEFAULT 0;
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS recent_rating REAL DEFAULT 0;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS bayesian_rating REAL DEFAULT 0;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS recent_rating REAL DEFAULT 0;
+
+-- Aggregates start empty; run `make backfill-ratings` once after migrating.
+
+-- +goose Down
+
+ALTER TABLE stores DROP COLUMN IF EXISTS recent_rating;
+ALTER TABLE stores DROP COLUMN IF EXISTS bayesian_rating;
+ALTER TABLE merchants DROP COLUMN IF EXISTS recent_rating;
+ALTER TABLE merchants DROP COLUMN IF EXISTS bayesian_rating;
+
+DROP TABLE IF EXISTS rating_aggregates;
@@ -0,0 +1,56 @@
+-- +goose Up
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Weighted search vectors: names and titles weigh A, secondary labels B, free text C.
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(city, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_stores_search_vector ON stores USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_stores_name_trgm ON stores USING GIN (name gin_trgm_ops);
+
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(business_name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(category, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_merchants_search_vector ON merchants USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_merchants_name_trgm ON merchants USING GIN (name gin_trgm_ops);
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(content, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_reviews_search_vector ON reviews USING GIN (search_vector);
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(content, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_posts_title_trgm ON posts USING GIN (title gin_trgm_ops);
+
+-- Category and tag names are matched as weight-B labels of the documents they belong to.
+CREATE INDEX IF NOT EXISTS idx_categories_name_trgm ON categories USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_tags_name_trgm ON tags USING GIN (name gin_trgm_ops);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_tags_name_trgm;
+DROP INDEX IF EXISTS idx_categories_name_trgm;
+
+DROP INDEX IF EXISTS idx_posts_title_trgm;
+DROP INDEX IF EXISTS idx_posts_search_vector;
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_reviews_search_vector;
+ALTER TABLE reviews DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_merchants_name_trgm;
+DROP INDEX IF EXISTS idx_merchants_search_vector;
+ALTER TABLE merchants DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_stores_name_trgm;
+DROP INDEX IF EXISTS idx_stores_search_vector;
+ALTER TABLE stores DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS recent_searches (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    query VARCHAR(200) NOT NULL,
+    searched_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recent_searches_user_query ON recent_searches (user_id, query);
+CREATE INDEX IF NOT EXISTS idx_recent_searches_user_time ON recent_searches (user_id, searched_at);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS recent_searches;
@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- Bounding-box prefilter for proximity queries.
+CREATE INDEX IF NOT EXISTS idx_stores_lat_lng ON stores (latitude, longitude);
+
+-- Keyset pagination for rating and popularity sorts of published stores.
+CREATE INDEX IF NOT EXISTS idx_stores_published_rating ON stores (bayesian_rating DESC, id DESC)
+    WHERE status = 1 AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_stores_published_popularity ON stores (review_count DESC, id DESC)
+    WHERE status = 1 AND deleted_at IS NULL;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_stores_published_popularity;
+DROP INDEX IF EXISTS idx_stores_published_rating;
+DROP INDEX IF EXISTS idx_stores_lat_lng;
@@ -0,0 +1,20 @@
+-- +goose Up
+
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE IF NOT EXISTS store_special_hours (
+    id BIGSERIAL PRIMARY KEY,
+    store_id BIGINT NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    date VARCHAR(10) NOT NULL,
+    open_time VARCHAR(10),
+    close_time VARCHAR(10),
+    is_closed BOOLEAN DEFAULT false,
+    note VARCHAR(100),
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_store_special_hours_store_date ON store_special_hours (store_id, date);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS store_special_hours;
+ALTER TABLE stores DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,14 @@
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS category_translations (
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    locale VARCHAR(16) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    PRIMARY KEY (category_id, locale)
+);
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_categories_parent_id;
+DROP TABLE IF EXISTS category_translations;
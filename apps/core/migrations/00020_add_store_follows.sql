@@ -0,0 +1,21 @@
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS store_follows (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    store_id BIGINT NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_store_follow ON store_follows (user_id, store_id);
+CREATE INDEX IF NOT EXISTS idx_store_follows_store_id ON store_follows (store_id);
+
+-- Backfill merchants.follower_count, which follows never maintained.
+UPDATE merchants SET follower_count = (
+    SELECT COUNT(*) FROM merchant_follows WHERE merchant_follows.merchant_id = merchants.id
+);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_store_follows_store_id;
+DROP INDEX IF EXISTS idx_store_follow;
+DROP TABLE IF EXISTS store_follows;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS deleted_for_everyone_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_messages_conv_id ON messages (conversation_id, id);
+
+CREATE TABLE IF NOT EXISTS message_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_message_deletion ON message_deletions (message_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_message_deletions_user_id ON message_deletions (user_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_message_deletions_user_id;
+DROP INDEX IF EXISTS idx_message_deletion;
+DROP TABLE IF EXISTS message_deletions;
+
+DROP INDEX IF EXISTS idx_messages_conv_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS deleted_for_everyone_at;
+ALTER TABLE messages DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS merchant_id BIGINT REFERENCES merchants(id) ON DELETE CASCADE;
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS store_id BIGINT REFERENCES stores(id) ON DELETE SET NULL;
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS order_id BIGINT REFERENCES orders(id) ON DELETE SET NULL;
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS voucher_id BIGINT REFERENCES vouchers(id) ON DELETE SET NULL;
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS support_status VARCHAR(20);
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS assignee_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS first_response_at TIMESTAMPTZ;
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS resolved_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_conversations_merchant_id ON conversations (merchant_id, support_status);
+CREATE INDEX IF NOT EXISTS idx_conversations_store_id ON conversations (store_id);
+CREATE INDEX IF NOT EXISTS idx_conversations_assignee_id ON conversations (assignee_id);
+
+ALTER TABLE merchant_analytics ADD COLUMN IF NOT EXISTS conversations_started INT DEFAULT 0;
+ALTER TABLE merchant_analytics ADD COLUMN IF NOT EXISTS conversations_responded INT DEFAULT 0;
+ALTER TABLE merchant_analytics ADD COLUMN IF NOT EXISTS response_time_seconds BIGINT DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE merchant_analytics DROP COLUMN IF EXISTS response_time_seconds;
+ALTER TABLE merchant_analytics DROP COLUMN IF EXISTS conversations_responded;
+ALTER TABLE merchant_analytics DROP COLUMN IF EXISTS conversations_started;
+
+DROP INDEX IF EXISTS idx_conversations_assignee_id;
+DROP INDEX IF EXISTS idx_conversations_store_id;
+DROP INDEX IF EXISTS idx_conversations_merchant_id;
+ALTER TABLE conversations DROP COLUMN IF EXISTS resolved_at;
+ALTER TABLE conversations DROP COLUMN IF EXISTS first_response_at;
+ALTER TABLE conversations DROP COLUMN IF EXISTS assignee_id;
+ALTER TABLE conversations DROP COLUMN IF EXISTS support_status;
+ALTER TABLE conversations DROP COLUMN IF EXISTS voucher_id;
+ALTER TABLE conversations DROP COLUMN IF EXISTS order_id;
+ALTER TABLE conversations DROP COLUMN IF EXISTS store_id;
+ALTER TABLE conversations DROP COLUMN IF EXISTS merchant_id;
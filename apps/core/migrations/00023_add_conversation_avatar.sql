@@ -0,0 +1,7 @@
+-- +goose Up
+
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(255);
+
+-- +goose Down
+
+ALTER TABLE conversations DROP COLUMN IF EXISTS avatar_url;
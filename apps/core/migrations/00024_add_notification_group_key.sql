@@ -0,0 +1,11 @@
+-- +goose Up
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS group_key VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_group_key ON notifications (group_key);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_notifications_group_key;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS group_key;
@@ -0,0 +1,59 @@
+-- +goose Up
+
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS channel_preferences JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS quiet_hours_start VARCHAR(5);
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS quiet_hours_end VARCHAR(5);
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS email_digest VARCHAR(10) NOT NULL DEFAULT 'daily';
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS last_digest_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS device_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(10) NOT NULL,
+    token VARCHAR(512) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_device_tokens_token ON device_tokens (token);
+CREATE INDEX IF NOT EXISTS idx_device_tokens_user_id ON device_tokens (user_id);
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(10) NOT NULL,
+    notification_id BIGINT REFERENCES notifications(id) ON DELETE CASCADE,
+    device_token_id BIGINT REFERENCES device_tokens(id) ON DELETE CASCADE,
+    recipient VARCHAR(255),
+    subject VARCHAR(255),
+    body TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_user_id ON notification_deliveries (user_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_notification_id ON notification_deliveries (notification_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_device_token_id ON notification_deliveries (device_token_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due ON notification_deliveries (status, next_attempt_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_notification_deliveries_due;
+DROP INDEX IF EXISTS idx_notification_deliveries_device_token_id;
+DROP INDEX IF EXISTS idx_notification_deliveries_notification_id;
+DROP INDEX IF EXISTS idx_notification_deliveries_user_id;
+DROP TABLE IF EXISTS notification_deliveries;
+
+DROP INDEX IF EXISTS idx_device_tokens_user_id;
+DROP INDEX IF EXISTS idx_device_tokens_token;
+DROP TABLE IF EXISTS device_tokens;
+
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS last_digest_at;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS email_digest;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS timezone;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS quiet_hours_end;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS quiet_hours_start;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS channel_preferences;
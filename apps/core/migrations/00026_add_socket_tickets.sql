@@ -0,0 +1,19 @@
+-- +goose Up
+
+CREATE TABLE IF NOT EXISTS socket_tickets (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_socket_tickets_token_hash ON socket_tickets (token_hash);
+CREATE INDEX IF NOT EXISTS idx_socket_tickets_user_id ON socket_tickets (user_id);
+CREATE INDEX IF NOT EXISTS idx_socket_tickets_expires_at ON socket_tickets (expires_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_socket_tickets_expires_at;
+DROP INDEX IF EXISTS idx_socket_tickets_user_id;
+DROP INDEX IF EXISTS idx_socket_tickets_token_hash;
+DROP TABLE IF EXISTS socket_tickets;
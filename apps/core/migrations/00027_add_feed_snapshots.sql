@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- user_id is 0 for anonymous feeds, so it has no foreign key.
+CREATE TABLE IF NOT EXISTS feed_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    items JSONB NOT NULL,
+    since TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_feed_snapshots_user_id ON feed_snapshots (user_id);
+CREATE INDEX IF NOT EXISTS idx_feed_snapshots_expires_at ON feed_snapshots (expires_at);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_feed_snapshots_expires_at;
+DROP INDEX IF EXISTS idx_feed_snapshots_user_id;
+DROP TABLE IF EXISTS feed_snapshots;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Groups created before roles existed have only members, so nobody could manage them.
+-- Promote each such group's earliest-joined participant to owner.
+UPDATE conversation_participants SET role = 'owner'
+WHERE id IN (
+    SELECT DISTINCT ON (cp.conversation_id) cp.id
+    FROM conversation_participants cp
+    JOIN conversations c ON c.id = cp.conversation_id
+    WHERE c.type = 'group'
+      AND NOT EXISTS (
+          SELECT 1 FROM conversation_participants o
+          WHERE o.conversation_id = cp.conversation_id AND o.role = 'owner'
+      )
+    ORDER BY cp.conversation_id, cp.joined_at, cp.id
+);
+
+-- +goose Down
+
+-- The promoted owners cannot be told apart from owners set since, so they are kept.
+SELECT 1;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- Category and tag names are matched with to_tsvector('simple', name), and matching tags are
+-- followed to the reviews and posts that carry them.
+CREATE INDEX IF NOT EXISTS idx_categories_name_tsv ON categories USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS idx_tags_name_tsv ON tags USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS idx_review_tags_tag_id ON review_tags (tag_id);
+CREATE INDEX IF NOT EXISTS idx_post_tags_tag_id ON post_tags (tag_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_post_tags_tag_id;
+DROP INDEX IF EXISTS idx_review_tags_tag_id;
+DROP INDEX IF EXISTS idx_tags_name_tsv;
+DROP INDEX IF EXISTS idx_categories_name_tsv;
@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- comment_count now counts visible comments only, so held and hidden ones counted before are
+-- taken out.
+UPDATE reviews SET comment_count = (
+    SELECT COUNT(*) FROM review_comments rc
+    WHERE rc.review_id = reviews.id AND rc.status = 0 AND rc.deleted_at IS NULL
+);
+UPDATE posts SET comment_count = (
+    SELECT COUNT(*) FROM post_comments pc
+    WHERE pc.post_id = posts.id AND pc.status = 0
+);
+
+-- +goose Down
+
+-- The counts before the recount are not kept; they are left as recounted.
+SELECT 1;
@@ -0,0 +1,86 @@
+// Package geo provides great-circle distance helpers for proximity queries.
+package geo
+
+import (
+	"math"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// EarthRadiusKM is the mean Earth radius used by the haversine formula.
+const EarthRadiusKM = 6371.0088
+
+// HaversineKM returns the great-circle distance in kilometres between two points.
+func HaversineKM(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadiusKM * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// DistanceSQL returns a SQL expression for the haversine distance in kilometres from
+// (lat, lng) to the given columns, with its bind arguments. It needs the trigonometric
+// functions Postgres provides out of the box.
+func DistanceSQL(latColumn, lngColumn string, lat, lng float64) (string, []interface{}) {
+	sql := "(2 * " + strconv.FormatFloat(EarthRadiusKM, 'f', -1, 64) + " * ASIN(LEAST(1, SQRT(" +
+		"POWER(SIN(RADIANS(" + latColumn + " - ?) / 2), 2) + " +
+		"COS(RADIANS(?)) * COS(RADIANS(" + latColumn + ")) * POWER(SIN(RADIANS(" + lngColumn + " - ?) / 2), 2)))))"
+	return sql, []interface{}{lat, lat, lng}
+}
+
+// Box is a latitude/longitude bounding box. MinLng > MaxLng means the box crosses the
+// antimeridian.
+type Box struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// BoundingBox returns the smallest box containing every point within radiusKM of
+// (lat, lng). Near the poles the box spans all longitudes.
+func BoundingBox(lat, lng, radiusKM float64) Box {
+	angular := radiusKM / EarthRadiusKM
+	latDelta := degrees(angular)
+	box := Box{MinLat: math.Max(lat-latDelta, -90), MaxLat: math.Min(lat+latDelta, 90), MinLng: -180, MaxLng: 180}
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+	ratio := math.Sin(angular) / math.Cos(radians(lat))
+	if ratio >= 1 {
+		return box
+	}
+	lngDelta := degrees(math.Asin(ratio))
+	box.MinLng = wrapLng(lng - lngDelta)
+	box.MaxLng = wrapLng(lng + lngDelta)
+	return box
+}
+
+// Scope filters rows to the box using the given columns, so an index on them can narrow
+// candidates before an exact distance check.
+func (b Box) Scope(latColumn, lngColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where(latColumn+" BETWEEN ? AND ?", b.MinLat, b.MaxLat)
+		switch {
+		case b.MinLng == -180 && b.MaxLng == 180:
+			return db
+		case b.MinLng > b.MaxLng:
+			return db.Where("("+lngColumn+" >= ? OR "+lngColumn+" <= ?)", b.MinLng, b.MaxLng)
+		default:
+			return db.Where(lngColumn+" BETWEEN ? AND ?", b.MinLng, b.MaxLng)
+		}
+	}
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+func wrapLng(lng float64) float64 {
+	switch {
+	case lng < -180:
+		return lng + 360
+	case lng > 180:
+		return lng - 360
+	}
+	return lng
+}
@@ -0,0 +1,38 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHaversineKM(t *testing.T) {
+	// San Francisco to Los Angeles is about 559 km.
+	if d := HaversineKM(37.7749, -122.4194, 34.0522, -118.2437); math.Abs(d-559) > 2 {
+		t.Fatalf("unexpected distance %.1f", d)
+	}
+	if d := HaversineKM(10, 20, 10, 20); d != 0 {
+		t.Fatalf("expected zero distance, got %f", d)
+	}
+}
+
+func TestBoundingBoxWidensWithLatitudeAndWrapsAntimeridian(t *testing.T) {
+	equator := BoundingBox(0, 0, 100)
+	north := BoundingBox(60, 0, 100)
+	if north.MaxLng-north.MinLng <= equator.MaxLng-equator.MinLng {
+		t.Fatalf("expected wider longitude span at 60N: %+v vs %+v", north, equator)
+	}
+	// A point 50 km east at 60N must fall inside the box.
+	if lng := 50 / (111.32 * math.Cos(60*math.Pi/180)); lng > north.MaxLng {
+		t.Fatalf("box %+v misses point at lng %.3f", north, lng)
+	}
+
+	wrapped := BoundingBox(0, 179.9, 50)
+	if wrapped.MinLng <= wrapped.MaxLng || wrapped.MaxLng > -179 {
+		t.Fatalf("expected antimeridian-crossing box, got %+v", wrapped)
+	}
+
+	polar := BoundingBox(89.9, 0, 50)
+	if polar.MinLng != -180 || polar.MaxLng != 180 || polar.MaxLat != 90 {
+		t.Fatalf("expected full longitude span near the pole, got %+v", polar)
+	}
+}
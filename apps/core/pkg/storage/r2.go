@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -78,3 +79,22 @@ func (c *R2Client) GeneratePresignedURL(ctx context.Context, objectKey, contentT
 func (c *R2Client) GetPublicURL(objectKey string) string {
 	return fmt.Sprintf("%s/%s", c.publicURL, objectKey)
 }
+
+// GetObject downloads an object, reading at most maxBytes+1 bytes so callers can detect
+// oversized files without buffering them entirely.
+func (c *R2Client) GetObject(ctx context.Context, objectKey string, maxBytes int64) ([]byte, error) {
+	out, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(out.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	return data, nil
+}